@@ -17,11 +17,11 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/user"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
-	"github.com/savaki/dynastore"
+	"github.com/gorilla/sessions"
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore.Store, signatureService signatures.SignatureService, eventsService events.Service) {
+func Configure(api *operations.ClaAPI, service IService, sessionStore sessions.Store, signatureService signatures.SignatureService, eventsService events.Service) {
 
 	api.CompanyAddCclaWhitelistRequestHandler = company.AddCclaWhitelistRequestHandlerFunc(
 		func(params company.AddCclaWhitelistRequestParams) middleware.Responder {
@@ -21,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 const (
@@ -46,7 +47,7 @@ type IRepository interface {
 
 type repository struct {
 	stage          string
-	dynamoDBClient *dynamodb.DynamoDB
+	dynamoDBClient storage.DynamoDBAPI
 	tableName      string
 }
 
@@ -54,7 +55,7 @@ type repository struct {
 func NewRepository(awsSession *session.Session, stage string) IRepository {
 	return repository{
 		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
+		dynamoDBClient: storage.NewClient(awsSession),
 		tableName:      fmt.Sprintf("cla-%s-ccla-whitelist-requests", stage),
 	}
 }
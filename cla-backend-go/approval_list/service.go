@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
@@ -115,7 +116,7 @@ func (s service) AddCclaWhitelistRequest(ctx context.Context, companyID string,
 	}
 
 	// Send the emails to the CLA managers for this CCLA Signature which includes the managers in the ACL list
-	s.sendRequestSentEmail(companyModel, projectModel, sig.Signatures[0], args.ContributorName, args.ContributorEmail, args.RecipientName, args.RecipientEmail, args.Message)
+	s.sendRequestSentEmail(ctx, companyModel, projectModel, sig.Signatures[0], args.ContributorName, args.ContributorEmail, args.RecipientName, args.RecipientEmail, args.Message)
 
 	return requestID, nil
 }
@@ -154,7 +155,7 @@ func (s service) ApproveCclaWhitelistRequest(ctx context.Context, companyID, pro
 	}
 
 	// Send the email
-	s.sendRequestApprovedEmailToRecipient(companyModel, projectModel, requestModel.UserName, requestModel.UserEmails[0])
+	s.sendRequestApprovedEmailToRecipient(ctx, companyModel, projectModel, requestModel.UserName, requestModel.UserEmails[0])
 
 	return nil
 }
@@ -201,7 +202,7 @@ func (s service) RejectCclaWhitelistRequest(ctx context.Context, companyID, proj
 	}
 
 	// Send the email
-	s.sendRequestRejectedEmailToRecipient(companyModel, projectModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0])
+	s.sendRequestRejectedEmailToRecipient(ctx, companyModel, projectModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0])
 
 	return nil
 }
@@ -217,13 +218,13 @@ func (s service) ListCclaWhitelistRequestByCompanyProjectUser(companyID string,
 }
 
 // sendRequestSentEmail sends emails to the CLA managers specified in the signature record
-func (s service) sendRequestSentEmail(companyModel *models.Company, projectModel *models.Project, signature *models.Signature, contributorName, contributorEmail, recipientName, recipientEmail, message string) {
+func (s service) sendRequestSentEmail(ctx context.Context, companyModel *models.Company, projectModel *models.Project, signature *models.Signature, contributorName, contributorEmail, recipientName, recipientEmail, message string) {
 
 	// If we have an override name and email from the request - possibly from the web form where the user selected the
 	// CLA Manager Name/Email from a list, send this to this recipient (CLA Manager) - otherwise we will send to all
 	// CLA Managers on the Signature ACL
 	if recipientName != "" && recipientEmail != "" {
-		s.sendRequestEmailToRecipient(companyModel, projectModel, contributorName, contributorEmail, recipientName, recipientEmail, message)
+		s.sendRequestEmailToRecipient(ctx, companyModel, projectModel, contributorName, contributorEmail, recipientName, recipientEmail, message)
 		return
 	}
 
@@ -244,94 +245,51 @@ func (s service) sendRequestSentEmail(companyModel *models.Company, projectModel
 			log.Warnf("unable to send email to manager: %+v - no email on file...", manager)
 		} else {
 			// Send the email
-			s.sendRequestEmailToRecipient(companyModel, projectModel, contributorName, contributorEmail, manager.Username, whichEmail, message)
+			s.sendRequestEmailToRecipient(ctx, companyModel, projectModel, contributorName, contributorEmail, manager.Username, whichEmail, message)
 		}
 	}
 }
 
 // sendRequestEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestEmailToRecipient(companyModel *models.Company, projectModel *models.Project, contributorName, contributorEmail, recipientName, recipientAddress, message string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	var optionalMessage = ""
-	if message != "" {
-		optionalMessage = fmt.Sprintf("<p>%s included the following message in the request:</p>", contributorName)
-		optionalMessage += fmt.Sprintf("<br/><p>%s</p><br/", message)
-	}
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Request to Authorize %s for %s", contributorName, projectName)
+func (s service) sendRequestEmailToRecipient(ctx context.Context, companyModel *models.Company, projectModel *models.Project, contributorName, contributorEmail, recipientName, recipientAddress, message string) {
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>%s (%s) has requested to be added to the Allow List as an authorized contributor from
-%s to the project %s. You are receiving this message as a CLA Manager from %s for
-%s.</p>
-%s
-<p>If you want to add them to the Allow List, please
-<a href="https://%s#/company/%s" target="_blank">log into the EasyCLA Corporate
-Console</a>, where you can approve this user's request by selecting the 'Manage Approved List' and adding the
-contributor's email, the contributor's entire email domain, their GitHub ID or the entire GitHub Organization for the
-repository. This will permit them to begin contributing to %s on behalf of %s.</p>
-<p>If you are not certain whether to add them to the Allow List, please reach out to them directly to discuss.</p>
-%s
-%s`,
-		recipientName, projectName, contributorName, contributorEmail,
-		companyName, projectName, companyName, projectName,
-		optionalMessage, s.corpConsoleURL,
-		companyModel.CompanyID, projectName, companyName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.ApprovalListRequestTemplate, recipients, notifications.ApprovalListRequestData{
+		RecipientName:    recipientName,
+		CompanyName:      companyModel.CompanyName,
+		ProjectName:      projectModel.ProjectName,
+		ContributorName:  contributorName,
+		ContributorEmail: contributorEmail,
+		Message:          message,
+		CompanyURL:       fmt.Sprintf("https://%s#/company/%s", s.corpConsoleURL, companyModel.CompanyID),
+		V2:               projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ApprovalListRequestTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.ApprovalListRequestTemplate, recipients)
 	}
 }
 
 // sendRequestApprovedEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestApprovedEmailToRecipient(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Contributor Access Approved for %s", projectName)
+func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string) {
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have now been approved as a contributor from %s for the project %s.</p>
-<p> To get started, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>,
-and select your company and then the project %s. From here you will
-be able to edit the list of approved employees and CLA Managers.
-</p>
-%s
-%s`,
-		recipientName, projectName,
-		companyName, projectName,
-		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.ApprovalListRequestApprovedTemplate, recipients, notifications.ApprovalListRequestApprovedData{
+		RecipientName:       recipientName,
+		CompanyName:         companyModel.CompanyName,
+		ProjectName:         projectModel.ProjectName,
+		CorporateConsoleURL: utils.GetCorporateURL(projectModel.Version == utils.V2),
+		V2:                  projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ApprovalListRequestApprovedTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.ApprovalListRequestApprovedTemplate, recipients)
 	}
 }
 
 // sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Company, projectModel *models.Project, signature *models.Signature, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	var claManagerText = ""
-	claManagerText += "<ul>"
-
-	// Build a fancy text string with CLA Manager name <email> as an HTML unordered list
+func (s service) sendRequestRejectedEmailToRecipient(ctx context.Context, companyModel *models.Company, projectModel *models.Project, signature *models.Signature, recipientName, recipientAddress string) {
+	var managers []notifications.Contact
 	for _, manager := range signature.SignatureACL {
 
 		// Need to determine which email...
@@ -347,32 +305,21 @@ func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Compan
 		if whichEmail == "" {
 			log.Warnf("unable to send email to manager: %+v - no email on file...", manager)
 		} else {
-			claManagerText += fmt.Sprintf("<li>%s <%s></li>", manager.Username, whichEmail)
+			managers = append(managers, notifications.Contact{Name: manager.Username, Email: whichEmail})
 		}
 	}
-	claManagerText += "</ul>"
 
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Denied for Project %s", projectName)
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>Your request to become a CLA Manager from %s for %s was denied by one of the existing CLA Managers.
-If you have further questions about this denial, please contact one of the existing CLA Managers from
-%s for %s:</p>
-%s
-%s
-%s`,
-		recipientName, projectName,
-		companyName, projectName, companyName, projectName,
-		claManagerText,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.ApprovalListRequestDeniedTemplate, recipients, notifications.ManagersContactData{
+		RecipientName: recipientName,
+		CompanyName:   companyModel.CompanyName,
+		ProjectName:   projectModel.ProjectName,
+		Managers:      managers,
+		V2:            projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ApprovalListRequestDeniedTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.ApprovalListRequestDeniedTemplate, recipients)
 	}
 }
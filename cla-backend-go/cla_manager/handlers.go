@@ -20,6 +20,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
@@ -182,7 +183,7 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 
 		// Send email to each manager
 		for _, manager := range claManagers {
-			sendRequestAccessEmailToCLAManagers(companyModel, projectModel,
+			sendRequestAccessEmailToCLAManagers(ctx, companyModel, projectModel,
 				params.Body.UserName, params.Body.UserEmail,
 				manager.Username, manager.LfEmail)
 		}
@@ -344,12 +345,12 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 
 		// Notify CLA Managers - send email to each manager
 		for _, manager := range claManagers {
-			sendRequestApprovedEmailToCLAManagers(companyModel, projectModel, request.UserName, request.UserEmail,
+			sendRequestApprovedEmailToCLAManagers(ctx, companyModel, projectModel, request.UserName, request.UserEmail,
 				manager.Username, manager.LfEmail)
 		}
 
 		// Notify the requester
-		sendRequestApprovedEmailToRequester(companyModel, projectModel, request.UserName, request.UserEmail)
+		sendRequestApprovedEmailToRequester(ctx, companyModel, projectModel, request.UserName, request.UserEmail)
 
 		return cla_manager.NewCreateCLAManagerRequestOK().WithXRequestID(reqID).WithPayload(request)
 	})
@@ -439,12 +440,12 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 
 		// Notify CLA Managers - send email to each manager
 		for _, manager := range claManagers {
-			sendRequestDeniedEmailToCLAManagers(companyModel, projectModel, request.UserName, request.UserEmail,
+			sendRequestDeniedEmailToCLAManagers(ctx, companyModel, projectModel, request.UserName, request.UserEmail,
 				manager.Username, manager.LfEmail)
 		}
 
 		// Notify the requester
-		sendRequestDeniedEmailToRequester(companyModel, projectModel, request.UserName, request.UserEmail)
+		sendRequestDeniedEmailToRequester(ctx, companyModel, projectModel, request.UserName, request.UserEmail)
 
 		return cla_manager.NewCreateCLAManagerRequestOK().WithPayload(request)
 	})
@@ -794,154 +795,45 @@ func buildErrorMessageDeleteManager(errPrefix string, params cla_manager.DeleteC
 }
 
 // sendRequestAccessEmailToCLAManagers sends the request access email to the specified CLA Managers
-func sendRequestAccessEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Request for %s on %s", companyName, projectName)
-	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You are currently listed as a CLA Manager from %s for the project %s. This means that you are able to maintain the
-list of employees allowed to contribute to %s on behalf of your company, as well as view and manage the list of
-your company’s CLA Managers for %s.</p>
-<p>%s (%s) has requested to be added as another CLA Manager from %s for %s. This would permit them to maintain the
-lists of approved contributors and CLA Managers as well.</p>
-<p>If you want to permit this, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>,
-select your company, then select the %s project. From the CLA Manager requests, you can approve this user as an
-additional CLA Manager.</p>
-%s
-%s
-`,
-		recipientName, projectName,
-		companyName, projectName, projectName, projectName,
-		requesterName, requesterEmail, companyName, projectName,
-		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+func sendRequestAccessEmailToCLAManagers(ctx context.Context, companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerAccessRequestTemplate, companyModel, projectModel, requesterName, requesterEmail, recipientName, recipientAddress)
 }
 
-func sendRequestApprovedEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Approval Notice for %s", projectName)
-	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>The following user has been approved as a CLA Manager from %s for the project %s. This means that they can now
-maintain the list of employees allowed to contribute to %s on behalf of your company, as well as view and manage the
-list of company’s CLA Managers for %s.</p>
-<ul>
-<li>%s (%s)</li>
-</ul>
-%s
-%s`,
-		recipientName, projectName,
-		companyName, projectName, projectName, projectName,
-		requesterName, requesterEmail,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// sendRequestApprovedEmailToCLAManagers notifies the specified CLA Manager that the request was approved
+func sendRequestApprovedEmailToCLAManagers(ctx context.Context, companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerAccessApprovedNoticeTemplate, companyModel, projectModel, requesterName, requesterEmail, recipientName, recipientAddress)
 }
 
-func sendRequestApprovedEmailToRequester(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Approved for %s", projectName)
-	recipients := []string{requesterEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have now been approved as a CLA Manager from %s for the project %s.  This means that you can now maintain the
-list of employees allowed to contribute to %s on behalf of your company, as well as view and manage the list of your
-company’s CLA Managers for %s.</p>
-<p> To get started, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>, and select your
-company and then the project %s. From here you will be able to edit the list of approved employees and CLA Managers.</p>
-%s
-%s`,
-		requesterName, projectName,
-		companyName, projectName, projectName, projectName,
-		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// sendRequestApprovedEmailToRequester notifies the requester that the request was approved
+func sendRequestApprovedEmailToRequester(ctx context.Context, companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerAccessApprovedTemplate, companyModel, projectModel, requesterName, requesterEmail, requesterName, requesterEmail)
 }
 
-func sendRequestDeniedEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
+// sendRequestDeniedEmailToCLAManagers notifies the specified CLA Manager that the request was denied
+func sendRequestDeniedEmailToCLAManagers(ctx context.Context, companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerAccessDeniedNoticeTemplate, companyModel, projectModel, requesterName, requesterEmail, recipientName, recipientAddress)
+}
 
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Denied Notice for %s", projectName)
-	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>The following user has been denied as a CLA Manager from %s for the project %s. This means that they will not
-be able to maintain the list of employees allowed to contribute to %s on behalf of your company.</p>
-<ul>
-<li>%s (%s)</li>
-</ul>
-%s
-%s`,
-		recipientName, projectName,
-		companyName, projectName, projectName,
-		requesterName, requesterEmail,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// sendRequestDeniedEmailToRequester notifies the requester that the request was denied
+func sendRequestDeniedEmailToRequester(ctx context.Context, companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerAccessDeniedTemplate, companyModel, projectModel, requesterName, requesterEmail, requesterName, requesterEmail)
 }
 
-func sendRequestDeniedEmailToRequester(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Denied for %s", projectName)
-	recipients := []string{requesterEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have been denied as a CLA Manager from %s for the project %s. This means that you can not maintain the
-list of employees allowed to contribute to %s on behalf of your company.</p>
-%s
-%s`,
-		requesterName, projectName,
-		companyName, projectName, projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+// sendCLAManagerNotice sends the CLA Manager notification about the specified user to the recipient
+func sendCLAManagerNotice(ctx context.Context, templateName string, companyModel *models.Company, projectModel *models.Project, userName, userEmail, recipientName, recipientAddress string) {
+	recipients := []string{recipientAddress}
+	err := notifications.Send(ctx, projectModel.ProjectID, templateName, recipients, notifications.CLAManagerNoticeData{
+		RecipientName:       recipientName,
+		CompanyName:         companyModel.CompanyName,
+		ProjectName:         projectModel.ProjectName,
+		UserName:            userName,
+		UserEmail:           userEmail,
+		CorporateConsoleURL: utils.GetCorporateURL(projectModel.Version == utils.V2),
+		V2:                  projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", templateName, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", templateName, recipients)
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/gofrs/uuid"
 )
@@ -40,7 +41,7 @@ type IRepository interface { //nolint
 
 type repository struct {
 	stage          string
-	dynamoDBClient *dynamodb.DynamoDB
+	dynamoDBClient storage.DynamoDBAPI
 	tableName      string
 }
 
@@ -48,7 +49,7 @@ type repository struct {
 func NewRepository(awsSession *session.Session, stage string) IRepository {
 	return repository{
 		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
+		dynamoDBClient: storage.NewClient(awsSession),
 		tableName:      fmt.Sprintf("cla-%s-cla-manager-requests", stage),
 	}
 }
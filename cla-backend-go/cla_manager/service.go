@@ -5,7 +5,6 @@ package cla_manager
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/communitybridge/easycla/cla-backend-go/company"
@@ -13,6 +12,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	sigAPI "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
@@ -226,11 +226,11 @@ func (s service) AddClaManager(ctx context.Context, companyID string, projectID
 
 	// Notify CLA Managers - send email to each manager
 	for _, manager := range claManagers {
-		sendClaManagerAddedEmailToCLAManagers(companyModel, projectModel, userModel.Username, userModel.LfEmail,
+		sendClaManagerAddedEmailToCLAManagers(ctx, companyModel, projectModel, userModel.Username, userModel.LfEmail,
 			manager.Username, manager.LfEmail)
 	}
 	// Notify the added user
-	sendClaManagerAddedEmailToUser(companyModel, projectModel, userModel.Username, userModel.LfEmail)
+	sendClaManagerAddedEmailToUser(ctx, companyModel, projectModel, userModel.Username, userModel.LfEmail)
 
 	// Send an event
 	s.eventsService.LogEvent(&events.LogEventArgs{
@@ -318,12 +318,12 @@ func (s service) RemoveClaManager(ctx context.Context, companyID string, project
 	claManagers := sigModel.SignatureACL
 	// Notify CLA Managers - send email to each manager
 	for _, manager := range claManagers {
-		sendClaManagerDeleteEmailToCLAManagers(companyModel, projectModel, userModel.LfUsername,
+		sendClaManagerDeleteEmailToCLAManagers(ctx, companyModel, projectModel, userModel.LfUsername,
 			manager.Username, manager.LfEmail)
 	}
 
 	// Notify the removed manager
-	sendRemovedClaManagerEmailToRecipient(companyModel, projectModel, userModel.LfUsername, userModel.LfEmail, claManagers)
+	sendRemovedClaManagerEmailToRecipient(ctx, companyModel, projectModel, userModel.LfUsername, userModel.LfEmail, claManagers)
 
 	// Send an event
 	s.eventsService.LogEvent(&events.LogEventArgs{
@@ -348,76 +348,30 @@ func (s service) RemoveClaManager(ctx context.Context, companyID string, project
 	return updatedSignature, nil
 }
 
-func sendClaManagerAddedEmailToUser(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Added as CLA Manager for Project :%s", projectName)
+func sendClaManagerAddedEmailToUser(ctx context.Context, companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
 	recipients := []string{requesterEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have been added as a CLA Manager from %s for the project %s.  This means that you can now maintain the
-list of employees allowed to contribute to %s on behalf of your company, as well as view and manage the list of your
-company’s CLA Managers for %s.</p>
-<p> To get started, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>, and select your
-company and then the project %s. From here you will be able to edit the list of approved employees and CLA Managers.</p>
-%s
-%s`,
-		requesterName, projectName,
-		companyName, projectName, projectName, projectName,
-		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.CLAManagerAddedTemplate, recipients, notifications.CLAManagerAddedData{
+		RecipientName:       requesterName,
+		CompanyName:         companyModel.CompanyName,
+		ProjectName:         projectModel.ProjectName,
+		CorporateConsoleURL: utils.GetCorporateURL(projectModel.Version == utils.V2),
+		V2:                  projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.CLAManagerAddedTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.CLAManagerAddedTemplate, recipients)
 	}
 }
 
-func sendClaManagerAddedEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, name, email, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Added Notice for %s", projectName)
-	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>The following user has been added as a CLA Manager from %s for the project %s. This means that they can now
-maintain the list of employees allowed to contribute to %s on behalf of your company, as well as view and manage the
-list of company’s CLA Managers for %s.</p>
-<ul>
-<li>%s (%s)</li>
-</ul>
-%s
-%s`,
-		recipientName, projectName,
-		companyName, projectName, projectName, projectName,
-		name, email,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// sendClaManagerAddedEmailToCLAManagers notifies the specified CLA Manager that a CLA Manager was added
+func sendClaManagerAddedEmailToCLAManagers(ctx context.Context, companyModel *models.Company, projectModel *models.Project, name, email, recipientName, recipientAddress string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerAddedNoticeTemplate, companyModel, projectModel, name, email, recipientName, recipientAddress)
 }
 
-// sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
-func sendRemovedClaManagerEmailToRecipient(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, claManagers []models.User) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	var companyManagerText = ""
-	companyManagerText += "<ul>"
-
-	// Build a fancy text string with CLA Manager name <email> as an HTML unordered list
+// sendRemovedClaManagerEmailToRecipient notifies the removed CLA Manager, listing the remaining CLA Managers to contact
+func sendRemovedClaManagerEmailToRecipient(ctx context.Context, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, claManagers []models.User) {
+	var managers []notifications.Contact
 	for _, companyAdmin := range claManagers {
 
 		// Need to determine which email...
@@ -434,56 +388,26 @@ func sendRemovedClaManagerEmailToRecipient(companyModel *models.Company, project
 		if whichEmail == "" {
 			log.Warnf("unable to send email to manager: %+v - no email on file...", companyAdmin)
 		} else {
-			companyManagerText += fmt.Sprintf("<li>%s <%s></li>", companyAdmin.LfUsername, whichEmail)
+			managers = append(managers, notifications.Contact{Name: companyAdmin.LfUsername, Email: whichEmail})
 		}
 	}
 
-	companyManagerText += "</ul>"
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Removed as CLA Manager for Project %s", projectName)
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have been removed as a CLA Manager from %s for the project %s.</p>
-<p>If you have further questions about this, please contact one of the existing managers from
-%s:</p>
-%s
-%s
-%s`,
-		recipientName, projectName, companyName, projectName, companyName, companyManagerText,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.CLAManagerRemovedTemplate, recipients, notifications.ManagersContactData{
+		RecipientName: recipientName,
+		CompanyName:   companyModel.CompanyName,
+		ProjectName:   projectModel.ProjectName,
+		Managers:      managers,
+		V2:            projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.CLAManagerRemovedTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.CLAManagerRemovedTemplate, recipients)
 	}
 }
 
-func sendClaManagerDeleteEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, name, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Removed Notice for %s", projectName)
-	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>%s has been removed as a CLA Manager from %s for the project %s.</p>
-%s
-%s
-`,
-		recipientName, projectName, name, companyName, projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// sendClaManagerDeleteEmailToCLAManagers notifies the specified CLA Manager that a CLA Manager was removed
+func sendClaManagerDeleteEmailToCLAManagers(ctx context.Context, companyModel *models.Company, projectModel *models.Project, name, recipientName, recipientAddress string) {
+	sendCLAManagerNotice(ctx, notifications.CLAManagerRemovedNoticeTemplate, companyModel, projectModel, name, "", recipientName, recipientAddress)
 }
@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	claevents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var signaturesService signatures.SignatureService
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+	userRepo := user.NewDynamoRepository(awsSession, stage)
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+
+	if err = utils.InitEmailSender(awsSession, configFile.Email, configFile.SNSEventTopicARN, configFile.SenderEmailAddress); err != nil {
+		log.Fatalf("Unable to set up the email sender - Error: %v", err)
+	}
+
+	// Services
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		project.ProjectRepository
+	}
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+	usersService := users.NewService(usersRepo, eventsService)
+	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService, company.NewDomainResolver())
+	signaturesService = signatures.NewService(signaturesRepo, companyService, usersService, eventsService, false, company.DomainVerificationWarn, projectRepo, nil)
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	reminderWindow := signatures.DefaultApprovalListReminderWindow
+	if days, err := strconv.Atoi(os.Getenv("APPROVAL_LIST_REMINDER_DAYS")); err == nil && days > 0 {
+		reminderWindow = time.Duration(days) * 24 * time.Hour
+	}
+
+	report, err := signaturesService.ProcessApprovalListExpiry(ctx, time.Now().UTC(), reminderWindow)
+	if err != nil {
+		log.Fatalf("Unable to process the approval list expiry. error = %s", err)
+	}
+	log.Infof("approval list expiry checked %d signatures, revoked %d entries and sent reminders for %d entries",
+		report.SignaturesChecked, report.EntriesRevoked, report.RemindersSent)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
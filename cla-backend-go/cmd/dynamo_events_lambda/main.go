@@ -23,8 +23,10 @@ import (
 	user_service "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
 
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/resign_campaigns"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
 
 	"github.com/communitybridge/easycla/cla-backend-go/token"
 
@@ -34,6 +36,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 
 	"github.com/aws/aws-lambda-go/lambda"
 
@@ -72,23 +75,39 @@ func init() {
 	if err != nil {
 		log.Panicf("Unable to load config - Error: %v", err)
 	}
+
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
+	project_service.InitClient(configFile.APIGatewayURL)
+
+	// the metrics are maintained by a separate consumer of the streams, see dynamo_events.NewMetricsService
+	if os.Getenv("DYNAMO_EVENTS_HANDLER") == "metrics" {
+		metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, projectClaGroupRepo)
+		dynamoEventsService = dynamo_events.NewMetricsService(stage, metricsRepo)
+		return
+	}
+
 	usersRepo := users.NewRepository(awsSession, stage)
 	companyRepo := company.NewRepository(awsSession, stage)
 	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
-	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
 	repositoriesRepo := repositories.NewRepository(awsSession, stage)
 	gerritRepo := gerrits.NewRepository(awsSession, stage)
 	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
 	eventsRepo := claevents.NewRepository(awsSession, stage)
 	claManagerRequestsRepo := cla_manager.NewRepository(awsSession, stage)
 	approvalListRequestsRepo := approval_list.NewRepository(awsSession, stage)
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
+	resignCampaignsRepo := resign_campaigns.NewRepository(awsSession, stage)
 
-	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
 	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
-	project_service.InitClient(configFile.APIGatewayURL)
+	if err = utils.InitBlobStore(awsSession, configFile.BlobStore, configFile.SignatureFilesBucket); err != nil {
+		log.Fatalf("Unable to set up the blob store - Error: %v", err)
+	}
 
 	// Services
 	projectService := project.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	webhooksService := webhooks.NewService(webhooksRepo)
+	resignCampaignsService := resign_campaigns.NewService(resignCampaignsRepo, signaturesRepo, projectRepo, usersRepo)
 
 	type combinedRepo struct {
 		users.UserRepository
@@ -102,11 +121,11 @@ func init() {
 	})
 	organization_service.InitClient(configFile.APIGatewayURL, eventsService)
 	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
-	dynamoEventsService = dynamo_events.NewService(stage, signaturesRepo, companyRepo, projectClaGroupRepo, eventsRepo, projectRepo, projectService, claManagerRequestsRepo, approvalListRequestsRepo)
+	dynamoEventsService = dynamo_events.NewService(stage, signaturesRepo, companyRepo, projectClaGroupRepo, eventsRepo, projectRepo, projectService, claManagerRequestsRepo, approvalListRequestsRepo, webhooksService, resignCampaignsService)
 }
 
-func handler(ctx context.Context, event events.DynamoDBEvent) {
-	dynamoEventsService.ProcessEvents(event)
+func handler(ctx context.Context, event events.DynamoDBEvent) error {
+	return dynamoEventsService.ProcessEvents(event)
 }
 
 func printBuildInfo() {
@@ -127,7 +146,9 @@ func main() {
 				log.Fatal(err)
 			}
 		}
-		handler(utils.NewContext(), dynamodbEvent)
+		if err := handler(utils.NewContext(), dynamodbEvent); err != nil {
+			log.Fatal(err)
+		}
 	} else {
 		lambda.Start(handler)
 	}
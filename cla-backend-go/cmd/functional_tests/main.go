@@ -26,6 +26,8 @@ const (
 	API_URL = "https://api.dev.lfcla.com" // nolint
 	// V2_API_URL is the API endpoint that goes through the API-GW + ACS
 	V2_API_URL = "https://api-gw.dev.platform.linuxfoundation.org/cla-service" // nolint
+	// LOCAL_API_URL is the endpoint of the local server running on the storage driver
+	LOCAL_API_URL = "http://localhost:8080" // nolint
 )
 
 func init() {
@@ -65,14 +67,19 @@ func loadUser(prefix string) test_models.Auth0Config {
 func main() {
 	// Direct V4 API
 	apiURL := os.Getenv("API_URL")
-	if apiURL == "" {
-		apiURL = API_URL
-	}
-
 	// V2 API URL goes through the API-GW and ACS
 	v2APIURL := os.Getenv("V2_API_URL")
+
+	// The local server running on the SQL storage driver (STORAGE_DRIVER) serves both APIs
+	defaultAPIURL, defaultV2APIURL := API_URL, V2_API_URL
+	if os.Getenv("STORAGE_DRIVER") != "" {
+		defaultAPIURL, defaultV2APIURL = LOCAL_API_URL, LOCAL_API_URL
+	}
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
 	if v2APIURL == "" {
-		v2APIURL = V2_API_URL
+		v2APIURL = defaultV2APIURL
 	}
 
 	log.Debugf("API_URL                 : %s", apiURL)
@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
@@ -59,9 +60,19 @@ func init() {
 }
 
 func handler(ctx context.Context, event events.CloudWatchEvent) {
-	err := metricsRepo.CalculateAndSaveMetrics()
+	// the metrics are maintained by the dynamo metrics events lambda - this run corrects any drift. METRICS_BACKFILL
+	// seeds the member records from the tables first, it migrates the metrics of the full calculation snapshots.
+	report, err := metricsRepo.ReconcileMetrics(os.Getenv("METRICS_BACKFILL") == "true")
 	if err != nil {
-		log.Fatalf("Unable to save metrics in dynamodb. error = %s", err)
+		log.Fatalf("Unable to reconcile metrics in dynamodb. error = %s", err)
+	}
+	if len(report.Drifts) > 0 {
+		log.Warnf("metrics reconciliation corrected %d of %d drifted counts out of %d", report.Corrected, len(report.Drifts), report.CountersChecked)
+	}
+	// the reconciled metrics are the daily rollup of the trends
+	err = metricsRepo.SaveDailyRollups(time.Now().UTC().Format(metrics.RollupDateFormat))
+	if err != nil {
+		log.Fatalf("Unable to save daily metrics rollups in dynamodb. error = %s", err)
 	}
 }
 
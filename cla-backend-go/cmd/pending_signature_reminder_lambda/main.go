@@ -0,0 +1,159 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+
+	acs_service "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+	project_service "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/sign"
+	user_service "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
+
+	"github.com/communitybridge/easycla/cla-backend-go/token"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	claevents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var signService sign.Service
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+	usersRepo := users.NewRepository(awsSession, stage)
+	userRepo := user.NewDynamoRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+	notificationsRepo := notifications.NewRepository(awsSession, stage)
+	pendingSignaturesRepo := sign.NewPendingSignatureRepository(awsSession, stage)
+
+	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
+	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	project_service.InitClient(configFile.APIGatewayURL)
+	if err = utils.InitEmailSender(awsSession, configFile.Email, configFile.SNSEventTopicARN, configFile.SenderEmailAddress); err != nil {
+		log.Fatalf("Unable to set up the email sender - Error: %v", err)
+	}
+
+	// Services
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		project.ProjectRepository
+	}
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+	organization_service.InitClient(configFile.APIGatewayURL, eventsService)
+	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	notifications.SetService(notifications.NewService(notificationsRepo, usersRepo))
+	usersService := users.NewService(usersRepo, eventsService)
+	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService, company.NewDomainResolver())
+	signService = sign.NewService(configFile.ClaV1ApiURL, companyRepo, projectRepo, projectClaGroupRepo, companyService, pendingSignaturesRepo, signaturesRepo)
+}
+
+// reminderPolicy returns the reminder policy, overridden by the PENDING_SIGNATURE_REMINDER_DAYS (comma separated list
+// of days after the request) and PENDING_SIGNATURE_ABANDON_DAYS environment variables
+func reminderPolicy() sign.PendingSignatureReminderPolicy {
+	policy := sign.DefaultPendingSignatureReminderPolicy
+	if value := os.Getenv("PENDING_SIGNATURE_REMINDER_DAYS"); value != "" {
+		var intervals []time.Duration
+		for _, item := range strings.Split(value, ",") {
+			days, err := strconv.Atoi(strings.TrimSpace(item))
+			if err != nil || days <= 0 {
+				log.Warnf("ignoring invalid PENDING_SIGNATURE_REMINDER_DAYS value: %s", value)
+				intervals = nil
+				break
+			}
+			intervals = append(intervals, time.Duration(days)*24*time.Hour)
+		}
+		if intervals != nil {
+			policy.ReminderIntervals = intervals
+		}
+	}
+	if days, err := strconv.Atoi(os.Getenv("PENDING_SIGNATURE_ABANDON_DAYS")); err == nil && days > 0 {
+		policy.AbandonAfter = time.Duration(days) * 24 * time.Hour
+	}
+	return policy
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	report, err := signService.ProcessPendingSignatures(ctx, time.Now().UTC(), reminderPolicy())
+	if err != nil {
+		log.Fatalf("Unable to process the pending corporate signatures. error = %s", err)
+	}
+	log.Infof("pending signatures checked %d requests, %d were signed, sent %d reminders and abandoned %d requests",
+		report.RequestsChecked, report.RequestsSigned, report.RemindersSent, report.RequestsAbandoned)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
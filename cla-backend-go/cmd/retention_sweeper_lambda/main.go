@@ -0,0 +1,105 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/retention"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var retentionService retention.Service
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	retentionRepo := retention.NewRepository(awsSession, stage)
+	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, projectClaGroupRepo)
+
+	if err = utils.InitBlobStore(awsSession, configFile.BlobStore, configFile.SignatureFilesBucket); err != nil {
+		log.Fatalf("Unable to set up the blob store - Error: %v", err)
+	}
+	retentionService = retention.NewService(retentionRepo, projectRepo, metricsRepo)
+}
+
+// dryRun returns true when the RETENTION_SWEEPER_DRY_RUN environment variable is set - the sweep then only reports the
+// expired signatures
+func dryRun() bool {
+	value, err := strconv.ParseBool(os.Getenv("RETENTION_SWEEPER_DRY_RUN"))
+	return err == nil && value
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	report, err := retentionService.Sweep(ctx, time.Now().UTC(), dryRun())
+	if err != nil {
+		log.Fatalf("Unable to sweep the expired signatures. error = %s", err)
+	}
+	log.Infof("retention sweep checked %d invalidated signatures, %d expired, deleted %d documents and %d zip archives, "+
+		"deleted %d and archived %d records, skipped %d under legal hold and %d without a policy, stamped the invalidation of %d, "+
+		"%d failures",
+		report.SignaturesChecked, report.SignaturesExpired, report.DocumentsDeleted, report.ArchivesDeleted, report.RecordsDeleted,
+		report.RecordsArchived, report.SkippedLegalHold, report.SkippedNoPolicy, report.InvalidationsStamped, report.Failures)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
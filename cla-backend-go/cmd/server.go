@@ -39,8 +39,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
 	v2Gerrits "github.com/communitybridge/easycla/cla-backend-go/v2/gerrits"
-
-	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_activity"
 
 	lfxAuth "github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/communitybridge/easycla/cla-backend-go/docs"
@@ -49,8 +48,12 @@ import (
 	v2Docs "github.com/communitybridge/easycla/cla-backend-go/v2/docs"
 	v2Events "github.com/communitybridge/easycla/cla-backend-go/v2/events"
 	v2Metrics "github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+	v2Notifications "github.com/communitybridge/easycla/cla-backend-go/v2/notifications"
 	v2Repositories "github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	v2ResignCampaigns "github.com/communitybridge/easycla/cla-backend-go/v2/resign_campaigns"
+	v2Retention "github.com/communitybridge/easycla/cla-backend-go/v2/retention"
 	v2Version "github.com/communitybridge/easycla/cla-backend-go/v2/version"
+	v2Webhooks "github.com/communitybridge/easycla/cla-backend-go/v2/webhooks"
 	"github.com/communitybridge/easycla/cla-backend-go/version"
 
 	"github.com/communitybridge/easycla/cla-backend-go/events"
@@ -69,7 +72,6 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/auth"
 	"github.com/communitybridge/easycla/cla-backend-go/company"
 	"github.com/communitybridge/easycla/cla-backend-go/config"
-	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
@@ -77,17 +79,26 @@ import (
 	v2Ops "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/health"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/renderer"
+	"github.com/communitybridge/easycla/cla-backend-go/resign_campaigns"
+	"github.com/communitybridge/easycla/cla-backend-go/retention"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 	"github.com/communitybridge/easycla/cla-backend-go/template"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
 	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
 	v2Company "github.com/communitybridge/easycla/cla-backend-go/v2/company"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/coverage"
 	v2Health "github.com/communitybridge/easycla/cla-backend-go/v2/health"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/privacy"
 	v2Template "github.com/communitybridge/easycla/cla-backend-go/v2/template"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 
 	"github.com/go-openapi/loads"
+	"github.com/gorilla/sessions"
 	"github.com/lytics/logrus"
 	"github.com/rs/cors"
-	"github.com/savaki/dynastore"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -150,6 +161,16 @@ func server(localMode bool) http.Handler {
 		}
 	}
 
+	var domainVerificationMode = company.DomainVerificationWarn // default is to warn about unverified domains
+	domainVerificationModeString := viper.GetString("DOMAIN_VERIFICATION_MODE")
+	if domainVerificationModeString != "" {
+		if !company.ValidDomainVerificationMode(domainVerificationModeString) {
+			log.Fatalf("DOMAIN_VERIFICATION_MODE value must be one of: %s, %s, %s", company.DomainVerificationOff,
+				company.DomainVerificationWarn, company.DomainVerificationBlock)
+		}
+		domainVerificationMode = domainVerificationModeString
+	}
+
 	stage := viper.GetString("STAGE")
 	dynamodbRegion := ini.GetProperty("DYNAMODB_AWS_REGION")
 
@@ -166,6 +187,7 @@ func server(localMode bool) http.Handler {
 	log.Infof("DYANAMODB_AWS_REGION    : %s", dynamodbRegion)
 	log.Infof("GH_ORG_VALIDATION       : %t", githubOrgValidation)
 	log.Infof("COMPANY_USER_VALIDATION : %t", companyUserValidation)
+	log.Infof("DOMAIN_VERIFICATION_MODE: %s", domainVerificationMode)
 	log.Infof("STAGE                   : %s", stage)
 	log.Infof("Service Host            : %s", host)
 	log.Infof("Service Port            : %d", *portFlag)
@@ -174,12 +196,17 @@ func server(localMode bool) http.Handler {
 	if err != nil {
 		log.Panicf("Unable to load AWS session - Error: %v", err)
 	}
+	telemetry.InstrumentAWSSession(awsSession)
 
 	configFile, err := config.LoadConfig(configFile, awsSession, stage)
 	if err != nil {
 		log.Panicf("Unable to load config - Error: %v", err)
 	}
 
+	if err = storage.Init(storageConfig(configFile.Storage)); err != nil {
+		log.Panicf("Unable to setup the storage driver - Error: %v", err)
+	}
+
 	swaggerSpec, err := loads.Analyzed(restapi.SwaggerJSON, "")
 	if err != nil {
 		logrus.Panicf("Invalid swagger file for initializing EasyCLA v1 - Error: %v", err)
@@ -193,9 +220,9 @@ func server(localMode bool) http.Handler {
 	api := operations.NewClaAPI(swaggerSpec)
 	v2API := v2Ops.NewEasyclaAPI(v2SwaggerSpec)
 
-	docraptorClient, err := docraptor.NewDocraptorClient(configFile.Docraptor.APIKey, configFile.Docraptor.TestMode)
+	pdfRenderer, err := renderer.NewPDFRenderer(configFile.Docraptor)
 	if err != nil {
-		logrus.Panicf("Unable to setup docraptor client - Error: %v", err)
+		logrus.Panicf("Unable to setup the PDF renderer - Error: %v", err)
 	}
 
 	authValidator, err := auth.NewAuthValidator(
@@ -225,6 +252,12 @@ func server(localMode bool) http.Handler {
 	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, projectClaGroupRepo)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
 	claManagerReqRepo := cla_manager.NewRepository(awsSession, stage)
+	notificationsRepo := notifications.NewRepository(awsSession, stage)
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
+	resignCampaignsRepo := resign_campaigns.NewRepository(awsSession, stage)
+	privacyRepo := privacy.NewRepository(awsSession, stage)
+	retentionRepo := retention.NewRepository(awsSession, stage)
+	pendingSignaturesRepo := sign.NewPendingSignatureRepository(awsSession, stage)
 
 	// Our service layer handlers
 	eventsService := events.NewService(eventsRepo, combinedRepo{
@@ -243,14 +276,15 @@ func server(localMode bool) http.Handler {
 
 	usersService := users.NewService(usersRepo, eventsService)
 	healthService := health.New(Version, Commit, Branch, BuildDate)
-	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession)
+	templateService := template.NewService(stage, templateRepo, pdfRenderer, awsSession)
 	projectService := project.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo)
 	v2ProjectService := v2Project.NewService(projectService, projectRepo, projectClaGroupRepo)
-	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService)
+	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService, company.NewDomainResolver())
 	v2CompanyService := v2Company.NewService(companyService, signaturesRepo, projectRepo, usersRepo, companyRepo, projectClaGroupRepo, eventsService)
-	v2SignService := sign.NewService(configFile.ClaV1ApiURL, companyRepo, projectRepo, projectClaGroupRepo, companyService)
-	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, githubOrgValidation)
-	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, projectService, companyService, signaturesService, projectClaGroupRepo)
+	v2SignService := sign.NewService(configFile.ClaV1ApiURL, companyRepo, projectRepo, projectClaGroupRepo, companyService, pendingSignaturesRepo, signaturesRepo)
+	resignCampaignsService := resign_campaigns.NewService(resignCampaignsRepo, signaturesRepo, projectRepo, usersRepo)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, githubOrgValidation, domainVerificationMode, projectRepo, resignCampaignsService)
+	v2SignatureService := v2Signatures.NewService(projectService, companyService, signaturesService, eventsService, projectClaGroupRepo)
 	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, companyService, projectService, usersService, signaturesService, eventsService, configFile.CorporateConsoleURL)
 	repositoriesService := repositories.NewService(repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
 	v2RepositoriesService := v2Repositories.NewService(repositoriesRepo, projectClaGroupRepo, githubOrganizationsRepo)
@@ -260,20 +294,30 @@ func server(localMode bool) http.Handler {
 	v2MetricsService := metrics.NewService(metricsRepo, projectClaGroupRepo)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, repositoriesRepo)
 	v2GithubOrganizationsService := v2GithubOrganizations.NewService(githubOrganizationsRepo, repositoriesRepo)
-	gerritService := gerrits.NewService(gerritRepo, &gerrits.LFGroup{
+	lfGroup := &gerrits.LFGroup{
 		LfBaseURL:    configFile.LFGroup.ClientURL,
 		ClientID:     configFile.LFGroup.ClientID,
 		ClientSecret: configFile.LFGroup.ClientSecret,
 		RefreshToken: configFile.LFGroup.RefreshToken,
-	})
+	}
+	gerritService := gerrits.NewService(gerritRepo, lfGroup)
+	v2GerritService := v2Gerrits.NewService(gerritRepo, usersService, signaturesService, lfGroup)
+	webhooksService := webhooks.NewService(webhooksRepo)
+	githubActivityService := github_activity.NewService(repositoriesRepo, signaturesService, usersService, configFile.ClaV1ApiURL, github.NewGithubAppClient)
 	v2ClaGroupService := cla_groups.NewService(projectService, templateService, projectClaGroupRepo, v1ClaManagerService, signaturesService, metricsRepo, gerritService, repositoriesService, eventsService)
+	coverageService := coverage.NewService(projectClaGroupRepo, signaturesRepo, projectService, companyRepo, signaturesService, usersService)
+	privacyService := privacy.NewService(privacyRepo, signaturesRepo, companyRepo, eventsService)
+	retentionService := retention.NewService(retentionRepo, projectRepo, metricsRepo)
 
-	sessionStore, err := dynastore.New(dynastore.Path("/"), dynastore.HTTPOnly(), dynastore.TableName(configFile.SessionStoreTableName), dynastore.DynamoDB(dynamodb.New(awsSession)))
-	if err != nil {
-		log.Fatalf("Unable to create new Dynastore session - Error: %v", err)
+	sessionStore := storage.NewSessionStore(storage.NewClient(awsSession), configFile.SessionStoreTableName, sessions.Options{Path: "/", HttpOnly: true})
+	if err = utils.InitEmailSender(awsSession, configFile.Email, configFile.SNSEventTopicARN, configFile.SenderEmailAddress); err != nil {
+		log.Fatalf("Unable to set up the email sender - Error: %v", err)
+	}
+	if err = utils.InitBlobStore(awsSession, configFile.BlobStore, configFile.SignatureFilesBucket); err != nil {
+		log.Fatalf("Unable to set up the blob store - Error: %v", err)
 	}
-	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
-	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
+	notificationsService := notifications.NewService(notificationsRepo, usersRepo)
+	notifications.SetService(notificationsService)
 
 	// Setup security handlers
 	api.OauthSecurityAuth = authorizer.SecurityAuth
@@ -298,18 +342,24 @@ func server(localMode bool) http.Handler {
 	v2Version.Configure(v2API, Version, Commit, Branch, BuildDate)
 	events.Configure(api, eventsService)
 	v2Events.Configure(v2API, eventsService, companyRepo, projectClaGroupRepo)
-	v2Metrics.Configure(v2API, v2MetricsService, companyRepo)
+	v2Metrics.Configure(v2API, v2MetricsService, companyRepo, projectClaGroupRepo)
 	github_organizations.Configure(api, githubOrganizationsService, eventsService)
 	v2GithubOrganizations.Configure(v2API, v2GithubOrganizationsService, eventsService)
 	repositories.Configure(api, repositoriesService, eventsService)
 	v2Repositories.Configure(v2API, v2RepositoriesService, eventsService)
 	gerrits.Configure(api, gerritService, projectService, eventsService)
-	v2Gerrits.Configure(v2API, gerritService, projectService, eventsService, projectClaGroupRepo)
+	v2Gerrits.Configure(v2API, gerritService, projectService, eventsService, projectClaGroupRepo, v2GerritService, configFile.GerritHookSecret)
 	v2Company.Configure(v2API, v2CompanyService, companyRepo, projectClaGroupRepo, configFile.LFXPortalURL)
 	cla_manager.Configure(api, v1ClaManagerService, companyService, projectService, usersService, signaturesService, eventsService, configFile.CorporateConsoleURL)
 	v2ClaManager.Configure(v2API, v2ClaManagerService, configFile.LFXPortalURL, projectClaGroupRepo, userRepo)
 	sign.Configure(v2API, v2SignService)
 	cla_groups.Configure(v2API, v2ClaGroupService, projectService, eventsService)
+	v2Notifications.Configure(v2API, notificationsService, projectService)
+	v2Webhooks.Configure(v2API, webhooksService, projectClaGroupRepo)
+	v2ResignCampaigns.Configure(v2API, resignCampaignsService, projectService, eventsService)
+	coverage.Configure(v2API, coverageService)
+	privacy.Configure(v2API, privacyService, eventsService)
+	v2Retention.Configure(v2API, retentionService, eventsService)
 
 	userCreaterMiddleware := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -321,7 +371,7 @@ func server(localMode bool) http.Handler {
 	// The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
 	// The middleware executes after routing but before authentication, binding and validation
 	middlewareSetupfunc := func(handler http.Handler) http.Handler {
-		return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(handler)))
+		return setRequestIDHandler(telemetry.RequestMiddleware(responseLoggingMiddleware(userCreaterMiddleware(handler))))
 	}
 
 	v2API.CsvProducer = openapi_runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
@@ -387,7 +437,23 @@ func server(localMode bool) http.Handler {
 				v2API.Serve(middlewareSetupfunc), v2SwaggerSpec.BasePath()),
 			configFile.AllowedOrigins)
 	}
-	return apiHandler
+
+	// The business gauges are read from the incrementally maintained total count metrics when scraped
+	telemetry.RegisterBusinessGauges(func() (map[string]int64, error) {
+		tcm, tcmErr := metricsRepo.GetTotalCountMetrics()
+		if tcmErr != nil {
+			return nil, tcmErr
+		}
+		return tcm.Counts(), nil
+	})
+	if configFile.MetricsBearerToken == "" {
+		log.Warn("the metrics bearer token is not set - the /metrics endpoint is unavailable")
+	}
+	apiHandler = wrapMetricsHandler(apiHandler, telemetry.Handler(configFile.MetricsBearerToken))
+	apiHandler = wrapBlobDownloadHandler(apiHandler, utils.BlobDownloadHandler())
+
+	// GitHub App webhook deliveries are authenticated by their payload signature rather than the API auth
+	return wrapGithubActivityHandler(apiHandler, github_activity.NewWebhookHandler(configFile.Github.WebhookSecret, githubActivityService))
 }
 
 // setupCORSHandler sets up the CORS logic and creates the middleware HTTP handler
@@ -449,6 +515,39 @@ func wrapHandlers(v1 http.Handler, v1BasePath string, v2 http.Handler, v2BasePat
 	})
 }
 
+// wrapGithubActivityHandler routes the GitHub App webhook deliveries to the github activity handler
+func wrapGithubActivityHandler(api http.Handler, githubActivity http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == github_activity.WebhookPath {
+			githubActivity.ServeHTTP(w, r)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+}
+
+// wrapMetricsHandler routes the scrapes of the OpenMetrics endpoint to the metrics handler
+func wrapMetricsHandler(api http.Handler, metricsHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == telemetry.MetricsPath {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+}
+
+// wrapBlobDownloadHandler routes the signed download URLs of the filesystem blob store to the blob download handler
+func wrapBlobDownloadHandler(api http.Handler, blobHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, utils.BlobDownloadPath) {
+			blobHandler.ServeHTTP(w, r)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+}
+
 // setupCORSHandlerLocal allows all origins and sets up the handler
 func setupCORSHandlerLocal(handler http.Handler) http.Handler {
 
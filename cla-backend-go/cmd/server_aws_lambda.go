@@ -8,6 +8,7 @@ package cmd
 import (
 	"github.com/LF-Engineering/aws-lambda-go-api-proxy/httpadapter"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/spf13/cobra"
 )
@@ -21,3 +22,8 @@ func runServer(cmd *cobra.Command, args []string) {
 	lambda.Start(lambdaHandler.Proxy)
 	log.Infof("Lambda shutting down...")
 }
+
+// storageConfig returns the storage driver of the lambda - the configuration file is used as is
+func storageConfig(cfg config.Storage) config.Storage {
+	return cfg
+}
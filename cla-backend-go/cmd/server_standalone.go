@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/communitybridge/easycla/cla-backend-go/config"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/spf13/cobra"
@@ -36,3 +37,15 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	log.Infof("HTTP Server terminated - errors: %v", <-errs)
 }
+
+// storageConfig returns the storage driver of the local server - the STORAGE_DRIVER and STORAGE_DATA_SOURCE
+// environment variables override the configuration file, e.g. to run the server and the functional tests on SQLite
+func storageConfig(cfg config.Storage) config.Storage {
+	if driver := viper.GetString("STORAGE_DRIVER"); driver != "" {
+		cfg.Driver = driver
+	}
+	if dataSource := viper.GetString("STORAGE_DATA_SOURCE"); dataSource != "" {
+		cfg.DataSource = dataSource
+	}
+	return cfg
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/signatures"
 
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+
 	"github.com/aws/aws-lambda-go/lambda"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -47,12 +49,15 @@ func init() {
 		log.Fatal("stage not set")
 	}
 	log.Infof("STAGE : %s", stage)
-	signaturesFileBucket := os.Getenv("CLA_SIGNATURE_FILES_BUCKET")
-	if signaturesFileBucket == "" {
-		log.Fatal("CLA_SIGNATURE_FILES_BUCKET is not set in environment")
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	if err = utils.InitBlobStore(awsSession, configFile.BlobStore, configFile.SignatureFilesBucket); err != nil {
+		log.Fatalf("Unable to set up the blob store - Error: %v", err)
 	}
-	log.Infof("CLA_SIGNATURE_FILES_BUCKET : %s", signaturesFileBucket)
-	zipBuilder = signatures.NewZipBuilder(awsSession, signaturesFileBucket)
+	zipBuilder = signatures.NewZipBuilder(utils.GetBlobStore())
 }
 
 func handler(ctx context.Context, event BuildZipEvent) error {
@@ -10,6 +10,7 @@ import (
 	"os"
 	"sync"
 
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
@@ -60,7 +61,7 @@ func handler(ctx context.Context, event events.CloudWatchEvent) {
 	if stage == "" {
 		log.Fatal("stage not set")
 	}
-	dynamoDBClient := dynamodb.New(awsSession)
+	dynamoDBClient := storage.NewClient(awsSession)
 	claGroups, err := getClaGroups(dynamoDBClient, stage)
 	if err != nil {
 		log.Error("unable to get cla groups", err)
@@ -110,7 +111,7 @@ func invokeLambda(wg *sync.WaitGroup, lambdaClient *lambda.Lambda, stage string,
 	}
 }
 
-func getClaGroups(dynamoDBClient *dynamodb.DynamoDB, stage string) ([]*ClaGroup, error) {
+func getClaGroups(dynamoDBClient storage.DynamoDBAPI, stage string) ([]*ClaGroup, error) {
 	var output []*ClaGroup
 	tableName := fmt.Sprintf("cla-%s-projects", stage)
 	projection := expression.NamesList(
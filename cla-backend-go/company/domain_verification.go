@@ -0,0 +1,294 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// domain verification methods
+const (
+	DomainVerificationMethodDNS   = "dns"
+	DomainVerificationMethodEmail = "email"
+)
+
+// domain verification status values
+const (
+	DomainVerificationStatusPending  = "pending"
+	DomainVerificationStatusVerified = "verified"
+)
+
+// domain verification modes - how an approval list update adding unverified domains is handled
+const (
+	DomainVerificationOff   = "off"
+	DomainVerificationWarn  = "warn"
+	DomainVerificationBlock = "block"
+)
+
+const (
+	// DomainVerificationTXTPrefix is the name prefix of the DNS TXT record holding the challenge
+	DomainVerificationTXTPrefix = "_easycla-challenge."
+	// DomainVerificationTXTValuePrefix is the value prefix of the DNS TXT record holding the challenge
+	DomainVerificationTXTValuePrefix = "easycla-domain-verification="
+	// DomainVerificationTTL is how long a domain verification challenge remains valid
+	DomainVerificationTTL = 7 * 24 * time.Hour
+)
+
+// errors
+var (
+	ErrDomainVerificationNotFound = errors.New("domain verification challenge not found")
+	ErrDomainVerificationExpired  = errors.New("domain verification challenge expired")
+	ErrDomainVerificationFailed   = errors.New("domain verification failed")
+	ErrDomainAlreadyVerified      = errors.New("domain already verified")
+)
+
+// DomainResolver looks up the DNS TXT records of a name - implemented by *net.Resolver
+type DomainResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewDomainResolver returns the DNS resolver used to check the domain verification TXT records
+func NewDomainResolver() DomainResolver {
+	return net.DefaultResolver
+}
+
+// ValidDomainVerificationMode returns true if the mode is one of the domain verification modes
+func ValidDomainVerificationMode(mode string) bool {
+	return mode == DomainVerificationOff || mode == DomainVerificationWarn || mode == DomainVerificationBlock
+}
+
+// GetDomainVerifications returns the domain verification challenges of the company
+func (s service) GetDomainVerifications(ctx context.Context, companyID string) ([]*DomainVerification, error) {
+	dbModel, err := s.repo.GetCompanyDomainVerifications(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return dbModel.DomainVerifications, nil
+}
+
+// CreateDomainVerification issues a DNS TXT or postmaster email challenge for the domain - an existing pending
+// challenge for the domain is replaced
+func (s service) CreateDomainVerification(ctx context.Context, companyID, domain, method, requestedBy string) (*DomainVerification, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateDomainVerification",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"domain":         domain,
+		"method":         method,
+	}
+
+	domain = normalizeDomain(domain)
+	if msg, valid := utils.ValidDomain(domain); !valid {
+		return nil, errors.New(msg)
+	}
+	if method != DomainVerificationMethodDNS && method != DomainVerificationMethodEmail {
+		return nil, fmt.Errorf("invalid domain verification method: %s", method)
+	}
+
+	dbModel, err := s.repo.GetCompanyDomainVerifications(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if utils.StringInSlice(domain, dbModel.VerifiedDomains) {
+		return nil, ErrDomainAlreadyVerified
+	}
+
+	token, err := newDomainVerificationToken()
+	if err != nil {
+		return nil, err
+	}
+	now, nowStr := utils.CurrentTime()
+	verification := &DomainVerification{
+		Domain:      domain,
+		Method:      method,
+		Status:      DomainVerificationStatusPending,
+		Token:       token,
+		RequestedBy: requestedBy,
+		Created:     nowStr,
+		Expires:     utils.TimeToString(now.Add(DomainVerificationTTL)),
+	}
+
+	verifications := []*DomainVerification{verification}
+	for _, existing := range dbModel.DomainVerifications {
+		if existing.Domain != domain {
+			verifications = append(verifications, existing)
+		}
+	}
+	err = s.repo.UpdateCompanyDomainVerifications(ctx, companyID, verifications, dbModel.VerifiedDomains)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store the domain verification challenge, error: %+v", err)
+		return nil, err
+	}
+
+	if method == DomainVerificationMethodEmail {
+		s.sendDomainVerificationEmail(ctx, dbModel, verification)
+	}
+
+	return verification, nil
+}
+
+// VerifyDomain completes the domain verification challenge - the DNS TXT record is looked up or the token sent to the
+// postmaster is compared - and adds the domain to the verified domains of the company
+func (s service) VerifyDomain(ctx context.Context, companyID, domain, token string) (*DomainVerification, error) {
+	f := logrus.Fields{
+		"functionName":   "VerifyDomain",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"domain":         domain,
+	}
+
+	domain = normalizeDomain(domain)
+	dbModel, err := s.repo.GetCompanyDomainVerifications(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var verification *DomainVerification
+	for _, existing := range dbModel.DomainVerifications {
+		if existing.Domain == domain {
+			verification = existing
+			break
+		}
+	}
+	if verification == nil {
+		return nil, ErrDomainVerificationNotFound
+	}
+	if verification.Status == DomainVerificationStatusVerified {
+		return verification, nil
+	}
+
+	now, nowStr := utils.CurrentTime()
+	expires, err := utils.ParseDateTime(verification.Expires)
+	if err != nil || now.After(expires) {
+		return nil, ErrDomainVerificationExpired
+	}
+
+	var checkErr error
+	switch verification.Method {
+	case DomainVerificationMethodDNS:
+		checkErr = s.checkDomainVerificationTXTRecord(ctx, verification)
+	case DomainVerificationMethodEmail:
+		if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(verification.Token)) != 1 {
+			checkErr = fmt.Errorf("%w: the token does not match the token sent to %s", ErrDomainVerificationFailed, postmasterAddress(domain))
+		}
+	default:
+		checkErr = fmt.Errorf("%w: unknown method %s", ErrDomainVerificationFailed, verification.Method)
+	}
+
+	verification.LastCheck = nowStr
+	verifiedDomains := dbModel.VerifiedDomains
+	if checkErr != nil {
+		verification.LastCheckMsg = checkErr.Error()
+	} else {
+		verification.Status = DomainVerificationStatusVerified
+		verification.Verified = nowStr
+		verification.LastCheckMsg = ""
+		verifiedDomains = append(verifiedDomains, domain)
+	}
+
+	err = s.repo.UpdateCompanyDomainVerifications(ctx, companyID, dbModel.DomainVerifications, verifiedDomains)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store the domain verification result, error: %+v", err)
+		return nil, err
+	}
+	if checkErr != nil {
+		log.WithFields(f).Debugf("domain verification failed: %+v", checkErr)
+		return verification, checkErr
+	}
+
+	log.WithFields(f).Debugf("domain verified")
+	return verification, nil
+}
+
+// checkDomainVerificationTXTRecord looks up the challenge TXT record of the domain
+func (s service) checkDomainVerificationTXTRecord(ctx context.Context, verification *DomainVerification) error {
+	name := DomainVerificationTXTPrefix + verification.Domain
+	records, err := s.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return fmt.Errorf("%w: unable to lookup the TXT record %s: %v", ErrDomainVerificationFailed, name, err)
+	}
+	expected := DomainVerificationTXTValuePrefix + verification.Token
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: the TXT record %s does not contain %s", ErrDomainVerificationFailed, name, expected)
+}
+
+// sendDomainVerificationEmail sends the domain verification token to the postmaster of the domain
+func (s service) sendDomainVerificationEmail(ctx context.Context, dbModel *DBModel, verification *DomainVerification) {
+	f := logrus.Fields{
+		"functionName":   "sendDomainVerificationEmail",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      dbModel.CompanyID,
+		"domain":         verification.Domain,
+	}
+	recipients := []string{postmasterAddress(verification.Domain)}
+	err := notifications.Send(ctx, "", notifications.DomainVerificationTemplate, recipients, notifications.DomainVerificationData{
+		CompanyName: dbModel.CompanyName,
+		Domain:      verification.Domain,
+		Token:       verification.Token,
+		Expires:     verification.Expires,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.DomainVerificationTemplate, recipients, err)
+	} else {
+		log.WithFields(f).Debugf("sent %s email to recipients: %+v", notifications.DomainVerificationTemplate, recipients)
+	}
+}
+
+// UnverifiedDomains returns the domain approval list entries which are not covered by the verified domains - an entry
+// is covered when it is a verified domain or a subdomain of one, the wildcard forms "*.domain" and ".domain" included
+func UnverifiedDomains(verifiedDomains []string, domains []string) []string {
+	var unverified []string
+	for _, entry := range domains {
+		domain := normalizeDomain(entry)
+		domain = strings.TrimPrefix(strings.TrimPrefix(domain, "*"), ".")
+		covered := false
+		for _, verified := range verifiedDomains {
+			verified = normalizeDomain(verified)
+			if domain != "" && (domain == verified || strings.HasSuffix(domain, "."+verified)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			unverified = append(unverified, entry)
+		}
+	}
+	return unverified
+}
+
+// normalizeDomain returns the lower case domain without the surrounding spaces and trailing dot
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// postmasterAddress returns the postmaster email address of the domain
+func postmasterAddress(domain string) string {
+	return "postmaster@" + domain
+}
+
+// newDomainVerificationToken returns a random challenge token
+func newDomainVerificationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
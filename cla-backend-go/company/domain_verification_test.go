@@ -0,0 +1,109 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeResolver returns the TXT records by name
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+// fakeDomainVerificationRepo keeps the company domain verifications in memory
+type fakeDomainVerificationRepo struct {
+	IRepository
+	dbModel *DBModel
+}
+
+func (r *fakeDomainVerificationRepo) GetCompanyDomainVerifications(ctx context.Context, companyID string) (*DBModel, error) {
+	return r.dbModel, nil
+}
+
+func (r *fakeDomainVerificationRepo) UpdateCompanyDomainVerifications(ctx context.Context, companyID string, verifications []*DomainVerification, verifiedDomains []string) error {
+	r.dbModel.DomainVerifications = verifications
+	r.dbModel.VerifiedDomains = verifiedDomains
+	return nil
+}
+
+// TestVerifyDomainDNS tests the DNS TXT record challenge
+func TestVerifyDomainDNS(t *testing.T) {
+	repo := &fakeDomainVerificationRepo{dbModel: &DBModel{CompanyID: "company-1"}}
+	resolver := fakeResolver{}
+	s := service{repo: repo, resolver: resolver}
+	ctx := context.Background()
+
+	verification, err := s.CreateDomainVerification(ctx, "company-1", " Example.ORG ", DomainVerificationMethodDNS, "manager1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "example.org", verification.Domain)
+	assert.Equal(t, DomainVerificationStatusPending, verification.Status)
+
+	// No TXT record published yet
+	_, err = s.VerifyDomain(ctx, "company-1", "example.org", "")
+	assert.True(t, errors.Is(err, ErrDomainVerificationFailed))
+	assert.Nil(t, repo.dbModel.VerifiedDomains)
+
+	resolver["_easycla-challenge.example.org"] = []string{"v=spf1 -all", DomainVerificationTXTValuePrefix + verification.Token}
+	verification, err = s.VerifyDomain(ctx, "company-1", "example.org", "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, DomainVerificationStatusVerified, verification.Status)
+		assert.Equal(t, []string{"example.org"}, repo.dbModel.VerifiedDomains)
+	}
+
+	_, err = s.CreateDomainVerification(ctx, "company-1", "example.org", DomainVerificationMethodDNS, "manager1")
+	assert.Equal(t, ErrDomainAlreadyVerified, err)
+}
+
+// TestVerifyDomainEmail tests the postmaster token challenge and the challenge expiry
+func TestVerifyDomainEmail(t *testing.T) {
+	now, nowStr := utils.CurrentTime()
+	repo := &fakeDomainVerificationRepo{dbModel: &DBModel{
+		CompanyID: "company-1",
+		DomainVerifications: []*DomainVerification{
+			{Domain: "example.org", Method: DomainVerificationMethodEmail, Status: DomainVerificationStatusPending, Token: "secret",
+				Created: nowStr, Expires: utils.TimeToString(now.Add(time.Hour))},
+			{Domain: "example.com", Method: DomainVerificationMethodEmail, Status: DomainVerificationStatusPending, Token: "secret",
+				Created: nowStr, Expires: utils.TimeToString(now.Add(-time.Hour))},
+		},
+	}}
+	s := service{repo: repo, resolver: fakeResolver{}}
+	ctx := context.Background()
+
+	_, err := s.VerifyDomain(ctx, "company-1", "example.org", "wrong")
+	assert.True(t, errors.Is(err, ErrDomainVerificationFailed))
+
+	verification, err := s.VerifyDomain(ctx, "company-1", "example.org", "secret")
+	if assert.NoError(t, err) {
+		assert.Equal(t, DomainVerificationStatusVerified, verification.Status)
+	}
+
+	_, err = s.VerifyDomain(ctx, "company-1", "example.com", "secret")
+	assert.Equal(t, ErrDomainVerificationExpired, err)
+
+	_, err = s.VerifyDomain(ctx, "company-1", "example.net", "secret")
+	assert.Equal(t, ErrDomainVerificationNotFound, err)
+}
+
+// TestUnverifiedDomains tests the coverage of the approval list domains by the verified domains
+func TestUnverifiedDomains(t *testing.T) {
+	verified := []string{"example.org", "Example.com."}
+	assert.Nil(t, UnverifiedDomains(verified, []string{"example.org", "eng.example.org", "*.example.com", ".example.com", "EXAMPLE.COM"}))
+	assert.Equal(t, []string{"example.net", "badexample.org", "*"}, UnverifiedDomains(verified, []string{"example.net", "badexample.org", "*"}))
+	assert.Equal(t, []string{"example.org"}, UnverifiedDomains(nil, []string{"example.org"}))
+}
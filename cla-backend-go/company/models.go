@@ -21,6 +21,24 @@ type DBModel struct {
 	Updated           string   `dynamodbav:"date_modified" json:"date_modified"`
 	Note              string   `dynamodbav:"note" json:"note"`
 	Version           string   `dynamodbav:"version" json:"version"`
+
+	// Domains the company has proven to own and the pending/completed domain verification challenges
+	VerifiedDomains     []string              `dynamodbav:"verified_domains,omitempty" json:"verified_domains,omitempty"`
+	DomainVerifications []*DomainVerification `dynamodbav:"domain_verifications,omitempty" json:"domain_verifications,omitempty"`
+}
+
+// DomainVerification data model - a DNS TXT or postmaster email challenge proving the company owns the domain
+type DomainVerification struct {
+	Domain       string `dynamodbav:"domain" json:"domain"`
+	Method       string `dynamodbav:"method" json:"method"`
+	Status       string `dynamodbav:"status" json:"status"`
+	Token        string `dynamodbav:"token" json:"token"`
+	RequestedBy  string `dynamodbav:"requested_by" json:"requested_by"`
+	Created      string `dynamodbav:"date_created" json:"date_created"`
+	Expires      string `dynamodbav:"date_expires" json:"date_expires"`
+	Verified     string `dynamodbav:"date_verified,omitempty" json:"date_verified,omitempty"`
+	LastCheck    string `dynamodbav:"date_last_check,omitempty" json:"date_last_check,omitempty"`
+	LastCheckMsg string `dynamodbav:"last_check_message,omitempty" json:"last_check_message,omitempty"`
 }
 
 // Invite data model
@@ -75,6 +93,7 @@ func (dbCompanyModel *DBModel) toModel() (*models.Company, error) {
 		Updated:           strfmt.DateTime(updateDateTime),
 		Note:              dbCompanyModel.Note,
 		Version:           dbCompanyModel.Version,
+		VerifiedDomains:   dbCompanyModel.VerifiedDomains,
 	}, nil
 }
 
@@ -103,5 +122,6 @@ func toSwaggerModel(dbCompanyModel *DBModel) (*models.Company, error) {
 		Updated:           strfmt.DateTime(updateDateTime),
 		Note:              dbCompanyModel.Note,
 		Version:           dbCompanyModel.Version,
+		VerifiedDomains:   dbCompanyModel.VerifiedDomains,
 	}, nil
 }
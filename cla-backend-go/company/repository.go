@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 	"github.com/gofrs/uuid"
 )
 
@@ -53,14 +54,16 @@ type IRepository interface { //nolint
 	GetUserInviteRequests(ctx context.Context, userID string) ([]Invite, error)
 	ApproveCompanyAccessRequest(ctx context.Context, companyInviteID string) error
 	RejectCompanyAccessRequest(ctx context.Context, companyInviteID string) error
-	updateInviteRequestStatus(ctx context.Context, companyInviteID, status string) error
 
 	UpdateCompanyAccessList(ctx context.Context, companyID string, companyACL []string) error
+
+	GetCompanyDomainVerifications(ctx context.Context, companyID string) (*DBModel, error)
+	UpdateCompanyDomainVerifications(ctx context.Context, companyID string, verifications []*DomainVerification, verifiedDomains []string) error
 }
 
 type repository struct {
 	stage                   string
-	dynamoDBClient          *dynamodb.DynamoDB
+	dynamoDBClient          storage.DynamoDBAPI
 	companyTableName        string
 	companyInvitesTableName string
 }
@@ -69,7 +72,7 @@ type repository struct {
 func NewRepository(awsSession *session.Session, stage string) IRepository {
 	return repository{
 		stage:                   stage,
-		dynamoDBClient:          dynamodb.New(awsSession),
+		dynamoDBClient:          storage.NewClient(awsSession),
 		companyTableName:        fmt.Sprintf("cla-%s-companies", stage),
 		companyInvitesTableName: fmt.Sprintf("cla-%s-company-invites", stage),
 	}
@@ -638,6 +641,7 @@ func buildCompanyModels(ctx context.Context, results *dynamodb.ScanOutput) ([]mo
 		CompanyExternalID string   `json:"company_external_id"`
 		Created           string   `json:"date_created"`
 		Modified          string   `json:"date_modified"`
+		VerifiedDomains   []string `json:"verified_domains"`
 	}
 
 	// The DB company model
@@ -673,6 +677,7 @@ func buildCompanyModels(ctx context.Context, results *dynamodb.ScanOutput) ([]mo
 			CompanyExternalID: dbCompany.CompanyExternalID,
 			Created:           strfmt.DateTime(createdDateTime),
 			Updated:           strfmt.DateTime(modifiedDateTime),
+			VerifiedDomains:   dbCompany.VerifiedDomains,
 		})
 	}
 
@@ -745,7 +750,7 @@ func (repo repository) GetCompanyInviteRequests(ctx context.Context, companyID s
 		WithProjection(buildInvitesProjection())
 
 	if status != nil {
-		builder.WithFilter(expression.Name("status").Equal(expression.Value(*status)))
+		builder = builder.WithFilter(expression.Name("status").Equal(expression.Value(*status)))
 	}
 
 	expr, err := builder.Build()
@@ -1167,3 +1172,88 @@ func (repo repository) CreateCompany(ctx context.Context, in *models.Company) (*
 	log.WithFields(f).Debugf("company created %#v\n", comp)
 	return comp.toModel()
 }
+
+// GetCompanyDomainVerifications returns the company record with the domain verification challenges and verified domains
+func (repo repository) GetCompanyDomainVerifications(ctx context.Context, companyID string) (*DBModel, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCompanyDomainVerifications",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+	companyTableData, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error fetching company table data using company id: %s, error: %v", companyID, err)
+		return nil, err
+	}
+	if len(companyTableData.Item) == 0 {
+		return nil, ErrCompanyDoesNotExist
+	}
+
+	dbCompanyModel := DBModel{}
+	err = dynamodbattribute.UnmarshalMap(companyTableData.Item, &dbCompanyModel)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling company table data, error: %v", err)
+		return nil, err
+	}
+
+	return &dbCompanyModel, nil
+}
+
+// UpdateCompanyDomainVerifications stores the domain verification challenges and verified domains of the company
+func (repo repository) UpdateCompanyDomainVerifications(ctx context.Context, companyID string, verifications []*DomainVerification, verifiedDomains []string) error {
+	f := logrus.Fields{
+		"functionName":    "UpdateCompanyDomainVerifications",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"companyID":       companyID,
+		"verifiedDomains": strings.Join(verifiedDomains, ","),
+	}
+	_, now := utils.CurrentTime()
+
+	verificationsAttr, err := dynamodbattribute.Marshal(verifications)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal the domain verifications, error: %v", err)
+		return err
+	}
+
+	expressionAttributeNames := map[string]*string{
+		"#V": aws.String("domain_verifications"),
+		"#D": aws.String("verified_domains"),
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":v": verificationsAttr,
+		":m": {
+			S: aws.String(now),
+		},
+	}
+	updateExpression := "SET #V = :v, #M = :m REMOVE #D"
+	if len(verifiedDomains) > 0 {
+		expressionAttributeValues[":d"] = &dynamodb.AttributeValue{SS: aws.StringSlice(verifiedDomains)}
+		updateExpression = "SET #V = :v, #D = :d, #M = :m"
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		TableName:                 aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		UpdateExpression: aws.String(updateExpression),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error updating the company domain verifications, error: %v", err)
+		return err
+	}
+
+	return nil
+}
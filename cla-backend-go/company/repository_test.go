@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetCompanyInviteRequestsStatusFilter checks the invites are filtered by status - the filter used to be built and
+// then dropped, returning the invites of every status
+func TestGetCompanyInviteRequestsStatusFilter(t *testing.T) {
+	db, err := storage.Open(config.StorageDriverSQLite, ":memory:")
+	if err != nil {
+		t.Skipf("the SQLite storage driver is not available: %v", err)
+	}
+	defer db.Close() // nolint
+
+	repo := repository{
+		stage:                   "test",
+		dynamoDBClient:          storage.NewSQLClient(db),
+		companyTableName:        "cla-test-companies",
+		companyInvitesTableName: "cla-test-company-invites",
+	}
+	for _, invite := range []Invite{
+		{CompanyInviteID: "i1", RequestedCompanyID: "c1", UserID: "u1", Status: "pending"},
+		{CompanyInviteID: "i2", RequestedCompanyID: "c1", UserID: "u2", Status: "rejected"},
+	} {
+		item, marshalErr := dynamodbattribute.MarshalMap(invite)
+		assert.NoError(t, marshalErr)
+		_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{TableName: aws.String(repo.companyInvitesTableName), Item: item})
+		assert.NoError(t, err)
+	}
+
+	invites, err := repo.GetCompanyInviteRequests(context.Background(), "c1", aws.String("pending"))
+	if assert.NoError(t, err) && assert.Len(t, invites, 1) {
+		assert.Equal(t, "i1", invites[0].CompanyInviteID)
+	}
+	invites, err = repo.GetCompanyInviteRequests(context.Background(), "c1", nil)
+	assert.NoError(t, err)
+	assert.Len(t, invites, 2)
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
 	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/client/organizations"
@@ -26,6 +27,7 @@ type service struct {
 	userDynamoRepo      user.RepositoryService
 	corporateConsoleURL string
 	userService         users.Service
+	resolver            DomainResolver
 }
 
 const (
@@ -45,12 +47,17 @@ type IService interface { // nolint
 	GetCompaniesByUserManagerWithInvites(ctx context.Context, userID string) (*models.CompaniesWithInvites, error)
 
 	AddUserToCompanyAccessList(ctx context.Context, companyID, lfid string) error
+	DisassociateUserFromCompany(ctx context.Context, userID, companyID string) error
 	GetCompanyInviteRequests(ctx context.Context, companyID string, status *string) ([]models.CompanyInviteUser, error)
 	GetCompanyUserInviteRequests(ctx context.Context, companyID string, userID string) (*models.CompanyInviteUser, error)
 	AddPendingCompanyInviteRequest(ctx context.Context, companyID string, userID string) (*InviteModel, error)
 	ApproveCompanyAccessRequest(ctx context.Context, companyInviteID string) (*InviteModel, error)
 	RejectCompanyAccessRequest(ctx context.Context, companyInviteID string) (*InviteModel, error)
 
+	GetDomainVerifications(ctx context.Context, companyID string) ([]*DomainVerification, error)
+	CreateDomainVerification(ctx context.Context, companyID, domain, method, requestedBy string) (*DomainVerification, error)
+	VerifyDomain(ctx context.Context, companyID, domain, token string) (*DomainVerification, error)
+
 	// calls org service
 	SearchOrganizationByName(ctx context.Context, orgName string, websiteName string, filter string) (*models.OrgList, error)
 
@@ -61,12 +68,13 @@ type IService interface { // nolint
 }
 
 // NewService creates a new company service object
-func NewService(repo IRepository, corporateConsoleURL string, userDynamoRepo user.RepositoryService, userService users.Service) IService {
+func NewService(repo IRepository, corporateConsoleURL string, userDynamoRepo user.RepositoryService, userService users.Service, resolver DomainResolver) IService {
 	return service{
 		repo:                repo,
 		userDynamoRepo:      userDynamoRepo,
 		corporateConsoleURL: corporateConsoleURL,
 		userService:         userService,
+		resolver:            resolver,
 	}
 }
 
@@ -399,84 +407,67 @@ func (s service) AddUserToCompanyAccessList(ctx context.Context, companyID, lfid
 	return nil
 }
 
-// sendRequestAccessEmail sends the request access email
-func (s service) sendRequestAccessEmail(ctx context.Context, companyModel *models.Company, requesterName, requesterEmail, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
+// DisassociateUserFromCompany clears the user's company when the user is currently associated with the specified company
+func (s service) DisassociateUserFromCompany(ctx context.Context, userID, companyID string) error {
+	userModel, err := s.userDynamoRepo.GetUser(userID)
+	if err != nil {
+		log.Warnf("DisassociateUserFromCompany - unable to locate user model by ID: %s, error: %+v", userID, err)
+		return err
+	}
 
-	requestedUserInfo := fmt.Sprintf("<ul><li>%s (%s)</li></ul>", requesterName, requesterEmail)
+	if userModel.UserCompanyID != companyID {
+		log.Debugf("DisassociateUserFromCompany - user: %s is associated with company ID: %s, not: %s - nothing to clear",
+			userID, userModel.UserCompanyID, companyID)
+		return nil
+	}
 
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New Company Manager Access Request for %s", companyName)
-	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the company %s.</p>
-<p>The following user has requested to join %s as a Company Manager. 
-By approving this request the user could view and apply for CLA Manager
-status on projects associated with your company. </p>
-%s
-<p>To get started, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>, and select your
-company. From there you will be able to view the list of projects which have EasyCLA configured and apply for CLA
-Manager status.
-</p>
-%s
-%s`,
-		recipientName, companyName, companyName, requestedUserInfo, utils.GetCorporateURL(false),
-		utils.GetEmailHelpContent(false), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	_, err = s.userDynamoRepo.SetCompanyID(userID, "")
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Warnf("DisassociateUserFromCompany - unable to clear the company ID of user: %s, error: %+v", userID, err)
+		return err
 	}
+
+	return nil
+}
+
+// sendRequestAccessEmail sends the request access email
+func (s service) sendRequestAccessEmail(ctx context.Context, companyModel *models.Company, requesterName, requesterEmail, recipientName, recipientAddress string) {
+	s.sendCompanyManagerAccessEmail(ctx, notifications.CompanyManagerAccessRequestTemplate, companyModel, requesterName, requesterEmail, recipientName, recipientAddress)
 }
 
 // sendRequestApprovedEmailToRecipient generates and sends an email to the specified recipient
 func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, companyModel *models.Company, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
+	s.sendCompanyManagerAccessEmail(ctx, notifications.CompanyManagerAccessApprovedTemplate, companyModel, recipientName, recipientAddress, recipientName, recipientAddress)
+}
 
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Company Manager Access Approved for %s", companyName)
+// sendCompanyManagerAccessEmail sends the company manager access notification about the specified user to the recipient
+func (s service) sendCompanyManagerAccessEmail(ctx context.Context, templateName string, companyModel *models.Company, userName, userEmail, recipientName, recipientAddress string) {
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the company %s.</p>
-<p>You have now been approved as a Company Manager for %s.
-This means that you can now view and apply for CLA Manager status on
-projects associated with your company.
-</p>
-<p>To get started, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>, and select your
-company. From there you will be able to view the list of projects which have EasyCLA configured and apply for CLA
-Manager status.
-</p>
-%s
-%s`,
-		recipientName, companyName, companyName, utils.GetCorporateURL(false),
-		utils.GetEmailHelpContent(false), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, "", templateName, recipients, notifications.CompanyManagerAccessData{
+		RecipientName:       recipientName,
+		CompanyName:         companyModel.CompanyName,
+		UserName:            userName,
+		UserEmail:           userEmail,
+		CorporateConsoleURL: utils.GetCorporateURL(false),
+		V2:                  false,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", templateName, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", templateName, recipients)
 	}
 }
 
 // sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
 func (s service) sendRequestRejectedEmailToRecipient(ctx context.Context, companyModel *models.Company, recipientName, recipientAddress string) {
-	companyName := companyModel.CompanyName
-
-	var companyManagerText = ""
-	companyManagerText += "<ul>"
-
-	// Build a fancy text string with CLA Manager name <email> as an HTML unordered list
+	var managers []notifications.Contact
 	for _, companyAdminLFID := range companyModel.CompanyACL {
 
 		userModel, userErr := s.userDynamoRepo.GetUserAndProfilesByLFID(companyAdminLFID)
 		if userErr != nil {
 			log.Warnf("RejectCompanyAccessRequest - unable to locate user model by ID: %s, error: %+v",
 				companyAdminLFID, userErr)
+			continue
 		}
 
 		// Need to determine which email...
@@ -493,32 +484,21 @@ func (s service) sendRequestRejectedEmailToRecipient(ctx context.Context, compan
 		if whichEmail == "" {
 			log.Warnf("unable to send email to manager: %+v - no email on file...", userModel)
 		} else {
-			companyManagerText += fmt.Sprintf("<li>%s <%s></li>", userModel.Name, whichEmail)
+			managers = append(managers, notifications.Contact{Name: userModel.Name, Email: whichEmail})
 		}
 	}
 
-	companyManagerText += "</ul>"
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Denied for %s", companyName)
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the company %s.</p>
-<p>Your request to become a Company Manager was denied by one of the existing Company Managers.
-If you have further questions about this denial, please contact one of the existing managers from
-%s:</p>
-%s
-%s
-%s`,
-		recipientName, companyName, companyName, companyManagerText,
-		utils.GetEmailHelpContent(false), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, "", notifications.CompanyManagerAccessDeniedTemplate, recipients, notifications.ManagersContactData{
+		RecipientName: recipientName,
+		CompanyName:   companyModel.CompanyName,
+		Managers:      managers,
+		V2:            false,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.CompanyManagerAccessDeniedTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.CompanyManagerAccessDeniedTemplate, recipients)
 	}
 }
 
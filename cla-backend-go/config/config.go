@@ -47,18 +47,30 @@ type Config struct {
 	// Sender Email Address
 	SenderEmailAddress string `json:"senderEmailAddress"`
 
+	// Email transport used to send the outgoing emails
+	Email Email `json:"email"`
+
+	// Storage driver of the repositories
+	Storage Storage `json:"storage"`
+
 	AllowedOriginsCommaSeparated string   `json:"allowedOriginsCommaSeparated"`
 	AllowedOrigins               []string `json:"-"`
 
 	CorporateConsoleURL   string `json:"corporateConsoleURL"`
 	CorporateConsoleV2URL string `json:"corporateConsoleV2URL"`
 
+	// ContributorConsoleV2URL is the host of the contributor console used to sign the CLAs
+	ContributorConsoleV2URL string `json:"contributorConsoleV2URL"`
+
 	// SNSEventTopic the topic ARN for events
 	SNSEventTopicARN string `json:"snsEventTopicARN"`
 
 	// S3 bucket to store signatures
 	SignatureFilesBucket string `json:"signatureFilesBucket"`
 
+	// Object storage of the signed documents and their zip files
+	BlobStore BlobStore `json:"blob_store"`
+
 	// LF Group
 	LFGroup LFGroup `json:"lf_group"`
 
@@ -70,6 +82,12 @@ type Config struct {
 
 	// LFXPortalURL is url of the LFX UI for the particular environment
 	LFXPortalURL string `json:"lfx_portal_url"`
+
+	// MetricsBearerToken must be sent by the scrapers of the /metrics endpoint
+	MetricsBearerToken string `json:"metrics_bearer_token"`
+
+	// GerritHookSecret is the shared secret sent by the Gerrit hooks calling the contributor agreement check
+	GerritHookSecret string `json:"gerrit_hook_secret"`
 }
 
 // Auth0 model
@@ -88,10 +106,85 @@ type Auth0Platform struct {
 	URL          string `json:"url"`
 }
 
+// PDF renderer types
+const (
+	PDFRendererDocraptor = "docraptor"
+	PDFRendererLocal     = "local"
+)
+
 // Docraptor model
 type Docraptor struct {
 	APIKey   string `json:"apiKey"`
 	TestMode bool   `json:"testMode"`
+	// Renderer is one of docraptor or local - defaults to docraptor. The local renderer generates the PDFs without
+	// the DocRaptor service, e.g. for development, tests and air-gapped deployments.
+	Renderer string `json:"renderer"`
+}
+
+// email transport types
+const (
+	EmailTransportSNS     = "sns"
+	EmailTransportSMTP    = "smtp"
+	EmailTransportMaildir = "maildir"
+)
+
+// Email contains the outgoing email transport configuration
+type Email struct {
+	// Transport is one of sns, smtp or maildir - defaults to sns
+	Transport string `json:"transport"`
+	SMTP      SMTP   `json:"smtp"`
+	// MaildirPath is the directory where the maildir transport writes the emails
+	MaildirPath string `json:"maildir_path"`
+}
+
+// SMTP contains the SMTP server access information
+type SMTP struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	StartTLS bool   `json:"starttls"`
+}
+
+// storage driver types
+const (
+	StorageDriverDynamoDB = "dynamodb"
+	StorageDriverSQLite   = "sqlite3"
+	StorageDriverPostgres = "postgres"
+)
+
+// Storage contains the storage driver configuration of the repositories
+type Storage struct {
+	// Driver is one of dynamodb, sqlite3 or postgres - defaults to dynamodb. The SQL drivers keep the tables in a
+	// local database, e.g. for running the backend standalone and the functional tests offline.
+	Driver string `json:"driver"`
+	// DataSource is the database file of the sqlite3 driver or the connection string of the postgres driver
+	DataSource string `json:"data_source"`
+}
+
+// blob store types
+const (
+	BlobStoreS3           = "s3"
+	BlobStoreS3Compatible = "s3-compatible"
+	BlobStoreFilesystem   = "filesystem"
+)
+
+// BlobStore contains the object storage configuration of the signed documents - the bucket is the signature files
+// bucket for the s3 and s3-compatible types
+type BlobStore struct {
+	// Type is one of s3, s3-compatible or filesystem - defaults to s3
+	Type string `json:"type"`
+	// Endpoint, Region and the access keys of the s3-compatible type, e.g. a MinIO server
+	Endpoint        string `json:"endpoint"`
+	Region          string `json:"region"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	// Path is the root directory of the filesystem type
+	Path string `json:"path"`
+	// BaseURL is the external URL of this server, the filesystem type serves the signed download URLs under it
+	BaseURL string `json:"base_url"`
+	// URLSigningKey is the secret used by the filesystem type to sign the download URLs
+	URLSigningKey string `json:"url_signing_key"`
 }
 
 // LFGroup contains LF LDAP group access information
@@ -114,6 +207,7 @@ type Github struct {
 	AccessToken   string `json:"accessToken"`
 	AppID         int    `json:"app_id"`
 	AppPrivateKey string `json:"app_private_key"`
+	WebhookSecret string `json:"webhook_secret"`
 }
 
 // GetConfig returns the current EasyCLA configuration
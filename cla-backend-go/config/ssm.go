@@ -59,8 +59,10 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-gh-access-token-%s", stage),
 		fmt.Sprintf("cla-gh-app-id-%s", stage),
 		fmt.Sprintf("cla-gh-app-private-key-%s", stage),
+		fmt.Sprintf("cla-gh-app-webhook-secret-%s", stage),
 		fmt.Sprintf("cla-corporate-base-%s", stage),
 		fmt.Sprintf("cla-corporate-v2-base-%s", stage),
+		fmt.Sprintf("cla-contributor-v2-base-%s", stage),
 		fmt.Sprintf("cla-doc-raptor-api-key-%s", stage),
 		fmt.Sprintf("cla-session-store-table-%s", stage),
 		fmt.Sprintf("cla-ses-sender-email-address-%s", stage),
@@ -79,6 +81,14 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-v1-api-url-%s", stage),
 		fmt.Sprintf("cla-acs-api-key-%s", stage),
 		fmt.Sprintf("cla-lfx-portal-url-%s", stage),
+		fmt.Sprintf("cla-gerrit-hook-secret-%s", stage),
+		fmt.Sprintf("cla-metrics-bearer-token-%s", stage),
+	}
+
+	// The keys which may be missing - the endpoint depending on the key is turned off when it is not set
+	optionalSSMKeys := map[string]bool{
+		fmt.Sprintf("cla-gerrit-hook-secret-%s", stage):   true,
+		fmt.Sprintf("cla-metrics-bearer-token-%s", stage): true,
 	}
 
 	// For each key to lookup
@@ -87,7 +97,10 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		go func(theKey string) {
 			theValue, err := getSSMString(ssmClient, theKey)
 			if err != nil {
-				log.Fatalf("error looking up key: %s", theKey)
+				if !optionalSSMKeys[theKey] {
+					log.Fatalf("error looking up key: %s", theKey)
+				}
+				log.Warnf("optional key: %s is not set", theKey)
 			}
 			// Send the response back through the channel
 			responseChannel <- configLookupResponse{
@@ -123,6 +136,8 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.Github.AppID = githubAppID
 		case fmt.Sprintf("cla-gh-app-private-key-%s", stage):
 			config.Github.AppPrivateKey = resp.value
+		case fmt.Sprintf("cla-gh-app-webhook-secret-%s", stage):
+			config.Github.WebhookSecret = resp.value
 
 		case fmt.Sprintf("cla-corporate-base-%s", stage):
 			corporateConsoleURLValue := resp.value
@@ -132,6 +147,8 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.CorporateConsoleURL = corporateConsoleURLValue
 		case fmt.Sprintf("cla-corporate-v2-base-%s", stage):
 			config.CorporateConsoleV2URL = resp.value
+		case fmt.Sprintf("cla-contributor-v2-base-%s", stage):
+			config.ContributorConsoleV2URL = resp.value
 		case fmt.Sprintf("cla-doc-raptor-api-key-%s", stage):
 			config.Docraptor.APIKey = resp.value
 			config.Docraptor.TestMode = stage != "prod" && stage != "staging"
@@ -167,6 +184,10 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.ClaV1ApiURL = resp.value
 		case fmt.Sprintf("cla-acs-api-key-%s", stage):
 			config.AcsAPIKey = resp.value
+		case fmt.Sprintf("cla-gerrit-hook-secret-%s", stage):
+			config.GerritHookSecret = resp.value
+		case fmt.Sprintf("cla-metrics-bearer-token-%s", stage):
+			config.MetricsBearerToken = resp.value
 		case fmt.Sprintf("cla-lfx-portal-url-%s", stage):
 			config.LFXPortalURL = resp.value
 		}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
@@ -63,6 +64,11 @@ func (dc Client) CreatePDF(html string) (io.ReadCloser, error) {
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("docraptor returned status %d: %s", resp.StatusCode, body)
+	}
 
 	return resp.Body, nil
 }
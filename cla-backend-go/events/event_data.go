@@ -5,6 +5,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 )
 
 // EventData returns event data string which is used for event logging and containsPII field
@@ -88,6 +89,13 @@ type CompanyACLUserAddedEventData struct {
 // CLATemplateCreatedEventData . . .
 type CLATemplateCreatedEventData struct{}
 
+// CLATemplateUploadedEventData . . .
+type CLATemplateUploadedEventData struct {
+	TemplateID     string
+	TemplateName   string
+	FoundationSFID string
+}
+
 // GithubOrganizationAddedEventData . . .
 type GithubOrganizationAddedEventData struct {
 	GithubOrganizationName string
@@ -229,6 +237,31 @@ type CLAApprovalListRemoveGitHubUsernameData struct {
 	ApprovalListGitHubUsername string
 }
 
+// CLAApprovalListSelfRemovedData . . .
+type CLAApprovalListSelfRemovedData struct {
+	UserName               string
+	UserEmail              string
+	UserLFID               string
+	RemovedEmails          []string
+	RemovedGitHubUsernames []string
+}
+
+// CLAApprovalListUnverifiedDomainsData . . .
+type CLAApprovalListUnverifiedDomainsData struct {
+	UserName  string
+	UserEmail string
+	UserLFID  string
+	Domains   []string
+}
+
+// CLAApprovalListEntryExpiredData . . .
+type CLAApprovalListEntryExpiredData struct {
+	ListType  string
+	Value     string
+	AddedBy   string
+	ExpiresAt string
+}
+
 // CLAApprovalListAddGitHubOrgData . . .
 type CLAApprovalListAddGitHubOrgData struct {
 	UserName              string
@@ -275,6 +308,64 @@ type CLAGroupUpdatedEventData struct{}
 // CLAGroupDeletedEventData . . .
 type CLAGroupDeletedEventData struct{}
 
+// ResignCampaignCreatedEventData . . .
+type ResignCampaignCreatedEventData struct {
+	CampaignID   string
+	ClaType      string
+	MajorVersion int64
+	GraceDate    string
+	SignerCount  int
+}
+
+// ResignCampaignCancelledEventData . . .
+type ResignCampaignCancelledEventData struct {
+	CampaignID   string
+	MajorVersion int64
+}
+
+// DataSubjectExportedEventData . . .
+type DataSubjectExportedEventData struct {
+	UserIDs     []string
+	RecordCount int
+}
+
+// DataSubjectErasedEventData . . .
+type DataSubjectErasedEventData struct {
+	UserIDs             []string
+	Reason              string
+	RedactedCount       int
+	RemovedCount        int
+	DeletedCount        int
+	RetainedRoleCount   int
+	PreservedSignatures int
+}
+
+// RetentionPolicyUpdatedEventData . . .
+type RetentionPolicyUpdatedEventData struct {
+	FoundationSFID                     string
+	DeletedClaGroupRetentionYears      int64
+	InvalidatedSignatureRetentionYears int64
+	Action                             string
+}
+
+// RetentionPolicyDeletedEventData . . .
+type RetentionPolicyDeletedEventData struct {
+	FoundationSFID string
+}
+
+// LegalHoldPlacedEventData . . .
+type LegalHoldPlacedEventData struct {
+	HoldType    string
+	ReferenceID string
+	Reason      string
+}
+
+// LegalHoldReleasedEventData . . .
+type LegalHoldReleasedEventData struct {
+	HoldType    string
+	ReferenceID string
+}
+
 // ContributorNotifyCompanyAdminData . . .
 type ContributorNotifyCompanyAdminData struct {
 	AdminName  string
@@ -381,6 +472,13 @@ func (ed *CLATemplateCreatedEventData) GetEventDetailsString(args *LogEventArgs)
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *CLATemplateUploadedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] uploaded custom CLA template [%s] with ID [%s] for foundation [%s]",
+		args.userName, ed.TemplateName, ed.TemplateID, ed.FoundationSFID)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *GithubOrganizationAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] added github organization [%s] with auto-enabled: %t",
@@ -500,6 +598,28 @@ func (ed *CLAApprovalListRemoveGitHubUsernameData) GetEventDetailsString(args *L
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListSelfRemovedData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Contributor [%s / %s / %s] removed themselves from the approval list for Company: %s, Project: %s - Emails: %s, GitHub Usernames: %s",
+		ed.UserName, ed.UserEmail, ed.UserLFID, args.companyName, args.projectName,
+		strings.Join(ed.RemovedEmails, ","), strings.Join(ed.RemovedGitHubUsernames, ","))
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListUnverifiedDomainsData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager [%s / %s / %s] added the domains %s, which the company has not verified, to the approval list for Company: %s, Project: %s",
+		ed.UserName, ed.UserEmail, ed.UserLFID, strings.Join(ed.Domains, ","), args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListEntryExpiredData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Approval list %s entry %s added by CLA Manager %s expired on %s and was removed from the approval list for Company: %s, Project: %s",
+		ed.ListType, ed.Value, ed.AddedBy, ed.ExpiresAt, args.companyName, args.projectName)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *CLAApprovalListAddGitHubOrgData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("CLA Manager [%s / %s / %s] added GitHub Org %s to the approval list for Company: %s, Project: %s",
@@ -570,6 +690,68 @@ func (ed *CLAGroupDeletedEventData) GetEventDetailsString(args *LogEventArgs) (s
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *ResignCampaignCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has created the re-sign campaign [%s] asking %d %s signers of CLA Group [%s - %s] to sign the document major version %d",
+		args.userName, ed.CampaignID, ed.SignerCount, ed.ClaType, args.projectName, args.ProjectID, ed.MajorVersion)
+	if ed.GraceDate != "" {
+		data = data + fmt.Sprintf(", older signatures are honored until %s", ed.GraceDate)
+	}
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ResignCampaignCancelledEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has cancelled the re-sign campaign [%s] for the document major version %d of CLA Group [%s - %s]",
+		args.userName, ed.CampaignID, ed.MajorVersion, args.projectName, args.ProjectID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *DataSubjectExportedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has exported the %d records held about the data subject with the user IDs [%s]",
+		args.userName, ed.RecordCount, strings.Join(ed.UserIDs, ","))
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *DataSubjectErasedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has erased the data subject with the user IDs [%s]: %d records redacted, %d approval list entries and CLA manager roles removed, %d requests deleted, %d signatures preserved in pseudonymized form",
+		args.userName, strings.Join(ed.UserIDs, ","), ed.RedactedCount, ed.RemovedCount, ed.DeletedCount, ed.PreservedSignatures)
+	if ed.RetainedRoleCount > 0 {
+		data = data + fmt.Sprintf(", %d CLA manager roles retained as the only manager", ed.RetainedRoleCount)
+	}
+	if ed.Reason != "" {
+		data = data + fmt.Sprintf(", reason: %s", ed.Reason)
+	}
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *RetentionPolicyUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has set the retention policy of the foundation [%s]: signatures of deleted CLA Groups kept for %d years, invalidated signatures kept for %d years, expired signatures are %sd",
+		args.userName, ed.FoundationSFID, ed.DeletedClaGroupRetentionYears, ed.InvalidatedSignatureRetentionYears, ed.Action)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *RetentionPolicyDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has deleted the retention policy of the foundation [%s]", args.userName, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *LegalHoldPlacedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has placed a legal hold on the %s [%s], reason: %s", args.userName, ed.HoldType, ed.ReferenceID, ed.Reason)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *LegalHoldReleasedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has released the legal hold on the %s [%s]", args.userName, ed.HoldType, ed.ReferenceID)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *GerritProjectDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("Deleted %d Gerrit Repositories due to CLA Group/Project: [%s] deletion",
@@ -723,6 +905,13 @@ func (ed *CLATemplateCreatedEventData) GetEventSummaryString(args *LogEventArgs)
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CLATemplateUploadedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s uploaded custom CLA template %s for foundation %s",
+		args.userName, ed.TemplateName, ed.FoundationSFID)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *GithubOrganizationAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user %s added github organization %s with auto-enabled: %t",
@@ -842,6 +1031,27 @@ func (ed *CLAApprovalListRemoveGitHubUsernameData) GetEventSummaryString(args *L
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListSelfRemovedData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Contributor %s removed themselves from the approval list for Company: %s, Project: %s",
+		ed.UserName, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListUnverifiedDomainsData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager %s added the unverified domains %s to the approval list for Company: %s, Project: %s",
+		ed.UserName, strings.Join(ed.Domains, ","), args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListEntryExpiredData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Approval list %s entry %s expired and was removed from the approval list for Company: %s, Project: %s",
+		ed.ListType, ed.Value, args.companyName, args.projectName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *CLAApprovalListAddGitHubOrgData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("CLA Manager %s added GitHub Org %s to the approval list for Company: %s, Project: %s",
@@ -912,6 +1122,56 @@ func (ed *CLAGroupDeletedEventData) GetEventSummaryString(args *LogEventArgs) (s
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *ResignCampaignCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s created a re-sign campaign asking %d %s signers of CLA Group %s to sign the document major version %d",
+		args.userName, ed.SignerCount, ed.ClaType, args.projectName, ed.MajorVersion)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ResignCampaignCancelledEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s cancelled the re-sign campaign for the document major version %d of CLA Group %s",
+		args.userName, ed.MajorVersion, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *DataSubjectExportedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s exported the records held about a data subject", args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *DataSubjectErasedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s erased the personal data of a data subject", args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *RetentionPolicyUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s set the retention policy of the foundation %s", args.userName, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *RetentionPolicyDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s deleted the retention policy of the foundation %s", args.userName, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *LegalHoldPlacedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s placed a legal hold on the %s %s", args.userName, ed.HoldType, ed.ReferenceID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *LegalHoldReleasedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s released the legal hold on the %s %s", args.userName, ed.HoldType, ed.ReferenceID)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *GerritProjectDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("Deleted %d Gerrit Repositories due to CLA Group/Project: %s deletion",
@@ -36,6 +36,8 @@ const (
 	UserUpdated        = "user.updated"
 	UserDeleted        = "user.deleted"
 
+	CLATemplateUploaded = "cla_template.uploaded"
+
 	RepositoryAdded    = "repository.added"
 	RepositoryDisabled = "repository.disabled"
 
@@ -57,6 +59,8 @@ const (
 
 	ApprovalListGithubOrganizationAdded   = "approval_list.github_organization_added"
 	ApprovalListGithubOrganizationDeleted = "approval_list.github_organization_deleted"
+	ApprovalListContributorSelfRemoved    = "approval_list.contributor_self_removed"
+	ApprovalListUnverifiedDomainAdded     = "approval_list.unverified_domain_added"
 
 	ClaManagerAccessRequestCreated  = "cla_manager.access_request_created"
 	ClaManagerAccessRequestApproved = "cla_manager.access_request_approved"
@@ -74,8 +78,19 @@ const (
 	CLAGroupUpdated = "cla_group.updated"
 	CLAGroupDeleted = "cla_group.deleted"
 
+	ResignCampaignCreated   = "resign_campaign.created"
+	ResignCampaignCancelled = "resign_campaign.cancelled"
+
 	InvalidatedSignature = "signature.invalidated"
 
+	DataSubjectExported = "data_subject.exported"
+	DataSubjectErased   = "data_subject.erased"
+
+	RetentionPolicyUpdated = "retention_policy.updated"
+	RetentionPolicyDeleted = "retention_policy.deleted"
+	LegalHoldPlaced        = "legal_hold.placed"
+	LegalHoldReleased      = "legal_hold.released"
+
 	ContributorNotifyCompanyAdminType = "contributor.notify_company_admin"
 	ContributorNotifyCLADesigneeType  = "contributor.notify_cla_designee"
 	ContributorAssignCLADesigneeType  = "contributor.assign_designee"
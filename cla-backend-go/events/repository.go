@@ -29,6 +29,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	eventOps "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/events"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // errors
@@ -67,14 +68,14 @@ type Repository interface {
 // repository data model
 type repository struct {
 	stage          string
-	dynamoDBClient *dynamodb.DynamoDB
+	dynamoDBClient storage.DynamoDBAPI
 }
 
 // NewRepository creates a new instance of the event repository
 func NewRepository(awsSession *session.Session, stage string) Repository {
 	return &repository{
 		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
+		dynamoDBClient: storage.NewClient(awsSession),
 	}
 }
 
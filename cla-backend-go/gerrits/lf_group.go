@@ -102,3 +102,37 @@ func (lfg *LFGroup) GetGroup(groupID string) (*LDAPGroup, error) {
 	}
 	return &out, nil
 }
+
+// AddUserToGroup adds the user to the LF LDAP group
+func (lfg *LFGroup) AddUserToGroup(groupID, username string) error {
+	accessToken, err := lfg.getAccessToken()
+	if err != nil {
+		return err
+	}
+	requestBody, err := json.Marshal(map[string]string{
+		"username": username,
+	})
+	if err != nil {
+		return err
+	}
+	addUserURL := fmt.Sprintf("%s/rest/auth0/og/%s", lfg.LfBaseURL, groupID)
+	req, err := http.NewRequest("PUT", addUserURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+
+	client := http.Client{
+		Timeout: DefaultHTTPTimeout,
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to add user %s to LDAP group %s, status code: %d", username, groupID, res.StatusCode)
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // errors
@@ -44,13 +45,13 @@ type Repository interface {
 func NewRepository(awsSession *session.Session, stage string) Repository {
 	return &repo{
 		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
+		dynamoDBClient: storage.NewClient(awsSession),
 	}
 }
 
 type repo struct {
 	stage          string
-	dynamoDBClient *dynamodb.DynamoDB
+	dynamoDBClient storage.DynamoDBAPI
 }
 
 func (repo *repo) ExistsByName(gerritName string) ([]*models.Gerrit, error) {
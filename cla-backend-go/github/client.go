@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/bradleyfalzon/ghinstallation"
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
 )
@@ -43,10 +45,12 @@ func isGithub403(resp *github.Response, err error) (bool, error) {
 
 func isGithubRateLimit(err error) (bool, error) {
 	if gErr, ok := err.(*github.RateLimitError); ok {
+		telemetry.IncGithubRateLimited()
 		return true, fmt.Errorf("%s : %w", gErr.Message, ErrRateLimited)
 	}
 
 	if gErr, ok := err.(*github.AbuseRateLimitError); ok {
+		telemetry.IncGithubRateLimited()
 		return true, fmt.Errorf("%s : %w", gErr.Message, ErrRateLimited)
 	}
 
@@ -71,7 +75,7 @@ func NewGithubAppClient(installationID int64) (*github.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return github.NewClient(&http.Client{Transport: itr}), nil
+	return github.NewClient(&http.Client{Transport: &rateLimitTransport{client: "app", base: itr}}), nil
 }
 
 // NewGithubOauthClient creates github client from global accessToken
@@ -86,5 +90,22 @@ func NewGithubOauthClientWithAccessToken(accessToken string) *github.Client {
 		&oauth2.Token{AccessToken: accessToken},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = &rateLimitTransport{client: "oauth", base: tc.Transport}
 	return github.NewClient(tc)
 }
+
+// rateLimitTransport records the remaining GitHub API requests reported in the response headers
+type rateLimitTransport struct {
+	client string
+	base   http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		if remaining, convErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); convErr == nil {
+			telemetry.SetGithubRateLimitRemaining(t.client, remaining)
+		}
+	}
+	return resp, err
+}
@@ -23,3 +23,28 @@ func GetUserDetails(user string) (*github.User, error) {
 	}
 	return userResp, nil
 }
+
+// GetUserOrganizations returns the login names of the public github organizations the user belongs to
+func GetUserOrganizations(user string) ([]string, error) {
+	client := NewGithubOauthClient()
+	var orgNames []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		orgs, resp, err := client.Organizations.List(context.TODO(), user, opts)
+		if err != nil {
+			logging.Warnf("GetUserOrganizations failed for user : %s, error = %s\n", user, err.Error())
+			if ok, wErr := checkAndWrapForKnownErrors(resp, err); ok {
+				return nil, wErr
+			}
+			return nil, fmt.Errorf("unable to get github organizations of %s", user)
+		}
+		for _, org := range orgs {
+			orgNames = append(orgNames, org.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return orgNames, nil
+}
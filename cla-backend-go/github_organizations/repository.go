@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // indexes
@@ -50,7 +51,7 @@ type Repository interface {
 
 type repository struct {
 	stage              string
-	dynamoDBClient     *dynamodb.DynamoDB
+	dynamoDBClient     storage.DynamoDBAPI
 	githubOrgTableName string
 }
 
@@ -58,7 +59,7 @@ type repository struct {
 func NewRepository(awsSession *session.Session, stage string) repository {
 	return repository{
 		stage:              stage,
-		dynamoDBClient:     dynamodb.New(awsSession),
+		dynamoDBClient:     storage.NewClient(awsSession),
 		githubOrgTableName: fmt.Sprintf("cla-%s-github-orgs", stage),
 	}
 }
@@ -31,7 +31,8 @@ require (
 	github.com/google/go-github v17.0.0+incompatible
 	github.com/google/go-github/v29 v29.0.2
 	github.com/google/uuid v1.1.1
-	github.com/gorilla/sessions v1.2.0 // indirect
+	github.com/gorilla/securecookie v1.1.1
+	github.com/gorilla/sessions v1.2.0
 	github.com/imroc/req v0.3.0
 	github.com/jessevdk/go-flags v1.4.0
 	github.com/jinzhu/copier v0.0.0-20190924061706-b57f9002281a
@@ -40,13 +41,15 @@ require (
 	github.com/juju/zip v0.0.0-20160205105221-f6b1e93fa2e2
 	github.com/kr/pretty v0.2.0 // indirect
 	github.com/labstack/gommon v0.2.8
+	github.com/lib/pq v1.0.0
 	github.com/lytics/logrus v0.0.0-20170528191427-4389a17ed024
+	github.com/mattn/go-sqlite3 v1.9.0
 	github.com/mozillazg/request v0.8.0 // indirect
 	github.com/pdfcpu/pdfcpu v0.3.5-0.20200802160406-be1e0eb55afc
 	github.com/pelletier/go-toml v1.8.0 // indirect
-	github.com/prometheus/common v0.4.0
+	github.com/prometheus/client_golang v1.7.0
+	github.com/prometheus/common v0.10.0
 	github.com/rs/cors v1.7.0
-	github.com/savaki/dynastore v0.0.0-20171109173440-28d8558bb429
 	github.com/sirupsen/logrus v1.5.0
 	github.com/spf13/afero v1.3.0 // indirect
 	github.com/spf13/cast v1.3.1 // indirect
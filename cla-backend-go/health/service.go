@@ -13,6 +13,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	ini "github.com/communitybridge/easycla/cla-backend-go/init"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // Service provides an API to the health API
@@ -133,7 +134,7 @@ func isDynamoAlive(tableName string) bool {
 	}
 
 	// Create a client and make a query - don't worry about the result - just check the error response
-	dynamoDBClient := dynamodb.New(awsSession)
+	dynamoDBClient := storage.NewClient(awsSession)
 	_, err = dynamoDBClient.DescribeTable(&dynamodb.DescribeTableInput{
 		TableName: &tableName,
 	})
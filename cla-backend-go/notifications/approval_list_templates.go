@@ -0,0 +1,227 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+// approval list notification template names
+const (
+	ApprovalListRequestTemplate            = "approval-list-request"
+	ApprovalListRequestDeniedTemplate      = "approval-list-request-denied"
+	ApprovalListUpdatedTemplate            = "approval-list-updated"
+	ApprovalListContributorUpdatedTemplate = "approval-list-contributor-updated"
+	ApprovalListExpiryReminderTemplate     = "approval-list-expiry-reminder"
+)
+
+// ApprovalListRequestData is the data of the notification asking a CLA manager to add a contributor to the approval list
+type ApprovalListRequestData struct {
+	RecipientName    string
+	CompanyName      string
+	ProjectName      string
+	ContributorName  string
+	ContributorEmail string
+	Message          string
+	CompanyURL       string
+	V2               bool
+}
+
+// ApprovalListUpdatedData is the data of the notification sent to the CLA managers when the approval list changes,
+// each change reads e.g. "Added Email: jane@example.org"
+type ApprovalListUpdatedData struct {
+	RecipientName string
+	CompanyName   string
+	ProjectName   string
+	Changes       []string
+	V2            bool
+}
+
+// ApprovalListContributorUpdatedData is the data of the notification sent to a contributor added to or removed from
+// the approval list
+type ApprovalListContributorUpdatedData struct {
+	RecipientName string
+	CompanyName   string
+	ProjectName   string
+	ManagerName   string
+	Added         bool
+	V2            bool
+}
+
+// ApprovalListExpiryReminderData is the data of the reminder sent to the CLA managers of the approval list entries
+// which expire soon, each entry reads e.g. "Email jane@example.org expires on January 2, 2026 15:04 UTC"
+type ApprovalListExpiryReminderData struct {
+	RecipientName string
+	CompanyName   string
+	ProjectName   string
+	Entries       []string
+	V2            bool
+}
+
+// approvalListTemplates are the built-in approval list templates by template name and locale
+var approvalListTemplates = map[string]map[string]Template{
+	ApprovalListRequestTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Request to Authorize {{.ContributorName}} for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>{{.ContributorName}} ({{.ContributorEmail}}) has requested to be added to the Allow List as an authorized contributor from
+{{.CompanyName}} to the project {{.ProjectName}}. You are receiving this message as a CLA Manager from {{.CompanyName}} for
+{{.ProjectName}}.</p>
+{{if .Message}}<p>{{.ContributorName}} included the following message in the request:</p>
+<br/><p>{{.Message}}</p><br/>
+{{end}}<p>If you want to add them to the Allow List, please
+<a href="{{.CompanyURL}}" target="_blank">log into the EasyCLA Corporate
+Console</a>, where you can approve this user's request by selecting the 'Manage Approved List' and adding the
+contributor's email, the contributor's entire email domain, their GitHub ID or the entire GitHub Organization for the
+repository. This will permit them to begin contributing to {{.ProjectName}} on behalf of {{.CompanyName}}.</p>
+<p>If you are not certain whether to add them to the Allow List, please reach out to them directly to discuss.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+{{.ContributorName}} ({{.ContributorEmail}}) has requested to be added to the Allow List as an authorized contributor
+from {{.CompanyName}} to the project {{.ProjectName}}. You are receiving this message as a CLA Manager from
+{{.CompanyName}} for {{.ProjectName}}.
+{{if .Message}}
+{{.ContributorName}} included the following message in the request:
+
+{{.Message}}
+{{end}}
+If you want to add them to the Allow List, please log into the EasyCLA Corporate Console at {{.CompanyURL}}, where you
+can approve this user's request by selecting the 'Manage Approved List' and adding the contributor's email, the
+contributor's entire email domain, their GitHub ID or the entire GitHub Organization for the repository. This will
+permit them to begin contributing to {{.ProjectName}} on behalf of {{.CompanyName}}.
+
+If you are not certain whether to add them to the Allow List, please reach out to them directly to discuss.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	ApprovalListRequestDeniedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: CLA Manager Access Denied for Project {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>Your request to become a CLA Manager from {{.CompanyName}} for {{.ProjectName}} was denied by one of the existing CLA Managers.
+If you have further questions about this denial, please contact one of the existing CLA Managers from
+{{.CompanyName}} for {{.ProjectName}}:</p>
+<ul>
+{{range .Managers}}<li>{{.Name}} &lt;{{.Email}}&gt;</li>
+{{end}}</ul>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+Your request to become a CLA Manager from {{.CompanyName}} for {{.ProjectName}} was denied by one of the existing CLA
+Managers. If you have further questions about this denial, please contact one of the existing CLA Managers from
+{{.CompanyName}} for {{.ProjectName}}:
+
+{{range .Managers}}- {{.Name}} <{{.Email}}>
+{{end}}
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	ApprovalListUpdatedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Approval List Update for {{.CompanyName}} on {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>The EasyCLA approval list for {{.CompanyName}} for project {{.ProjectName}} was modified.</p>
+<p>The modification was as follows:</p>
+<ul>
+{{range .Changes}}<li>{{.}}</li>
+{{end}}</ul>
+<p>Contributors with previously failed pull requests to {{.ProjectName}} can close and re-open the pull request to force a recheck by
+the EasyCLA system.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+The EasyCLA approval list for {{.CompanyName}} for project {{.ProjectName}} was modified.
+
+The modification was as follows:
+
+{{range .Changes}}- {{.}}
+{{end}}
+Contributors with previously failed pull requests to {{.ProjectName}} can close and re-open the pull request to force a
+recheck by the EasyCLA system.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	ApprovalListContributorUpdatedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Approval List Update for {{.CompanyName}} on {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+{{if .Added}}<p>You have been added to the Approval List of {{.CompanyName}} for {{.ProjectName}} by CLA Manager {{.ManagerName}}. This means that you are authorized to contribute to {{.ProjectName}} on behalf of {{.CompanyName}}.</p>
+{{else}}<p>You have been removed from the Approval List of {{.CompanyName}} for {{.ProjectName}} by CLA Manager {{.ManagerName}}. This means that you are no longer authorized to contribute to {{.ProjectName}} on behalf of {{.CompanyName}}.</p>
+{{end}}<p>If you had previously submitted one or more pull requests to {{.ProjectName}} that had failed, you should
+close and re-open the pull request to force a recheck by the EasyCLA system.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+{{if .Added}}You have been added to the Approval List of {{.CompanyName}} for {{.ProjectName}} by CLA Manager
+{{.ManagerName}}. This means that you are authorized to contribute to {{.ProjectName}} on behalf of {{.CompanyName}}.
+{{else}}You have been removed from the Approval List of {{.CompanyName}} for {{.ProjectName}} by CLA Manager
+{{.ManagerName}}. This means that you are no longer authorized to contribute to {{.ProjectName}} on behalf of
+{{.CompanyName}}.
+{{end}}
+If you had previously submitted one or more pull requests to {{.ProjectName}} that had failed, you should close and
+re-open the pull request to force a recheck by the EasyCLA system.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	ApprovalListExpiryReminderTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Approval List Entries Expiring for {{.CompanyName}} on {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>The following entries of the EasyCLA approval list for {{.CompanyName}} for project {{.ProjectName}} expire soon and will be removed from
+the approval list:</p>
+<ul>
+{{range .Entries}}<li>{{.}}</li>
+{{end}}</ul>
+<p>To keep the contributors covered, add the entries to the approval list again with a later expiry date or without
+an expiry date.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+The following entries of the EasyCLA approval list for {{.CompanyName}} for project {{.ProjectName}} expire soon and
+will be removed from the approval list:
+
+{{range .Entries}}- {{.}}
+{{end}}
+To keep the contributors covered, add the entries to the approval list again with a later expiry date or without an
+expiry date.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+}
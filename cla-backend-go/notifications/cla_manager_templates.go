@@ -0,0 +1,344 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+// CLA manager notification template names
+const (
+	CLAManagerAccessRequestTemplate        = "cla-manager-access-request"
+	CLAManagerAccessApprovedNoticeTemplate = "cla-manager-access-approved-notice"
+	CLAManagerAccessApprovedTemplate       = "cla-manager-access-approved"
+	CLAManagerAccessDeniedNoticeTemplate   = "cla-manager-access-denied-notice"
+	CLAManagerAccessDeniedTemplate         = "cla-manager-access-denied"
+	CLAManagerAddedNoticeTemplate          = "cla-manager-added-notice"
+	CLAManagerRemovedTemplate              = "cla-manager-removed"
+	CLAManagerRemovedNoticeTemplate        = "cla-manager-removed-notice"
+	CLAManagerInviteTemplate               = "cla-manager-invite"
+	ContributorApprovalRequestTemplate     = "contributor-approval-request"
+)
+
+// Contact is the name and email of a user listed in a notification, e.g. the CLA Managers to contact
+type Contact struct {
+	Name  string
+	Email string
+}
+
+// CLAManagerNoticeData is the data of the CLA manager notifications about a user - the user requesting access, or
+// approved, denied, added or removed as a CLA manager
+type CLAManagerNoticeData struct {
+	RecipientName       string
+	CompanyName         string
+	ProjectName         string
+	UserName            string
+	UserEmail           string
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// ManagersContactData is the data of the notifications listing the managers the recipient can contact
+type ManagersContactData struct {
+	RecipientName string
+	CompanyName   string
+	ProjectName   string
+	Managers      []Contact
+	V2            bool
+}
+
+// CLAManagerInviteData is the data of the invitation to create a LF Login and become a CLA manager, the accept link
+// placeholder USERACCEPTLINK is replaced by the invite service
+type CLAManagerInviteData struct {
+	RecipientName  string
+	ProjectName    string
+	RequesterName  string
+	RequesterEmail string
+	Role           string
+}
+
+// ContributorApprovalRequestData is the data of the notification asking a CLA manager to approve a contributor
+type ContributorApprovalRequestData struct {
+	RecipientName      string
+	CompanyName        string
+	ClaGroupName       string
+	ContributorName    string
+	ContributorDetails string
+}
+
+// claManagerTemplates are the built-in CLA manager templates by template name and locale
+var claManagerTemplates = map[string]map[string]Template{
+	CLAManagerAccessRequestTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: New CLA Manager Access Request for {{.CompanyName}} on {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>You are currently listed as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that you are able to maintain the
+list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as view and manage the list of
+your company’s CLA Managers for {{.ProjectName}}.</p>
+<p>{{.UserName}} ({{.UserEmail}}) has requested to be added as another CLA Manager from {{.CompanyName}} for {{.ProjectName}}. This would permit them to maintain the
+lists of approved contributors and CLA Managers as well.</p>
+<p>If you want to permit this, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>,
+select your company, then select the {{.ProjectName}} project. From the CLA Manager requests, you can approve this user as an
+additional CLA Manager.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+You are currently listed as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that you are
+able to maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as
+view and manage the list of your company’s CLA Managers for {{.ProjectName}}.
+
+{{.UserName}} ({{.UserEmail}}) has requested to be added as another CLA Manager from {{.CompanyName}} for {{.ProjectName}}.
+This would permit them to maintain the lists of approved contributors and CLA Managers as well.
+
+If you want to permit this, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, select your
+company, then select the {{.ProjectName}} project. From the CLA Manager requests, you can approve this user as an
+additional CLA Manager.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerAccessApprovedNoticeTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: CLA Manager Access Approval Notice for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>The following user has been approved as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that they can now
+maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as view and manage the
+list of company’s CLA Managers for {{.ProjectName}}.</p>
+<ul>
+<li>{{.UserName}} ({{.UserEmail}})</li>
+</ul>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+The following user has been approved as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means
+that they can now maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as
+well as view and manage the list of company’s CLA Managers for {{.ProjectName}}.
+
+- {{.UserName}} ({{.UserEmail}})
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerAccessApprovedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: New CLA Manager Access Approved for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>You have now been approved as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}.  This means that you can now maintain the
+list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as view and manage the list of your
+company’s CLA Managers for {{.ProjectName}}.</p>
+<p> To get started, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>, and select your
+company and then the project {{.ProjectName}}. From here you will be able to edit the list of approved employees and CLA Managers.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+You have now been approved as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that you
+can now maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as
+view and manage the list of your company’s CLA Managers for {{.ProjectName}}.
+
+To get started, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, and select your company and
+then the project {{.ProjectName}}. From here you will be able to edit the list of approved employees and CLA Managers.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerAccessDeniedNoticeTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: CLA Manager Access Denied Notice for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>The following user has been denied as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that they will not
+be able to maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company.</p>
+<ul>
+<li>{{.UserName}} ({{.UserEmail}})</li>
+</ul>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+The following user has been denied as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means
+that they will not be able to maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your
+company.
+
+- {{.UserName}} ({{.UserEmail}})
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerAccessDeniedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: New CLA Manager Access Denied for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>You have been denied as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that you can not maintain the
+list of employees allowed to contribute to {{.ProjectName}} on behalf of your company.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+You have been denied as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that you can not
+maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerAddedNoticeTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: CLA Manager Added Notice for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>The following user has been added as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that they can now
+maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as view and manage the
+list of company’s CLA Managers for {{.ProjectName}}.</p>
+<ul>
+<li>{{.UserName}} ({{.UserEmail}})</li>
+</ul>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+The following user has been added as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means
+that they can now maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as
+well as view and manage the list of company’s CLA Managers for {{.ProjectName}}.
+
+- {{.UserName}} ({{.UserEmail}})
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerRemovedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Removed as CLA Manager for Project {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>You have been removed as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}.</p>
+<p>If you have further questions about this, please contact one of the existing managers from
+{{.CompanyName}}:</p>
+<ul>
+{{range .Managers}}<li>{{.Name}} &lt;{{.Email}}&gt;</li>
+{{end}}</ul>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+You have been removed as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}.
+
+If you have further questions about this, please contact one of the existing managers from {{.CompanyName}}:
+
+{{range .Managers}}- {{.Name}} <{{.Email}}>
+{{end}}
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerRemovedNoticeTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: CLA Manager Removed Notice for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>{{.UserName}} has been removed as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+{{.UserName}} has been removed as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerInviteTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Invitation to create LF Login and complete process of becoming CLA Manager with {{.Role}} role`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the Project {{.ProjectName}} in the EasyCLA system.</p>
+<p>User {{.RequesterName}} ({{.RequesterEmail}}) was trying to add you as a CLA Manager for Project {{.ProjectName}} but was unable to identify your account details in
+the EasyCLA system. In order to become a CLA Manager for Project {{.ProjectName}}, you will need to accept invite below.
+Once complete, notify the user {{.RequesterName}} and they will be able to add you as a CLA Manager.</p>
+<p> <a href="USERACCEPTLINK">Accept Invite</a> </p>
+{{helpContent true}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the Project {{.ProjectName}} in the EasyCLA system.
+
+User {{.RequesterName}} ({{.RequesterEmail}}) was trying to add you as a CLA Manager for Project {{.ProjectName}} but was
+unable to identify your account details in the EasyCLA system. In order to become a CLA Manager for Project
+{{.ProjectName}}, you will need to accept the invite at USERACCEPTLINK. Once complete, notify the user {{.RequesterName}}
+and they will be able to add you as a CLA Manager.
+
+{{helpText true}}
+
+{{signOffText}}`,
+		},
+	},
+	ContributorApprovalRequestTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Approval Request for contributor: {{.ContributorName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the organization {{.CompanyName}}.</p>
+<p>The following contributor would like to submit a contribution to the {{.ClaGroupName}} CLA Group
+and is requesting to be approved as a contributor for your organization: </p>
+<p>{{.ContributorDetails}}</p>
+<p>Please notify the contributor once they are added so that they may complete the contribution process.</p>
+{{helpContent true}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the organization {{.CompanyName}}.
+
+The following contributor would like to submit a contribution to the {{.ClaGroupName}} CLA Group and is requesting to
+be approved as a contributor for your organization:
+
+{{.ContributorDetails}}
+
+Please notify the contributor once they are added so that they may complete the contribution process.
+
+{{helpText true}}
+
+{{signOffText}}`,
+		},
+	},
+}
@@ -0,0 +1,216 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+// company notification template names
+const (
+	CompanyManagerAccessRequestTemplate  = "company-manager-access-request"
+	CompanyManagerAccessApprovedTemplate = "company-manager-access-approved"
+	CompanyManagerAccessDeniedTemplate   = "company-manager-access-denied"
+	CompanyProfileTemplate               = "company-profile"
+	CompanyOwnerInviteTemplate           = "company-owner-invite"
+	DomainVerificationTemplate           = "domain-verification"
+)
+
+// CompanyManagerAccessData is the data of the company manager access request and approved notifications
+type CompanyManagerAccessData struct {
+	RecipientName       string
+	CompanyName         string
+	UserName            string
+	UserEmail           string
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// CompanyProfileData is the data of the notification sent to the owner of a newly created organization
+type CompanyProfileData struct {
+	RecipientName    string
+	OrganizationName string
+	LFXPortalURL     string
+}
+
+// CompanyOwnerInviteData is the data of the invitation to create a LF Login sent to the owner of an organization, the
+// accept link placeholder USERACCEPTLINK is replaced by the invite service
+type CompanyOwnerInviteData struct {
+	RecipientName    string
+	OrganizationName string
+	Role             string
+}
+
+// DomainVerificationData is the data of the notification sending the domain verification token to the postmaster of
+// the domain
+type DomainVerificationData struct {
+	CompanyName string
+	Domain      string
+	Token       string
+	Expires     string
+}
+
+// companyTemplates are the built-in company templates by template name and locale
+var companyTemplates = map[string]map[string]Template{
+	CompanyManagerAccessRequestTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: New Company Manager Access Request for {{.CompanyName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the company {{.CompanyName}}.</p>
+<p>The following user has requested to join {{.CompanyName}} as a Company Manager.
+By approving this request the user could view and apply for CLA Manager
+status on projects associated with your company. </p>
+<ul><li>{{.UserName}} ({{.UserEmail}})</li></ul>
+<p>To get started, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>, and select your
+company. From there you will be able to view the list of projects which have EasyCLA configured and apply for CLA
+Manager status.
+</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the company {{.CompanyName}}.
+
+The following user has requested to join {{.CompanyName}} as a Company Manager. By approving this request the user could
+view and apply for CLA Manager status on projects associated with your company.
+
+- {{.UserName}} ({{.UserEmail}})
+
+To get started, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, and select your company. From
+there you will be able to view the list of projects which have EasyCLA configured and apply for CLA Manager status.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CompanyManagerAccessApprovedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Company Manager Access Approved for {{.CompanyName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the company {{.CompanyName}}.</p>
+<p>You have now been approved as a Company Manager for {{.CompanyName}}.
+This means that you can now view and apply for CLA Manager status on
+projects associated with your company.
+</p>
+<p>To get started, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>, and select your
+company. From there you will be able to view the list of projects which have EasyCLA configured and apply for CLA
+Manager status.
+</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the company {{.CompanyName}}.
+
+You have now been approved as a Company Manager for {{.CompanyName}}. This means that you can now view and apply for CLA
+Manager status on projects associated with your company.
+
+To get started, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, and select your company. From
+there you will be able to view the list of projects which have EasyCLA configured and apply for CLA Manager status.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CompanyManagerAccessDeniedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: CLA Manager Access Denied for {{.CompanyName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the company {{.CompanyName}}.</p>
+<p>Your request to become a Company Manager was denied by one of the existing Company Managers.
+If you have further questions about this denial, please contact one of the existing managers from
+{{.CompanyName}}:</p>
+<ul>
+{{range .Managers}}<li>{{.Name}} &lt;{{.Email}}&gt;</li>
+{{end}}</ul>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the company {{.CompanyName}}.
+
+Your request to become a Company Manager was denied by one of the existing Company Managers. If you have further
+questions about this denial, please contact one of the existing managers from {{.CompanyName}}:
+
+{{range .Managers}}- {{.Name}} <{{.Email}}>
+{{end}}
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CompanyProfileTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Company Profile `,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the newly created Salesforce Organization {{.OrganizationName}}.</p>
+<p> You have been assigned as the company owner for this new organization </p>
+<p>The organization profile can be completed via <a href="{{.LFXPortalURL}}/company/manage/" target="_blank">clicking this link</a>
+{{helpContent true}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the newly created Salesforce Organization {{.OrganizationName}}.
+
+You have been assigned as the company owner for this new organization.
+
+The organization profile can be completed at {{.LFXPortalURL}}/company/manage/
+
+{{helpText true}}
+
+{{signOffText}}`,
+		},
+	},
+	CompanyOwnerInviteTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Invitation to create LF Login and complete process of becoming CLA Manager with {{.Role}} role`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the organization {{.OrganizationName}}.</p>
+<p> You have been identified as company owner </p>
+<p> <a href="USERACCEPTLINK">Accept Invite</a> </p>
+{{helpContent true}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the organization {{.OrganizationName}}.
+
+You have been identified as company owner, you can accept the invite at USERACCEPTLINK
+
+{{helpText true}}
+
+{{signOffText}}`,
+		},
+	},
+	DomainVerificationTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Domain Verification for {{.Domain}}`,
+			HTMLBody: `
+<p>Hello,</p>
+<p>This is a notification email from EasyCLA regarding the company {{.CompanyName}}.</p>
+<p>A company manager of {{.CompanyName}} has requested to verify that {{.CompanyName}} owns the domain {{.Domain}}. If you manage this domain and
+approve the request, please provide the following verification token to the company manager:</p>
+<p><b>{{.Token}}</b></p>
+<p>The token expires on {{.Expires}}. If you did not expect this request, you can ignore this email.</p>
+{{helpContent false}}
+{{signOffContent}}`,
+			TextBody: `Hello,
+
+This is a notification email from EasyCLA regarding the company {{.CompanyName}}.
+
+A company manager of {{.CompanyName}} has requested to verify that {{.CompanyName}} owns the domain {{.Domain}}. If you
+manage this domain and approve the request, please provide the following verification token to the company manager:
+
+{{.Token}}
+
+The token expires on {{.Expires}}. If you did not expect this request, you can ignore this email.
+
+{{helpText false}}
+
+{{signOffText}}`,
+		},
+	},
+}
@@ -0,0 +1,169 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// TemplateOverride is a per CLA Group notification template stored in the notification templates table
+type TemplateOverride struct {
+	ClaGroupID   string `json:"cla_group_id"`
+	TemplateKey  string `json:"template_key"`
+	TemplateName string `json:"template_name"`
+	Locale       string `json:"locale"`
+	Subject      string `json:"subject"`
+	HTMLBody     string `json:"html_body"`
+	TextBody     string `json:"text_body"`
+	DateCreated  string `json:"date_created"`
+	DateModified string `json:"date_modified"`
+}
+
+// Repository defines functions of the notification template overrides
+type Repository interface {
+	GetTemplateOverride(claGroupID, templateName, locale string) (*TemplateOverride, error)
+	GetTemplateOverrides(claGroupID string) ([]*TemplateOverride, error)
+	PutTemplateOverride(override *TemplateOverride) (*TemplateOverride, error)
+	DeleteTemplateOverride(claGroupID, templateName, locale string) error
+}
+
+type repo struct {
+	stage          string
+	dynamoDBClient storage.DynamoDBAPI
+	tableName      string
+}
+
+// NewRepository creates a new notification template repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repo{
+		stage:          stage,
+		dynamoDBClient: storage.NewClient(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-notification-templates", stage),
+	}
+}
+
+// templateKey returns the range key of the template override
+func templateKey(templateName, locale string) string {
+	return fmt.Sprintf("%s#%s", templateName, locale)
+}
+
+// GetTemplateOverride returns the template override, or nil if the CLA Group has no override
+func (repo *repo) GetTemplateOverride(claGroupID, templateName, locale string) (*TemplateOverride, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"cla_group_id": {S: aws.String(claGroupID)},
+			"template_key": {S: aws.String(templateKey(templateName, locale))},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching notification template %s for cla group: %s, error: %v", templateName, claGroupID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var override TemplateOverride
+	err = dynamodbattribute.UnmarshalMap(result.Item, &override)
+	if err != nil {
+		log.Warnf("error unmarshalling notification template, error: %v", err)
+		return nil, err
+	}
+	return &override, nil
+}
+
+// GetTemplateOverrides returns the template overrides of the CLA Group
+func (repo *repo) GetTemplateOverrides(claGroupID string) ([]*TemplateOverride, error) {
+	condition := expression.Key("cla_group_id").Equal(expression.Value(claGroupID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+	}
+
+	overrides := make([]*TemplateOverride, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching notification templates for cla group: %s, error: %v", claGroupID, errQuery)
+			return nil, errQuery
+		}
+
+		var page []*TemplateOverride
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling notification templates, error: %v", err)
+			return nil, err
+		}
+		overrides = append(overrides, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return overrides, nil
+}
+
+// PutTemplateOverride creates or replaces the template override
+func (repo *repo) PutTemplateOverride(override *TemplateOverride) (*TemplateOverride, error) {
+	_, currentTime := utils.CurrentTime()
+	existing, err := repo.GetTemplateOverride(override.ClaGroupID, override.TemplateName, override.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	override.TemplateKey = templateKey(override.TemplateName, override.Locale)
+	override.DateCreated = currentTime
+	if existing != nil {
+		override.DateCreated = existing.DateCreated
+	}
+	override.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(override)
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.Warnf("error storing notification template %s for cla group: %s, error: %v", override.TemplateName, override.ClaGroupID, err)
+		return nil, err
+	}
+	return override, nil
+}
+
+// DeleteTemplateOverride deletes the template override
+func (repo *repo) DeleteTemplateOverride(claGroupID, templateName, locale string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"cla_group_id": {S: aws.String(claGroupID)},
+			"template_key": {S: aws.String(templateKey(templateName, locale))},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.Warnf("error deleting notification template %s for cla group: %s, error: %v", templateName, claGroupID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	ErrTemplateNotFound = errors.New("notification template not found")
+)
+
+// UserLookup provides the user lookup used to resolve the preferred locale of the recipients
+type UserLookup interface {
+	GetUserByEmail(userEmail string) (*models.User, error)
+}
+
+// Service contains the notification rendering and delivery functions
+type Service interface {
+	Render(ctx context.Context, claGroupID, templateName, locale string, data interface{}) (*Message, error)
+	Send(ctx context.Context, claGroupID, templateName string, recipients []string, data interface{}) error
+
+	GetTemplateOverrides(ctx context.Context, claGroupID string) ([]*TemplateOverride, error)
+	PutTemplateOverride(ctx context.Context, override *TemplateOverride) (*TemplateOverride, error)
+	DeleteTemplateOverride(ctx context.Context, claGroupID, templateName, locale string) error
+}
+
+type service struct {
+	repo       Repository
+	userLookup UserLookup
+}
+
+// NewService creates a new notification service - the repository and user lookup are optional, without them only
+// the built-in templates in the default locale are used
+func NewService(repo Repository, userLookup UserLookup) Service {
+	return service{
+		repo:       repo,
+		userLookup: userLookup,
+	}
+}
+
+var defaultService Service = NewService(nil, nil)
+
+// SetService sets the service used by the package level Send and Render functions
+func SetService(s Service) {
+	defaultService = s
+}
+
+// Send renders and sends the notification using the service configured by SetService
+func Send(ctx context.Context, claGroupID, templateName string, recipients []string, data interface{}) error {
+	return defaultService.Send(ctx, claGroupID, templateName, recipients, data)
+}
+
+// Render renders the notification using the service configured by SetService, for the messages delivered by other
+// services such as the user invites
+func Render(ctx context.Context, claGroupID, templateName, locale string, data interface{}) (*Message, error) {
+	return defaultService.Render(ctx, claGroupID, templateName, locale, data)
+}
+
+// Render renders the notification template for the locale. The CLA Group template overrides take precedence over
+// the built-in templates, each are looked up for the locale, the base language and the default locale.
+func (s service) Render(ctx context.Context, claGroupID, templateName, locale string, data interface{}) (*Message, error) {
+	f := logrus.Fields{
+		"functionName":   "Render",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateName":   templateName,
+		"locale":         locale,
+	}
+
+	tmpl, err := s.lookupTemplate(claGroupID, templateName, locale)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup notification template, error: %+v", err)
+		return nil, err
+	}
+
+	msg, err := tmpl.render(data)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to render notification template, error: %+v", err)
+		return nil, err
+	}
+	return msg, nil
+}
+
+// lookupTemplate returns the template to use for the CLA Group and locale
+func (s service) lookupTemplate(claGroupID, templateName, locale string) (*Template, error) {
+	locales := localeFallbacks(locale)
+
+	if s.repo != nil && claGroupID != "" {
+		for _, l := range locales {
+			override, err := s.repo.GetTemplateOverride(claGroupID, templateName, l)
+			if err != nil {
+				return nil, err
+			}
+			if override != nil {
+				return &Template{
+					Subject:  override.Subject,
+					HTMLBody: override.HTMLBody,
+					TextBody: override.TextBody,
+				}, nil
+			}
+		}
+	}
+
+	byLocale, ok := builtInTemplates[templateName]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	for _, l := range locales {
+		if tmpl, ok := byLocale[l]; ok {
+			return &tmpl, nil
+		}
+	}
+	return nil, ErrTemplateNotFound
+}
+
+// Send renders the notification in the preferred locale of each recipient and sends it
+func (s service) Send(ctx context.Context, claGroupID, templateName string, recipients []string, data interface{}) error {
+	f := logrus.Fields{
+		"functionName":   "Send",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateName":   templateName,
+		"recipients":     strings.Join(recipients, ","),
+	}
+
+	var sendErr error
+	for locale, localeRecipients := range s.groupRecipientsByLocale(recipients) {
+		msg, err := s.Render(ctx, claGroupID, templateName, locale, data)
+		if err != nil {
+			return err
+		}
+
+		err = utils.SendEmailWithAlternative(msg.Subject, msg.HTMLBody, msg.TextBody, localeRecipients)
+		if err != nil {
+			log.WithFields(f).Warnf("problem sending %s notification in locale: %s, error: %+v", templateName, locale, err)
+			sendErr = err
+			continue
+		}
+		log.WithFields(f).Debugf("sent %s notification in locale: %s to recipients: %v", templateName, locale, localeRecipients)
+	}
+	return sendErr
+}
+
+// groupRecipientsByLocale groups the recipients by the preferred locale of their user record
+func (s service) groupRecipientsByLocale(recipients []string) map[string][]string {
+	result := map[string][]string{}
+	for _, recipient := range recipients {
+		locale := DefaultLocale
+		if s.userLookup != nil {
+			userModel, err := s.userLookup.GetUserByEmail(recipient)
+			if err == nil && userModel != nil && userModel.PreferredLocale != "" {
+				locale = userModel.PreferredLocale
+			}
+		}
+		result[locale] = append(result[locale], recipient)
+	}
+	return result
+}
+
+// GetTemplateOverrides returns the template overrides of the CLA Group
+func (s service) GetTemplateOverrides(ctx context.Context, claGroupID string) ([]*TemplateOverride, error) {
+	if s.repo == nil {
+		return []*TemplateOverride{}, nil
+	}
+	return s.repo.GetTemplateOverrides(claGroupID)
+}
+
+// PutTemplateOverride validates and stores the template override
+func (s service) PutTemplateOverride(ctx context.Context, override *TemplateOverride) (*TemplateOverride, error) {
+	if s.repo == nil {
+		return nil, errors.New("notification template repository not configured")
+	}
+	if !IsBuiltInTemplate(override.TemplateName) {
+		return nil, ErrTemplateNotFound
+	}
+	if strings.TrimSpace(override.Locale) == "" {
+		return nil, errors.New("notification template locale is required")
+	}
+	override.Locale = NormalizeLocale(override.Locale)
+
+	tmpl := Template{Subject: override.Subject, HTMLBody: override.HTMLBody, TextBody: override.TextBody}
+	if err := tmpl.validate(); err != nil {
+		return nil, fmt.Errorf("invalid notification template: %w", err)
+	}
+
+	log.WithFields(logrus.Fields{
+		"functionName":   "PutTemplateOverride",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     override.ClaGroupID,
+		"templateName":   override.TemplateName,
+		"locale":         override.Locale,
+	}).Debug("storing notification template override")
+	return s.repo.PutTemplateOverride(override)
+}
+
+// DeleteTemplateOverride deletes the template override - the built-in template is used afterwards
+func (s service) DeleteTemplateOverride(ctx context.Context, claGroupID, templateName, locale string) error {
+	if s.repo == nil {
+		return errors.New("notification template repository not configured")
+	}
+	return s.repo.DeleteTemplateOverride(claGroupID, templateName, NormalizeLocale(locale))
+}
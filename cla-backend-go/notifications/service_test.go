@@ -0,0 +1,132 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepository struct {
+	Repository
+	overrides map[string]*TemplateOverride
+}
+
+func (r fakeRepository) GetTemplateOverride(claGroupID, templateName, locale string) (*TemplateOverride, error) {
+	return r.overrides[claGroupID+"/"+templateKey(templateName, locale)], nil
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	assert.Equal(t, []string{"fr-CA", "fr", "en"}, localeFallbacks("fr_CA"))
+	assert.Equal(t, []string{"de", "en"}, localeFallbacks("de"))
+	assert.Equal(t, []string{"en"}, localeFallbacks("EN"))
+	assert.Equal(t, []string{"en"}, localeFallbacks(""))
+}
+
+func TestRenderBuiltInTemplate(t *testing.T) {
+	s := NewService(nil, nil)
+	msg, err := s.Render(context.Background(), "", CLAManagerAddedTemplate, "fr", CLAManagerAddedData{
+		RecipientName:       "<Jane>",
+		CompanyName:         "Acme",
+		ProjectName:         "Kubernetes",
+		CorporateConsoleURL: "https://corporate.example.org",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "EasyCLA: Added as CLA Manager for Project :Kubernetes", msg.Subject)
+	assert.Contains(t, msg.HTMLBody, "Hello &lt;Jane&gt;,")
+	assert.Contains(t, msg.TextBody, "Hello <Jane>,")
+	assert.True(t, strings.Contains(msg.TextBody, "https://corporate.example.org"))
+}
+
+func TestRenderOverrideFallsBackToBaseLanguage(t *testing.T) {
+	s := NewService(fakeRepository{overrides: map[string]*TemplateOverride{
+		"cla-group-1/" + templateKey(CLAManagerAddedTemplate, "fr"): {
+			Subject:  "EasyCLA : ajouté comme gestionnaire CLA pour {{.ProjectName}}",
+			HTMLBody: "<p>Bonjour {{.RecipientName}},</p>",
+			TextBody: "Bonjour {{.RecipientName}},",
+		},
+	}}, nil)
+
+	msg, err := s.Render(context.Background(), "cla-group-1", CLAManagerAddedTemplate, "fr-CA", CLAManagerAddedData{
+		RecipientName: "Jane",
+		ProjectName:   "Kubernetes",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "EasyCLA : ajouté comme gestionnaire CLA pour Kubernetes", msg.Subject)
+	assert.Equal(t, "Bonjour Jane,", msg.TextBody)
+
+	// other CLA Groups use the built-in template
+	msg, err = s.Render(context.Background(), "cla-group-2", CLAManagerAddedTemplate, "fr-CA", CLAManagerAddedData{
+		ProjectName: "Kubernetes",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "EasyCLA: Added as CLA Manager for Project :Kubernetes", msg.Subject)
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	_, err := NewService(nil, nil).Render(context.Background(), "", "unknown", DefaultLocale, nil)
+	assert.Equal(t, ErrTemplateNotFound, err)
+}
+
+func TestRenderAllBuiltInTemplates(t *testing.T) {
+	managers := []Contact{{Name: "John", Email: "john@example.org"}}
+	templateData := map[string]interface{}{
+		ApprovalListRequestApprovedTemplate:    ApprovalListRequestApprovedData{},
+		CLAManagerAddedTemplate:                CLAManagerAddedData{},
+		OrgAdminSignatureRequestTemplate:       OrgAdminSignatureRequestData{ProjectNames: []string{"Kubernetes"}},
+		ResignInvitationTemplate:               ResignInvitationData{},
+		CorporateSignatureReminderTemplate:     CorporateSignatureReminderData{},
+		CLAManagerAccessRequestTemplate:        CLAManagerNoticeData{},
+		CLAManagerAccessApprovedNoticeTemplate: CLAManagerNoticeData{},
+		CLAManagerAccessApprovedTemplate:       CLAManagerNoticeData{},
+		CLAManagerAccessDeniedNoticeTemplate:   CLAManagerNoticeData{},
+		CLAManagerAccessDeniedTemplate:         CLAManagerNoticeData{},
+		CLAManagerAddedNoticeTemplate:          CLAManagerNoticeData{},
+		CLAManagerRemovedTemplate:              ManagersContactData{Managers: managers},
+		CLAManagerRemovedNoticeTemplate:        CLAManagerNoticeData{},
+		CLAManagerInviteTemplate:               CLAManagerInviteData{},
+		ContributorApprovalRequestTemplate:     ContributorApprovalRequestData{},
+		CompanyManagerAccessRequestTemplate:    CompanyManagerAccessData{},
+		CompanyManagerAccessApprovedTemplate:   CompanyManagerAccessData{},
+		CompanyManagerAccessDeniedTemplate:     ManagersContactData{Managers: managers},
+		CompanyProfileTemplate:                 CompanyProfileData{},
+		CompanyOwnerInviteTemplate:             CompanyOwnerInviteData{},
+		DomainVerificationTemplate:             DomainVerificationData{Domain: "example.org", Token: "abc123"},
+		ApprovalListRequestTemplate:            ApprovalListRequestData{Message: "please"},
+		ApprovalListRequestDeniedTemplate:      ManagersContactData{Managers: managers},
+		ApprovalListUpdatedTemplate:            ApprovalListUpdatedData{Changes: []string{"Added Email: jane@example.org"}},
+		ApprovalListContributorUpdatedTemplate: ApprovalListContributorUpdatedData{Added: true},
+		ApprovalListExpiryReminderTemplate:     ApprovalListExpiryReminderData{Entries: []string{"Email jane@example.org expires on January 2, 2026 15:04 UTC"}},
+	}
+	assert.Len(t, builtInTemplates, len(templateData))
+
+	s := NewService(nil, nil)
+	for templateName := range builtInTemplates {
+		data, ok := templateData[templateName]
+		if !assert.True(t, ok, "no test data for template: %s", templateName) {
+			continue
+		}
+		msg, err := s.Render(context.Background(), "", templateName, DefaultLocale, data)
+		if assert.Nil(t, err, templateName) {
+			assert.NotEmpty(t, msg.Subject, templateName)
+			assert.NotContains(t, msg.HTMLBody, "<no value>", templateName)
+			assert.NotContains(t, msg.TextBody, "<no value>", templateName)
+		}
+	}
+}
+
+func TestRenderManagerList(t *testing.T) {
+	msg, err := NewService(nil, nil).Render(context.Background(), "", CLAManagerRemovedTemplate, DefaultLocale, ManagersContactData{
+		RecipientName: "Jane",
+		CompanyName:   "Acme",
+		ProjectName:   "Kubernetes",
+		Managers:      []Contact{{Name: "John", Email: "john@example.org"}},
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, msg.HTMLBody, "<li>John &lt;john@example.org&gt;</li>")
+	assert.Contains(t, msg.TextBody, "- John <john@example.org>")
+}
@@ -0,0 +1,392 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"bytes"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// DefaultLocale is the locale used when no template exists for the recipient locale
+const DefaultLocale = "en"
+
+// notification template names
+const (
+	ApprovalListRequestApprovedTemplate = "approval-list-request-approved"
+	CLAManagerAddedTemplate             = "cla-manager-added"
+	OrgAdminSignatureRequestTemplate    = "org-admin-signature-request"
+	ResignInvitationTemplate            = "cla-resign-invitation"
+	CorporateSignatureReminderTemplate  = "corporate-signature-reminder"
+)
+
+// Template contains the subject, HTML body and plain text body templates of a notification email
+type Template struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Message is a rendered notification email
+type Message struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// ApprovalListRequestApprovedData is the data of the approval list request approved notification
+type ApprovalListRequestApprovedData struct {
+	RecipientName       string
+	CompanyName         string
+	ProjectName         string
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// CLAManagerAddedData is the data of the CLA manager added notification
+type CLAManagerAddedData struct {
+	RecipientName       string
+	CompanyName         string
+	ProjectName         string
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// OrgAdminSignatureRequestData is the data of the notification asking an organization admin to sign the CCLA
+type OrgAdminSignatureRequestData struct {
+	RecipientName       string
+	CompanyName         string
+	ProjectNames        []string
+	ContributorID       string
+	ContributorName     string
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// ResignInvitationData is the data of the invitation to sign the new major version of the CLA Group document
+type ResignInvitationData struct {
+	RecipientName       string
+	ProjectName         string
+	CompanyName         string
+	Corporate           bool
+	SignedVersion       string
+	MajorVersion        int64
+	GraceDate           string
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// CorporateSignatureReminderData is the data of the reminder sent to a CLA signatory who did not sign the CCLA yet
+type CorporateSignatureReminderData struct {
+	RecipientName       string
+	CompanyName         string
+	ProjectName         string
+	RequestedBy         string
+	DateRequested       string
+	SendAsEmail         bool
+	CorporateConsoleURL string
+	V2                  bool
+}
+
+// builtInTemplates are the default templates by template name and locale
+var builtInTemplates = map[string]map[string]Template{
+	ApprovalListRequestApprovedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Contributor Access Approved for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>You have now been approved as a contributor from {{.CompanyName}} for the project {{.ProjectName}}.</p>
+<p> To get started, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>,
+and select your company and then the project {{.ProjectName}}. From here you will
+be able to edit the list of approved employees and CLA Managers.
+</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+You have now been approved as a contributor from {{.CompanyName}} for the project {{.ProjectName}}.
+
+To get started, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, and select your company and
+then the project {{.ProjectName}}. From here you will be able to edit the list of approved employees and CLA Managers.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CLAManagerAddedTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Added as CLA Manager for Project :{{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>You have been added as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}.  This means that you can now maintain the
+list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as view and manage the list of your
+company’s CLA Managers for {{.ProjectName}}.</p>
+<p> To get started, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>, and select your
+company and then the project {{.ProjectName}}. From here you will be able to edit the list of approved employees and CLA Managers.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+You have been added as a CLA Manager from {{.CompanyName}} for the project {{.ProjectName}}. This means that you can now
+maintain the list of employees allowed to contribute to {{.ProjectName}} on behalf of your company, as well as view and
+manage the list of your company’s CLA Managers for {{.ProjectName}}.
+
+To get started, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, and select your company and
+then the project {{.ProjectName}}. From here you will be able to edit the list of approved employees and CLA Managers.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	OrgAdminSignatureRequestTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA:  Invitation to Sign the {{.CompanyName}} Corporate CLA and add to approved list {{.ContributorID}} `,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project(s) {{join .ProjectNames ", "}}.</p>
+<p>The following contributor is requesting to sign CLA for organization: </p>
+<p> {{.ContributorName}} {{.ContributorID}} </p>
+<p>Before the user contribution can be accepted, your organization must sign a CLA.
+<p>Kindly login to this portal {{.CorporateConsoleURL}} and sign the CLA for any of the projects {{join .ProjectNames ", "}}. </p>
+<p>Please notify the contributor once they are added so that they may complete the contribution process.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project(s) {{join .ProjectNames ", "}}.
+
+The following contributor is requesting to sign CLA for organization:
+
+{{.ContributorName}} {{.ContributorID}}
+
+Before the user contribution can be accepted, your organization must sign a CLA.
+
+Kindly login to this portal {{.CorporateConsoleURL}} and sign the CLA for any of the projects {{join .ProjectNames ", "}}.
+
+Please notify the contributor once they are added so that they may complete the contribution process.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+
+	ResignInvitationTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Please Sign Version {{.MajorVersion}} of the {{.ProjectName}} {{if .Corporate}}Corporate{{else}}Individual{{end}} CLA`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>The project {{.ProjectName}} has published version {{.MajorVersion}} of its {{if .Corporate}}Corporate{{else}}Individual{{end}}
+Contributor License Agreement. {{if .Corporate}}{{.CompanyName}} has{{else}}You have{{end}} signed version {{.SignedVersion}} and
+the project asks all signers to sign the new version.</p>
+{{if .GraceDate}}<p>Signatures of the previous versions are honored until {{.GraceDate}}. After this date, contributions
+covered by an older signature will be blocked until the new version is signed.</p>{{end}}
+{{if .Corporate}}<p>To sign the new version, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>,
+select {{.CompanyName}} and then the project {{.ProjectName}}.</p>{{else}}<p>To sign the new version, open a pull request or
+follow the EasyCLA link on your next contribution to {{.ProjectName}}.</p>{{end}}
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+The project {{.ProjectName}} has published version {{.MajorVersion}} of its {{if .Corporate}}Corporate{{else}}Individual{{end}}
+Contributor License Agreement. {{if .Corporate}}{{.CompanyName}} has{{else}}You have{{end}} signed version {{.SignedVersion}} and
+the project asks all signers to sign the new version.
+{{if .GraceDate}}
+Signatures of the previous versions are honored until {{.GraceDate}}. After this date, contributions covered by an
+older signature will be blocked until the new version is signed.
+{{end}}
+{{if .Corporate}}To sign the new version, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, select
+{{.CompanyName}} and then the project {{.ProjectName}}.{{else}}To sign the new version, open a pull request or follow the
+EasyCLA link on your next contribution to {{.ProjectName}}.{{end}}
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+	CorporateSignatureReminderTemplate: {
+		DefaultLocale: {
+			Subject: `EasyCLA: Reminder to sign the Corporate CLA for {{.ProjectName}}`,
+			HTMLBody: `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.ProjectName}}.</p>
+<p>On {{.DateRequested}}, {{.RequestedBy}} asked you to sign the Corporate Contributor License Agreement for
+{{.CompanyName}} with the project {{.ProjectName}}. The agreement has not been signed yet, and the employees of
+{{.CompanyName}} can not contribute to {{.ProjectName}} until it is.</p>
+{{if .SendAsEmail}}<p>Please follow the link of the DocuSign email you received to review and sign the agreement.</p>{{else}}<p>To sign the
+agreement, please log into the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>, and select
+{{.CompanyName}} and then the project {{.ProjectName}}.</p>{{end}}
+<p>If you are not the right person to sign, please let {{.RequestedBy}} know so the request can be cancelled.</p>
+{{helpContent .V2}}
+{{signOffContent}}`,
+			TextBody: `Hello {{.RecipientName}},
+
+This is a notification email from EasyCLA regarding the project {{.ProjectName}}.
+
+On {{.DateRequested}}, {{.RequestedBy}} asked you to sign the Corporate Contributor License Agreement for
+{{.CompanyName}} with the project {{.ProjectName}}. The agreement has not been signed yet, and the employees of
+{{.CompanyName}} can not contribute to {{.ProjectName}} until it is.
+
+{{if .SendAsEmail}}Please follow the link of the DocuSign email you received to review and sign the agreement.{{else}}To sign the
+agreement, please log into the EasyCLA Corporate Console at {{.CorporateConsoleURL}}, and select {{.CompanyName}} and
+then the project {{.ProjectName}}.{{end}}
+
+If you are not the right person to sign, please let {{.RequestedBy}} know so the request can be cancelled.
+
+{{helpText .V2}}
+
+{{signOffText}}`,
+		},
+	},
+}
+
+// add the built-in templates of each area
+func init() {
+	for _, templates := range []map[string]map[string]Template{claManagerTemplates, companyTemplates, approvalListTemplates} {
+		for templateName, byLocale := range templates {
+			builtInTemplates[templateName] = byLocale
+		}
+	}
+}
+
+// htmlFuncs are the functions available to the HTML body templates
+var htmlFuncs = htmlTemplate.FuncMap{
+	"join": strings.Join,
+	"helpContent": func(v2 bool) htmlTemplate.HTML {
+		return htmlTemplate.HTML(utils.GetEmailHelpContent(v2)) // nolint
+	},
+	"signOffContent": func() htmlTemplate.HTML {
+		return htmlTemplate.HTML(utils.GetEmailSignOffContent()) // nolint
+	},
+}
+
+// textFuncs are the functions available to the subject and plain text body templates
+var textFuncs = textTemplate.FuncMap{
+	"join":        strings.Join,
+	"helpText":    getEmailHelpText,
+	"signOffText": getEmailSignOffText,
+}
+
+// getEmailHelpText returns the plain text version of the standard email help paragraph
+func getEmailHelpText(v2 bool) string {
+	docURL := "https://docs.linuxfoundation.org/docs/communitybridge/communitybridge-easycla"
+	if v2 {
+		docURL = "https://docs.linuxfoundation.org/docs/v/v2/communitybridge/easycla"
+	}
+	return "If you need help or have questions about EasyCLA, you can read the documentation at " + docURL +
+		" or reach out to us for support at https://jira.linuxfoundation.org/servicedesk/customer/portal/4/create/143."
+}
+
+// getEmailSignOffText returns the plain text version of the standard email sign-off
+func getEmailSignOffText() string {
+	return "Thanks,\nEasyCLA support team"
+}
+
+// validate parses each part of the template and returns the first parse error
+func (t Template) validate() error {
+	if _, err := textTemplate.New("subject").Funcs(textFuncs).Parse(t.Subject); err != nil {
+		return err
+	}
+	if _, err := htmlTemplate.New("html").Funcs(htmlFuncs).Parse(t.HTMLBody); err != nil {
+		return err
+	}
+	if _, err := textTemplate.New("text").Funcs(textFuncs).Parse(t.TextBody); err != nil {
+		return err
+	}
+	return nil
+}
+
+// render executes each part of the template with the specified data
+func (t Template) render(data interface{}) (*Message, error) {
+	subject, err := executeText("subject", t.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+
+	htmlTmpl, err := htmlTemplate.New("html").Funcs(htmlFuncs).Parse(t.HTMLBody)
+	if err != nil {
+		return nil, err
+	}
+	var htmlBody bytes.Buffer
+	if err = htmlTmpl.Execute(&htmlBody, data); err != nil {
+		return nil, err
+	}
+
+	textBody, err := executeText("text", t.TextBody, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject:  strings.TrimSpace(subject),
+		HTMLBody: htmlBody.String(),
+		TextBody: textBody,
+	}, nil
+}
+
+// executeText parses and executes the text template
+func executeText(name, text string, data interface{}) (string, error) {
+	tmpl, err := textTemplate.New(name).Funcs(textFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err = tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// NormalizeLocale returns the locale with a lower case language and upper case region, e.g. fr_ca becomes fr-CA
+func NormalizeLocale(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	parts[0] = strings.ToLower(parts[0])
+	if len(parts) > 1 {
+		parts[1] = strings.ToUpper(parts[1])
+	}
+	return strings.Join(parts, "-")
+}
+
+// localeFallbacks returns the locales to try for the requested locale, e.g. fr-CA, fr and the default locale
+func localeFallbacks(locale string) []string {
+	locale = NormalizeLocale(locale)
+	var locales []string
+	if locale != "" {
+		locales = append(locales, locale)
+		if idx := strings.Index(locale, "-"); idx > 0 {
+			locales = append(locales, locale[:idx])
+		}
+	}
+	locales = append(locales, DefaultLocale)
+
+	var unique []string
+	seen := map[string]bool{}
+	for _, l := range locales {
+		if !seen[l] {
+			seen[l] = true
+			unique = append(unique, l)
+		}
+	}
+	return unique
+}
+
+// IsBuiltInTemplate returns true if the template name is one of the built-in notification templates
+func IsBuiltInTemplate(templateName string) bool {
+	_, ok := builtInTemplates[templateName]
+	return ok
+}
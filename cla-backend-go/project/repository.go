@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // errors
@@ -61,7 +62,7 @@ type ProjectRepository interface { //nolint
 // NewRepository creates instance of project repository
 func NewRepository(awsSession *session.Session, stage string, ghRepo repositories.Repository, gerritRepo gerrits.Repository, projectClaGroupRepo projects_cla_groups.Repository) ProjectRepository {
 	return &repo{
-		dynamoDBClient:      dynamodb.New(awsSession),
+		dynamoDBClient:      storage.NewClient(awsSession),
 		stage:               stage,
 		ghRepo:              ghRepo,
 		gerritRepo:          gerritRepo,
@@ -72,7 +73,7 @@ func NewRepository(awsSession *session.Session, stage string, ghRepo repositorie
 
 type repo struct {
 	stage               string
-	dynamoDBClient      *dynamodb.DynamoDB
+	dynamoDBClient      storage.DynamoDBAPI
 	ghRepo              repositories.Repository
 	gerritRepo          gerrits.Repository
 	projectClaGroupRepo projects_cla_groups.Repository
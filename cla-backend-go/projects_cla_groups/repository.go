@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // constants
@@ -48,7 +49,6 @@ type Repository interface {
 	GetProjectsIdsForAllFoundation() ([]*ProjectClaGroup, error)
 	AssociateClaGroupWithProject(claGroupID string, projectSFID string, foundationSFID string) error
 	RemoveProjectAssociatedWithClaGroup(claGroupID string, projectSFIDList []string, all bool) error
-	getCLAGroupNameByID(claGroupID string) (string, error)
 
 	IsExistingFoundationLevelCLAGroup(foundationSFID string) (bool, error)
 	IsAssociated(projectSFID string, claGroupID string) (bool, error)
@@ -57,7 +57,7 @@ type Repository interface {
 
 type repo struct {
 	tableName      string
-	dynamoDBClient *dynamodb.DynamoDB
+	dynamoDBClient storage.DynamoDBAPI
 	stage          string
 }
 
@@ -65,7 +65,7 @@ type repo struct {
 func NewRepository(awsSession *session.Session, stage string) Repository {
 	return &repo{
 		tableName:      fmt.Sprintf("cla-%s-projects-cla-groups", stage),
-		dynamoDBClient: dynamodb.New(awsSession),
+		dynamoDBClient: storage.NewClient(awsSession),
 		stage:          stage,
 	}
 }
@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+// The local renderer uses the standard Helvetica fonts which every PDF reader provides, so no font is embedded. The
+// widths are the Adobe font metrics of the printable ASCII characters (32 to 126) in 1/1000 of the font size.
+
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 to 9
+	278, 278, 584, 584, 584, 556, 1015, // : to @
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A to M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N to Z
+	278, 278, 278, 469, 556, 333, // [ to `
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a to m
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n to z
+	334, 260, 334, 584, // { to ~
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 to 9
+	333, 333, 584, 584, 584, 611, 975, // : to @
+	722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, // A to M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N to Z
+	333, 278, 333, 584, 556, 333, // [ to `
+	556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, // a to m
+	611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, // n to z
+	389, 280, 389, 584, // { to ~
+}
+
+// winAnsi maps the typographic characters outside of Latin-1 to their WinAnsiEncoding code
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, '‰': 0x89, '‹': 0x8b,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99, '›': 0x9b,
+}
+
+// winAnsiWidths are the widths of the non ASCII WinAnsiEncoding codes which differ from the default width
+var winAnsiWidths = map[byte]int{
+	0x82: 222, 0x84: 333, 0x85: 1000, 0x89: 1000, 0x8b: 333, 0x91: 222, 0x92: 222, 0x93: 333, 0x94: 333,
+	0x95: 350, 0x96: 556, 0x97: 1000, 0x99: 1000, 0x9b: 333, 0xa0: 278, 0xa9: 737, 0xae: 737,
+}
+
+// encodeWinAnsi converts the text to the WinAnsiEncoding of the standard fonts - characters which can not be
+// encoded are replaced by a question mark
+func encodeWinAnsi(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case r == '\t':
+			encoded = append(encoded, ' ')
+		default:
+			if b, ok := winAnsi[r]; ok {
+				encoded = append(encoded, b)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
+
+// textWidth returns the width in points of the encoded text
+func textWidth(text []byte, bold bool, size float64) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range text {
+		switch {
+		case b >= 32 && b <= 126:
+			total += widths[b-32]
+		default:
+			if w, ok := winAnsiWidths[b]; ok {
+				total += w
+			} else {
+				total += 556
+			}
+		}
+	}
+	return float64(total) * size / 1000
+}
@@ -0,0 +1,512 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// page layout in points - US Letter with one inch margins
+const (
+	pageWidth    = 612.0
+	pageHeight   = 792.0
+	pageMargin   = 72.0
+	bodyFontSize = 11.0
+	listIndent   = 18.0
+	lineSpacing  = 1.4
+)
+
+// spacing after a block, relative to its font size
+const (
+	paragraphSpacing = 0.6
+	headingSpacing   = 0.5
+	listItemSpacing  = 0.25
+	tableRowSpacing  = 0.3
+)
+
+var headingSizes = map[string]float64{"h1": 18, "h2": 16, "h3": 14, "h4": 12, "h5": 11, "h6": 11}
+
+var blockElements = map[string]bool{
+	"html": true, "body": true, "p": true, "div": true, "section": true, "article": true, "header": true,
+	"footer": true, "main": true, "nav": true, "aside": true, "blockquote": true, "address": true, "center": true,
+	"form": true, "fieldset": true, "figure": true, "figcaption": true, "table": true, "thead": true, "tbody": true,
+	"tfoot": true, "tr": true, "ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+var skippedElements = map[string]bool{
+	"head": true, "title": true, "script": true, "style": true, "noscript": true, "template": true,
+}
+
+// LocalRenderer converts HTML into a PDF without an external service. It renders the text of the document - headings,
+// paragraphs, lists, tables as rows of text, line breaks, bold and centered text, horizontal rules and page breaks -
+// with the standard Helvetica fonts. Images and any other styling are not rendered.
+type LocalRenderer struct{}
+
+// NewLocalRenderer creates a new local PDF renderer
+func NewLocalRenderer() LocalRenderer {
+	return LocalRenderer{}
+}
+
+// CreatePDF accepts an HTML document and returns a PDF
+func (r LocalRenderer) CreatePDF(document string) (io.ReadCloser, error) {
+	root, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &layout{}
+	l.walk(root, style{size: bodyFontSize})
+	l.endBlock(paragraphSpacing)
+	return ioutil.NopCloser(bytes.NewReader(writePDF(l.paginate()))), nil
+}
+
+// style is the text style inherited from the enclosing HTML elements
+type style struct {
+	size   float64
+	indent float64
+	bold   bool
+	center bool
+	pre    bool
+}
+
+// segment is a piece of text in one font - a word is made of one or more segments without whitespace in between
+type segment struct {
+	text []byte
+	bold bool
+}
+
+type word []segment
+
+// block is a paragraph laid out with one font size, alignment and indentation
+type block struct {
+	style      style
+	words      []word
+	prefix     []byte
+	spaceAfter float64
+	rule       bool
+	pageBreak  bool
+}
+
+type list struct {
+	ordered bool
+	count   int
+}
+
+// layout collects the blocks of the HTML document
+type layout struct {
+	blocks  []*block
+	current *block
+	prefix  []byte
+	lists   []*list
+	space   bool
+}
+
+func (l *layout) walk(n *html.Node, st style) {
+	switch n.Type {
+	case html.DocumentNode:
+		l.walkChildren(n, st)
+		return
+	case html.TextNode:
+		l.text(n.Data, st)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	tag := n.Data
+	if skippedElements[tag] {
+		return
+	}
+	css := inlineStyle(n)
+	if tag == "center" || strings.EqualFold(attribute(n, "align"), "center") || strings.Contains(css, "text-align:center") {
+		st.center = true
+	}
+
+	switch tag {
+	case "br":
+		l.lineBreak(st)
+		return
+	case "hr":
+		l.endBlock(paragraphSpacing)
+		l.blocks = append(l.blocks, &block{rule: true, spaceAfter: bodyFontSize * paragraphSpacing * 2})
+		return
+	case "b", "strong", "th", "dt":
+		st.bold = true
+	case "pre":
+		st.pre = true
+	case "td":
+		l.space = true
+	}
+	if size, ok := headingSizes[tag]; ok {
+		st.size = size
+		st.bold = true
+	}
+
+	if !blockElements[tag] {
+		l.walkChildren(n, st)
+		return
+	}
+
+	l.endBlock(paragraphSpacing)
+	if strings.Contains(css, "page-break-before:always") {
+		l.pageBreak()
+	}
+	switch tag {
+	case "ul", "ol":
+		l.lists = append(l.lists, &list{ordered: tag == "ol"})
+		st.indent += listIndent
+	case "dd", "blockquote":
+		st.indent += listIndent
+	case "li":
+		l.prefix = l.listMarker()
+	}
+
+	l.walkChildren(n, st)
+
+	spacing := paragraphSpacing
+	switch tag {
+	case "ul", "ol":
+		l.lists = l.lists[:len(l.lists)-1]
+	case "li":
+		l.prefix = nil
+		spacing = listItemSpacing
+	case "tr", "dt":
+		spacing = tableRowSpacing
+	}
+	if _, ok := headingSizes[tag]; ok {
+		spacing = headingSpacing
+	}
+	l.endBlock(spacing)
+	if strings.Contains(css, "page-break-after:always") {
+		l.pageBreak()
+	}
+}
+
+func (l *layout) walkChildren(n *html.Node, st style) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		l.walk(c, st)
+	}
+}
+
+// text adds the words of the text to the current block - whitespace is collapsed unless preformatted
+func (l *layout) text(data string, st style) {
+	if st.pre {
+		for i, line := range strings.Split(data, "\n") {
+			if i > 0 {
+				l.lineBreak(st)
+			}
+			line = strings.TrimRight(line, "\r")
+			if line != "" {
+				b := l.block(st)
+				b.words = append(b.words, word{{text: encodeWinAnsi(line), bold: st.bold}})
+			}
+		}
+		return
+	}
+
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		if data != "" {
+			l.space = true
+		}
+		return
+	}
+	if unicode.IsSpace([]rune(data)[0]) {
+		l.space = true
+	}
+	for _, field := range fields {
+		seg := segment{text: encodeWinAnsi(field), bold: st.bold}
+		b := l.block(st)
+		if !l.space && len(b.words) > 0 {
+			last := len(b.words) - 1
+			b.words[last] = append(b.words[last], seg)
+		} else {
+			b.words = append(b.words, word{seg})
+		}
+		l.space = true
+	}
+	runes := []rune(data)
+	l.space = unicode.IsSpace(runes[len(runes)-1])
+}
+
+// block returns the current block, starting a new one if needed
+func (l *layout) block(st style) *block {
+	if l.current == nil {
+		l.current = &block{style: st, prefix: l.prefix}
+		l.prefix = nil
+	}
+	return l.current
+}
+
+// endBlock ends the current block, if any, followed by the spacing relative to its font size
+func (l *layout) endBlock(spacing float64) {
+	if l.current == nil {
+		return
+	}
+	l.current.spaceAfter = l.current.style.size * spacing
+	l.blocks = append(l.blocks, l.current)
+	l.current = nil
+}
+
+// lineBreak ends the current line - a line break without text adds an empty line
+func (l *layout) lineBreak(st style) {
+	l.block(st)
+	l.endBlock(0)
+}
+
+func (l *layout) pageBreak() {
+	l.endBlock(paragraphSpacing)
+	l.blocks = append(l.blocks, &block{pageBreak: true})
+}
+
+// listMarker returns the bullet or number of the next item of the innermost list
+func (l *layout) listMarker() []byte {
+	if len(l.lists) == 0 {
+		return encodeWinAnsi("•")
+	}
+	current := l.lists[len(l.lists)-1]
+	current.count++
+	if current.ordered {
+		return encodeWinAnsi(fmt.Sprintf("%d.", current.count))
+	}
+	return encodeWinAnsi("•")
+}
+
+// span is a run of text in one font at a position of the page
+type span struct {
+	x, y, size float64
+	bold       bool
+	text       []byte
+}
+
+// rule is a horizontal line of the page
+type rule struct {
+	x1, x2, y float64
+}
+
+type page struct {
+	spans []span
+	rules []rule
+}
+
+// paginate breaks the blocks into lines and the lines into pages
+func (l *layout) paginate() []*page {
+	current := &page{}
+	pages := []*page{current}
+	y := pageHeight - pageMargin
+	empty := true
+	newPage := func() {
+		current = &page{}
+		pages = append(pages, current)
+		y = pageHeight - pageMargin
+		empty = true
+	}
+
+	for _, b := range l.blocks {
+		if b.pageBreak {
+			if !empty {
+				newPage()
+			}
+			continue
+		}
+		if b.rule {
+			if y-b.spaceAfter < pageMargin {
+				newPage()
+			}
+			current.rules = append(current.rules, rule{x1: pageMargin, x2: pageWidth - pageMargin, y: y - b.spaceAfter/2})
+			y -= b.spaceAfter
+			empty = false
+			continue
+		}
+
+		size := b.style.size
+		left := pageMargin + b.style.indent
+		textLeft := left
+		if len(b.prefix) > 0 {
+			textLeft += textWidth(b.prefix, false, size) + size/2
+		}
+		maxWidth := pageWidth - pageMargin - textLeft
+		lines := wrap(b.words, size, maxWidth)
+		if len(lines) == 0 {
+			lines = [][]word{nil}
+		}
+
+		for i, line := range lines {
+			if y-size*lineSpacing < pageMargin {
+				newPage()
+			}
+			baseline := y - size
+			x := textLeft
+			if b.style.center {
+				x += (maxWidth - lineWidth(line, size)) / 2
+			}
+			if i == 0 && len(b.prefix) > 0 {
+				current.spans = append(current.spans, span{x: left, y: baseline, size: size, text: b.prefix})
+			}
+			current.spans = append(current.spans, lineSpans(line, x, baseline, size)...)
+			y -= size * lineSpacing
+			empty = false
+		}
+		y -= b.spaceAfter
+	}
+	return pages
+}
+
+// wrap breaks the words into lines no wider than the maximum width - a word wider than a line is left on its own line
+func wrap(words []word, size, maxWidth float64) [][]word {
+	var lines [][]word
+	var line []word
+	width := 0.0
+	space := textWidth([]byte(" "), false, size)
+	for _, w := range words {
+		ww := wordWidth(w, size)
+		if len(line) > 0 && width+space+ww > maxWidth {
+			lines = append(lines, line)
+			line = nil
+			width = 0
+		}
+		if len(line) > 0 {
+			width += space
+		}
+		line = append(line, w)
+		width += ww
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func wordWidth(w word, size float64) float64 {
+	width := 0.0
+	for _, seg := range w {
+		width += textWidth(seg.text, seg.bold, size)
+	}
+	return width
+}
+
+func lineWidth(line []word, size float64) float64 {
+	width := 0.0
+	for i, w := range line {
+		if i > 0 {
+			width += textWidth([]byte(" "), false, size)
+		}
+		width += wordWidth(w, size)
+	}
+	return width
+}
+
+// lineSpans returns the spans of the line, joining the consecutive words of the same font so that the text of the
+// line, such as a signature anchor string, can be found in the PDF
+func lineSpans(line []word, x, y, size float64) []span {
+	var spans []span
+	space := textWidth([]byte(" "), false, size)
+	for i, w := range line {
+		for j, seg := range w {
+			separated := i > 0 && j == 0
+			if separated {
+				x += space
+			}
+			if n := len(spans); n > 0 && spans[n-1].bold == seg.bold {
+				if separated {
+					spans[n-1].text = append(spans[n-1].text, ' ')
+				}
+				spans[n-1].text = append(spans[n-1].text, seg.text...)
+			} else {
+				spans = append(spans, span{x: x, y: y, size: size, bold: seg.bold, text: append([]byte(nil), seg.text...)})
+			}
+			x += textWidth(seg.text, seg.bold, size)
+		}
+	}
+	return spans
+}
+
+// writePDF writes the pages as a PDF document using the standard Helvetica fonts
+func writePDF(pages []*page) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// objects: 1 catalog, 2 page tree, 3 and 4 fonts, 5 document information, then a page and its content per page
+	const firstPageObject = 6
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageObject+2*i))
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Producer (EasyCLA) >>")
+	for i, p := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPageObject+2*i+1))
+		content := p.content()
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// content returns the content stream of the page
+func (p *page) content() []byte {
+	var buf bytes.Buffer
+	for _, r := range p.rules {
+		fmt.Fprintf(&buf, "0.5 w %.2f %.2f m %.2f %.2f l S\n", r.x1, r.y, r.x2, r.y)
+	}
+	for _, s := range p.spans {
+		font := "F1"
+		if s.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&buf, "BT /%s %.1f Tf %.2f %.2f Td (", font, s.size, s.x, s.y)
+		for _, b := range s.text {
+			if b == '(' || b == ')' || b == '\\' {
+				buf.WriteByte('\\')
+			}
+			buf.WriteByte(b)
+		}
+		buf.WriteString(") Tj ET\n")
+	}
+	return buf.Bytes()
+}
+
+// inlineStyle returns the style attribute of the element in lower case without whitespace
+func inlineStyle(n *html.Node) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, attribute(n, "style"))
+}
+
+func attribute(n *html.Node, name string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
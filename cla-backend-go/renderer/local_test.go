@@ -0,0 +1,49 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func renderLocal(t *testing.T, document string) []byte {
+	pdf, err := NewLocalRenderer().CreatePDF(document)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer pdf.Close()
+	b, err := ioutil.ReadAll(pdf)
+	assert.NoError(t, err)
+	return b
+}
+
+func TestLocalRendererCreatesPDF(t *testing.T) {
+	b := renderLocal(t, `<html><body>
+		<h3 style="text-align: center">Individual Contributor License Agreement (“Agreement”)</h3>
+		<p>Project Name: <b>Test</b></br>Full name: (required)</p>
+		<ul><li>first</li><li>second</li></ul>
+		</body></html>`)
+
+	assert.True(t, bytes.HasPrefix(b, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(b, []byte("%%EOF\n")))
+	assert.Contains(t, string(b), "/Count 1")
+	assert.Contains(t, string(b), "(Individual Contributor License Agreement \\(\x93Agreement\x94\\)) Tj")
+	assert.Contains(t, string(b), "/F2 11.0 Tf")
+	assert.Contains(t, string(b), "(Full name: \\(required\\)) Tj")
+	assert.Contains(t, string(b), "(\x95) Tj")
+}
+
+func TestLocalRendererPagination(t *testing.T) {
+	b := renderLocal(t, `<p style="page-break-after: always">cover</p>`+strings.Repeat("<p>"+strings.Repeat("lorem ipsum ", 60)+"</p>", 20))
+	assert.NotContains(t, string(b), "/Count 1 ")
+	assert.NotContains(t, string(b), "/Count 2 ")
+
+	b = renderLocal(t, "")
+	assert.Contains(t, string(b), "/Count 1")
+}
@@ -0,0 +1,33 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
+)
+
+// PDFRenderer converts an HTML document into a PDF
+type PDFRenderer interface {
+	CreatePDF(html string) (io.ReadCloser, error)
+}
+
+// NewPDFRenderer returns the PDF renderer selected by the configuration - DocRaptor unless the local renderer is selected
+func NewPDFRenderer(cfg config.Docraptor) (PDFRenderer, error) {
+	switch cfg.Renderer {
+	case config.PDFRendererLocal:
+		return NewLocalRenderer(), nil
+	case "", config.PDFRendererDocraptor:
+		client, err := docraptor.NewDocraptorClient(cfg.APIKey, cfg.TestMode)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unsupported PDF renderer: %s", cfg.Renderer)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // index
@@ -46,6 +47,7 @@ type Repository interface {
 	DisableRepositoriesByProjectID(projectID string) error
 	DisableRepositoriesOfGithubOrganization(externalProjectID, githubOrgName string) error
 	GetRepository(repositoryID string) (*models.GithubRepository, error)
+	GetRepositoryByGithubID(externalID string, enabled bool) (*models.GithubRepository, error)
 	GetRepositoriesByCLAGroup(claGroup string, enabled bool) ([]*models.GithubRepository, error)
 	GetCLAGroupRepositoriesGroupByOrgs(projectID string, enabled bool) ([]*models.GithubRepositoriesGroupByOrgs, error)
 	ListProjectRepositories(externalProjectID string, projectSFID string, enabled bool) (*models.ListGithubRepositories, error)
@@ -55,14 +57,14 @@ type Repository interface {
 func NewRepository(awsSession *session.Session, stage string) Repository {
 	return &repo{
 		stage:               stage,
-		dynamoDBClient:      dynamodb.New(awsSession),
+		dynamoDBClient:      storage.NewClient(awsSession),
 		repositoryTableName: fmt.Sprintf("cla-%s-repositories", stage),
 	}
 }
 
 type repo struct {
 	stage               string
-	dynamoDBClient      *dynamodb.DynamoDB
+	dynamoDBClient      storage.DynamoDBAPI
 	repositoryTableName string
 }
 
@@ -79,7 +81,7 @@ func (repo repo) AddGithubRepository(externalProjectID string, projectSFID strin
 	}
 
 	// Check first to see if the repository already exists
-	_, err := repo.getRepositoryByGithubID(utils.StringValue(input.RepositoryExternalID), true)
+	_, err := repo.GetRepositoryByGithubID(utils.StringValue(input.RepositoryExternalID), true)
 	if err != nil {
 		// Expecting Not found - no issue if not found - all other error we throw
 		if err != ErrGithubRepositoryNotFound {
@@ -417,7 +419,8 @@ func (repo repo) getRepositoriesByGithubOrg(githubOrgName string) ([]*models.Git
 	return out, nil
 }
 
-func (repo repo) getRepositoryByGithubID(externalID string, enabled bool) (*models.GithubRepository, error) {
+// GetRepositoryByGithubID fetches the repository model by its external github id
+func (repo repo) GetRepositoryByGithubID(externalID string, enabled bool) (*models.GithubRepository, error) {
 	var condition expression.KeyConditionBuilder
 	builder := expression.NewBuilder()
 	condition = expression.Key("repository_external_id").Equal(expression.Value(externalID))
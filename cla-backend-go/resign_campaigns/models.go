@@ -0,0 +1,90 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaigns
+
+// campaign CLA types - the campaign targets the ICLA signers, the CCLA signers or both
+const (
+	ClaTypeICLA = "icla"
+	ClaTypeCCLA = "ccla"
+	ClaTypeAll  = "all"
+)
+
+// campaign status values
+const (
+	CampaignStatusActive    = "active"
+	CampaignStatusCompleted = "completed"
+	CampaignStatusCancelled = "cancelled"
+)
+
+// signer status values
+const (
+	SignerStatusPending  = "pending"
+	SignerStatusInvited  = "invited"
+	SignerStatusResigned = "resigned"
+)
+
+// Campaign asks the signers of the CLA Group on an older document major version to sign the new major version. When a
+// grace date is set the older signatures are no longer honored after that date.
+type Campaign struct {
+	CampaignID    string `json:"campaign_id"`
+	ClaGroupID    string `json:"cla_group_id"`
+	ClaType       string `json:"cla_type"`
+	MajorVersion  int64  `json:"major_version"`
+	GraceDate     string `json:"grace_date,omitempty"`
+	Status        string `json:"status"`
+	CreatedBy     string `json:"created_by"`
+	DateCreated   string `json:"date_created"`
+	DateModified  string `json:"date_modified"`
+	DateCompleted string `json:"date_completed,omitempty"`
+
+	// progress of the campaign, computed from the signers - not stored
+	Progress *Progress `json:"-"`
+}
+
+// Progress counts the signers of a campaign by status
+type Progress struct {
+	Total    int
+	Pending  int
+	Invited  int
+	Resigned int
+}
+
+// Signer is an ICLA or CCLA signature of the CLA Group which has to be signed again for the campaign
+type Signer struct {
+	CampaignID          string   `json:"campaign_id"`
+	SignatureID         string   `json:"signature_id"`
+	ClaType             string   `json:"cla_type"`
+	ReferenceID         string   `json:"reference_id"`
+	Name                string   `json:"name"`
+	Emails              []string `json:"emails,omitempty"`
+	SignedMajorVersion  int64    `json:"signed_major_version"`
+	Status              string   `json:"status"`
+	Invitations         int64    `json:"invitations"`
+	DateInvited         string   `json:"date_invited,omitempty"`
+	DateResigned        string   `json:"date_resigned,omitempty"`
+	ResignedSignatureID string   `json:"resigned_signature_id,omitempty"`
+	DateCreated         string   `json:"date_created"`
+	DateModified        string   `json:"date_modified"`
+}
+
+// covers returns true if the campaign targets the signatures of the CLA type
+func (c *Campaign) covers(claType string) bool {
+	return c.ClaType == ClaTypeAll || c.ClaType == claType
+}
+
+// progress counts the signers by status
+func progress(signers []*Signer) *Progress {
+	p := &Progress{Total: len(signers)}
+	for _, signer := range signers {
+		switch signer.Status {
+		case SignerStatusPending:
+			p.Pending++
+		case SignerStatusInvited:
+			p.Invited++
+		case SignerStatusResigned:
+			p.Resigned++
+		}
+	}
+	return p
+}
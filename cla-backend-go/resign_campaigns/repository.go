@@ -0,0 +1,210 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaigns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// indexes
+const (
+	ClaGroupIDIndex = "cla-group-id-index"
+)
+
+// errors
+var (
+	ErrCampaignNotFound = errors.New("re-sign campaign not found")
+)
+
+// Repository defines functions of the re-sign campaigns and their signers
+type Repository interface {
+	PutCampaign(campaign *Campaign) error
+	GetCampaign(campaignID string) (*Campaign, error)
+	GetCampaignsByClaGroup(claGroupID string) ([]*Campaign, error)
+
+	PutSigner(signer *Signer) error
+	GetSigners(campaignID string, status string) ([]*Signer, error)
+}
+
+type repo struct {
+	stage              string
+	dynamoDBClient     storage.DynamoDBAPI
+	campaignsTableName string
+	signersTableName   string
+}
+
+// NewRepository creates a new re-sign campaign repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repo{
+		stage:              stage,
+		dynamoDBClient:     storage.NewClient(awsSession),
+		campaignsTableName: fmt.Sprintf("cla-%s-resign-campaigns", stage),
+		signersTableName:   fmt.Sprintf("cla-%s-resign-signers", stage),
+	}
+}
+
+// PutCampaign creates or replaces the re-sign campaign
+func (repo *repo) PutCampaign(campaign *Campaign) error {
+	_, currentTime := utils.CurrentTime()
+	if campaign.DateCreated == "" {
+		campaign.DateCreated = currentTime
+	}
+	campaign.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(campaign)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.campaignsTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing re-sign campaign for CLA Group: %s, error: %v", campaign.ClaGroupID, err)
+		return err
+	}
+	return nil
+}
+
+// GetCampaign returns the re-sign campaign
+func (repo *repo) GetCampaign(campaignID string) (*Campaign, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"campaign_id": {S: aws.String(campaignID)},
+		},
+		TableName: aws.String(repo.campaignsTableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching re-sign campaign: %s, error: %v", campaignID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrCampaignNotFound
+	}
+
+	var campaign Campaign
+	err = dynamodbattribute.UnmarshalMap(result.Item, &campaign)
+	if err != nil {
+		log.Warnf("error unmarshalling re-sign campaign, error: %v", err)
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// GetCampaignsByClaGroup returns the re-sign campaigns of the CLA Group
+func (repo *repo) GetCampaignsByClaGroup(claGroupID string) ([]*Campaign, error) {
+	condition := expression.Key("cla_group_id").Equal(expression.Value(claGroupID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.campaignsTableName),
+		IndexName:                 aws.String(ClaGroupIDIndex),
+	}
+
+	campaigns := make([]*Campaign, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching re-sign campaigns for CLA Group: %s, error: %v", claGroupID, errQuery)
+			return nil, errQuery
+		}
+
+		var page []*Campaign
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling re-sign campaigns, error: %v", err)
+			return nil, err
+		}
+		campaigns = append(campaigns, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return campaigns, nil
+}
+
+// PutSigner creates or replaces the campaign signer
+func (repo *repo) PutSigner(signer *Signer) error {
+	_, currentTime := utils.CurrentTime()
+	if signer.DateCreated == "" {
+		signer.DateCreated = currentTime
+	}
+	signer.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(signer)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.signersTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing re-sign campaign: %s signer: %s, error: %v", signer.CampaignID, signer.SignatureID, err)
+		return err
+	}
+	return nil
+}
+
+// GetSigners returns the signers of the campaign, optionally filtered by status
+func (repo *repo) GetSigners(campaignID string, status string) ([]*Signer, error) {
+	condition := expression.Key("campaign_id").Equal(expression.Value(campaignID))
+	builder := expression.NewBuilder().WithKeyCondition(condition)
+	if status != "" {
+		builder = builder.WithFilter(expression.Name("status").Equal(expression.Value(status)))
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signersTableName),
+	}
+
+	signers := make([]*Signer, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching signers for re-sign campaign: %s, error: %v", campaignID, errQuery)
+			return nil, errQuery
+		}
+
+		var page []*Signer
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling re-sign campaign signers, error: %v", err)
+			return nil, err
+		}
+		signers = append(signers, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return signers, nil
+}
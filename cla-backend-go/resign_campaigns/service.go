@@ -0,0 +1,473 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaigns
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	ErrInvalidClaType      = errors.New("re-sign campaign CLA type must be icla, ccla or all")
+	ErrUnknownMajorVersion = errors.New("the CLA Group has no document with the re-sign campaign major version")
+	ErrInvalidGraceDate    = errors.New("re-sign campaign grace date must be a RFC3339 date time")
+	ErrCampaignExists      = errors.New("an active re-sign campaign already covers the CLA type")
+	ErrCampaignNotActive   = errors.New("re-sign campaign is not active")
+	ErrClaGroupNotFound    = errors.New("CLA Group of the re-sign campaign not found")
+)
+
+// SignatureRepository is the signature lookup used to compute the signers of a campaign
+type SignatureRepository interface {
+	ProjectSignatures(ctx context.Context, projectID string) (*models.Signatures, error)
+}
+
+// ClaGroupRepository is the CLA Group lookup used to validate the campaign major version
+type ClaGroupRepository interface {
+	GetCLAGroupByID(projectID string, loadRepoDetails bool) (*models.Project, error)
+}
+
+// UserLookup is the user lookup used to resolve the email of the ICLA signers
+type UserLookup interface {
+	GetUser(userID string) (*models.User, error)
+}
+
+// Service contains the re-sign campaign functions
+type Service interface {
+	CreateCampaign(ctx context.Context, campaign *Campaign) (*Campaign, error)
+	GetCampaign(ctx context.Context, campaignID string) (*Campaign, error)
+	GetCampaigns(ctx context.Context, claGroupID string) ([]*Campaign, error)
+	CancelCampaign(ctx context.Context, campaignID string) (*Campaign, error)
+
+	GetSigners(ctx context.Context, campaignID string, status string) ([]*Signer, error)
+	SendInvitations(ctx context.Context, campaignID string, resend bool) (int, error)
+	RecordSignature(ctx context.Context, claGroupID, claType, referenceID, signatureID string, majorVersion int64) error
+
+	RequiredMajorVersion(ctx context.Context, claGroupID, claType string, now time.Time) (int64, error)
+}
+
+type service struct {
+	repo          Repository
+	signatureRepo SignatureRepository
+	claGroupRepo  ClaGroupRepository
+	userLookup    UserLookup
+}
+
+// NewService creates a new re-sign campaign service
+func NewService(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, userLookup UserLookup) Service {
+	return &service{
+		repo:          repo,
+		signatureRepo: signatureRepo,
+		claGroupRepo:  claGroupRepo,
+		userLookup:    userLookup,
+	}
+}
+
+// CreateCampaign validates and stores the re-sign campaign together with the ICLA/CCLA signers of the CLA Group which
+// signed an older document major version. A campaign without signers is completed right away.
+func (s *service) CreateCampaign(ctx context.Context, campaign *Campaign) (*Campaign, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateCampaign",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     campaign.ClaGroupID,
+		"claType":        campaign.ClaType,
+		"majorVersion":   campaign.MajorVersion,
+	}
+
+	if campaign.ClaType != ClaTypeICLA && campaign.ClaType != ClaTypeCCLA && campaign.ClaType != ClaTypeAll {
+		return nil, ErrInvalidClaType
+	}
+	if campaign.GraceDate != "" {
+		graceDate, err := utils.ParseDateTime(campaign.GraceDate)
+		if err != nil {
+			return nil, ErrInvalidGraceDate
+		}
+		campaign.GraceDate = utils.TimeToString(graceDate)
+	}
+
+	claGroupModel, err := s.claGroupRepo.GetCLAGroupByID(campaign.ClaGroupID, false)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).Warnf("unable to load the CLA Group, error: %+v", err)
+		return nil, ErrClaGroupNotFound
+	}
+	if (campaign.covers(ClaTypeICLA) && !hasDocumentMajorVersion(claGroupModel.ProjectIndividualDocuments, campaign.MajorVersion)) ||
+		(campaign.covers(ClaTypeCCLA) && !hasDocumentMajorVersion(claGroupModel.ProjectCorporateDocuments, campaign.MajorVersion)) {
+		return nil, ErrUnknownMajorVersion
+	}
+
+	existing, err := s.repo.GetCampaignsByClaGroup(campaign.ClaGroupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Status == CampaignStatusActive && (other.covers(ClaTypeICLA) && campaign.covers(ClaTypeICLA) ||
+			other.covers(ClaTypeCCLA) && campaign.covers(ClaTypeCCLA)) {
+			return nil, ErrCampaignExists
+		}
+	}
+
+	sigs, err := s.signatureRepo.ProjectSignatures(ctx, campaign.ClaGroupID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the CLA Group signatures, error: %+v", err)
+		return nil, err
+	}
+
+	campaignID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to generate a UUID for the re-sign campaign, error: %v", err)
+		return nil, err
+	}
+	campaign.CampaignID = campaignID.String()
+	campaign.Status = CampaignStatusActive
+
+	signers := selectSigners(sigs.Signatures, campaign)
+	if len(signers) == 0 {
+		_, now := utils.CurrentTime()
+		campaign.Status = CampaignStatusCompleted
+		campaign.DateCompleted = now
+	}
+
+	log.WithFields(f).Debugf("creating re-sign campaign with %d signers", len(signers))
+	if err = s.repo.PutCampaign(campaign); err != nil {
+		return nil, err
+	}
+	for _, signer := range signers {
+		signer.Emails = s.signerEmails(ctx, signer, sigs.Signatures)
+		if err = s.repo.PutSigner(signer); err != nil {
+			return nil, err
+		}
+	}
+
+	campaign.Progress = progress(signers)
+	return campaign, nil
+}
+
+// GetCampaign returns the re-sign campaign and its progress
+func (s *service) GetCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	campaign, err := s.repo.GetCampaign(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := s.repo.GetSigners(campaignID, "")
+	if err != nil {
+		return nil, err
+	}
+	campaign.Progress = progress(signers)
+	return campaign, nil
+}
+
+// GetCampaigns returns the re-sign campaigns of the CLA Group and their progress, the most recent first
+func (s *service) GetCampaigns(ctx context.Context, claGroupID string) ([]*Campaign, error) {
+	campaigns, err := s.repo.GetCampaignsByClaGroup(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, campaign := range campaigns {
+		signers, signersErr := s.repo.GetSigners(campaign.CampaignID, "")
+		if signersErr != nil {
+			return nil, signersErr
+		}
+		campaign.Progress = progress(signers)
+	}
+	sort.Slice(campaigns, func(i, j int) bool {
+		return campaigns[i].DateCreated > campaigns[j].DateCreated
+	})
+	return campaigns, nil
+}
+
+// CancelCampaign cancels the active re-sign campaign - the older signatures are honored again
+func (s *service) CancelCampaign(ctx context.Context, campaignID string) (*Campaign, error) {
+	campaign, err := s.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != CampaignStatusActive {
+		return nil, ErrCampaignNotActive
+	}
+	campaign.Status = CampaignStatusCancelled
+	if err = s.repo.PutCampaign(campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// GetSigners returns the signers of the re-sign campaign, optionally filtered by status
+func (s *service) GetSigners(ctx context.Context, campaignID string, status string) ([]*Signer, error) {
+	return s.repo.GetSigners(campaignID, status)
+}
+
+// SendInvitations emails the re-sign invitation to the signers which have not been invited yet, or with resend to all
+// the signers which have not signed the new version, and returns the number of signers invited
+func (s *service) SendInvitations(ctx context.Context, campaignID string, resend bool) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "SendInvitations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"campaignID":     campaignID,
+		"resend":         resend,
+	}
+
+	campaign, err := s.repo.GetCampaign(campaignID)
+	if err != nil {
+		return 0, err
+	}
+	if campaign.Status != CampaignStatusActive {
+		return 0, ErrCampaignNotActive
+	}
+	claGroupModel, err := s.claGroupRepo.GetCLAGroupByID(campaign.ClaGroupID, false)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).Warnf("unable to load the CLA Group, error: %+v", err)
+		return 0, ErrClaGroupNotFound
+	}
+	signers, err := s.repo.GetSigners(campaignID, "")
+	if err != nil {
+		return 0, err
+	}
+
+	v2 := claGroupModel.Version == utils.V2
+	invited := 0
+	for _, signer := range signers {
+		if signer.Status == SignerStatusResigned || (signer.Status == SignerStatusInvited && !resend) {
+			continue
+		}
+		if len(signer.Emails) == 0 {
+			log.WithFields(f).Warnf("no email address for the %s signer: %s of signature: %s - skipping the invitation",
+				signer.ClaType, signer.ReferenceID, signer.SignatureID)
+			continue
+		}
+
+		data := notifications.ResignInvitationData{
+			RecipientName:       signer.Name,
+			ProjectName:         claGroupModel.ProjectName,
+			SignedVersion:       strconv.FormatInt(signer.SignedMajorVersion, 10),
+			MajorVersion:        campaign.MajorVersion,
+			GraceDate:           campaign.GraceDate,
+			CorporateConsoleURL: utils.GetCorporateURL(v2),
+			V2:                  v2,
+		}
+		if signer.ClaType == ClaTypeCCLA {
+			data.RecipientName = "CLA Manager"
+			data.CompanyName = signer.Name
+			data.Corporate = true
+		}
+		sendErr := notifications.Send(ctx, campaign.ClaGroupID, notifications.ResignInvitationTemplate, signer.Emails, data)
+		if sendErr != nil {
+			log.WithFields(f).Warnf("unable to send the re-sign invitation to: %+v, error: %+v", signer.Emails, sendErr)
+			continue
+		}
+
+		_, now := utils.CurrentTime()
+		signer.Status = SignerStatusInvited
+		signer.Invitations++
+		signer.DateInvited = now
+		if err = s.repo.PutSigner(signer); err != nil {
+			return invited, err
+		}
+		invited++
+	}
+
+	log.WithFields(f).Debugf("sent %d re-sign invitations", invited)
+	return invited, nil
+}
+
+// RecordSignature marks the signer as re-signed in the active campaigns of the CLA Group which the new signature
+// satisfies - a campaign is completed once all its signers have re-signed
+func (s *service) RecordSignature(ctx context.Context, claGroupID, claType, referenceID, signatureID string, majorVersion int64) error {
+	f := logrus.Fields{
+		"functionName":   "RecordSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+		"referenceID":    referenceID,
+		"signatureID":    signatureID,
+		"majorVersion":   majorVersion,
+	}
+
+	campaigns, err := s.repo.GetCampaignsByClaGroup(claGroupID)
+	if err != nil {
+		return err
+	}
+	for _, campaign := range campaigns {
+		if campaign.Status != CampaignStatusActive || !campaign.covers(claType) || majorVersion < campaign.MajorVersion {
+			continue
+		}
+		signers, signersErr := s.repo.GetSigners(campaign.CampaignID, "")
+		if signersErr != nil {
+			return signersErr
+		}
+
+		_, now := utils.CurrentTime()
+		for _, signer := range signers {
+			if signer.ClaType != claType || signer.ReferenceID != referenceID || signer.Status == SignerStatusResigned {
+				continue
+			}
+			log.WithFields(f).Debugf("signer re-signed for campaign: %s", campaign.CampaignID)
+			signer.Status = SignerStatusResigned
+			signer.DateResigned = now
+			signer.ResignedSignatureID = signatureID
+			if err = s.repo.PutSigner(signer); err != nil {
+				return err
+			}
+		}
+
+		if progress(signers).Resigned == len(signers) {
+			log.WithFields(f).Debugf("all signers re-signed, completing campaign: %s", campaign.CampaignID)
+			campaign.Status = CampaignStatusCompleted
+			campaign.DateCompleted = now
+			if err = s.repo.PutCampaign(campaign); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RequiredMajorVersion returns the lowest document major version still honored for the CLA type of the CLA Group -
+// the highest major version of the campaigns whose grace date has passed, or 0 when all versions are honored
+func (s *service) RequiredMajorVersion(ctx context.Context, claGroupID, claType string, now time.Time) (int64, error) {
+	campaigns, err := s.repo.GetCampaignsByClaGroup(claGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return requiredMajorVersion(campaigns, claType, now), nil
+}
+
+// requiredMajorVersion returns the highest major version of the campaigns covering the CLA type whose grace date has
+// passed - cancelled campaigns are ignored
+func requiredMajorVersion(campaigns []*Campaign, claType string, now time.Time) int64 {
+	var required int64
+	for _, campaign := range campaigns {
+		if campaign.Status == CampaignStatusCancelled || campaign.GraceDate == "" || !campaign.covers(claType) {
+			continue
+		}
+		graceDate, err := utils.ParseDateTime(campaign.GraceDate)
+		if err != nil || now.Before(graceDate) {
+			continue
+		}
+		if campaign.MajorVersion > required {
+			required = campaign.MajorVersion
+		}
+	}
+	return required
+}
+
+// selectSigners returns the signers of the ICLA/CCLA signatures covered by the campaign which were signed on an older
+// document major version. Signers which already signed the campaign version, or a newer one, are left out and each
+// signer is listed once with the latest of its older signatures.
+func selectSigners(sigs []*models.Signature, campaign *Campaign) []*Signer {
+	latest := map[string]*models.Signature{}
+	upToDate := map[string]bool{}
+	var keys []string
+	for _, sig := range sigs {
+		if (sig.ClaType != ClaTypeICLA && sig.ClaType != ClaTypeCCLA) || !campaign.covers(sig.ClaType) {
+			continue
+		}
+		key := sig.ClaType + "#" + sig.SignatureReferenceID.String()
+		major := utils.SignatureMajorVersion(sig)
+		if major >= campaign.MajorVersion {
+			upToDate[key] = true
+			continue
+		}
+		current, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || major > utils.SignatureMajorVersion(current) {
+			latest[key] = sig
+		}
+	}
+
+	signers := make([]*Signer, 0, len(keys))
+	for _, key := range keys {
+		if upToDate[key] {
+			continue
+		}
+		sig := latest[key]
+		name := sig.UserName
+		if sig.ClaType == ClaTypeCCLA {
+			name = sig.CompanyName
+		}
+		if name == "" {
+			name = sig.SignatureReferenceName
+		}
+		signers = append(signers, &Signer{
+			CampaignID:         campaign.CampaignID,
+			SignatureID:        sig.SignatureID.String(),
+			ClaType:            sig.ClaType,
+			ReferenceID:        sig.SignatureReferenceID.String(),
+			Name:               name,
+			SignedMajorVersion: utils.SignatureMajorVersion(sig),
+			Status:             SignerStatusPending,
+		})
+	}
+	return signers
+}
+
+// signerEmails returns the email addresses the invitation of the signer is sent to - the ICLA signer or the CLA
+// Managers of the CCLA
+func (s *service) signerEmails(ctx context.Context, signer *Signer, sigs []*models.Signature) []string {
+	f := logrus.Fields{
+		"functionName":   "signerEmails",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signer.SignatureID,
+	}
+
+	var emails []string
+	if signer.ClaType == ClaTypeICLA {
+		userModel, err := s.userLookup.GetUser(signer.ReferenceID)
+		if err != nil || userModel == nil {
+			log.WithFields(f).Warnf("unable to lookup the ICLA signer by ID: %s, error: %+v", signer.ReferenceID, err)
+			return nil
+		}
+		if email := bestEmail(*userModel); email != "" {
+			emails = append(emails, email)
+		}
+		return emails
+	}
+
+	for _, sig := range sigs {
+		if sig.SignatureID.String() != signer.SignatureID {
+			continue
+		}
+		for _, claManager := range sig.SignatureACL {
+			if email := bestEmail(claManager); email != "" && !utils.StringInSlice(email, emails) {
+				emails = append(emails, email)
+			}
+		}
+	}
+	return emails
+}
+
+// hasDocumentMajorVersion returns true if one of the CLA Group documents has the major version
+func hasDocumentMajorVersion(docs []models.ProjectDocument, majorVersion int64) bool {
+	for _, doc := range docs {
+		major, err := strconv.ParseInt(doc.DocumentMajorVersion, 10, 64)
+		if err == nil && major == majorVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// bestEmail returns the best email address of the user
+func bestEmail(user models.User) string {
+	if user.LfEmail != "" {
+		return user.LfEmail
+	}
+	for _, email := range user.Emails {
+		if email != "" {
+			return email
+		}
+	}
+	return ""
+}
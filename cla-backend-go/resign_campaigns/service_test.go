@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaigns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func signature(id, claType, referenceID, majorVersion string) *models.Signature {
+	return &models.Signature{
+		SignatureID:           strfmt.UUID4(id),
+		ClaType:               claType,
+		SignatureReferenceID:  strfmt.UUID4(referenceID),
+		SignatureMajorVersion: majorVersion,
+		UserName:              "user " + referenceID,
+		CompanyName:           "company " + referenceID,
+	}
+}
+
+func TestSelectSignersSkipsUpToDateSigners(t *testing.T) {
+	campaign := &Campaign{CampaignID: "c1", ClaType: ClaTypeAll, MajorVersion: 3}
+	sigs := []*models.Signature{
+		signature("s1", ClaTypeICLA, "u1", "1"),
+		signature("s2", ClaTypeICLA, "u1", "2"),
+		signature("s3", ClaTypeICLA, "u2", "2"),
+		signature("s4", ClaTypeICLA, "u2", "3"),
+		signature("s5", ClaTypeCCLA, "co1", "2"),
+		signature("s6", "cla", "u3", "1"),
+	}
+
+	signers := selectSigners(sigs, campaign)
+	if assert.Len(t, signers, 2) {
+		assert.Equal(t, "s2", signers[0].SignatureID)
+		assert.Equal(t, int64(2), signers[0].SignedMajorVersion)
+		assert.Equal(t, "user u1", signers[0].Name)
+		assert.Equal(t, "s5", signers[1].SignatureID)
+		assert.Equal(t, "company co1", signers[1].Name)
+		assert.Equal(t, SignerStatusPending, signers[1].Status)
+	}
+
+	campaign.ClaType = ClaTypeCCLA
+	signers = selectSigners(sigs, campaign)
+	if assert.Len(t, signers, 1) {
+		assert.Equal(t, ClaTypeCCLA, signers[0].ClaType)
+	}
+}
+
+func TestRequiredMajorVersionOnlyAfterGraceDate(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	campaigns := []*Campaign{
+		{ClaType: ClaTypeICLA, MajorVersion: 2, GraceDate: "2026-05-01T00:00:00Z", Status: CampaignStatusCompleted},
+		{ClaType: ClaTypeAll, MajorVersion: 3, GraceDate: "2026-07-01T00:00:00Z", Status: CampaignStatusActive},
+		{ClaType: ClaTypeCCLA, MajorVersion: 4, GraceDate: "2026-05-01T00:00:00Z", Status: CampaignStatusCancelled},
+		{ClaType: ClaTypeCCLA, MajorVersion: 5, Status: CampaignStatusActive},
+	}
+
+	assert.Equal(t, int64(2), requiredMajorVersion(campaigns, ClaTypeICLA, now))
+	assert.Equal(t, int64(0), requiredMajorVersion(campaigns, ClaTypeCCLA, now))
+	assert.Equal(t, int64(3), requiredMajorVersion(campaigns, ClaTypeCCLA, now.AddDate(0, 2, 0)))
+}
@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package retention
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+)
+
+// retention actions - the expired signed documents are always deleted, the archive action keeps the signature record
+// marked as archived, the delete action deletes the signature record as well
+const (
+	ActionDelete  = "delete"
+	ActionArchive = "archive"
+)
+
+// legal hold types
+const (
+	HoldTypeClaGroup = "cla-group"
+	HoldTypeCompany  = "company"
+)
+
+// signature types and reference types of the signature records
+const (
+	signatureTypeCLA     = "cla"
+	signatureTypeCCLA    = "ccla"
+	referenceTypeCompany = "company"
+)
+
+// Policy is the retention policy of the signed CLAs of a foundation. A retention period of zero years keeps the
+// signatures forever.
+type Policy struct {
+	FoundationSFID                     string `json:"foundation_sfid"`
+	DeletedClaGroupRetentionYears      int64  `json:"deleted_cla_group_retention_years"`
+	InvalidatedSignatureRetentionYears int64  `json:"invalidated_signature_retention_years"`
+	Action                             string `json:"action"`
+	CreatedBy                          string `json:"created_by"`
+	DateCreated                        string `json:"date_created"`
+	DateModified                       string `json:"date_modified"`
+}
+
+// LegalHold suspends the deletion of the signatures of a CLA Group or a company, the hold is keyed by the CLA Group ID
+// or the company ID so it outlives the deletion of the CLA Group
+type LegalHold struct {
+	ReferenceID   string `json:"reference_id"`
+	ReferenceType string `json:"reference_type"`
+	Reason        string `json:"reason"`
+	CreatedBy     string `json:"created_by"`
+	DateCreated   string `json:"date_created"`
+}
+
+// Signature holds the attributes of an invalidated signature record the sweeper needs
+type Signature struct {
+	SignatureID                string   `json:"signature_id"`
+	SignatureProjectID         string   `json:"signature_project_id"`
+	SignatureType              string   `json:"signature_type"`
+	SignatureReferenceID       string   `json:"signature_reference_id"`
+	SignatureReferenceType     string   `json:"signature_reference_type"`
+	SignatureUserCompanyID     string   `json:"signature_user_ccla_company_id"`
+	SignatureReferenceName     string   `json:"signature_reference_name"`
+	SignatureACL               []string `json:"signature_acl"`
+	SignatureClaGroupDeletedOn string   `json:"signature_cla_group_deleted_on"`
+	SignatureInvalidatedOn     string   `json:"signature_invalidated_on"`
+	SignatureFoundationSFID    string   `json:"signature_foundation_sfid"`
+}
+
+// SweepReport counts the outcome of a retention sweep
+type SweepReport struct {
+	SignaturesChecked int
+	SignaturesExpired int
+	DocumentsDeleted  int
+	RecordsDeleted    int
+	RecordsArchived   int
+	SkippedLegalHold  int
+	SkippedNoPolicy   int
+	// InvalidationsStamped counts the invalidated signatures recorded before the invalidation date was kept, their
+	// retention starts from the sweep which stamps them
+	InvalidationsStamped int
+	// ArchivesDeleted counts the CLA Group zip archives deleted with the documents they held, the zip builder
+	// rebuilds them from the remaining documents
+	ArchivesDeleted int
+	Failures        int
+}
+
+// expiresAt returns when the retention of the signature ends under the policy - the signatures of a deleted CLA Group
+// are kept from the deletion, the other invalidated signatures from their invalidation. It returns false when the
+// policy keeps the signature forever or the start of the retention is unknown.
+func (p *Policy) expiresAt(sig *Signature) (time.Time, bool) {
+	years, since := p.InvalidatedSignatureRetentionYears, sig.SignatureInvalidatedOn
+	if sig.SignatureClaGroupDeletedOn != "" {
+		years, since = p.DeletedClaGroupRetentionYears, sig.SignatureClaGroupDeletedOn
+	}
+	if years <= 0 || since == "" {
+		return time.Time{}, false
+	}
+	start, err := utils.ParseDateTime(since)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start.AddDate(int(years), 0, 0), true
+}
+
+// claType returns the CLA type of the signed document path, empty for the employee acknowledgements which have no
+// signed document
+func (sig *Signature) claType() string {
+	switch {
+	case sig.SignatureType == signatureTypeCCLA:
+		return signatureTypeCCLA
+	case sig.SignatureType == signatureTypeCLA && sig.SignatureUserCompanyID == "":
+		return "icla"
+	}
+	return ""
+}
+
+// metricsSignature returns the signature as seen by the metrics
+func (sig *Signature) metricsSignature() *metrics.ItemSignature {
+	return &metrics.ItemSignature{
+		SignatureID:            sig.SignatureID,
+		SignatureReferenceID:   sig.SignatureReferenceID,
+		SignatureReferenceName: sig.SignatureReferenceName,
+		SignatureACL:           sig.SignatureACL,
+		SignatureUserCompanyID: sig.SignatureUserCompanyID,
+		SignatureType:          sig.SignatureType,
+		SignatureReferenceType: sig.SignatureReferenceType,
+		SignatureProjectID:     sig.SignatureProjectID,
+	}
+}
+
+// companyID returns the company of the corporate or employee signature, empty for an individual signature
+func (sig *Signature) companyID() string {
+	if sig.SignatureReferenceType == referenceTypeCompany {
+		return sig.SignatureReferenceID
+	}
+	return sig.SignatureUserCompanyID
+}
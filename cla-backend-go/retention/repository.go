@@ -0,0 +1,351 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package retention
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// errors
+var (
+	ErrPolicyNotFound    = errors.New("retention policy not found")
+	ErrLegalHoldNotFound = errors.New("legal hold not found")
+)
+
+// Repository defines the functions of the retention policies, the legal holds and the invalidated signature records
+type Repository interface {
+	PutPolicy(policy *Policy) error
+	GetPolicy(foundationSFID string) (*Policy, error)
+	GetPolicies() ([]*Policy, error)
+	DeletePolicy(foundationSFID string) error
+
+	PutLegalHold(hold *LegalHold) error
+	GetLegalHold(referenceID string) (*LegalHold, error)
+	GetLegalHolds() ([]*LegalHold, error)
+	DeleteLegalHold(referenceID string) error
+
+	GetInvalidatedSignatures() ([]*Signature, error)
+	StampInvalidation(signatureID, invalidatedOn string) error
+	ArchiveSignature(signatureID string) error
+	DeleteSignature(signatureID string) error
+}
+
+type repo struct {
+	stage              string
+	dynamoDBClient     storage.DynamoDBAPI
+	policiesTableName  string
+	holdsTableName     string
+	signatureTableName string
+}
+
+// NewRepository creates a new retention repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repo{
+		stage:              stage,
+		dynamoDBClient:     storage.NewClient(awsSession),
+		policiesTableName:  fmt.Sprintf("cla-%s-retention-policies", stage),
+		holdsTableName:     fmt.Sprintf("cla-%s-legal-holds", stage),
+		signatureTableName: fmt.Sprintf("cla-%s-signatures", stage),
+	}
+}
+
+// PutPolicy creates or replaces the retention policy of the foundation
+func (repo *repo) PutPolicy(policy *Policy) error {
+	_, currentTime := utils.CurrentTime()
+	if policy.DateCreated == "" {
+		policy.DateCreated = currentTime
+	}
+	policy.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(policy)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.policiesTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing retention policy for foundation: %s, error: %v", policy.FoundationSFID, err)
+		return err
+	}
+	return nil
+}
+
+// GetPolicy returns the retention policy of the foundation
+func (repo *repo) GetPolicy(foundationSFID string) (*Policy, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"foundation_sfid": {S: aws.String(foundationSFID)},
+		},
+		TableName: aws.String(repo.policiesTableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching retention policy for foundation: %s, error: %v", foundationSFID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrPolicyNotFound
+	}
+
+	var policy Policy
+	err = dynamodbattribute.UnmarshalMap(result.Item, &policy)
+	if err != nil {
+		log.Warnf("error unmarshalling retention policy, error: %v", err)
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// GetPolicies returns the retention policies of all the foundations
+func (repo *repo) GetPolicies() ([]*Policy, error) {
+	policies := make([]*Policy, 0)
+	err := repo.scan(&dynamodb.ScanInput{TableName: aws.String(repo.policiesTableName)}, func(items []map[string]*dynamodb.AttributeValue) error {
+		var page []*Policy
+		if err := dynamodbattribute.UnmarshalListOfMaps(items, &page); err != nil {
+			return err
+		}
+		policies = append(policies, page...)
+		return nil
+	})
+	if err != nil {
+		log.Warnf("error fetching retention policies, error: %v", err)
+		return nil, err
+	}
+	return policies, nil
+}
+
+// DeletePolicy deletes the retention policy of the foundation, the signatures of the foundation are kept forever
+func (repo *repo) DeletePolicy(foundationSFID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"foundation_sfid": {S: aws.String(foundationSFID)},
+		},
+		TableName: aws.String(repo.policiesTableName),
+	})
+	if err != nil {
+		log.Warnf("error deleting retention policy for foundation: %s, error: %v", foundationSFID, err)
+		return err
+	}
+	return nil
+}
+
+// PutLegalHold creates or replaces the legal hold of the CLA Group or company
+func (repo *repo) PutLegalHold(hold *LegalHold) error {
+	if hold.DateCreated == "" {
+		_, hold.DateCreated = utils.CurrentTime()
+	}
+
+	av, err := dynamodbattribute.MarshalMap(hold)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.holdsTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing legal hold for %s: %s, error: %v", hold.ReferenceType, hold.ReferenceID, err)
+		return err
+	}
+	return nil
+}
+
+// GetLegalHold returns the legal hold of the CLA Group or company
+func (repo *repo) GetLegalHold(referenceID string) (*LegalHold, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"reference_id": {S: aws.String(referenceID)},
+		},
+		TableName: aws.String(repo.holdsTableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching legal hold: %s, error: %v", referenceID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrLegalHoldNotFound
+	}
+
+	var hold LegalHold
+	err = dynamodbattribute.UnmarshalMap(result.Item, &hold)
+	if err != nil {
+		log.Warnf("error unmarshalling legal hold, error: %v", err)
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// GetLegalHolds returns all the legal holds
+func (repo *repo) GetLegalHolds() ([]*LegalHold, error) {
+	holds := make([]*LegalHold, 0)
+	err := repo.scan(&dynamodb.ScanInput{TableName: aws.String(repo.holdsTableName)}, func(items []map[string]*dynamodb.AttributeValue) error {
+		var page []*LegalHold
+		if err := dynamodbattribute.UnmarshalListOfMaps(items, &page); err != nil {
+			return err
+		}
+		holds = append(holds, page...)
+		return nil
+	})
+	if err != nil {
+		log.Warnf("error fetching legal holds, error: %v", err)
+		return nil, err
+	}
+	return holds, nil
+}
+
+// DeleteLegalHold releases the legal hold of the CLA Group or company
+func (repo *repo) DeleteLegalHold(referenceID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"reference_id": {S: aws.String(referenceID)},
+		},
+		TableName: aws.String(repo.holdsTableName),
+	})
+	if err != nil {
+		log.Warnf("error deleting legal hold: %s, error: %v", referenceID, err)
+		return err
+	}
+	return nil
+}
+
+// GetInvalidatedSignatures returns the signed signatures which are no longer approved and not archived yet - the
+// signatures are not indexed by approval, the lookup scans the table
+func (repo *repo) GetInvalidatedSignatures() ([]*Signature, error) {
+	filter := expression.Name("signature_signed").Equal(expression.Value(true)).
+		And(expression.Name("signature_approved").Equal(expression.Value(false))).
+		And(expression.Name("signature_archived_on").AttributeNotExists())
+	projection := expression.NamesList(expression.Name("signature_id"), expression.Name("signature_project_id"),
+		expression.Name("signature_type"), expression.Name("signature_reference_id"), expression.Name("signature_reference_type"),
+		expression.Name("signature_user_ccla_company_id"), expression.Name("signature_reference_name"), expression.Name("signature_acl"),
+		expression.Name("signature_cla_group_deleted_on"), expression.Name("signature_invalidated_on"),
+		expression.Name("signature_foundation_sfid"))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	sigs := make([]*Signature, 0)
+	err = repo.scan(scanInput, func(items []map[string]*dynamodb.AttributeValue) error {
+		var page []*Signature
+		if err := dynamodbattribute.UnmarshalListOfMaps(items, &page); err != nil {
+			return err
+		}
+		sigs = append(sigs, page...)
+		return nil
+	})
+	if err != nil {
+		log.Warnf("error scanning invalidated signatures, error: %v", err)
+		return nil, err
+	}
+	return sigs, nil
+}
+
+// StampInvalidation records the invalidation date of an invalidated signature which has none - the signatures
+// invalidated before the date was kept. An invalidation date recorded in the meantime is left unchanged.
+func (repo *repo) StampInvalidation(signatureID, invalidatedOn string) error {
+	update := expression.Set(expression.Name("signature_invalidated_on"), expression.Value(invalidatedOn))
+	condition := expression.Name("signature_id").AttributeExists().
+		And(expression.Name("signature_invalidated_on").AttributeNotExists())
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {S: aws.String(signatureID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		TableName:                 aws.String(repo.signatureTableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil
+		}
+		log.Warnf("error stamping the invalidation of signature: %s, error: %v", signatureID, err)
+		return err
+	}
+	return nil
+}
+
+// ArchiveSignature marks the signature record as archived, the record is kept without its signed document
+func (repo *repo) ArchiveSignature(signatureID string) error {
+	_, currentTime := utils.CurrentTime()
+	update := expression.Set(expression.Name("signature_archived_on"), expression.Value(currentTime)).
+		Set(expression.Name("date_modified"), expression.Value(currentTime))
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {S: aws.String(signatureID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		TableName:                 aws.String(repo.signatureTableName),
+	})
+	if err != nil {
+		log.Warnf("error archiving signature: %s, error: %v", signatureID, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteSignature deletes the signature record
+func (repo *repo) DeleteSignature(signatureID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {S: aws.String(signatureID)},
+		},
+		TableName: aws.String(repo.signatureTableName),
+	})
+	if err != nil {
+		log.Warnf("error deleting signature: %s, error: %v", signatureID, err)
+		return err
+	}
+	return nil
+}
+
+// scan pages through the scan results
+func (repo *repo) scan(scanInput *dynamodb.ScanInput, page func(items []map[string]*dynamodb.AttributeValue) error) error {
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			return err
+		}
+		if err = page(results.Items); err != nil {
+			return err
+		}
+		if len(results.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package retention
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	ErrInvalidAction         = errors.New("retention action must be delete or archive")
+	ErrInvalidRetentionYears = errors.New("retention years must not be negative")
+	ErrInvalidHoldType       = errors.New("legal hold type must be cla-group or company")
+	ErrLegalHoldReason       = errors.New("legal hold reason is required")
+)
+
+// ClaGroupRepository is the CLA Group lookup used to resolve the foundation of the invalidated signatures
+type ClaGroupRepository interface {
+	GetCLAGroupByID(projectID string, loadRepoDetails bool) (*models.Project, error)
+}
+
+// MetricsRepository is the metrics update used to remove the purged signatures from the counts
+type MetricsRepository interface {
+	PurgeSignatureMetrics(sig *metrics.ItemSignature) error
+}
+
+// Service contains the retention policy, legal hold and retention sweep functions
+type Service interface {
+	GetPolicy(ctx context.Context, foundationSFID string) (*Policy, error)
+	PutPolicy(ctx context.Context, policy *Policy) (*Policy, error)
+	DeletePolicy(ctx context.Context, foundationSFID string) error
+
+	GetLegalHolds(ctx context.Context) ([]*LegalHold, error)
+	PlaceLegalHold(ctx context.Context, hold *LegalHold) (*LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, holdType, referenceID string) error
+
+	Sweep(ctx context.Context, now time.Time, dryRun bool) (*SweepReport, error)
+}
+
+type service struct {
+	repo         Repository
+	claGroupRepo ClaGroupRepository
+	metricsRepo  MetricsRepository
+
+	// deleteDocument deletes the signed document or the zip archive from the signature files bucket
+	deleteDocument func(filename string) error
+}
+
+// NewService creates a new retention service
+func NewService(repo Repository, claGroupRepo ClaGroupRepository, metricsRepo MetricsRepository) Service {
+	return &service{
+		repo:           repo,
+		claGroupRepo:   claGroupRepo,
+		metricsRepo:    metricsRepo,
+		deleteDocument: utils.DeleteFromS3,
+	}
+}
+
+// GetPolicy returns the retention policy of the foundation
+func (s *service) GetPolicy(ctx context.Context, foundationSFID string) (*Policy, error) {
+	return s.repo.GetPolicy(foundationSFID)
+}
+
+// PutPolicy validates and stores the retention policy of the foundation, the action defaults to delete
+func (s *service) PutPolicy(ctx context.Context, policy *Policy) (*Policy, error) {
+	if policy.Action == "" {
+		policy.Action = ActionDelete
+	}
+	if policy.Action != ActionDelete && policy.Action != ActionArchive {
+		return nil, ErrInvalidAction
+	}
+	if policy.DeletedClaGroupRetentionYears < 0 || policy.InvalidatedSignatureRetentionYears < 0 {
+		return nil, ErrInvalidRetentionYears
+	}
+
+	existing, err := s.repo.GetPolicy(policy.FoundationSFID)
+	if err != nil && err != ErrPolicyNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		policy.CreatedBy = existing.CreatedBy
+		policy.DateCreated = existing.DateCreated
+	}
+
+	if err = s.repo.PutPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeletePolicy deletes the retention policy of the foundation
+func (s *service) DeletePolicy(ctx context.Context, foundationSFID string) error {
+	if _, err := s.repo.GetPolicy(foundationSFID); err != nil {
+		return err
+	}
+	return s.repo.DeletePolicy(foundationSFID)
+}
+
+// GetLegalHolds returns all the legal holds
+func (s *service) GetLegalHolds(ctx context.Context) ([]*LegalHold, error) {
+	return s.repo.GetLegalHolds()
+}
+
+// PlaceLegalHold validates and stores the legal hold - the CLA Group or company is not required to exist, a hold may
+// be placed on the signatures of a deleted CLA Group
+func (s *service) PlaceLegalHold(ctx context.Context, hold *LegalHold) (*LegalHold, error) {
+	if hold.ReferenceType != HoldTypeClaGroup && hold.ReferenceType != HoldTypeCompany {
+		return nil, ErrInvalidHoldType
+	}
+	if hold.Reason == "" {
+		return nil, ErrLegalHoldReason
+	}
+	if err := s.repo.PutLegalHold(hold); err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// ReleaseLegalHold deletes the legal hold of the CLA Group or company
+func (s *service) ReleaseLegalHold(ctx context.Context, holdType, referenceID string) error {
+	hold, err := s.repo.GetLegalHold(referenceID)
+	if err != nil {
+		return err
+	}
+	if hold.ReferenceType != holdType {
+		return ErrLegalHoldNotFound
+	}
+	return s.repo.DeleteLegalHold(referenceID)
+}
+
+// Sweep deletes the signed documents of the invalidated signatures whose retention expired under the policy of their
+// foundation, then deletes or archives the signature records. The zip archives of the CLA Groups which held the
+// deleted documents are deleted as well, the zip builder rebuilds them from the remaining documents. The signatures of
+// the CLA Groups and companies under a legal hold are skipped, as are the signatures of the foundations without a
+// policy. A dry run only counts the expired signatures.
+func (s *service) Sweep(ctx context.Context, now time.Time, dryRun bool) (*SweepReport, error) {
+	f := logrus.Fields{
+		"functionName":   "Sweep",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"dryRun":         dryRun,
+	}
+
+	report := &SweepReport{}
+	policyList, err := s.repo.GetPolicies()
+	if err != nil {
+		return nil, err
+	}
+	if len(policyList) == 0 {
+		log.WithFields(f).Debug("no retention policies configured")
+		return report, nil
+	}
+	policies := make(map[string]*Policy, len(policyList))
+	for _, policy := range policyList {
+		policies[policy.FoundationSFID] = policy
+	}
+
+	holdList, err := s.repo.GetLegalHolds()
+	if err != nil {
+		return nil, err
+	}
+	holds := make(map[string]bool, len(holdList))
+	for _, hold := range holdList {
+		holds[hold.ReferenceID] = true
+	}
+
+	sigs, err := s.repo.GetInvalidatedSignatures()
+	if err != nil {
+		return nil, err
+	}
+
+	_, nowStr := utils.CurrentTime()
+	foundations := map[string]string{}
+	archives := map[string]bool{}
+	for _, sig := range sigs {
+		report.SignaturesChecked++
+
+		if sig.SignatureClaGroupDeletedOn == "" && sig.SignatureInvalidatedOn == "" {
+			// invalidated before the invalidation date was kept - the retention starts now
+			report.InvalidationsStamped++
+			if dryRun {
+				continue
+			}
+			if err = s.repo.StampInvalidation(sig.SignatureID, nowStr); err != nil {
+				log.WithFields(f).Warnf("unable to stamp the invalidation of signature: %s, error: %+v", sig.SignatureID, err)
+				report.Failures++
+			}
+			continue
+		}
+
+		foundationSFID := sig.SignatureFoundationSFID
+		if foundationSFID == "" {
+			foundationSFID, err = s.foundationOf(sig.SignatureProjectID, foundations)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to load the CLA Group: %s of signature: %s, error: %+v", sig.SignatureProjectID, sig.SignatureID, err)
+				report.Failures++
+				continue
+			}
+		}
+		policy, ok := policies[foundationSFID]
+		if !ok {
+			report.SkippedNoPolicy++
+			continue
+		}
+
+		expiry, ok := policy.expiresAt(sig)
+		if !ok || now.Before(expiry) {
+			continue
+		}
+		report.SignaturesExpired++
+
+		if holds[sig.SignatureProjectID] || (sig.companyID() != "" && holds[sig.companyID()]) {
+			report.SkippedLegalHold++
+			continue
+		}
+		if dryRun {
+			continue
+		}
+
+		if err = s.expire(policy, sig, archives, report); err != nil {
+			log.WithFields(f).Warnf("unable to %s the expired signature: %s, error: %+v", policy.Action, sig.SignatureID, err)
+			report.Failures++
+		}
+	}
+
+	// the zip archives still hold the deleted documents
+	var archiveList []string
+	for archive := range archives {
+		archiveList = append(archiveList, archive)
+	}
+	sort.Strings(archiveList)
+	for _, archive := range archiveList {
+		if err = s.deleteDocument(archive); err != nil {
+			log.WithFields(f).Warnf("unable to delete the zip archive: %s, error: %+v", archive, err)
+			report.Failures++
+			continue
+		}
+		report.ArchivesDeleted++
+	}
+
+	return report, nil
+}
+
+// expire deletes the signed document of the signature and records the zip archive holding it, removes the signature
+// from the metrics, then deletes or archives the signature record - the record is kept when the document cannot be
+// deleted or the metrics updated so the next sweep retries
+func (s *service) expire(policy *Policy, sig *Signature, archives map[string]bool, report *SweepReport) error {
+	if claType := sig.claType(); claType != "" {
+		err := s.deleteDocument(utils.SignedCLAFilename(sig.SignatureProjectID, claType, sig.SignatureReferenceID, sig.SignatureID))
+		if err != nil {
+			return err
+		}
+		report.DocumentsDeleted++
+		archives[utils.SignedClaGroupZipFilename(sig.SignatureProjectID, claType)] = true
+	}
+
+	if err := s.metricsRepo.PurgeSignatureMetrics(sig.metricsSignature()); err != nil {
+		return err
+	}
+
+	if policy.Action == ActionArchive {
+		if err := s.repo.ArchiveSignature(sig.SignatureID); err != nil {
+			return err
+		}
+		report.RecordsArchived++
+		return nil
+	}
+
+	if err := s.repo.DeleteSignature(sig.SignatureID); err != nil {
+		return err
+	}
+	report.RecordsDeleted++
+	return nil
+}
+
+// foundationOf returns the foundation of the CLA Group, empty when the CLA Group no longer exists and the signature
+// predates the recording of the foundation on invalidation
+func (s *service) foundationOf(claGroupID string, cache map[string]string) (string, error) {
+	if foundationSFID, ok := cache[claGroupID]; ok {
+		return foundationSFID, nil
+	}
+	claGroupModel, err := s.claGroupRepo.GetCLAGroupByID(claGroupID, false)
+	if err != nil && err != project.ErrProjectDoesNotExist {
+		return "", err
+	}
+	var foundationSFID string
+	if claGroupModel != nil {
+		foundationSFID = claGroupModel.FoundationSFID
+	}
+	cache[claGroupID] = foundationSFID
+	return foundationSFID, nil
+}
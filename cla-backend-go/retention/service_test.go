@@ -0,0 +1,160 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRepository holds the policies, holds and signatures in memory and records the changes
+type stubRepository struct {
+	Repository
+	policies []*Policy
+	holds    []*LegalHold
+	sigs     []*Signature
+	archived []string
+	deleted  []string
+	stamped  []string
+}
+
+func (r *stubRepository) GetPolicies() ([]*Policy, error) {
+	return r.policies, nil
+}
+
+func (r *stubRepository) GetLegalHolds() ([]*LegalHold, error) {
+	return r.holds, nil
+}
+
+func (r *stubRepository) GetInvalidatedSignatures() ([]*Signature, error) {
+	return r.sigs, nil
+}
+
+func (r *stubRepository) ArchiveSignature(signatureID string) error {
+	r.archived = append(r.archived, signatureID)
+	return nil
+}
+
+func (r *stubRepository) DeleteSignature(signatureID string) error {
+	r.deleted = append(r.deleted, signatureID)
+	return nil
+}
+
+func (r *stubRepository) StampInvalidation(signatureID, invalidatedOn string) error {
+	r.stamped = append(r.stamped, signatureID)
+	return nil
+}
+
+type stubClaGroupRepo map[string]string
+
+func (r stubClaGroupRepo) GetCLAGroupByID(projectID string, loadRepoDetails bool) (*models.Project, error) {
+	foundationSFID, ok := r[projectID]
+	if !ok {
+		return nil, project.ErrProjectDoesNotExist
+	}
+	return &models.Project{ProjectID: projectID, FoundationSFID: foundationSFID}, nil
+}
+
+// stubMetricsRepo records the signatures removed from the metrics
+type stubMetricsRepo struct {
+	purged []string
+}
+
+func (r *stubMetricsRepo) PurgeSignatureMetrics(sig *metrics.ItemSignature) error {
+	r.purged = append(r.purged, sig.SignatureID)
+	return nil
+}
+
+func TestSweep(t *testing.T) {
+	repo := &stubRepository{
+		policies: []*Policy{
+			{FoundationSFID: "f1", DeletedClaGroupRetentionYears: 2, InvalidatedSignatureRetentionYears: 5, Action: ActionDelete},
+			{FoundationSFID: "f2", DeletedClaGroupRetentionYears: 1, Action: ActionArchive},
+		},
+		holds: []*LegalHold{{ReferenceID: "held-company", ReferenceType: HoldTypeCompany}},
+		sigs: []*Signature{
+			// deleted CLA Group, expired
+			{SignatureID: "s1", SignatureProjectID: "deleted", SignatureType: "cla", SignatureReferenceID: "u1",
+				SignatureClaGroupDeletedOn: "2023-01-01T00:00:00Z", SignatureFoundationSFID: "f1"},
+			// deleted CLA Group, not expired yet
+			{SignatureID: "s2", SignatureProjectID: "deleted", SignatureType: "cla", SignatureReferenceID: "u2",
+				SignatureClaGroupDeletedOn: "2025-01-01T00:00:00Z", SignatureFoundationSFID: "f1"},
+			// invalidated in an existing CLA Group, expired
+			{SignatureID: "s3", SignatureProjectID: "p1", SignatureType: "ccla", SignatureReferenceID: "c1",
+				SignatureReferenceType: "company", SignatureInvalidatedOn: "2020-01-01T00:00:00Z"},
+			// expired, the company is under a legal hold
+			{SignatureID: "s4", SignatureProjectID: "p1", SignatureType: "cla", SignatureReferenceID: "u4",
+				SignatureUserCompanyID: "held-company", SignatureInvalidatedOn: "2020-01-01T00:00:00Z"},
+			// the policy keeps the invalidated signatures forever
+			{SignatureID: "s5", SignatureProjectID: "p2", SignatureType: "cla", SignatureReferenceID: "u5",
+				SignatureInvalidatedOn: "2020-01-01T00:00:00Z"},
+			// deleted CLA Group, expired, archived
+			{SignatureID: "s6", SignatureProjectID: "deleted2", SignatureType: "ccla", SignatureReferenceID: "c6",
+				SignatureReferenceType: "company", SignatureClaGroupDeletedOn: "2024-01-01T00:00:00Z", SignatureFoundationSFID: "f2"},
+			// deleted CLA Group without a recorded foundation
+			{SignatureID: "s7", SignatureProjectID: "unknown", SignatureType: "cla", SignatureReferenceID: "u7",
+				SignatureClaGroupDeletedOn: "2010-01-01T00:00:00Z"},
+			// invalidated before the invalidation date was kept
+			{SignatureID: "s8", SignatureProjectID: "p1", SignatureType: "cla", SignatureReferenceID: "u8"},
+		},
+	}
+	metricsRepo := &stubMetricsRepo{}
+	var documents []string
+	s := NewService(repo, stubClaGroupRepo{"p1": "f1", "p2": "f2"}, metricsRepo).(*service)
+	s.deleteDocument = func(filename string) error {
+		documents = append(documents, filename)
+		return nil
+	}
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	report, err := s.Sweep(context.Background(), now, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 8, report.SignaturesChecked)
+		assert.Equal(t, 4, report.SignaturesExpired)
+		assert.Equal(t, 1, report.SkippedLegalHold)
+		assert.Equal(t, 1, report.SkippedNoPolicy)
+		assert.Equal(t, 1, report.InvalidationsStamped)
+		assert.Empty(t, documents)
+		assert.Empty(t, repo.deleted)
+		assert.Empty(t, repo.stamped)
+		assert.Empty(t, metricsRepo.purged)
+	}
+
+	report, err = s.Sweep(context.Background(), now, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, report.DocumentsDeleted)
+		assert.Equal(t, 2, report.RecordsDeleted)
+		assert.Equal(t, 1, report.RecordsArchived)
+		assert.Equal(t, 3, report.ArchivesDeleted)
+		assert.Equal(t, []string{
+			"contract-group/deleted/icla/u1/s1.pdf",
+			"contract-group/p1/ccla/c1/s3.pdf",
+			"contract-group/deleted2/ccla/c6/s6.pdf",
+			"contract-group/deleted/icla.zip",
+			"contract-group/deleted2/ccla.zip",
+			"contract-group/p1/ccla.zip",
+		}, documents)
+		assert.Equal(t, []string{"s1", "s3"}, repo.deleted)
+		assert.Equal(t, []string{"s6"}, repo.archived)
+		assert.Equal(t, []string{"s8"}, repo.stamped)
+		assert.Equal(t, []string{"s1", "s3", "s6"}, metricsRepo.purged)
+	}
+}
+
+func TestPutPolicyValidation(t *testing.T) {
+	s := NewService(&stubRepository{}, stubClaGroupRepo{}, &stubMetricsRepo{})
+
+	_, err := s.PutPolicy(context.Background(), &Policy{FoundationSFID: "f1", Action: "shred"})
+	assert.Equal(t, ErrInvalidAction, err)
+	_, err = s.PutPolicy(context.Background(), &Policy{FoundationSFID: "f1", DeletedClaGroupRetentionYears: -1})
+	assert.Equal(t, ErrInvalidRetentionYears, err)
+	_, err = s.PlaceLegalHold(context.Background(), &LegalHold{ReferenceID: "c1", ReferenceType: HoldTypeCompany})
+	assert.Equal(t, ErrLegalHoldReason, err)
+}
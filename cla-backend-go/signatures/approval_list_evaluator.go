@@ -0,0 +1,136 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+)
+
+// approval list rule types
+const (
+	ApprovalListRuleEmail          = "email"
+	ApprovalListRuleDomain         = "domain"
+	ApprovalListRuleGitHubUsername = "github_username"
+	ApprovalListRuleGitHubOrg      = "github_org"
+)
+
+// ApprovalListCandidate describes the contributor which is evaluated against a CCLA approval list
+type ApprovalListCandidate struct {
+	LFUsername     string
+	Emails         []string
+	GitHubUsername string
+	GitHubOrgs     []string
+}
+
+// ApprovalListDecision is the result of evaluating a contributor against a CCLA approval list
+type ApprovalListDecision struct {
+	Approved     bool
+	SignatureID  string
+	RuleType     string
+	Rule         string
+	MatchedValue string
+	Reason       string
+}
+
+// EvaluateApprovalList evaluates the candidate against the approval lists of the specified CCLA signature and
+// returns the first matching rule. Rules are checked in the order: exact email, email domain, GitHub username
+// and GitHub organization. Domain entries may be a plain domain (example.com) which matches only that domain
+// or a wildcard (*.example.com or .example.com) which matches the domain and all of its sub-domains. A wildcard
+// without a domain (*) matches nothing.
+func EvaluateApprovalList(sig *models.Signature, candidate *ApprovalListCandidate) *ApprovalListDecision {
+	if sig == nil {
+		return &ApprovalListDecision{Reason: "no corporate signature"}
+	}
+	if candidate == nil {
+		candidate = &ApprovalListCandidate{}
+	}
+
+	for _, email := range candidate.Emails {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		for _, rule := range sig.EmailApprovalList {
+			if strings.EqualFold(strings.TrimSpace(rule), email) {
+				return approved(sig, ApprovalListRuleEmail, rule, email)
+			}
+		}
+	}
+
+	for _, email := range candidate.Emails {
+		for _, rule := range sig.DomainApprovalList {
+			if emailMatchesDomain(email, rule) {
+				return approved(sig, ApprovalListRuleDomain, rule, email)
+			}
+		}
+	}
+
+	if candidate.GitHubUsername != "" {
+		for _, rule := range sig.GithubUsernameApprovalList {
+			if strings.EqualFold(strings.TrimSpace(rule), candidate.GitHubUsername) {
+				return approved(sig, ApprovalListRuleGitHubUsername, rule, candidate.GitHubUsername)
+			}
+		}
+	}
+
+	for _, org := range candidate.GitHubOrgs {
+		for _, rule := range sig.GithubOrgApprovalList {
+			if strings.EqualFold(strings.TrimSpace(rule), org) {
+				return approved(sig, ApprovalListRuleGitHubOrg, rule, org)
+			}
+		}
+	}
+
+	return &ApprovalListDecision{
+		SignatureID: sig.SignatureID,
+		Reason:      "contributor does not match any approval list entry",
+	}
+}
+
+// approved returns an approved decision for the matching rule
+func approved(sig *models.Signature, ruleType, rule, value string) *ApprovalListDecision {
+	return &ApprovalListDecision{
+		Approved:     true,
+		SignatureID:  sig.SignatureID,
+		RuleType:     ruleType,
+		Rule:         rule,
+		MatchedValue: value,
+		Reason:       "contributor matches the " + ruleType + " approval list entry",
+	}
+}
+
+// emailMatchesDomain returns true if the email address belongs to the domain approval list entry
+func emailMatchesDomain(email, rule string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	if domain == "" || rule == "" {
+		return false
+	}
+
+	if !ValidDomainRule(rule) {
+		return false
+	}
+	if strings.HasPrefix(rule, "*.") {
+		rule = rule[1:]
+	}
+	if strings.HasPrefix(rule, ".") {
+		return domain == rule[1:] || strings.HasSuffix(domain, rule)
+	}
+	return domain == rule
+}
+
+// ValidDomainRule returns true if the domain approval list entry names a domain - a wildcard alone (*, *. or .) would
+// approve every email address
+func ValidDomainRule(rule string) bool {
+	rule = strings.TrimSpace(rule)
+	rule = strings.TrimPrefix(rule, "*")
+	rule = strings.TrimPrefix(rule, ".")
+	return rule != "" && !strings.Contains(rule, "*")
+}
@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEvaluateApprovalList tests the matching rules of the approval list evaluator
+func TestEvaluateApprovalList(t *testing.T) {
+	sig := &models.Signature{
+		SignatureID:                "ccla-1",
+		EmailApprovalList:          []string{"Jane@Example.org"},
+		DomainApprovalList:         []string{"example.com", "*.example.net", ".example.io"},
+		GithubUsernameApprovalList: []string{"octocat"},
+		GithubOrgApprovalList:      []string{"example-org"},
+	}
+
+	testCases := []struct {
+		Name         string
+		candidate    *ApprovalListCandidate
+		approved     bool
+		ruleType     string
+		matchedValue string
+	}{
+		{Name: "nil candidate", candidate: nil},
+		{Name: "exact email case insensitive", candidate: &ApprovalListCandidate{Emails: []string{"jane@example.org"}}, approved: true, ruleType: ApprovalListRuleEmail, matchedValue: "jane@example.org"},
+		{Name: "plain domain", candidate: &ApprovalListCandidate{Emails: []string{"joe@example.com"}}, approved: true, ruleType: ApprovalListRuleDomain, matchedValue: "joe@example.com"},
+		{Name: "plain domain does not match sub-domain", candidate: &ApprovalListCandidate{Emails: []string{"joe@eu.example.com"}}},
+		{Name: "wildcard domain matches sub-domain", candidate: &ApprovalListCandidate{Emails: []string{"joe@eu.example.net"}}, approved: true, ruleType: ApprovalListRuleDomain, matchedValue: "joe@eu.example.net"},
+		{Name: "wildcard domain matches domain", candidate: &ApprovalListCandidate{Emails: []string{"joe@example.net"}}, approved: true, ruleType: ApprovalListRuleDomain, matchedValue: "joe@example.net"},
+		{Name: "wildcard domain does not match suffix", candidate: &ApprovalListCandidate{Emails: []string{"joe@badexample.net"}}},
+		{Name: "dot domain matches sub-domain", candidate: &ApprovalListCandidate{Emails: []string{"joe@a.example.io"}}, approved: true, ruleType: ApprovalListRuleDomain, matchedValue: "joe@a.example.io"},
+		{Name: "github username", candidate: &ApprovalListCandidate{GitHubUsername: "OctoCat"}, approved: true, ruleType: ApprovalListRuleGitHubUsername, matchedValue: "OctoCat"},
+		{Name: "github org", candidate: &ApprovalListCandidate{GitHubUsername: "someone", GitHubOrgs: []string{"other", "Example-Org"}}, approved: true, ruleType: ApprovalListRuleGitHubOrg, matchedValue: "Example-Org"},
+		{Name: "no match", candidate: &ApprovalListCandidate{Emails: []string{"joe@other.com"}, GitHubUsername: "someone"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			decision := EvaluateApprovalList(sig, tc.candidate)
+			assert.Equal(t, tc.approved, decision.Approved)
+			assert.Equal(t, tc.ruleType, decision.RuleType)
+			assert.Equal(t, tc.matchedValue, decision.MatchedValue)
+			assert.Equal(t, "ccla-1", decision.SignatureID)
+		})
+	}
+
+	assert.False(t, EvaluateApprovalList(nil, &ApprovalListCandidate{Emails: []string{"jane@example.org"}}).Approved)
+	// a wildcard without a domain approves nobody
+	assert.False(t, EvaluateApprovalList(&models.Signature{DomainApprovalList: []string{"*", "*.", "."}}, &ApprovalListCandidate{Emails: []string{"jane@example.org"}}).Approved)
+}
+
+// TestValidDomainRule tests the validation of the domain approval list entries
+func TestValidDomainRule(t *testing.T) {
+	for _, rule := range []string{"example.com", "*.example.com", ".example.com"} {
+		assert.True(t, ValidDomainRule(rule), rule)
+	}
+	for _, rule := range []string{"", " ", "*", "*.", ".", "**", "*.*", "a.*.example.com"} {
+		assert.False(t, ValidDomainRule(rule), rule)
+	}
+}
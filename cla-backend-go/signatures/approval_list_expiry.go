@@ -0,0 +1,318 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultApprovalListReminderWindow is how long before the expiry of an approval list entry the CLA Managers are reminded
+const DefaultApprovalListReminderWindow = 7 * 24 * time.Hour
+
+// ClaGroupRepository is the CLA Group lookup used by the approval list expiry notifications
+type ClaGroupRepository interface {
+	GetCLAGroupByID(projectID string, loadRepoDetails bool) (*models.Project, error)
+}
+
+// ApprovalListExpiryReport summarizes an approval list expiry run
+type ApprovalListExpiryReport struct {
+	SignaturesChecked int
+	EntriesRevoked    int
+	RemindersSent     int
+}
+
+// ProcessApprovalListExpiry removes the expired approval list entries and reminds the CLA Managers of the entries
+// which expire within the reminder window
+func (s service) ProcessApprovalListExpiry(ctx context.Context, now time.Time, reminderWindow time.Duration) (*ApprovalListExpiryReport, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessApprovalListExpiry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	sigs, err := s.repo.GetSignaturesWithApprovalListEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ApprovalListExpiryReport{}
+	for _, sig := range sigs {
+		report.SignaturesChecked++
+		expired, expiring := partitionApprovalListEntries(sig.ApprovalListEntries, now, reminderWindow)
+		if len(expired) == 0 && len(expiring) == 0 {
+			continue
+		}
+
+		companyModel, companyErr := s.companyService.GetCompany(ctx, sig.SignatureReferenceID.String())
+		if companyErr != nil || companyModel == nil {
+			log.WithFields(f).Warnf("unable to locate company by ID: %s for signature: %s, error: %+v",
+				sig.SignatureReferenceID, sig.SignatureID, companyErr)
+			continue
+		}
+		projectModel, projectErr := s.claGroupRepo.GetCLAGroupByID(sig.ProjectID, false)
+		if projectErr != nil || projectModel == nil {
+			log.WithFields(f).Warnf("unable to locate CLA Group by ID: %s for signature: %s, error: %+v",
+				sig.ProjectID, sig.SignatureID, projectErr)
+			continue
+		}
+
+		if len(expired) > 0 {
+			removal := approvalListEntriesRemoval(expired)
+			_, updateErr := s.repo.UpdateApprovalList(ctx, sig.ProjectID, companyModel.CompanyID, removal)
+			if updateErr != nil {
+				log.WithFields(f).Warnf("unable to remove the expired approval list entries of signature: %s, error: %+v",
+					sig.SignatureID, updateErr)
+				continue
+			}
+			report.EntriesRevoked += len(expired)
+			s.createExpiredEntryEventLogEntries(companyModel, projectModel, sig.SignatureACL, expired)
+			for _, claManager := range sig.SignatureACL {
+				s.sendApprovalListUpdateEmailToCLAManagers(ctx, companyModel, projectModel, claManager.Username, getBestEmail(claManager), removal)
+			}
+		}
+
+		if len(expiring) > 0 {
+			for _, claManager := range sig.SignatureACL {
+				s.sendApprovalListExpiryReminderEmail(ctx, companyModel, projectModel, claManager.Username, getBestEmail(claManager), expiring)
+			}
+			report.RemindersSent += len(expiring)
+		}
+
+		_, entriesErr := s.applyApprovalListEntries(ctx, sig, func(entries []*models.ApprovalListEntry) []*models.ApprovalListEntry {
+			return remindedApprovalListEntries(withoutApprovalListEntries(entries, expired), expiring)
+		})
+		if entriesErr != nil {
+			log.WithFields(f).Warnf("unable to update the approval list entries of signature: %s, error: %+v",
+				sig.SignatureID, entriesErr)
+		}
+	}
+
+	return report, nil
+}
+
+// createExpiredEntryEventLogEntries logs an approval list update event for each revoked entry - the event is
+// attributed to the CLA Manager who added the entry, or to a CLA Manager of the signature when none was recorded
+func (s service) createExpiredEntryEventLogEntries(companyModel *models.Company, projectModel *models.Project, claManagers []models.User, expired []*models.ApprovalListEntry) {
+	var fallbackLFUsername string
+	for _, claManager := range claManagers {
+		if claManager.LfUsername != "" {
+			fallbackLFUsername = claManager.LfUsername
+			break
+		}
+	}
+
+	for _, entry := range expired {
+		lfUsername := entry.AddedBy
+		if lfUsername == "" {
+			log.Warnf("no CLA Manager recorded for the expired approval list entry %s: %s - attributing the event to CLA Manager: %s",
+				entry.ListType, entry.Value, fallbackLFUsername)
+			lfUsername = fallbackLFUsername
+		}
+		if lfUsername == "" {
+			log.Warnf("unable to log the expiry event of approval list entry %s: %s - the signature has no CLA Manager", entry.ListType, entry.Value)
+			continue
+		}
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:         events.ClaApprovalListUpdated,
+			ProjectID:         projectModel.ProjectID,
+			ProjectModel:      projectModel,
+			CompanyID:         companyModel.CompanyID,
+			CompanyModel:      companyModel,
+			LfUsername:        lfUsername,
+			ExternalProjectID: projectModel.ProjectExternalID,
+			EventData: &events.CLAApprovalListEntryExpiredData{
+				ListType:  entry.ListType,
+				Value:     entry.Value,
+				AddedBy:   entry.AddedBy,
+				ExpiresAt: entry.ExpiresAt.String(),
+			},
+		})
+	}
+}
+
+// sendApprovalListExpiryReminderEmail reminds the CLA Manager of the approval list entries which expire soon
+func (s service) sendApprovalListExpiryReminderEmail(ctx context.Context, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, expiring []*models.ApprovalListEntry) {
+	f := logrus.Fields{
+		"function":          "sendApprovalListExpiryReminderEmail",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"projectName":       projectModel.ProjectName,
+		"projectExternalID": projectModel.ProjectExternalID,
+		"companyName":       companyModel.CompanyName,
+		"companyExternalID": companyModel.CompanyExternalID,
+		"recipientName":     recipientName,
+		"recipientAddress":  recipientAddress}
+
+	var entries []string
+	for _, entry := range expiring {
+		entries = append(entries, fmt.Sprintf("%s %s expires on %s", approvalListEntryLabel(entry.ListType), entry.Value,
+			time.Time(*entry.ExpiresAt).UTC().Format("January 2, 2006 15:04 MST")))
+	}
+
+	recipients := []string{recipientAddress}
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.ApprovalListExpiryReminderTemplate, recipients, notifications.ApprovalListExpiryReminderData{
+		RecipientName: recipientName,
+		CompanyName:   companyModel.CompanyName,
+		ProjectName:   projectModel.ProjectName,
+		Entries:       entries,
+		V2:            projectModel.Version == utils.V2,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ApprovalListExpiryReminderTemplate, recipients, err)
+	} else {
+		log.WithFields(f).Debugf("sent %s email to recipients: %+v", notifications.ApprovalListExpiryReminderTemplate, recipients)
+	}
+}
+
+// approvalListEntryLabel returns the email label of the approval list type
+func approvalListEntryLabel(listType string) string {
+	switch listType {
+	case ApprovalListRuleEmail:
+		return "Email"
+	case ApprovalListRuleDomain:
+		return "Domain"
+	case ApprovalListRuleGitHubUsername:
+		return "GitHub User"
+	case ApprovalListRuleGitHubOrg:
+		return "GitHub Organization"
+	}
+	return listType
+}
+
+// mergeApprovalListEntries applies the approval list changes to the entry metadata - the added entries are recorded
+// with the CLA Manager and the expiry of the change, replacing any previous metadata, and the removed entries are dropped
+func mergeApprovalListEntries(existing []*models.ApprovalListEntry, params *models.ApprovalList, addedBy, dateAdded string) []*models.ApprovalListEntry {
+	changes := []struct {
+		listType string
+		add      []string
+		remove   []string
+	}{
+		{ApprovalListRuleEmail, params.AddEmailApprovalList, params.RemoveEmailApprovalList},
+		{ApprovalListRuleDomain, params.AddDomainApprovalList, params.RemoveDomainApprovalList},
+		{ApprovalListRuleGitHubUsername, params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList},
+		{ApprovalListRuleGitHubOrg, params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList},
+	}
+
+	changed := map[string]bool{}
+	for _, change := range changes {
+		for _, value := range change.add {
+			changed[change.listType+"#"+strings.TrimSpace(value)] = true
+		}
+		for _, value := range change.remove {
+			changed[change.listType+"#"+strings.TrimSpace(value)] = true
+		}
+	}
+
+	var entries []*models.ApprovalListEntry
+	for _, entry := range existing {
+		if !changed[entry.ListType+"#"+entry.Value] {
+			entries = append(entries, entry)
+		}
+	}
+	for _, change := range changes {
+		for _, value := range change.add {
+			value = strings.TrimSpace(value)
+			if value == "" || utils.StringInSlice(value, change.remove) {
+				continue
+			}
+			entries = append(entries, &models.ApprovalListEntry{
+				ListType:  change.listType,
+				Value:     value,
+				AddedBy:   addedBy,
+				DateAdded: dateAdded,
+				ExpiresAt: params.ExpiresAt,
+			})
+		}
+	}
+
+	return entries
+}
+
+// partitionApprovalListEntries returns the entries which have expired and the entries which expire within the
+// reminder window and have not been reminded of yet
+func partitionApprovalListEntries(entries []*models.ApprovalListEntry, now time.Time, reminderWindow time.Duration) ([]*models.ApprovalListEntry, []*models.ApprovalListEntry) {
+	var expired, expiring []*models.ApprovalListEntry
+	for _, entry := range entries {
+		if entry.ExpiresAt == nil {
+			continue
+		}
+		expiresAt := time.Time(*entry.ExpiresAt)
+		switch {
+		case !expiresAt.After(now):
+			expired = append(expired, entry)
+		case !entry.ReminderSent && expiresAt.Before(now.Add(reminderWindow)):
+			expiring = append(expiring, entry)
+		}
+	}
+	return expired, expiring
+}
+
+// approvalListEntriesRemoval returns the approval list update which removes the entries
+func approvalListEntriesRemoval(entries []*models.ApprovalListEntry) *models.ApprovalList {
+	removal := &models.ApprovalList{}
+	for _, entry := range entries {
+		switch entry.ListType {
+		case ApprovalListRuleEmail:
+			removal.RemoveEmailApprovalList = append(removal.RemoveEmailApprovalList, entry.Value)
+		case ApprovalListRuleDomain:
+			removal.RemoveDomainApprovalList = append(removal.RemoveDomainApprovalList, entry.Value)
+		case ApprovalListRuleGitHubUsername:
+			removal.RemoveGithubUsernameApprovalList = append(removal.RemoveGithubUsernameApprovalList, entry.Value)
+		case ApprovalListRuleGitHubOrg:
+			removal.RemoveGithubOrgApprovalList = append(removal.RemoveGithubOrgApprovalList, entry.Value)
+		}
+	}
+	return removal
+}
+
+// withoutApprovalListEntries returns the entries less the removed ones
+func withoutApprovalListEntries(entries, removed []*models.ApprovalListEntry) []*models.ApprovalListEntry {
+	var remaining []*models.ApprovalListEntry
+	for _, entry := range entries {
+		keep := true
+		for _, r := range removed {
+			if sameApprovalListEntry(entry, r) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			remaining = append(remaining, entry)
+		}
+	}
+	return remaining
+}
+
+// remindedApprovalListEntries returns the entries with the reminded ones marked as such
+func remindedApprovalListEntries(entries, reminded []*models.ApprovalListEntry) []*models.ApprovalListEntry {
+	for _, entry := range entries {
+		for _, r := range reminded {
+			if sameApprovalListEntry(entry, r) {
+				entry.ReminderSent = true
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// sameApprovalListEntry returns true if both are the metadata of the same entry with the same expiry - an entry added
+// again with another expiry is a different one
+func sameApprovalListEntry(a, b *models.ApprovalListEntry) bool {
+	if a.ListType != b.ListType || a.Value != b.Value {
+		return false
+	}
+	if a.ExpiresAt == nil || b.ExpiresAt == nil {
+		return a.ExpiresAt == nil && b.ExpiresAt == nil
+	}
+	return time.Time(*a.ExpiresAt).Equal(time.Time(*b.ExpiresAt))
+}
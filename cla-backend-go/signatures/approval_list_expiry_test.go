@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMergeApprovalListEntries tests that the added entries are recorded with the CLA Manager and the expiry
+func TestMergeApprovalListEntries(t *testing.T) {
+	expiresAt := strfmt.DateTime(time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC))
+	existing := []*models.ApprovalListEntry{
+		{ListType: ApprovalListRuleEmail, Value: "jane@example.org", AddedBy: "manager1"},
+		{ListType: ApprovalListRuleDomain, Value: "example.com", AddedBy: "manager1"},
+	}
+
+	entries := mergeApprovalListEntries(existing, &models.ApprovalList{
+		AddEmailApprovalList:          []string{" jane@example.org", "john@example.org"},
+		RemoveDomainApprovalList:      []string{"example.com"},
+		AddGithubUsernameApprovalList: []string{"octocat"},
+		ExpiresAt:                     &expiresAt,
+	}, "manager2", "2020-09-01T00:00:00Z")
+
+	if assert.Len(t, entries, 3) {
+		for _, entry := range entries {
+			assert.Equal(t, "manager2", entry.AddedBy)
+			assert.Equal(t, &expiresAt, entry.ExpiresAt)
+		}
+		assert.Equal(t, "jane@example.org", entries[0].Value)
+		assert.Equal(t, "john@example.org", entries[1].Value)
+		assert.Equal(t, ApprovalListRuleGitHubUsername, entries[2].ListType)
+	}
+}
+
+// TestPartitionApprovalListEntries tests the selection of the expired entries and the entries to remind of
+func TestPartitionApprovalListEntries(t *testing.T) {
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *strfmt.DateTime {
+		dt := strfmt.DateTime(now.Add(d))
+		return &dt
+	}
+	entries := []*models.ApprovalListEntry{
+		{Value: "no-expiry"},
+		{Value: "expired", ExpiresAt: at(-time.Hour)},
+		{Value: "expires-now", ExpiresAt: at(0)},
+		{Value: "expiring", ExpiresAt: at(48 * time.Hour)},
+		{Value: "reminded", ExpiresAt: at(48 * time.Hour), ReminderSent: true},
+		{Value: "later", ExpiresAt: at(30 * 24 * time.Hour)},
+	}
+
+	expired, expiring := partitionApprovalListEntries(entries, now, DefaultApprovalListReminderWindow)
+	assert.Equal(t, []*models.ApprovalListEntry{entries[1], entries[2]}, expired)
+	assert.Equal(t, []*models.ApprovalListEntry{entries[3]}, expiring)
+
+	remaining := withoutApprovalListEntries(entries, expired)
+	assert.Len(t, remaining, 4)
+	assert.Equal(t, "no-expiry", remaining[0].Value)
+
+	// the entry added again with another expiry is kept
+	readded := []*models.ApprovalListEntry{{Value: "expired", ExpiresAt: at(24 * time.Hour)}}
+	assert.Len(t, withoutApprovalListEntries(readded, expired), 1)
+	reminded := remindedApprovalListEntries([]*models.ApprovalListEntry{{Value: "expiring", ExpiresAt: at(48 * time.Hour)}}, expiring)
+	assert.True(t, reminded[0].ReminderSent)
+
+	removal := approvalListEntriesRemoval([]*models.ApprovalListEntry{
+		{ListType: ApprovalListRuleEmail, Value: "jane@example.org"},
+		{ListType: ApprovalListRuleGitHubOrg, Value: "example-org"},
+	})
+	assert.Equal(t, []string{"jane@example.org"}, removal.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"example-org"}, removal.RemoveGithubOrgApprovalList)
+}
+
+// conflictingSignatureRepo fails the first approval list entries update as changed concurrently
+type conflictingSignatureRepo struct {
+	SignatureRepository
+	latest   *models.Signature
+	versions []int64
+}
+
+func (r *conflictingSignatureRepo) UpdateApprovalListEntries(ctx context.Context, signatureID string, version int64, entries []*models.ApprovalListEntry) error {
+	r.versions = append(r.versions, version)
+	if version != r.latest.ApprovalListEntriesVersion {
+		return ErrApprovalListEntriesChanged
+	}
+	return nil
+}
+
+func (r *conflictingSignatureRepo) GetSignature(ctx context.Context, signatureID string) (*models.Signature, error) {
+	return r.latest, nil
+}
+
+// TestApplyApprovalListEntriesRetriesOnConflict tests that the change is applied again to the latest metadata when it
+// changed since it was loaded
+func TestApplyApprovalListEntriesRetriesOnConflict(t *testing.T) {
+	repo := &conflictingSignatureRepo{latest: &models.Signature{
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{ListType: ApprovalListRuleEmail, Value: "jane@example.org", AddedBy: "manager1"},
+			{ListType: ApprovalListRuleEmail, Value: "john@example.org", AddedBy: "manager2"},
+		},
+		ApprovalListEntriesVersion: 2,
+	}}
+	s := service{repo: repo}
+	loaded := &models.Signature{
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{ListType: ApprovalListRuleEmail, Value: "jane@example.org", AddedBy: "manager1"},
+		},
+		ApprovalListEntriesVersion: 1,
+	}
+
+	entries, err := s.applyApprovalListEntries(context.Background(), loaded, func(existing []*models.ApprovalListEntry) []*models.ApprovalListEntry {
+		return withoutApprovalListEntries(existing, []*models.ApprovalListEntry{{ListType: ApprovalListRuleEmail, Value: "jane@example.org"}})
+	})
+	if assert.NoError(t, err) && assert.Len(t, entries, 1) {
+		assert.Equal(t, "john@example.org", entries[0].Value)
+	}
+	assert.Equal(t, []int64{1, 2}, repo.versions)
+}
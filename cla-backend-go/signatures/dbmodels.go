@@ -31,6 +31,26 @@ type ItemSignature struct {
 	SigtypeSignedApprovedID       string   `json:"sigtype_signed_approved_id"`
 	SignedOn                      string   `json:"signed_on"`
 	SignatoryName                 string   `json:"signatory_name"`
+	SignatureDocumentSha256       string   `json:"signature_document_sha256"`
+	SignatureDocumentHashedOn     string   `json:"signature_document_hashed_on"`
+	SignatureClaGroupDeletedOn    string   `json:"signature_cla_group_deleted_on"`
+	SignatureInvalidatedOn        string   `json:"signature_invalidated_on"`
+	SignatureFoundationSFID       string   `json:"signature_foundation_sfid"`
+
+	// ApprovalListEntries holds the adding CLA Manager and the expiry of the approval list entries
+	ApprovalListEntries []ItemApprovalListEntry `json:"approval_list_entries"`
+	// ApprovalListEntriesVersion is incremented on each update of the approval list entries
+	ApprovalListEntriesVersion int64 `json:"approval_list_entries_version"`
+}
+
+// ItemApprovalListEntry database model of the metadata of an approval list entry
+type ItemApprovalListEntry struct {
+	ListType     string `json:"list_type"`
+	Value        string `json:"value"`
+	AddedBy      string `json:"added_by"`
+	DateAdded    string `json:"date_added"`
+	ExpiresAt    string `json:"expires_at,omitempty"`
+	ReminderSent bool   `json:"reminder_sent"`
 }
 
 // DBManagersModel is a database model for only the ACL/Manager column
@@ -18,11 +18,11 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
-	"github.com/savaki/dynastore"
+	"github.com/gorilla/sessions"
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *dynastore.Store, eventsService events.Service) { // nolint
+func Configure(api *operations.ClaAPI, service SignatureService, sessionStore sessions.Store, eventsService events.Service) { // nolint
 
 	api.SignaturesGetSignedICLADocumentHandler = signatures.GetSignedICLADocumentHandlerFunc(func(params signatures.GetSignedICLADocumentParams) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
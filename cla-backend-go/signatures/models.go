@@ -10,3 +10,12 @@ type SignatureCompanyID struct {
 	CompanySFID string
 	CompanyName string
 }
+
+// CorporateSignatureSummary is a simple data model to hold the signed and approved CCLA signature of a company for a
+// CLA Group and its CLA Managers
+type CorporateSignatureSummary struct {
+	SignatureID  string
+	CompanyID    string
+	MajorVersion string
+	ClaManagers  []string
+}
@@ -34,6 +34,10 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("user_email"),
 		expression.Name("signed_on"),
 		expression.Name("signatory_name"),
+		expression.Name("signature_document_sha256"), // SHA-256 of the signed document recorded at signing time
+		expression.Name("signature_document_hashed_on"),
+		expression.Name("approval_list_entries"),         // added by, expiry of the approval list entries
+		expression.Name("approval_list_entries_version"), // conditional update of the approval list entries
 	)
 }
 
@@ -29,9 +29,11 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // constants
@@ -64,12 +66,17 @@ const (
 	HugePageSize = 10000
 )
 
+// errors
+var (
+	ErrApprovalListEntriesChanged = errors.New("approval list entries changed since they were loaded")
+)
+
 // SignatureRepository interface defines the functions for the github whitelist service
 type SignatureRepository interface {
 	GetGithubOrganizationsFromWhitelist(ctx context.Context, signatureID string) ([]models.GithubOrg, error)
 	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID, githubOrganizationID string) ([]models.GithubOrg, error)
 	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID, githubOrganizationID string) ([]models.GithubOrg, error)
-	InvalidateProjectRecord(ctx context.Context, signatureID string, projectName string) error
+	InvalidateProjectRecord(ctx context.Context, signatureID, foundationSFID, projectName string) error
 
 	GetSignature(ctx context.Context, signatureID string) (*models.Signature, error)
 	GetIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error)
@@ -81,18 +88,21 @@ type SignatureRepository interface {
 	GetProjectCompanyEmployeeSignatures(ctx context.Context, params signatures.GetProjectCompanyEmployeeSignaturesParams, pageSize int64) (*models.Signatures, error)
 	GetCompanySignatures(ctx context.Context, params signatures.GetCompanySignaturesParams, pageSize int64, loadACL bool) (*models.Signatures, error)
 	GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]SignatureCompanyID, error)
+	GetClaGroupCorporateSignatureSummaries(ctx context.Context, claGroupID string) ([]CorporateSignatureSummary, error)
+	GetClaGroupEmployeeSignatureCounts(ctx context.Context, claGroupID string) (map[string]int64, error)
 	GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams, pageSize int64) (*models.Signatures, error)
 	ProjectSignatures(ctx context.Context, projectID string) (*models.Signatures, error)
 	UpdateApprovalList(ctx context.Context, projectID, companyID string, params *models.ApprovalList) (*models.Signature, error)
+	UpdateApprovalListEntries(ctx context.Context, signatureID string, version int64, entries []*models.ApprovalListEntry) error
+	GetSignaturesWithApprovalListEntries(ctx context.Context) ([]*models.Signature, error)
 
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 
-	removeColumn(ctx context.Context, signatureID, columnName string) (*models.Signature, error)
-
 	AddSigTypeSignedApprovedID(ctx context.Context, signatureID string, val string) error
 	AddUsersDetails(ctx context.Context, signatureID string, userID string) error
 	AddSignedOn(ctx context.Context, signatureID string) error
+	AddSignedDocumentHash(ctx context.Context, signatureID string, sha256 string) error
 
 	GetClaGroupICLASignatures(ctx context.Context, claGroupID string, searchTerm *string) (*models.IclaSignatures, error)
 	GetClaGroupCorporateContributors(ctx context.Context, claGroupID string, companyID *string, searchTerm *string) (*models.CorporateContributorList, error)
@@ -101,7 +111,7 @@ type SignatureRepository interface {
 // repository data model
 type repository struct {
 	stage              string
-	dynamoDBClient     *dynamodb.DynamoDB
+	dynamoDBClient     storage.DynamoDBAPI
 	companyRepo        company.IRepository
 	usersRepo          users.UserRepository
 	signatureTableName string
@@ -111,7 +121,7 @@ type repository struct {
 func NewRepository(awsSession *session.Session, stage string, companyRepo company.IRepository, usersRepo users.UserRepository) SignatureRepository {
 	return repository{
 		stage:              stage,
-		dynamoDBClient:     dynamodb.New(awsSession),
+		dynamoDBClient:     storage.NewClient(awsSession),
 		companyRepo:        companyRepo,
 		usersRepo:          usersRepo,
 		signatureTableName: fmt.Sprintf("cla-%s-signatures", stage),
@@ -521,7 +531,7 @@ func (repo repository) GetIndividualSignature(ctx context.Context, claGroupID, u
 		log.WithFields(f).Warnf("found multiple matching ICLA signatures - found %d total", len(sigs))
 	}
 
-	return sigs[0], nil
+	return latestSignature(sigs), nil
 }
 
 // GetCorporateSignature returns the signature record for the specified CLA Group and Company ID
@@ -613,7 +623,7 @@ func (repo repository) GetCorporateSignature(ctx context.Context, claGroupID, co
 		log.WithFields(f).Warnf("found multiple matching ICLA signatures - found %d total", len(sigs))
 	}
 
-	return sigs[0], nil
+	return latestSignature(sigs), nil
 }
 
 // GetSignatureACL returns the signature ACL for the specified signature id
@@ -1022,7 +1032,7 @@ func (repo repository) GetProjectCompanySignatures(ctx context.Context, companyI
 	}, nil
 }
 
-// Get project signatures with no pagination
+// ProjectSignatures returns all the signed and approved signatures of the project - the result is not paginated
 func (repo repository) ProjectSignatures(ctx context.Context, projectID string) (*models.Signatures, error) {
 	f := logrus.Fields{
 		"functionName":   "ProjectSignatures",
@@ -1070,20 +1080,28 @@ func (repo repository) ProjectSignatures(ctx context.Context, projectID string)
 		IndexName:                 aws.String(indexName), // Name of a secondary index to scan
 	}
 
-	results, errQuery := repo.dynamoDBClient.Query(queryInput)
+	sigs := make([]*models.Signature, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving project signature ID for project: %s, error: %v",
+				projectID, errQuery)
+			return nil, errQuery
+		}
 
-	if errQuery != nil {
-		log.WithFields(f).Warnf("error retrieving project signature ID for project: %s, error: %v",
-			projectID, errQuery)
-		return nil, errQuery
-	}
+		// Convert the list of DB models to a list of response models
+		signatureList, modelErr := repo.buildProjectSignatureModels(ctx, results, projectID, LoadACLDetails)
+		if modelErr != nil {
+			log.WithFields(f).Warnf("error converting DB model to response model for signatures with project %s, error: %v",
+				projectID, modelErr)
+			return nil, modelErr
+		}
+		sigs = append(sigs, signatureList...)
 
-	// Convert the list of DB models to a list of response models
-	sigs, modelErr := repo.buildProjectSignatureModels(ctx, results, projectID, LoadACLDetails)
-	if modelErr != nil {
-		log.WithFields(f).Warnf("error converting DB model to response model for signatures with project %s, error: %v",
-			projectID, modelErr)
-		return nil, modelErr
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
 	}
 
 	return &models.Signatures{
@@ -1092,8 +1110,10 @@ func (repo repository) ProjectSignatures(ctx context.Context, projectID string)
 	}, nil
 }
 
-// InvalidateProjectRecord invalidates the specified project record by setting the signature_approved flag to false
-func (repo repository) InvalidateProjectRecord(ctx context.Context, signatureID string, projectName string) error {
+// InvalidateProjectRecord invalidates the specified project record by setting the signature_approved flag to false.
+// The invalidation date, the deletion date and the foundation of the CLA Group are kept on the record for the retention
+// policies.
+func (repo repository) InvalidateProjectRecord(ctx context.Context, signatureID, foundationSFID, projectName string) error {
 	f := logrus.Fields{
 		"functionName":   "InvalidateProjectRecord",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -1114,7 +1134,26 @@ func (repo repository) InvalidateProjectRecord(ctx context.Context, signatureID
 	expressionAttributeNames["#S"] = aws.String("note")
 	note := fmt.Sprintf("Signature invalidated (approved set to false) due to CLA Group/Project: %s deletion", projectName)
 	expressionAttributeValues[":s"] = &dynamodb.AttributeValue{S: aws.String(note)}
-	updateExpression = updateExpression + " #S = :s"
+	updateExpression = updateExpression + " #S = :s,"
+
+	_, now := utils.CurrentTime()
+	expressionAttributeNames["#D"] = aws.String("signature_cla_group_deleted_on")
+	expressionAttributeValues[":d"] = &dynamodb.AttributeValue{S: aws.String(now)}
+	updateExpression = updateExpression + " #D = :d,"
+
+	// a signature invalidated earlier keeps its invalidation date
+	expressionAttributeNames["#I"] = aws.String("signature_invalidated_on")
+	updateExpression = updateExpression + " #I = if_not_exists(#I, :d),"
+
+	expressionAttributeNames["#M"] = aws.String("date_modified")
+	expressionAttributeValues[":m"] = &dynamodb.AttributeValue{S: aws.String(now)}
+	updateExpression = updateExpression + " #M = :m"
+
+	if foundationSFID != "" {
+		expressionAttributeNames["#F"] = aws.String("signature_foundation_sfid")
+		expressionAttributeValues[":f"] = &dynamodb.AttributeValue{S: aws.String(foundationSFID)}
+		updateExpression = updateExpression + ", #F = :f"
+	}
 
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
@@ -2054,6 +2093,157 @@ func (repo repository) AddSignedOn(ctx context.Context, signatureID string) erro
 	return nil
 }
 
+// AddSignedDocumentHash records the SHA-256 of the signed document on the signature
+func (repo repository) AddSignedDocumentHash(ctx context.Context, signatureID string, sha256 string) error {
+	f := logrus.Fields{
+		"functionName":   "AddSignedDocumentHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"sha256":         sha256,
+	}
+	_, currentTime := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#sha256":    aws.String("signature_document_sha256"),
+			"#hashed_on": aws.String("signature_document_hashed_on"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":sha256": {
+				S: aws.String(sha256),
+			},
+			":current_time": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: aws.String("SET #sha256 = :sha256, #hashed_on = :current_time"),
+	}
+
+	log.WithFields(f).Debug("updating signed document hash...")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).Warnf("unable to update signed document hash for signature ID: %s, error = %s",
+			signatureID, updateErr.Error())
+		return updateErr
+	}
+
+	log.WithFields(f).Debug("successfully updated signed document hash...")
+	return nil
+}
+
+// UpdateApprovalListEntries replaces the metadata of the approval list entries of the signature if it is still at the
+// version it was loaded with, otherwise ErrApprovalListEntriesChanged is returned - each update increments the version
+func (repo repository) UpdateApprovalListEntries(ctx context.Context, signatureID string, version int64, entries []*models.ApprovalListEntry) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateApprovalListEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"version":        version,
+	}
+
+	dbEntries := make([]ItemApprovalListEntry, 0, len(entries))
+	for _, entry := range entries {
+		dbEntry := ItemApprovalListEntry{
+			ListType:     entry.ListType,
+			Value:        entry.Value,
+			AddedBy:      entry.AddedBy,
+			DateAdded:    entry.DateAdded,
+			ReminderSent: entry.ReminderSent,
+		}
+		if entry.ExpiresAt != nil {
+			dbEntry.ExpiresAt = entry.ExpiresAt.String()
+		}
+		dbEntries = append(dbEntries, dbEntry)
+	}
+
+	update := expression.Set(expression.Name("approval_list_entries_version"), expression.Value(version+1))
+	if len(dbEntries) == 0 {
+		update = update.Remove(expression.Name("approval_list_entries"))
+	} else {
+		update = update.Set(expression.Name("approval_list_entries"), expression.Value(dbEntries))
+	}
+	condition := expression.Name("approval_list_entries_version").AttributeNotExists()
+	if version > 0 {
+		condition = expression.Name("approval_list_entries_version").Equal(expression.Value(version))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to build the approval list entries update, error: %v", err)
+		return err
+	}
+
+	_, updateErr := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if updateErr != nil {
+		if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("the approval list entries changed since they were loaded")
+			return ErrApprovalListEntriesChanged
+		}
+		log.WithFields(f).Warnf("unable to update the approval list entries for signature ID: %s, error: %v", signatureID, updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// GetSignaturesWithApprovalListEntries returns the signed and approved CCLA signatures which have approval list entry
+// metadata, i.e. the ones which may have expiring approval list entries
+func (repo repository) GetSignaturesWithApprovalListEntries(ctx context.Context) ([]*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetSignaturesWithApprovalListEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	filter := expression.AttributeExists(expression.Name("approval_list_entries")).
+		And(expression.Name("signature_type").Equal(expression.Value(SignatureTypeCCLA))).
+		And(expression.Name("signature_signed").Equal(expression.Value(true))).
+		And(expression.Name("signature_approved").Equal(expression.Value(true)))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the approval list entries scan, error: %v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	var items []map[string]*dynamodb.AttributeValue
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).Warnf("error scanning the signatures with approval list entries, error: %v", scanErr)
+			return nil, scanErr
+		}
+		items = append(items, results.Items...)
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return repo.buildProjectSignatureModels(ctx, &dynamodb.QueryOutput{Items: items}, "", LoadACLDetails)
+}
+
 // buildProjectSignatureModels converts the response model into a response data model
 func (repo repository) buildProjectSignatureModels(ctx context.Context, results *dynamodb.QueryOutput, projectID string, loadACLDetails bool) ([]*models.Signature, error) {
 	f := logrus.Fields{
@@ -2120,6 +2310,10 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			UserGHID:                    dbSignature.UserGithubUsername,
 			SignedOn:                    dbSignature.SignedOn,
 			SignatoryName:               dbSignature.SignatoryName,
+			SignatureDocumentSha256:     dbSignature.SignatureDocumentSha256,
+			SignatureDocumentHashedOn:   dbSignature.SignatureDocumentHashedOn,
+			ApprovalListEntries:         buildApprovalListEntryModels(dbSignature.ApprovalListEntries),
+			ApprovalListEntriesVersion:  dbSignature.ApprovalListEntriesVersion,
 		}
 		sigs = append(sigs, sig)
 		go func(sigModel *models.Signature, signatureUserCompanyID string, sigACL []string) {
@@ -2196,6 +2390,33 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 	return sigs, nil
 }
 
+// buildApprovalListEntryModels is a helper function which converts the approval list entry database models
+func buildApprovalListEntryModels(dbEntries []ItemApprovalListEntry) []*models.ApprovalListEntry {
+	if len(dbEntries) == 0 {
+		return nil
+	}
+	entries := make([]*models.ApprovalListEntry, 0, len(dbEntries))
+	for _, dbEntry := range dbEntries {
+		entry := &models.ApprovalListEntry{
+			ListType:     dbEntry.ListType,
+			Value:        dbEntry.Value,
+			AddedBy:      dbEntry.AddedBy,
+			DateAdded:    dbEntry.DateAdded,
+			ReminderSent: dbEntry.ReminderSent,
+		}
+		if dbEntry.ExpiresAt != "" {
+			expiresAt, err := strfmt.ParseDateTime(dbEntry.ExpiresAt)
+			if err != nil {
+				log.Warnf("unable to parse the approval list entry expiry: %s, error: %v", dbEntry.ExpiresAt, err)
+			} else {
+				entry.ExpiresAt = &expiresAt
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 // buildResponse is a helper function which converts a database model to a GitHub organization response model
 func buildResponse(items []*dynamodb.AttributeValue) []models.GithubOrg {
 	// Convert to a response model
@@ -2263,6 +2484,111 @@ func buildApprovalAttributeList(ctx context.Context, existingList, addEntries, r
 }
 
 // buildCompanyIDList is a helper function to convert the DB response models into a simple list of company IDs
+// GetClaGroupCorporateSignatureSummaries returns the signed and approved CCLA signatures of the CLA Group with their
+// CLA Managers - the company and user details are not loaded
+func (repo repository) GetClaGroupCorporateSignatureSummaries(ctx context.Context, claGroupID string) ([]CorporateSignatureSummary, error) {
+	f := logrus.Fields{
+		"functionName":   "GetClaGroupCorporateSignatureSummaries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+	filter := expression.Name("signature_type").Equal(expression.Value(SignatureTypeCCLA)).
+		And(expression.Name("signature_reference_type").Equal(expression.Value(ReferenceTypeCompany))).
+		And(expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true)))).
+		And(expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true))))
+	projection := expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("signature_reference_id"),
+		expression.Name("signature_document_major_version"),
+		expression.Name("signature_acl"),
+	)
+
+	var summaries []CorporateSignatureSummary
+	err := repo.queryClaGroupSignatures(claGroupID, filter, projection, func(items []ItemSignature) {
+		for _, item := range items {
+			summaries = append(summaries, CorporateSignatureSummary{
+				SignatureID:  item.SignatureID,
+				CompanyID:    item.SignatureReferenceID,
+				MajorVersion: item.SignatureDocumentMajorVersion,
+				ClaManagers:  item.SignatureACL,
+			})
+		}
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to query the corporate signatures of the CLA Group, error: %+v", err)
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// GetClaGroupEmployeeSignatureCounts returns the number of signed and approved employee signatures of the CLA Group by
+// company ID
+func (repo repository) GetClaGroupEmployeeSignatureCounts(ctx context.Context, claGroupID string) (map[string]int64, error) {
+	f := logrus.Fields{
+		"functionName":   "GetClaGroupEmployeeSignatureCounts",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+	filter := expression.Name("signature_type").Equal(expression.Value(SignatureTypeCLA)).
+		And(expression.Name("signature_reference_type").Equal(expression.Value(ReferenceTypeUser))).
+		And(expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true)))).
+		And(expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true)))).
+		And(expression.AttributeExists(expression.Name("signature_user_ccla_company_id")))
+	projection := expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("signature_user_ccla_company_id"),
+	)
+
+	counts := make(map[string]int64)
+	err := repo.queryClaGroupSignatures(claGroupID, filter, projection, func(items []ItemSignature) {
+		for _, item := range items {
+			if item.SignatureUserCompanyID != "" {
+				counts[item.SignatureUserCompanyID]++
+			}
+		}
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to query the employee signatures of the CLA Group, error: %+v", err)
+		return nil, err
+	}
+	return counts, nil
+}
+
+// queryClaGroupSignatures queries all the pages of the signatures of the CLA Group matching the filter and passes the
+// projected records of each page to the handler
+func (repo repository) queryClaGroupSignatures(claGroupID string, filter expression.ConditionBuilder, projection expression.ProjectionBuilder, handler func(items []ItemSignature)) error {
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		return err
+	}
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String(SignatureProjectIDIndex),
+	}
+
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			return queryErr
+		}
+		var items []ItemSignature
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &items); err != nil {
+			return err
+		}
+		handler(items)
+		if len(results.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+}
+
 func (repo repository) buildCompanyIDList(ctx context.Context, results *dynamodb.QueryOutput) ([]SignatureCompanyID, error) {
 	f := logrus.Fields{
 		"functionName":   "buildCompanyIDList",
@@ -7,7 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -17,11 +19,13 @@ import (
 
 	"github.com/LF-Engineering/lfx-kit/auth"
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	githubpkg "github.com/google/go-github/github"
@@ -32,7 +36,9 @@ import (
 type SignatureService interface {
 	GetSignature(ctx context.Context, signatureID string) (*models.Signature, error)
 	GetIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error)
+	GetHonoredIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error)
 	GetCorporateSignature(ctx context.Context, claGroupID, companyID string) (*models.Signature, error)
+	EvaluateApprovalList(ctx context.Context, claGroupID, companyID string, candidate *ApprovalListCandidate) (*ApprovalListDecision, error)
 	GetProjectSignatures(ctx context.Context, params signatures.GetProjectSignaturesParams) (*models.Signatures, error)
 	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signature, error)
 	GetProjectCompanySignatures(ctx context.Context, params signatures.GetProjectCompanySignaturesParams) (*models.Signatures, error)
@@ -40,12 +46,14 @@ type SignatureService interface {
 	GetCompanySignatures(ctx context.Context, params signatures.GetCompanySignaturesParams) (*models.Signatures, error)
 	GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]SignatureCompanyID, error)
 	GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams) (*models.Signatures, error)
-	InvalidateProjectRecords(ctx context.Context, projectID string, projectName string) (int, error)
+	InvalidateProjectRecords(ctx context.Context, projectID, foundationSFID, projectName string) (int, error)
 
 	GetGithubOrganizationsFromWhitelist(ctx context.Context, signatureID string, githubAccessToken string) ([]models.GithubOrg, error)
 	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
 	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
 	UpdateApprovalList(ctx context.Context, authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error)
+	RemoveSelfFromApprovalList(ctx context.Context, authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string) (*models.ApprovalList, error)
+	ProcessApprovalListExpiry(ctx context.Context, now time.Time, reminderWindow time.Duration) (*ApprovalListExpiryReport, error)
 
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, ignatureID, claManagerID string) (*models.Signature, error)
@@ -60,16 +68,24 @@ type service struct {
 	usersService        users.Service
 	eventsService       events.Service
 	githubOrgValidation bool
+	// domainVerificationMode is how approval list updates adding unverified domains are handled
+	domainVerificationMode string
+	claGroupRepo           ClaGroupRepository
+	// versionPolicy decides which document major versions are still honored, nil honors all versions
+	versionPolicy SignatureVersionPolicy
 }
 
 // NewService creates a new whitelist service
-func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool) SignatureService {
+func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, domainVerificationMode string, claGroupRepo ClaGroupRepository, versionPolicy SignatureVersionPolicy) SignatureService {
 	return service{
 		repo,
 		companyService,
 		usersService,
 		eventsService,
 		githubOrgValidation,
+		domainVerificationMode,
+		claGroupRepo,
+		versionPolicy,
 	}
 }
 
@@ -83,11 +99,80 @@ func (s service) GetIndividualSignature(ctx context.Context, claGroupID, userID
 	return s.repo.GetIndividualSignature(ctx, claGroupID, userID)
 }
 
+// GetHonoredIndividualSignature returns the signature associated with the specified CLA Group and User ID if its
+// document major version is still honored
+func (s service) GetHonoredIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error) {
+	sig, err := s.repo.GetIndividualSignature(ctx, claGroupID, userID)
+	if err != nil || sig == nil {
+		return sig, err
+	}
+	if !s.isSignatureVersionHonored(ctx, sig, ICLA) {
+		return nil, nil
+	}
+	return sig, nil
+}
+
 // GetCorporateSignature returns the signature associated with the specified CLA Group and Company ID
 func (s service) GetCorporateSignature(ctx context.Context, claGroupID, companyID string) (*models.Signature, error) {
 	return s.repo.GetCorporateSignature(ctx, claGroupID, companyID)
 }
 
+// EvaluateApprovalList evaluates the contributor against the approval lists of the CCLA signed by the company for
+// the CLA Group. When the LF username is provided the contributor's emails and GitHub username are completed from
+// the user record and the GitHub organization memberships are loaded when the CCLA has a GitHub org approval list.
+func (s service) EvaluateApprovalList(ctx context.Context, claGroupID, companyID string, candidate *ApprovalListCandidate) (*ApprovalListDecision, error) {
+	f := logrus.Fields{
+		"functionName":   "EvaluateApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companyID":      companyID,
+	}
+
+	sig, err := s.repo.GetCorporateSignature(ctx, claGroupID, companyID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the corporate signature, error: %+v", err)
+		return nil, err
+	}
+	if sig == nil {
+		return &ApprovalListDecision{Reason: "company has not signed a CCLA for this CLA Group"}, nil
+	}
+	if !s.isSignatureVersionHonored(ctx, sig, CCLA) {
+		return &ApprovalListDecision{Reason: "company has not signed the current version of the CCLA for this CLA Group"}, nil
+	}
+
+	// the looked up emails and GitHub organizations are added to a copy, the caller's candidate is left unchanged
+	evaluated := &ApprovalListCandidate{}
+	if candidate != nil {
+		*evaluated = *candidate
+		evaluated.Emails = append([]string(nil), candidate.Emails...)
+		evaluated.GitHubOrgs = append([]string(nil), candidate.GitHubOrgs...)
+	}
+	candidate = evaluated
+	if candidate.LFUsername != "" {
+		userModel, userErr := s.usersService.GetUserByLFUserName(candidate.LFUsername)
+		if userErr != nil || userModel == nil {
+			log.WithFields(f).Debugf("unable to load user by LF username: %s, error: %+v", candidate.LFUsername, userErr)
+		} else {
+			candidate.Emails = append(candidate.Emails, userModel.LfEmail)
+			candidate.Emails = append(candidate.Emails, userModel.Emails...)
+			if candidate.GitHubUsername == "" {
+				candidate.GitHubUsername = userModel.GithubUsername
+			}
+		}
+	}
+
+	if len(sig.GithubOrgApprovalList) > 0 && len(candidate.GitHubOrgs) == 0 && candidate.GitHubUsername != "" {
+		orgs, orgErr := github.GetUserOrganizations(candidate.GitHubUsername)
+		if orgErr != nil {
+			log.WithFields(f).Warnf("unable to load GitHub organizations for user: %s, error: %+v", candidate.GitHubUsername, orgErr)
+		} else {
+			candidate.GitHubOrgs = orgs
+		}
+	}
+
+	return EvaluateApprovalList(sig, candidate), nil
+}
+
 // GetProjectSignatures returns the list of signatures associated with the specified project
 func (s service) GetProjectSignatures(ctx context.Context, params signatures.GetProjectSignaturesParams) (*models.Signatures, error) {
 
@@ -414,28 +499,222 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, pr
 		return nil, userErr
 	}
 
+	now, nowStr := utils.CurrentTime()
+	if params.ExpiresAt != nil && !time.Time(*params.ExpiresAt).After(now) {
+		msg := fmt.Sprintf("the approval list entries expiry: %s must be in the future", params.ExpiresAt)
+		log.Warn(msg)
+		return nil, NewBadRequestError(msg)
+	}
+
+	// A domain entry without a domain would approve every contributor
+	for _, domain := range params.AddDomainApprovalList {
+		if !ValidDomainRule(domain) {
+			msg := fmt.Sprintf("the approval list domain: %s must name a domain, e.g. example.com or *.example.com", domain)
+			log.Warn(msg)
+			return nil, NewBadRequestError(msg)
+		}
+	}
+
+	// Check the added domains against the domains the company has verified
+	if s.domainVerificationMode != company.DomainVerificationOff && len(params.AddDomainApprovalList) > 0 {
+		unverified := company.UnverifiedDomains(companyModel.VerifiedDomains, params.AddDomainApprovalList)
+		if len(unverified) > 0 {
+			msg := fmt.Sprintf("company: %s has not verified the ownership of the approval list domains: %s",
+				companyModel.CompanyName, strings.Join(unverified, ","))
+			if s.domainVerificationMode == company.DomainVerificationBlock {
+				log.Warn(msg)
+				return nil, NewBadRequestError(msg + " - verify the domains before adding them to the approval list")
+			}
+			log.Warn(msg)
+			s.createUnverifiedDomainsEventLogEntry(companyModel, projectModel, userModel, unverified)
+		}
+	}
+
 	updatedSig, err := s.repo.UpdateApprovalList(ctx, projectModel.ProjectID, companyModel.CompanyID, params)
 	if err != nil {
 		return updatedSig, err
 	}
 
+	// Record who added the entries and when they expire
+	updatedSig.ApprovalListEntries = s.updateApprovalListEntries(ctx, sigModel, params, authUser.UserName, nowStr)
+
 	// Log Events
 	s.createEventLogEntries(companyModel, projectModel, userModel, params)
 
 	// Send an email to the CLA Managers
 	for _, claManager := range claManagers {
 		claManagerEmail := getBestEmail(claManager)
-		s.sendApprovalListUpdateEmailToCLAManagers(companyModel, projectModel, claManager.Username, claManagerEmail, params)
+		s.sendApprovalListUpdateEmailToCLAManagers(ctx, companyModel, projectModel, claManager.Username, claManagerEmail, params)
 	}
 
 	// Send emails to contributors if email or GH username as added/removed
-	s.sendRequestAccessEmailToContributors(authUser, companyModel, projectModel, params)
+	s.sendRequestAccessEmailToContributors(ctx, authUser, companyModel, projectModel, params)
 
 	return updatedSig, nil
 }
 
+// RemoveSelfFromApprovalList removes the email and GitHub username entries of the current user from the company CCLA
+// approval list, notifies the CLA Managers and clears the user's company association
+func (s service) RemoveSelfFromApprovalList(ctx context.Context, authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string) (*models.ApprovalList, error) {
+	f := logrus.Fields{
+		"functionName": "RemoveSelfFromApprovalList",
+		"claGroupID":   claGroupID,
+		"companyID":    companyModel.CompanyID,
+		"userName":     authUser.UserName,
+	}
+
+	pageSize := int64(1)
+	signed, approved := true, true
+	sigModel, sigErr := s.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if sigErr != nil {
+		msg := fmt.Sprintf("unable to locate project company signature by Company ID: %s, Project ID: %s, CLA Group ID: %s, error: %+v",
+			companyModel.CompanyID, projectModel.ProjectID, claGroupID, sigErr)
+		log.WithFields(f).Warn(msg)
+		return nil, NewBadRequestError(msg)
+	}
+	if sigModel == nil {
+		msg := fmt.Sprintf("unable to locate signature for company ID: %s CLA Group ID: %s, type: ccla, signed: %t, approved: %t",
+			companyModel.CompanyID, claGroupID, signed, approved)
+		log.WithFields(f).Warn(msg)
+		return nil, NewBadRequestError(msg)
+	}
+
+	// Lookup the user making the request
+	userModel, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
+	if userErr != nil {
+		return nil, userErr
+	}
+	if userModel == nil {
+		msg := fmt.Sprintf("unable to locate user by LF username: %s", authUser.UserName)
+		log.WithFields(f).Warn(msg)
+		return nil, NewBadRequestError(msg)
+	}
+
+	removal := selfApprovalListEntries(sigModel, userModel)
+	if len(removal.RemoveEmailApprovalList) == 0 && len(removal.RemoveGithubUsernameApprovalList) == 0 {
+		msg := fmt.Sprintf("user %s is not on the approval list of company: %s for CLA Group ID: %s",
+			authUser.UserName, companyModel.CompanyName, claGroupID)
+		log.WithFields(f).Warn(msg)
+		return nil, NewBadRequestError(msg)
+	}
+
+	_, err := s.repo.UpdateApprovalList(ctx, projectModel.ProjectID, companyModel.CompanyID, removal)
+	if err != nil {
+		return nil, err
+	}
+	_, nowStr := utils.CurrentTime()
+	s.updateApprovalListEntries(ctx, sigModel, removal, authUser.UserName, nowStr)
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ApprovalListContributorSelfRemoved,
+		ProjectID:         projectModel.ProjectID,
+		ProjectModel:      projectModel,
+		CompanyID:         companyModel.CompanyID,
+		CompanyModel:      companyModel,
+		LfUsername:        userModel.LfUsername,
+		UserID:            userModel.UserID,
+		UserModel:         userModel,
+		ExternalProjectID: projectModel.ProjectExternalID,
+		EventData: &events.CLAApprovalListSelfRemovedData{
+			UserName:               userModel.LfUsername,
+			UserEmail:              userModel.LfEmail,
+			UserLFID:               userModel.UserID,
+			RemovedEmails:          removal.RemoveEmailApprovalList,
+			RemovedGitHubUsernames: removal.RemoveGithubUsernameApprovalList,
+		},
+	})
+
+	// Let the CLA Managers know that the contributor left
+	for _, claManager := range sigModel.SignatureACL {
+		claManagerEmail := getBestEmail(claManager)
+		s.sendApprovalListUpdateEmailToCLAManagers(ctx, companyModel, projectModel, claManager.Username, claManagerEmail, removal)
+	}
+
+	// The user no longer works for the company
+	err = s.companyService.DisassociateUserFromCompany(ctx, userModel.UserID, companyModel.CompanyID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to clear the company of user: %s, error: %+v", userModel.UserID, err)
+	}
+
+	return removal, nil
+}
+
+// approvalListEntriesUpdateAttempts is how many times the approval list entry metadata is written when it keeps
+// changing concurrently
+const approvalListEntriesUpdateAttempts = 3
+
+// updateApprovalListEntries applies the approval list changes to the entry metadata of the signature and returns the
+// updated metadata - a failure is logged only as the approval list itself was updated
+func (s service) updateApprovalListEntries(ctx context.Context, sigModel *models.Signature, params *models.ApprovalList, addedBy, dateAdded string) []*models.ApprovalListEntry {
+	entries, err := s.applyApprovalListEntries(ctx, sigModel, func(existing []*models.ApprovalListEntry) []*models.ApprovalListEntry {
+		return mergeApprovalListEntries(existing, params, addedBy, dateAdded)
+	})
+	if err != nil {
+		log.Warnf("unable to update the approval list entries of signature: %s, error: %+v", sigModel.SignatureID, err)
+	}
+	return entries
+}
+
+// applyApprovalListEntries applies the change to the entry metadata of the signature and writes it conditionally on
+// the version it was loaded with - when it changed concurrently the signature is loaded again and the change applied
+// to the latest metadata. The updated metadata is returned, or the metadata as loaded on failure.
+func (s service) applyApprovalListEntries(ctx context.Context, sigModel *models.Signature, change func([]*models.ApprovalListEntry) []*models.ApprovalListEntry) ([]*models.ApprovalListEntry, error) {
+	entries, version := sigModel.ApprovalListEntries, sigModel.ApprovalListEntriesVersion
+	for attempt := 1; ; attempt++ {
+		updated := change(entries)
+		if len(updated) == 0 && len(entries) == 0 {
+			return nil, nil
+		}
+
+		err := s.repo.UpdateApprovalListEntries(ctx, sigModel.SignatureID.String(), version, updated)
+		if err == nil {
+			return updated, nil
+		}
+		if err != ErrApprovalListEntriesChanged || attempt == approvalListEntriesUpdateAttempts {
+			return entries, err
+		}
+
+		latest, getErr := s.repo.GetSignature(ctx, sigModel.SignatureID.String())
+		if getErr != nil {
+			return entries, getErr
+		}
+		if latest == nil {
+			return entries, err
+		}
+		entries, version = latest.ApprovalListEntries, latest.ApprovalListEntriesVersion
+	}
+}
+
+// selfApprovalListEntries returns the approval list removal of the email and GitHub username entries which belong to
+// the user - the entries are returned as stored on the signature
+func selfApprovalListEntries(sig *models.Signature, userModel *models.User) *models.ApprovalList {
+	removal := &models.ApprovalList{}
+
+	userEmails := append([]string{userModel.LfEmail}, userModel.Emails...)
+	for _, entry := range sig.EmailApprovalList {
+		for _, email := range userEmails {
+			email = strings.TrimSpace(email)
+			if email != "" && strings.EqualFold(strings.TrimSpace(entry), email) {
+				removal.RemoveEmailApprovalList = append(removal.RemoveEmailApprovalList, entry)
+				break
+			}
+		}
+	}
+
+	githubUsername := strings.TrimSpace(userModel.GithubUsername)
+	if githubUsername != "" {
+		for _, entry := range sig.GithubUsernameApprovalList {
+			if strings.EqualFold(strings.TrimSpace(entry), githubUsername) {
+				removal.RemoveGithubUsernameApprovalList = append(removal.RemoveGithubUsernameApprovalList, entry)
+			}
+		}
+	}
+
+	return removal
+}
+
 // Disassociate project signatures
-func (s service) InvalidateProjectRecords(ctx context.Context, projectID string, projectName string) (int, error) {
+func (s service) InvalidateProjectRecords(ctx context.Context, projectID, foundationSFID, projectName string) (int, error) {
 	f := logrus.Fields{
 		"functionName": "InvalidateProjectRecords",
 		"projectID":    projectID,
@@ -456,7 +735,7 @@ func (s service) InvalidateProjectRecords(ctx context.Context, projectID string,
 			// Do this in parallel, as we could have a lot to invalidate
 			go func(sigID, projName string) {
 				defer wg.Done()
-				updateErr := s.repo.InvalidateProjectRecord(ctx, sigID, projName)
+				updateErr := s.repo.InvalidateProjectRecord(ctx, sigID, foundationSFID, projName)
 				if updateErr != nil {
 					log.WithFields(f).Warnf("Unable to update signature: %s with project name: %s, error: %v",
 						sigID, projName, updateErr)
@@ -481,38 +760,33 @@ func (s service) RemoveCLAManager(ctx context.Context, signatureID, claManagerID
 	return s.repo.RemoveCLAManager(ctx, signatureID, claManagerID)
 }
 
-// appendList is a helper function to generate the email content of the Approval List changes
-func appendList(approvalList []string, message string) string {
-	approvalListSummary := ""
-
-	if len(approvalList) > 0 {
-		for _, value := range approvalList {
-			approvalListSummary += fmt.Sprintf("<li>%s %s</li>", message, value)
-		}
+// appendChanges is a helper function to generate the email content of the Approval List changes
+func appendChanges(changes []string, approvalList []string, message string) []string {
+	for _, value := range approvalList {
+		changes = append(changes, fmt.Sprintf("%s %s", message, value))
 	}
-
-	return approvalListSummary
+	return changes
 }
 
-// buildApprovalListSummary is a helper function to generate the email content of the Approval List changes
-func buildApprovalListSummary(approvalListChanges *models.ApprovalList) string {
-	approvalListSummary := "<ul>"
-	approvalListSummary += appendList(approvalListChanges.AddEmailApprovalList, "Added Email:")
-	approvalListSummary += appendList(approvalListChanges.RemoveEmailApprovalList, "Removed Email:")
-	approvalListSummary += appendList(approvalListChanges.AddDomainApprovalList, "Added Domain:")
-	approvalListSummary += appendList(approvalListChanges.RemoveDomainApprovalList, "Removed Domain:")
-	approvalListSummary += appendList(approvalListChanges.AddGithubUsernameApprovalList, "Added GithHub User:")
-	approvalListSummary += appendList(approvalListChanges.RemoveGithubUsernameApprovalList, "Removed GitHub User:")
-	approvalListSummary += appendList(approvalListChanges.AddGithubOrgApprovalList, "Added GithHub Organization:")
-	approvalListSummary += appendList(approvalListChanges.RemoveGithubOrgApprovalList, "Removed GitHub Organization:")
-	approvalListSummary += "</ul>"
-	return approvalListSummary
+// buildApprovalListChanges is a helper function to generate the email content of the Approval List changes
+func buildApprovalListChanges(approvalListChanges *models.ApprovalList) []string {
+	var changes []string
+	changes = appendChanges(changes, approvalListChanges.AddEmailApprovalList, "Added Email:")
+	changes = appendChanges(changes, approvalListChanges.RemoveEmailApprovalList, "Removed Email:")
+	changes = appendChanges(changes, approvalListChanges.AddDomainApprovalList, "Added Domain:")
+	changes = appendChanges(changes, approvalListChanges.RemoveDomainApprovalList, "Removed Domain:")
+	changes = appendChanges(changes, approvalListChanges.AddGithubUsernameApprovalList, "Added GithHub User:")
+	changes = appendChanges(changes, approvalListChanges.RemoveGithubUsernameApprovalList, "Removed GitHub User:")
+	changes = appendChanges(changes, approvalListChanges.AddGithubOrgApprovalList, "Added GithHub Organization:")
+	changes = appendChanges(changes, approvalListChanges.RemoveGithubOrgApprovalList, "Removed GitHub Organization:")
+	return changes
 }
 
-// sendRequestAccessEmailToCLAManagers sends the request access email to the specified CLA Managers
-func (s service) sendApprovalListUpdateEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, approvalListChanges *models.ApprovalList) {
+// sendApprovalListUpdateEmailToCLAManagers sends the approval list update email to the specified CLA Manager
+func (s service) sendApprovalListUpdateEmailToCLAManagers(ctx context.Context, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, approvalListChanges *models.ApprovalList) {
 	f := logrus.Fields{
 		"function":          "sendApprovalListUpdateEmailToCLAManagers",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
 		"projectName":       projectModel.ProjectName,
 		"projectExternalID": projectModel.ProjectExternalID,
 		"foundationSFID":    projectModel.FoundationSFID,
@@ -521,30 +795,18 @@ func (s service) sendApprovalListUpdateEmailToCLAManagers(companyModel *models.C
 		"recipientName":     recipientName,
 		"recipientAddress":  recipientAddress}
 
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Approval List Update for %s on %s", companyName, projectName)
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>The EasyCLA approval list for %s for project %s was modified.</p>
-<p>The modification was as follows:</p>
-%s
-<p>Contributors with previously failed pull requests to %s can close and re-open the pull request to force a recheck by
-the EasyCLA system.</p>
-%s
-%s`,
-		recipientName, projectName, companyName, projectName, buildApprovalListSummary(approvalListChanges), projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.ApprovalListUpdatedTemplate, recipients, notifications.ApprovalListUpdatedData{
+		RecipientName: recipientName,
+		CompanyName:   companyModel.CompanyName,
+		ProjectName:   projectModel.ProjectName,
+		Changes:       buildApprovalListChanges(approvalListChanges),
+		V2:            projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.WithFields(f).Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ApprovalListUpdatedTemplate, recipients, err)
 	} else {
-		log.WithFields(f).Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.WithFields(f).Debugf("sent %s email to recipients: %+v", notifications.ApprovalListUpdatedTemplate, recipients)
 	}
 }
 
@@ -607,25 +869,46 @@ func (s service) getRemoveGitHubContributors(approvalList *models.ApprovalList)
 
 	return userModelList
 }
-func (s service) sendRequestAccessEmailToContributors(authUser *auth.User, companyModel *models.Company, projectModel *models.Project, approvalList *models.ApprovalList) {
+func (s service) sendRequestAccessEmailToContributors(ctx context.Context, authUser *auth.User, companyModel *models.Company, projectModel *models.Project, approvalList *models.ApprovalList) {
 	addEmailUsers := s.getAddEmailContributors(approvalList)
 	for _, user := range addEmailUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, projectModel, user.Username, user.LfEmail, "added", "to", "you are authorized to contribute to")
+		sendRequestAccessEmailToContributorRecipient(ctx, authUser, companyModel, projectModel, user.Username, user.LfEmail, true)
 	}
 	removeEmailUsers := s.getRemoveEmailContributors(approvalList)
 	for _, user := range removeEmailUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, projectModel, user.Username, user.LfEmail, "removed", "from", "you are no longer authorized to contribute to")
+		sendRequestAccessEmailToContributorRecipient(ctx, authUser, companyModel, projectModel, user.Username, user.LfEmail, false)
 	}
 	addGitHubUsers := s.getAddGitHubContributors(approvalList)
 	for _, user := range addGitHubUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, projectModel, user.Username, user.LfEmail, "added", "to", "you are authorized to contribute to")
+		sendRequestAccessEmailToContributorRecipient(ctx, authUser, companyModel, projectModel, user.Username, user.LfEmail, true)
 	}
 	removeGitHubUsers := s.getRemoveGitHubContributors(approvalList)
 	for _, user := range removeGitHubUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, projectModel, user.Username, user.LfEmail, "removed", "from", "you are no longer authorized to contribute to")
+		sendRequestAccessEmailToContributorRecipient(ctx, authUser, companyModel, projectModel, user.Username, user.LfEmail, false)
 	}
 }
 
+// createUnverifiedDomainsEventLogEntry logs that the CLA Manager added domains the company has not verified
+func (s service) createUnverifiedDomainsEventLogEntry(companyModel *models.Company, projectModel *models.Project, userModel *models.User, domains []string) {
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ApprovalListUnverifiedDomainAdded,
+		ProjectID:         projectModel.ProjectID,
+		ProjectModel:      projectModel,
+		CompanyID:         companyModel.CompanyID,
+		CompanyModel:      companyModel,
+		LfUsername:        userModel.LfUsername,
+		UserID:            userModel.UserID,
+		UserModel:         userModel,
+		ExternalProjectID: projectModel.ProjectExternalID,
+		EventData: &events.CLAApprovalListUnverifiedDomainsData{
+			UserName:  userModel.LfUsername,
+			UserEmail: userModel.LfEmail,
+			UserLFID:  userModel.UserID,
+			Domains:   domains,
+		},
+	})
+}
+
 func (s service) createEventLogEntries(companyModel *models.Company, projectModel *models.Project, userModel *models.User, approvalList *models.ApprovalList) {
 	for _, value := range approvalList.AddEmailApprovalList {
 		// Send an event
@@ -797,31 +1080,21 @@ func (s service) GetClaGroupCorporateContributors(ctx context.Context, claGroupI
 	return s.repo.GetClaGroupCorporateContributors(ctx, claGroupID, companyID, searchTerm)
 }
 
-// sendRequestAccessEmailToContributors sends the request access email to the specified contributors
-func sendRequestAccessEmailToContributorRecipient(authUser *auth.User, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress, addRemove, toFrom, authorizedString string) {
-	companyName := companyModel.CompanyName
-	projectName := projectModel.ProjectName
-
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Approval List Update for %s on %s", companyName, projectName)
+// sendRequestAccessEmailToContributorRecipient notifies the contributor added to or removed from the approval list
+func sendRequestAccessEmailToContributorRecipient(ctx context.Context, authUser *auth.User, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, added bool) {
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have been %s %s the Approval List of %s for %s by CLA Manager %s. This means that %s on behalf of %s.</p>
-<p>If you had previously submitted one or more pull requests to %s that had failed, you should 
-close and re-open the pull request to force a recheck by the EasyCLA system.</p>
-%s
-%s`,
-		recipientName, projectName, addRemove, toFrom,
-		companyName, projectName, authUser.UserName, authorizedString, projectName, projectName,
-		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, projectModel.ProjectID, notifications.ApprovalListContributorUpdatedTemplate, recipients, notifications.ApprovalListContributorUpdatedData{
+		RecipientName: recipientName,
+		CompanyName:   companyModel.CompanyName,
+		ProjectName:   projectModel.ProjectName,
+		ManagerName:   authUser.UserName,
+		Added:         added,
+		V2:            projectModel.Version == utils.V2,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ApprovalListContributorUpdatedTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.ApprovalListContributorUpdatedTemplate, recipients)
 	}
 }
 
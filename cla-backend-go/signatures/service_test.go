@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSelfApprovalListEntries tests that only the entries of the user are removed from the approval list
+func TestSelfApprovalListEntries(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"Jane@Example.org", "john@example.org", "jane@personal.io"},
+		DomainApprovalList:         []string{"example.org"},
+		GithubUsernameApprovalList: []string{"JaneDoe", "johndoe"},
+	}
+
+	removal := selfApprovalListEntries(sig, &models.User{
+		LfEmail:        "jane@example.org",
+		Emails:         []string{"", "jane@personal.io"},
+		GithubUsername: "janedoe",
+	})
+	assert.Equal(t, []string{"Jane@Example.org", "jane@personal.io"}, removal.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"JaneDoe"}, removal.RemoveGithubUsernameApprovalList)
+	assert.Nil(t, removal.RemoveDomainApprovalList)
+
+	removal = selfApprovalListEntries(sig, &models.User{LfEmail: "someone@else.org"})
+	assert.Nil(t, removal.RemoveEmailApprovalList)
+	assert.Nil(t, removal.RemoveGithubUsernameApprovalList)
+}
+
+// countingVersionPolicy requires major version 2 and counts the evaluations
+type countingVersionPolicy struct {
+	calls int
+}
+
+func (p *countingVersionPolicy) RequiredMajorVersion(ctx context.Context, claGroupID, claType string, now time.Time) (int64, error) {
+	p.calls++
+	return 2, nil
+}
+
+// TestSignatureVersionPolicyCache tests that the version policy is evaluated once per CLA Group and CLA type with a
+// cached context and on every signature without
+func TestSignatureVersionPolicyCache(t *testing.T) {
+	policy := &countingVersionPolicy{}
+	s := service{versionPolicy: policy}
+	current := &models.Signature{ProjectID: "cla-group-1", SignatureMajorVersion: "2"}
+	outdated := &models.Signature{ProjectID: "cla-group-1", SignatureMajorVersion: "1"}
+
+	ctx := WithVersionPolicyCache(context.Background())
+	assert.True(t, s.isSignatureVersionHonored(ctx, current, ICLA))
+	assert.False(t, s.isSignatureVersionHonored(ctx, outdated, ICLA))
+	assert.Equal(t, 1, policy.calls)
+	assert.True(t, s.isSignatureVersionHonored(ctx, current, CCLA))
+	assert.Equal(t, 2, policy.calls)
+
+	assert.False(t, s.isSignatureVersionHonored(context.Background(), outdated, ICLA))
+	assert.False(t, s.isSignatureVersionHonored(context.Background(), outdated, ICLA))
+	assert.Equal(t, 4, policy.calls)
+}
@@ -0,0 +1,96 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// SignatureVersionPolicy decides the lowest document major version still honored for the CLA type (icla or ccla) of
+// the CLA Group - 0 honors all versions. Implemented by the re-sign campaigns once their grace date has passed.
+type SignatureVersionPolicy interface {
+	RequiredMajorVersion(ctx context.Context, claGroupID, claType string, now time.Time) (int64, error)
+}
+
+// isSignatureVersionHonored returns true if the document major version of the signature is still honored. The
+// signature is honored when the policy can not be evaluated so that an outage does not block all contributors.
+func (s service) isSignatureVersionHonored(ctx context.Context, sig *models.Signature, claType string) bool {
+	if s.versionPolicy == nil {
+		return true
+	}
+	f := logrus.Fields{
+		"functionName":   "isSignatureVersionHonored",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     sig.ProjectID,
+		"claType":        claType,
+	}
+
+	required, err := s.requiredMajorVersion(ctx, sig.ProjectID, claType)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to evaluate the signature version policy, honoring the signature, error: %+v", err)
+		return true
+	}
+	if required == 0 || utils.SignatureMajorVersion(sig) >= required {
+		return true
+	}
+
+	log.WithFields(f).Debugf("signature document major version %s is no longer honored, version %d is required",
+		sig.SignatureMajorVersion, required)
+	return false
+}
+
+// versionPolicyCacheKey is the context key of the versionPolicyCache
+type versionPolicyCacheKey struct{}
+
+// versionPolicyCache holds the required major versions evaluated with the context by CLA Group and CLA type
+type versionPolicyCache struct {
+	mu       sync.Mutex
+	required map[string]int64
+}
+
+// WithVersionPolicyCache returns a context caching the required major versions evaluated with it - a PR check wraps
+// its context so the policy is evaluated once per CLA Group and CLA type instead of once per commit author
+func WithVersionPolicyCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, versionPolicyCacheKey{}, &versionPolicyCache{required: map[string]int64{}})
+}
+
+// requiredMajorVersion evaluates the version policy, the result is cached when the context carries a cache
+func (s service) requiredMajorVersion(ctx context.Context, claGroupID, claType string) (int64, error) {
+	cache, ok := ctx.Value(versionPolicyCacheKey{}).(*versionPolicyCache)
+	if !ok {
+		return s.versionPolicy.RequiredMajorVersion(ctx, claGroupID, claType, time.Now().UTC())
+	}
+
+	key := claGroupID + "#" + claType
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if required, found := cache.required[key]; found {
+		return required, nil
+	}
+	required, err := s.versionPolicy.RequiredMajorVersion(ctx, claGroupID, claType, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	cache.required[key] = required
+	return required, nil
+}
+
+// latestSignature returns the signature with the highest document major version, the first one on a tie
+func latestSignature(sigs []*models.Signature) *models.Signature {
+	var latest *models.Signature
+	for _, sig := range sigs {
+		if latest == nil || utils.SignatureMajorVersion(sig) > utils.SignatureMajorVersion(latest) {
+			latest = sig
+		}
+	}
+	return latest
+}
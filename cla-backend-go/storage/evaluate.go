@@ -0,0 +1,548 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// item is a DynamoDB item - a map of attribute names to values
+type item map[string]*dynamodb.AttributeValue
+
+// attribute value types
+const (
+	typeString    = "S"
+	typeNumber    = "N"
+	typeBinary    = "B"
+	typeBool      = "BOOL"
+	typeNull      = "NULL"
+	typeStringSet = "SS"
+	typeNumberSet = "NS"
+	typeBinarySet = "BS"
+	typeList      = "L"
+	typeMap       = "M"
+)
+
+// valueType returns the type of the attribute value
+func valueType(v *dynamodb.AttributeValue) string {
+	switch {
+	case v == nil:
+		return ""
+	case v.S != nil:
+		return typeString
+	case v.N != nil:
+		return typeNumber
+	case v.B != nil:
+		return typeBinary
+	case v.BOOL != nil:
+		return typeBool
+	case v.NULL != nil:
+		return typeNull
+	case v.SS != nil:
+		return typeStringSet
+	case v.NS != nil:
+		return typeNumberSet
+	case v.BS != nil:
+		return typeBinarySet
+	case v.L != nil:
+		return typeList
+	case v.M != nil:
+		return typeMap
+	}
+	return ""
+}
+
+func parseNumber(n string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(n))
+	if !ok {
+		return nil, fmt.Errorf("invalid number: %s", n)
+	}
+	return r, nil
+}
+
+// formatNumber returns the canonical string of the number, so 1, 1.0 and 1e0 are stored the same
+func formatNumber(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return strings.TrimRight(r.FloatString(38), "0")
+}
+
+// compareValues compares the scalar values of the same type, returning false if they are not comparable
+func compareValues(a, b *dynamodb.AttributeValue) (int, bool) {
+	if valueType(a) != valueType(b) {
+		return 0, false
+	}
+	switch valueType(a) {
+	case typeString:
+		return strings.Compare(*a.S, *b.S), true
+	case typeNumber:
+		x, errX := parseNumber(*a.N)
+		y, errY := parseNumber(*b.N)
+		if errX != nil || errY != nil {
+			return 0, false
+		}
+		return x.Cmp(y), true
+	case typeBinary:
+		return bytes.Compare(a.B, b.B), true
+	}
+	return 0, false
+}
+
+// equalValues returns true if the values have the same type and content - sets are compared regardless of order
+func equalValues(a, b *dynamodb.AttributeValue) bool {
+	if valueType(a) != valueType(b) {
+		return false
+	}
+	switch valueType(a) {
+	case typeString, typeNumber, typeBinary:
+		c, ok := compareValues(a, b)
+		return ok && c == 0
+	case typeBool:
+		return *a.BOOL == *b.BOOL
+	case typeNull:
+		return *a.NULL == *b.NULL
+	case typeStringSet, typeNumberSet, typeBinarySet:
+		members := setMembers(a)
+		if len(members) != len(setMembers(b)) {
+			return false
+		}
+		for _, m := range members {
+			if !setContains(b, m) {
+				return false
+			}
+		}
+		return true
+	case typeList:
+		if len(a.L) != len(b.L) {
+			return false
+		}
+		for i := range a.L {
+			if !equalValues(a.L[i], b.L[i]) {
+				return false
+			}
+		}
+		return true
+	case typeMap:
+		if len(a.M) != len(b.M) {
+			return false
+		}
+		for k, v := range a.M {
+			if !equalValues(v, b.M[k]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// setMembers returns the members of the set as scalar values
+func setMembers(set *dynamodb.AttributeValue) []*dynamodb.AttributeValue {
+	var members []*dynamodb.AttributeValue
+	for _, s := range set.SS {
+		members = append(members, &dynamodb.AttributeValue{S: s})
+	}
+	for _, n := range set.NS {
+		members = append(members, &dynamodb.AttributeValue{N: n})
+	}
+	for _, b := range set.BS {
+		members = append(members, &dynamodb.AttributeValue{B: b})
+	}
+	return members
+}
+
+func setContains(set *dynamodb.AttributeValue, member *dynamodb.AttributeValue) bool {
+	for _, m := range setMembers(set) {
+		if equalValues(m, member) {
+			return true
+		}
+	}
+	return false
+}
+
+// newSet returns a set of the type with the scalar members
+func newSet(setType string, members []*dynamodb.AttributeValue) *dynamodb.AttributeValue {
+	set := &dynamodb.AttributeValue{}
+	for _, m := range members {
+		switch setType {
+		case typeStringSet:
+			set.SS = append(set.SS, m.S)
+		case typeNumberSet:
+			set.NS = append(set.NS, m.N)
+		case typeBinarySet:
+			set.BS = append(set.BS, m.B)
+		}
+	}
+	return set
+}
+
+// getPath returns the value at the attribute path, or nil if it does not exist
+func getPath(it item, path attributePath) *dynamodb.AttributeValue {
+	v := it[path[0].name]
+	for _, element := range path[1:] {
+		switch {
+		case v == nil:
+			return nil
+		case element.isIndex:
+			if element.index >= len(v.L) {
+				return nil
+			}
+			v = v.L[element.index]
+		default:
+			v = v.M[element.name]
+		}
+	}
+	return v
+}
+
+// setPath sets the value at the attribute path - the parent of a nested path must exist
+func setPath(it item, path attributePath, value *dynamodb.AttributeValue) error {
+	if len(path) == 1 {
+		it[path[0].name] = value
+		return nil
+	}
+	parent := getPath(it, path[:len(path)-1])
+	last := path[len(path)-1]
+	switch {
+	case last.isIndex && parent != nil && parent.L != nil:
+		if last.index >= len(parent.L) {
+			parent.L = append(parent.L, value)
+		} else {
+			parent.L[last.index] = value
+		}
+	case !last.isIndex && parent != nil && parent.M != nil:
+		parent.M[last.name] = value
+	default:
+		return fmt.Errorf("the document path provided in the update expression is invalid for update")
+	}
+	return nil
+}
+
+// removePath removes the value at the attribute path
+func removePath(it item, path attributePath) {
+	if len(path) == 1 {
+		delete(it, path[0].name)
+		return
+	}
+	parent := getPath(it, path[:len(path)-1])
+	last := path[len(path)-1]
+	switch {
+	case parent == nil:
+	case last.isIndex && last.index < len(parent.L):
+		parent.L = append(parent.L[:last.index], parent.L[last.index+1:]...)
+	case !last.isIndex && parent.M != nil:
+		delete(parent.M, last.name)
+	}
+}
+
+// evaluateOperand returns the value of the operand for the item, or nil if the attribute does not exist
+func evaluateOperand(it item, o *operand) (*dynamodb.AttributeValue, error) {
+	switch {
+	case o.value != nil:
+		return o.value, nil
+	case o.path != nil:
+		return getPath(it, o.path), nil
+	case o.operator != "":
+		left, err := evaluateOperand(it, o.args[0])
+		if err != nil {
+			return nil, err
+		}
+		right, err := evaluateOperand(it, o.args[1])
+		if err != nil {
+			return nil, err
+		}
+		if valueType(left) != typeNumber || valueType(right) != typeNumber {
+			return nil, fmt.Errorf("an operand in the update expression has an incorrect data type")
+		}
+		x, err := parseNumber(*left.N)
+		if err != nil {
+			return nil, err
+		}
+		y, err := parseNumber(*right.N)
+		if err != nil {
+			return nil, err
+		}
+		if o.operator == "+" {
+			return &dynamodb.AttributeValue{N: aws.String(formatNumber(new(big.Rat).Add(x, y)))}, nil
+		}
+		return &dynamodb.AttributeValue{N: aws.String(formatNumber(new(big.Rat).Sub(x, y)))}, nil
+	}
+
+	switch o.function {
+	case "size":
+		v := getPath(it, o.args[0].path)
+		var size int
+		switch valueType(v) {
+		case "":
+			return nil, nil
+		case typeString:
+			size = utf8.RuneCountInString(*v.S)
+		case typeBinary:
+			size = len(v.B)
+		case typeStringSet, typeNumberSet, typeBinarySet:
+			size = len(setMembers(v))
+		case typeList:
+			size = len(v.L)
+		case typeMap:
+			size = len(v.M)
+		default:
+			return nil, nil
+		}
+		return &dynamodb.AttributeValue{N: aws.String(fmt.Sprintf("%d", size))}, nil
+	case "if_not_exists":
+		if v := getPath(it, o.args[0].path); v != nil {
+			return v, nil
+		}
+		return evaluateOperand(it, o.args[1])
+	case "list_append":
+		left, err := evaluateOperand(it, o.args[0])
+		if err != nil {
+			return nil, err
+		}
+		right, err := evaluateOperand(it, o.args[1])
+		if err != nil {
+			return nil, err
+		}
+		if valueType(left) != typeList || valueType(right) != typeList {
+			return nil, fmt.Errorf("an operand in the update expression has an incorrect data type")
+		}
+		list := append(append([]*dynamodb.AttributeValue{}, left.L...), right.L...)
+		return &dynamodb.AttributeValue{L: list}, nil
+	}
+	return nil, fmt.Errorf("invalid operand")
+}
+
+// evaluateCondition returns true if the item satisfies the condition
+func evaluateCondition(it item, c *condition) (bool, error) {
+	switch c.operator {
+	case "AND", "OR":
+		left, err := evaluateCondition(it, c.children[0])
+		if err != nil {
+			return false, err
+		}
+		if c.operator == "AND" && !left {
+			return false, nil
+		}
+		if c.operator == "OR" && left {
+			return true, nil
+		}
+		return evaluateCondition(it, c.children[1])
+	case "NOT":
+		result, err := evaluateCondition(it, c.children[0])
+		return !result, err
+	case "attribute_exists":
+		return getPath(it, c.operands[0].path) != nil, nil
+	case "attribute_not_exists":
+		return getPath(it, c.operands[0].path) == nil, nil
+	}
+
+	values := make([]*dynamodb.AttributeValue, len(c.operands))
+	for i, o := range c.operands {
+		v, err := evaluateOperand(it, o)
+		if err != nil {
+			return false, err
+		}
+		values[i] = v
+	}
+
+	switch c.operator {
+	case "=":
+		return values[0] != nil && equalValues(values[0], values[1]), nil
+	case "<>":
+		return values[0] == nil || values[1] == nil || !equalValues(values[0], values[1]), nil
+	case "<", "<=", ">", ">=":
+		cmp, ok := compareValues(values[0], values[1])
+		if !ok {
+			return false, nil
+		}
+		switch c.operator {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	case "BETWEEN":
+		low, okLow := compareValues(values[0], values[1])
+		high, okHigh := compareValues(values[0], values[2])
+		return okLow && okHigh && low >= 0 && high <= 0, nil
+	case "IN":
+		for _, v := range values[1:] {
+			if values[0] != nil && equalValues(values[0], v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "attribute_type":
+		return values[0] != nil && values[1] != nil && values[1].S != nil && valueType(values[0]) == *values[1].S, nil
+	case "begins_with":
+		switch {
+		case valueType(values[0]) == typeString && valueType(values[1]) == typeString:
+			return strings.HasPrefix(*values[0].S, *values[1].S), nil
+		case valueType(values[0]) == typeBinary && valueType(values[1]) == typeBinary:
+			return bytes.HasPrefix(values[0].B, values[1].B), nil
+		}
+		return false, nil
+	case "contains":
+		switch valueType(values[0]) {
+		case typeString:
+			return valueType(values[1]) == typeString && strings.Contains(*values[0].S, *values[1].S), nil
+		case typeStringSet, typeNumberSet, typeBinarySet:
+			return values[1] != nil && setContains(values[0], values[1]), nil
+		case typeList:
+			for _, v := range values[0].L {
+				if values[1] != nil && equalValues(v, values[1]) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported condition operator %s", c.operator)
+}
+
+// applyUpdate applies the update actions to the item and returns the names of the updated top level attributes. The
+// values of all the actions are evaluated against the original item, as DynamoDB does.
+func applyUpdate(it item, actions []*updateAction) ([]string, error) {
+	original := cloneItem(it)
+	var updated []string
+	for _, action := range actions {
+		updated = append(updated, action.path.topLevel())
+		switch action.action {
+		case "SET":
+			v, err := evaluateOperand(original, action.value)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				return nil, fmt.Errorf("the provided expression refers to an attribute that does not exist in the item")
+			}
+			if err = setPath(it, action.path, cloneValue(v)); err != nil {
+				return nil, err
+			}
+		case "REMOVE":
+			removePath(it, action.path)
+		case "ADD":
+			current := getPath(it, action.path)
+			v := action.value.value
+			switch {
+			case current == nil:
+				if err := setPath(it, action.path, cloneValue(v)); err != nil {
+					return nil, err
+				}
+			case valueType(current) == typeNumber && valueType(v) == typeNumber:
+				x, err := parseNumber(*current.N)
+				if err != nil {
+					return nil, err
+				}
+				y, err := parseNumber(*v.N)
+				if err != nil {
+					return nil, err
+				}
+				current.N = aws.String(formatNumber(new(big.Rat).Add(x, y)))
+			case isSet(current) && valueType(current) == valueType(v):
+				members := setMembers(current)
+				for _, m := range setMembers(v) {
+					if !setContains(current, m) {
+						members = append(members, m)
+					}
+				}
+				if err := setPath(it, action.path, newSet(valueType(current), members)); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("an operand in the update expression has an incorrect data type")
+			}
+		case "DELETE":
+			current := getPath(it, action.path)
+			v := action.value.value
+			if current == nil {
+				continue
+			}
+			if !isSet(current) || valueType(current) != valueType(v) {
+				return nil, fmt.Errorf("an operand in the update expression has an incorrect data type")
+			}
+			var members []*dynamodb.AttributeValue
+			for _, m := range setMembers(current) {
+				if !setContains(v, m) {
+					members = append(members, m)
+				}
+			}
+			if len(members) == 0 {
+				removePath(it, action.path)
+			} else if err := setPath(it, action.path, newSet(valueType(current), members)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return updated, nil
+}
+
+func isSet(v *dynamodb.AttributeValue) bool {
+	t := valueType(v)
+	return t == typeStringSet || t == typeNumberSet || t == typeBinarySet
+}
+
+// project returns the item with only the attributes of the projection paths
+func project(it item, paths []attributePath) item {
+	if len(paths) == 0 {
+		return it
+	}
+	projected := item{}
+	for _, path := range paths {
+		// nested paths return the whole top level attribute, which is sufficient for unmarshalling the items
+		if v, ok := it[path.topLevel()]; ok {
+			projected[path.topLevel()] = v
+		}
+	}
+	return projected
+}
+
+func cloneItem(it item) item {
+	clone := make(item, len(it))
+	for k, v := range it {
+		clone[k] = cloneValue(v)
+	}
+	return clone
+}
+
+func cloneValue(v *dynamodb.AttributeValue) *dynamodb.AttributeValue {
+	if v == nil {
+		return nil
+	}
+	clone := *v
+	if v.L != nil {
+		clone.L = make([]*dynamodb.AttributeValue, len(v.L))
+		for i, e := range v.L {
+			clone.L[i] = cloneValue(e)
+		}
+	}
+	if v.M != nil {
+		clone.M = make(map[string]*dynamodb.AttributeValue, len(v.M))
+		for k, e := range v.M {
+			clone.M[k] = cloneValue(e)
+		}
+	}
+	if v.SS != nil {
+		clone.SS = append([]*string{}, v.SS...)
+	}
+	if v.NS != nil {
+		clone.NS = append([]*string{}, v.NS...)
+	}
+	if v.BS != nil {
+		clone.BS = append([][]byte{}, v.BS...)
+	}
+	return &clone
+}
@@ -0,0 +1,508 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// The SQL driver evaluates the DynamoDB expressions itself. The parser supports the subset of the expression syntax
+// the repositories use: the comparators, BETWEEN, IN, AND, OR, NOT, the attribute_exists, attribute_not_exists,
+// attribute_type, begins_with, contains and size functions, the SET, REMOVE, ADD and DELETE update actions with
+// the if_not_exists and list_append functions and the + and - operators, and the projection expressions.
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenName
+	tokenNamePlaceholder
+	tokenValuePlaceholder
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits the expression into tokens
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#' || c == ':':
+			j := i + 1
+			for j < len(expression) && isNameChar(expression[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("invalid expression: missing placeholder name at position %d", i)
+			}
+			kind := tokenNamePlaceholder
+			if c == ':' {
+				kind = tokenValuePlaceholder
+			}
+			tokens = append(tokens, token{kind: kind, text: expression[i:j]})
+			i = j
+		case isNameChar(c):
+			j := i
+			for j < len(expression) && isNameChar(expression[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenName, text: expression[i:j]})
+			i = j
+		case c == '<' || c == '>':
+			if i+1 < len(expression) && (expression[i+1] == '=' || (c == '<' && expression[i+1] == '>')) {
+				tokens = append(tokens, token{kind: tokenPunct, text: expression[i : i+2]})
+				i += 2
+			} else {
+				tokens = append(tokens, token{kind: tokenPunct, text: string(c)})
+				i++
+			}
+		case strings.IndexByte("()[],.=+-", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c)})
+			i++
+		default:
+			return nil, fmt.Errorf("invalid expression: unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// pathElement is an attribute name or a list index of an attribute path, e.g. a.b[2]
+type pathElement struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+type attributePath []pathElement
+
+// topLevel returns the name of the top level attribute of the path
+func (p attributePath) topLevel() string {
+	return p[0].name
+}
+
+// operand is an attribute path, a value placeholder, a function call or an arithmetic expression
+type operand struct {
+	path     attributePath
+	value    *dynamodb.AttributeValue
+	function string
+	args     []*operand
+	operator string
+}
+
+// condition is a node of a condition, filter or key condition expression
+type condition struct {
+	operator string
+	children []*condition
+	operands []*operand
+}
+
+// updateAction is a single SET, REMOVE, ADD or DELETE action of an update expression
+type updateAction struct {
+	action string
+	path   attributePath
+	value  *operand
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	names  map[string]*string
+	values map[string]*dynamodb.AttributeValue
+}
+
+func newParser(expression string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (*parser, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens, names: names, values: values}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the next token is the specified keyword, case insensitive
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenName && strings.EqualFold(t.text, keyword)
+}
+
+func (p *parser) isPunct(punct string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.text == punct
+}
+
+func (p *parser) expectPunct(punct string) error {
+	if !p.isPunct(punct) {
+		return fmt.Errorf("invalid expression: expected %q, found %q", punct, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expectEOF() error {
+	if p.peek().kind != tokenEOF {
+		return fmt.Errorf("invalid expression: unexpected token %q", p.peek().text)
+	}
+	return nil
+}
+
+// parseCondition parses a condition, filter or key condition expression
+func parseCondition(expression string, names map[string]*string, values map[string]*dynamodb.AttributeValue) (*condition, error) {
+	p, err := newParser(expression, names, values)
+	if err != nil {
+		return nil, err
+	}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	return c, p.expectEOF()
+}
+
+func (p *parser) parseOr() (*condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &condition{operator: "OR", children: []*condition{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (*condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &condition{operator: "AND", children: []*condition{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (*condition, error) {
+	if p.isKeyword("NOT") {
+		p.next()
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &condition{operator: "NOT", children: []*condition{child}}, nil
+	}
+	return p.parsePrimary()
+}
+
+// condition functions and the number of arguments they take
+var conditionFunctions = map[string]int{
+	"attribute_exists":     1,
+	"attribute_not_exists": 1,
+	"attribute_type":       2,
+	"begins_with":          2,
+	"contains":             2,
+}
+
+func (p *parser) parsePrimary() (*condition, error) {
+	if p.isPunct("(") {
+		p.next()
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return c, p.expectPunct(")")
+	}
+
+	t := p.peek()
+	if argCount, ok := conditionFunctions[strings.ToLower(t.text)]; ok && t.kind == tokenName && p.tokens[p.pos+1].text == "(" {
+		p.next()
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != argCount {
+			return nil, fmt.Errorf("invalid expression: %s expects %d arguments", t.text, argCount)
+		}
+		if args[0].path == nil {
+			return nil, fmt.Errorf("invalid expression: the first argument of %s must be an attribute path", t.text)
+		}
+		return &condition{operator: strings.ToLower(t.text), operands: args}, nil
+	}
+
+	left, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.isKeyword("BETWEEN"):
+		p.next()
+		low, err := p.parseOperand(false)
+		if err != nil {
+			return nil, err
+		}
+		if !p.isKeyword("AND") {
+			return nil, fmt.Errorf("invalid expression: expected AND in BETWEEN, found %q", p.peek().text)
+		}
+		p.next()
+		high, err := p.parseOperand(false)
+		if err != nil {
+			return nil, err
+		}
+		return &condition{operator: "BETWEEN", operands: []*operand{left, low, high}}, nil
+	case p.isKeyword("IN"):
+		p.next()
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		return &condition{operator: "IN", operands: append([]*operand{left}, args...)}, nil
+	}
+
+	comparator := p.next()
+	switch comparator.text {
+	case "=", "<>", "<", "<=", ">", ">=":
+	default:
+		return nil, fmt.Errorf("invalid expression: expected a comparator, found %q", comparator.text)
+	}
+	right, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+	return &condition{operator: comparator.text, operands: []*operand{left, right}}, nil
+}
+
+// parseArguments parses the parenthesized, comma separated list of operands
+func (p *parser) parseArguments() ([]*operand, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var args []*operand
+	for {
+		arg, err := p.parseOperand(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	return args, p.expectPunct(")")
+}
+
+// parseOperand parses an attribute path, a value placeholder or the size function - update expressions also
+// accept the if_not_exists and list_append functions and the + and - operators
+func (p *parser) parseOperand(update bool) (*operand, error) {
+	t := p.peek()
+	var result *operand
+	switch {
+	case t.kind == tokenValuePlaceholder:
+		p.next()
+		value, ok := p.values[t.text]
+		if !ok || value == nil {
+			return nil, fmt.Errorf("invalid expression: missing value for placeholder %s", t.text)
+		}
+		result = &operand{value: value}
+	case t.kind == tokenName && p.tokens[p.pos+1].text == "(":
+		function := strings.ToLower(t.text)
+		if function != "size" && (!update || (function != "if_not_exists" && function != "list_append")) {
+			return nil, fmt.Errorf("invalid expression: unsupported function %s", t.text)
+		}
+		p.next()
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		var args []*operand
+		for {
+			arg, err := p.parseOperand(update)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if !p.isPunct(",") {
+				break
+			}
+			p.next()
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		if (function == "size" && len(args) != 1) || (function != "size" && len(args) != 2) {
+			return nil, fmt.Errorf("invalid expression: wrong number of arguments for %s", t.text)
+		}
+		if (function == "size" || function == "if_not_exists") && args[0].path == nil {
+			return nil, fmt.Errorf("invalid expression: the first argument of %s must be an attribute path", t.text)
+		}
+		result = &operand{function: function, args: args}
+	default:
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		result = &operand{path: path}
+	}
+
+	if update && (p.isPunct("+") || p.isPunct("-")) {
+		operator := p.next().text
+		right, err := p.parseOperand(update)
+		if err != nil {
+			return nil, err
+		}
+		return &operand{operator: operator, args: []*operand{result, right}}, nil
+	}
+	return result, nil
+}
+
+// parsePath parses an attribute path, resolving the name placeholders
+func (p *parser) parsePath() (attributePath, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	path := attributePath{{name: name}}
+	for {
+		switch {
+		case p.isPunct("."):
+			p.next()
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, pathElement{name: name})
+		case p.isPunct("["):
+			p.next()
+			t := p.next()
+			index, err := strconv.Atoi(t.text)
+			if err != nil || t.kind != tokenName || index < 0 {
+				return nil, fmt.Errorf("invalid expression: invalid list index %q", t.text)
+			}
+			if err := p.expectPunct("]"); err != nil {
+				return nil, err
+			}
+			path = append(path, pathElement{index: index, isIndex: true})
+		default:
+			return path, nil
+		}
+	}
+}
+
+func (p *parser) parseName() (string, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNamePlaceholder:
+		name, ok := p.names[t.text]
+		if !ok || name == nil {
+			return "", fmt.Errorf("invalid expression: missing name for placeholder %s", t.text)
+		}
+		return *name, nil
+	case tokenName:
+		return t.text, nil
+	default:
+		return "", fmt.Errorf("invalid expression: expected an attribute name, found %q", t.text)
+	}
+}
+
+// parseUpdate parses an update expression into its actions
+func parseUpdate(expression string, names map[string]*string, values map[string]*dynamodb.AttributeValue) ([]*updateAction, error) {
+	p, err := newParser(expression, names, values)
+	if err != nil {
+		return nil, err
+	}
+	var actions []*updateAction
+	for p.peek().kind != tokenEOF {
+		clause := strings.ToUpper(p.next().text)
+		switch clause {
+		case "SET", "REMOVE", "ADD", "DELETE":
+		default:
+			return nil, fmt.Errorf("invalid update expression: expected SET, REMOVE, ADD or DELETE, found %q", clause)
+		}
+		for {
+			path, err := p.parsePath()
+			if err != nil {
+				return nil, err
+			}
+			action := &updateAction{action: clause, path: path}
+			switch clause {
+			case "SET":
+				if err = p.expectPunct("="); err != nil {
+					return nil, err
+				}
+				action.value, err = p.parseOperand(true)
+			case "ADD", "DELETE":
+				action.value, err = p.parseOperand(false)
+				if err == nil && action.value.value == nil {
+					err = fmt.Errorf("invalid update expression: %s expects a value placeholder", clause)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, action)
+			if !p.isPunct(",") {
+				break
+			}
+			p.next()
+		}
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("invalid update expression: no actions")
+	}
+	return actions, nil
+}
+
+// parseProjection parses a projection expression into its attribute paths
+func parseProjection(expression string, names map[string]*string) ([]attributePath, error) {
+	p, err := newParser(expression, names, nil)
+	if err != nil {
+		return nil, err
+	}
+	var paths []attributePath
+	for {
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	return paths, p.expectEOF()
+}
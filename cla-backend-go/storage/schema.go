@@ -0,0 +1,75 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"strings"
+)
+
+// keySchema is the primary key of a table
+type keySchema struct {
+	HashKey  string
+	RangeKey string
+}
+
+// tableKeySchemas are the primary keys of the tables, by the table name without the cla-<stage>- prefix. The SQL
+// driver needs the primary key to store the items - new tables must be added here.
+var tableKeySchemas = map[string]keySchema{
+	"ccla-whitelist-requests": {HashKey: "request_id"},
+	"cla-manager-requests":    {HashKey: "request_id"},
+	"cla-templates":           {HashKey: "template_id"},
+	"companies":               {HashKey: "company_id"},
+	"company-invites":         {HashKey: "company_invite_id"},
+	"events":                  {HashKey: "event_id"},
+	"gerrit-instances":        {HashKey: "gerrit_id"},
+	"legal-holds":             {HashKey: "reference_id"},
+	"privacy-erasures":        {HashKey: "erasure_id"},
+	"github-orgs":             {HashKey: "organization_name"},
+	"metrics":                 {HashKey: "metric_type", RangeKey: "id"},
+	"metrics-history":         {HashKey: "id", RangeKey: "date"},
+	"notification-templates":  {HashKey: "cla_group_id", RangeKey: "template_key"},
+	"pending-signatures":      {HashKey: "request_id"},
+	"projects":                {HashKey: "project_id"},
+	"projects-cla-groups":     {HashKey: "project_sfid"},
+	"repositories":            {HashKey: "repository_id"},
+	"resign-campaigns":        {HashKey: "campaign_id"},
+	"resign-signers":          {HashKey: "campaign_id", RangeKey: "signature_id"},
+	"retention-policies":      {HashKey: "foundation_sfid"},
+	"session-store":           {HashKey: "id"},
+	"signatures":              {HashKey: "signature_id"},
+	"store":                   {HashKey: "key"},
+	"user-permissions":        {HashKey: "username"},
+	"users":                   {HashKey: "user_id"},
+	"webhook-deliveries":      {HashKey: "delivery_id"},
+	"webhook-subscriptions":   {HashKey: "subscription_id"},
+}
+
+// indexRangeKeys are the sort keys of the global secondary indexes the repositories query without a sort key
+// condition but rely on the order of the results
+var indexRangeKeys = map[string]string{
+	"company-id-project-id-index":                         "project_id",
+	"company-sfid-foundation-sfid-event-time-epoch-index": "event_time_epoch",
+	"company-sfid-project-id-event-time-epoch-index":      "event_time_epoch",
+	"event-date-and-contains-pii-event-time-epoch-index":  "event_time_epoch",
+	"event-foundation-sfid-event-time-epoch-index":        "event_time_epoch",
+	"event-project-id-event-time-epoch-index":             "event_time_epoch",
+	"foundation-sfid-project-name-index":                  "project_name",
+	"metric-type-salesforce-id-index":                     "salesforce_id",
+	"project-sfid-organization-name-index":                "organization_name",
+	"project-sfid-repository-organization-name-index":     "repository_organization_name",
+	"status-next-attempt-at-index":                        "next_attempt_at",
+	"status-date-created-index":                           "date_created",
+}
+
+// keySchemaForTable returns the primary key of the table, matching the longest registered table name suffix
+func keySchemaForTable(tableName string) (keySchema, bool) {
+	var schema keySchema
+	var matched string
+	for name, s := range tableKeySchemas {
+		if (tableName == name || strings.HasSuffix(tableName, "-"+name)) && len(name) > len(matched) {
+			schema, matched = s, name
+		}
+	}
+	return schema, matched != ""
+}
@@ -0,0 +1,173 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+)
+
+// the session item attributes - the same layout as the sessions previously stored by the dynastore package, so the
+// existing sessions remain valid
+const (
+	sessionIDField      = "id"
+	sessionValuesField  = "values"
+	sessionOptionsField = "options"
+	sessionTTLField     = "ttl"
+)
+
+// errSessionNotFound is returned when the session is missing or expired
+var errSessionNotFound = errors.New("session not found")
+
+// SessionStore is a gorilla sessions store keeping the sessions in the session store table of the storage driver
+type SessionStore struct {
+	client    DynamoDBAPI
+	tableName string
+	options   sessions.Options
+}
+
+// NewSessionStore creates a new session store using the storage client
+func NewSessionStore(client DynamoDBAPI, tableName string, options sessions.Options) *SessionStore {
+	return &SessionStore{
+		client:    client,
+		tableName: tableName,
+		options:   options,
+	}
+}
+
+// Get returns the session of the request, cached in the request registry
+func (store *SessionStore) Get(req *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(req).Get(store, name)
+}
+
+// New loads the session of the session cookie, or creates a new session
+func (store *SessionStore) New(req *http.Request, name string) (*sessions.Session, error) {
+	if cookie, err := req.Cookie(name); err == nil {
+		session := sessions.NewSession(store, name)
+		if err = store.load(cookie.Value, session); err == nil {
+			return session, nil
+		}
+	}
+
+	session := sessions.NewSession(store, name)
+	session.ID = strings.TrimRight(base32.StdEncoding.EncodeToString(securecookie.GenerateRandomKey(32)), "=")
+	session.IsNew = true
+	options := store.options
+	session.Options = &options
+	return session, nil
+}
+
+// Save stores the session, and sets the session cookie of a new session. A session with a negative max age is deleted.
+func (store *SessionStore) Save(req *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	if err := store.save(session); err != nil {
+		return err
+	}
+
+	if session.Options != nil && session.Options.MaxAge < 0 {
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		_, err := store.client.DeleteItem(&dynamodb.DeleteItemInput{
+			TableName: aws.String(store.tableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				sessionIDField: {S: aws.String(session.ID)},
+			},
+		})
+		return err
+	}
+
+	if session.IsNew {
+		options := session.Options
+		if options == nil {
+			options = &store.options
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), session.ID, options))
+	}
+	return nil
+}
+
+// save stores the gob encoded session values
+func (store *SessionStore) save(session *sessions.Session) error {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(session.Values); err != nil {
+		return err
+	}
+
+	item := map[string]*dynamodb.AttributeValue{
+		sessionIDField:     {S: aws.String(session.ID)},
+		sessionValuesField: {S: aws.String(base64.StdEncoding.EncodeToString(buf.Bytes()))},
+	}
+	if session.Options != nil {
+		options, err := dynamodbattribute.Marshal(session.Options)
+		if err != nil {
+			return err
+		}
+		item[sessionOptionsField] = options
+		if session.Options.MaxAge > 0 {
+			expiresAt := time.Now().Add(time.Duration(session.Options.MaxAge) * time.Second)
+			item[sessionTTLField] = &dynamodb.AttributeValue{N: aws.String(strconv.FormatInt(expiresAt.Unix(), 10))}
+		}
+	}
+
+	_, err := store.client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(store.tableName),
+		Item:      item,
+	})
+	return err
+}
+
+// load reads the session, ignoring the expired sessions not yet removed by the table TTL
+func (store *SessionStore) load(id string, session *sessions.Session) error {
+	out, err := store.client.GetItem(&dynamodb.GetItemInput{
+		TableName:      aws.String(store.tableName),
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]*dynamodb.AttributeValue{
+			sessionIDField: {S: aws.String(id)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(out.Item) == 0 || out.Item[sessionValuesField] == nil || out.Item[sessionValuesField].S == nil {
+		return errSessionNotFound
+	}
+	if ttl, ok := out.Item[sessionTTLField]; ok && ttl.N != nil {
+		expiresAt, parseErr := strconv.ParseInt(*ttl.N, 10, 64)
+		if parseErr != nil || (expiresAt > 0 && expiresAt < time.Now().Unix()) {
+			return errSessionNotFound
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(*out.Item[sessionValuesField].S)
+	if err != nil {
+		return err
+	}
+	values := map[interface{}]interface{}{}
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+
+	session.ID = id
+	session.IsNew = false
+	session.Values = values
+	if av, ok := out.Item[sessionOptionsField]; ok {
+		options := &sessions.Options{}
+		if err = dynamodbattribute.Unmarshal(av, options); err != nil {
+			return err
+		}
+		session.Options = options
+	}
+	return nil
+}
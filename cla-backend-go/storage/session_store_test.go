@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/sessions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionStore(t *testing.T) {
+	store := NewSessionStore(newTestClient(t), "cla-test-session-store", sessions.Options{Path: "/", HttpOnly: true, MaxAge: 3600})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	session, err := store.Get(req, "test")
+	assert.Nil(t, err)
+	assert.True(t, session.IsNew)
+	session.Values["state"] = "abc"
+
+	rec := httptest.NewRecorder()
+	assert.Nil(t, store.Save(req, rec, session))
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, session.ID, cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+	}
+
+	// a new request with the session cookie loads the stored values
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	loaded, err := store.Get(req, "test")
+	assert.Nil(t, err)
+	assert.False(t, loaded.IsNew)
+	assert.Equal(t, session.ID, loaded.ID)
+	assert.Equal(t, "abc", loaded.Values["state"])
+
+	// a negative max age deletes the session
+	loaded.Options.MaxAge = -1
+	assert.Nil(t, store.Save(req, httptest.NewRecorder(), loaded))
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	deleted, err := store.Get(req, "test")
+	assert.Nil(t, err)
+	assert.True(t, deleted.IsNew)
+	assert.NotEqual(t, session.ID, deleted.ID)
+}
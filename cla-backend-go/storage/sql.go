@@ -0,0 +1,809 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCodeValidationException is the DynamoDB error code of an invalid request
+const ErrCodeValidationException = "ValidationException"
+
+// sqlClient stores the DynamoDB items in a SQL database - one table per DynamoDB table with the encoded primary key,
+// the encoded hash key and the item in the DynamoDB JSON format. The queries on the primary hash key are answered by
+// the database, the other queries, the scans and the expressions are evaluated in memory, which is fine for the
+// development and test data sets the driver is intended for. The writes are serialized, so the conditional writes
+// are consistent within a single backend process.
+type sqlClient struct {
+	db      *sqlx.DB
+	writeMu sync.Mutex
+	tables  sync.Map
+}
+
+// newSQLClient creates a new SQL backed DynamoDB client
+func newSQLClient(db *sqlx.DB) *sqlClient {
+	return &sqlClient{db: db}
+}
+
+// row is an item of the table with its encoded primary key
+type row struct {
+	key  string
+	item item
+}
+
+func validationError(err error) error {
+	return awserr.New(ErrCodeValidationException, err.Error(), err)
+}
+
+func conditionalCheckFailed() error {
+	return awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "The conditional request failed", nil)
+}
+
+// quoteIdentifier quotes the table or index name - the DynamoDB table names contain dashes
+func quoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// ensureTable creates the SQL table of the DynamoDB table if it does not exist yet
+func (c *sqlClient) ensureTable(tableName string) error {
+	if _, ok := c.tables.Load(tableName); ok {
+		return nil
+	}
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (item_key TEXT NOT NULL PRIMARY KEY, hash_key TEXT NOT NULL, item TEXT NOT NULL)",
+			quoteIdentifier(tableName)),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (hash_key)", quoteIdentifier(tableName+"-hash-key"), quoteIdentifier(tableName)),
+	}
+	for _, statement := range statements {
+		if _, err := c.db.Exec(statement); err != nil {
+			log.WithFields(logrus.Fields{"functionName": "ensureTable", "tableName": tableName}).Warnf("unable to create table, error: %+v", err)
+			return err
+		}
+	}
+	c.tables.Store(tableName, true)
+	return nil
+}
+
+// encodeItem returns the item in the DynamoDB JSON format
+func encodeItem(it item) (string, error) {
+	encoded, err := json.Marshal(it)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// decodeItem returns the item stored in the DynamoDB JSON format
+func decodeItem(encoded string) (item, error) {
+	var it item
+	if err := json.Unmarshal([]byte(encoded), &it); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// encodeKeyValue returns the string form of a key attribute value
+func encodeKeyValue(v *dynamodb.AttributeValue) (string, error) {
+	switch valueType(v) {
+	case typeString:
+		return "S:" + *v.S, nil
+	case typeNumber:
+		n, err := parseNumber(*v.N)
+		if err != nil {
+			return "", err
+		}
+		return "N:" + formatNumber(n), nil
+	case typeBinary:
+		b, err := json.Marshal(v.B)
+		if err != nil {
+			return "", err
+		}
+		return "B:" + string(b), nil
+	}
+	return "", errors.New("the key attributes must be of type string, number or binary")
+}
+
+// encodeKey returns the encoded primary key and hash key of the item or key attributes
+func encodeKey(schema keySchema, attributes item) (string, string, error) {
+	hash, err := encodeKeyValue(attributes[schema.HashKey])
+	if err != nil {
+		return "", "", fmt.Errorf("missing or invalid key attribute %s: %v", schema.HashKey, err)
+	}
+	if schema.RangeKey == "" {
+		return hash, hash, nil
+	}
+	rangeValue, err := encodeKeyValue(attributes[schema.RangeKey])
+	if err != nil {
+		return "", "", fmt.Errorf("missing or invalid key attribute %s: %v", schema.RangeKey, err)
+	}
+	key, err := json.Marshal([]string{hash, rangeValue})
+	if err != nil {
+		return "", "", err
+	}
+	return string(key), hash, nil
+}
+
+// tableSchema returns the primary key of the table and makes sure the SQL table exists
+func (c *sqlClient) tableSchema(tableName *string) (string, keySchema, error) {
+	name := aws.StringValue(tableName)
+	schema, ok := keySchemaForTable(name)
+	if !ok {
+		return "", schema, validationError(fmt.Errorf("no key schema registered for table %s", name))
+	}
+	return name, schema, c.ensureTable(name)
+}
+
+// loadItem returns the item with the primary key, or nil if not found
+func (c *sqlClient) loadItem(q sqlx.Queryer, tableName, key string) (item, error) {
+	var encoded string
+	err := q.QueryRowx(c.db.Rebind(fmt.Sprintf("SELECT item FROM %s WHERE item_key = ?", quoteIdentifier(tableName))), key).Scan(&encoded)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeItem(encoded)
+}
+
+// loadRows returns the items of the table, optionally only the items with the hash key, ordered by primary key
+func (c *sqlClient) loadRows(tableName, hashKey string) ([]row, error) {
+	query := fmt.Sprintf("SELECT item_key, item FROM %s", quoteIdentifier(tableName))
+	var args []interface{}
+	if hashKey != "" {
+		query += " WHERE hash_key = ?"
+		args = append(args, hashKey)
+	}
+	rows, err := c.db.Queryx(c.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // nolint
+
+	var result []row
+	for rows.Next() {
+		var key, encoded string
+		if err = rows.Scan(&key, &encoded); err != nil {
+			return nil, err
+		}
+		it, err := decodeItem(encoded)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row{key: key, item: it})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].key < result[j].key })
+	return result, nil
+}
+
+// write runs the read-modify-write function in a transaction - the function returns the new item to store, or nil
+// to delete the item
+func (c *sqlClient) write(tableName, key, hashKey string, fn func(existing item) (item, error)) (item, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	tx, err := c.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := c.writeItem(tx, tableName, key, hashKey, fn)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return existing, tx.Commit()
+}
+
+// writeItem runs the read-modify-write function within the transaction and returns the existing item
+func (c *sqlClient) writeItem(tx *sqlx.Tx, tableName, key, hashKey string, fn func(existing item) (item, error)) (item, error) {
+	existing, err := c.loadItem(tx, tableName, key)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fn(existing)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(c.db.Rebind(fmt.Sprintf("DELETE FROM %s WHERE item_key = ?", quoteIdentifier(tableName))), key); err != nil {
+		return nil, err
+	}
+	if updated != nil {
+		encoded, err := encodeItem(updated)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = tx.Exec(c.db.Rebind(fmt.Sprintf("INSERT INTO %s (item_key, hash_key, item) VALUES (?, ?, ?)", quoteIdentifier(tableName))),
+			key, hashKey, encoded); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// checkCondition evaluates the condition expression of a write against the existing item
+func checkCondition(existing item, expression *string, names map[string]*string, values map[string]*dynamodb.AttributeValue) error {
+	if aws.StringValue(expression) == "" {
+		return nil
+	}
+	c, err := parseCondition(*expression, names, values)
+	if err != nil {
+		return validationError(err)
+	}
+	if existing == nil {
+		existing = item{}
+	}
+	ok, err := evaluateCondition(existing, c)
+	if err != nil {
+		return validationError(err)
+	}
+	if !ok {
+		return conditionalCheckFailed()
+	}
+	return nil
+}
+
+// GetItem returns the item with the primary key
+func (c *sqlClient) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, _, err := encodeKey(schema, input.Key)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	it, err := c.loadItem(c.db, tableName, key)
+	if err != nil || it == nil {
+		return &dynamodb.GetItemOutput{}, err
+	}
+	if aws.StringValue(input.ProjectionExpression) != "" {
+		paths, err := parseProjection(*input.ProjectionExpression, input.ExpressionAttributeNames)
+		if err != nil {
+			return nil, validationError(err)
+		}
+		it = project(it, paths)
+	}
+	return &dynamodb.GetItemOutput{Item: it}, nil
+}
+
+// PutItem creates or replaces the item
+func (c *sqlClient) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, hashKey, err := encodeKey(schema, input.Item)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	existing, err := c.write(tableName, key, hashKey, func(existing item) (item, error) {
+		if err := checkCondition(existing, input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues); err != nil {
+			return nil, err
+		}
+		return cloneItem(input.Item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := &dynamodb.PutItemOutput{}
+	if aws.StringValue(input.ReturnValues) == dynamodb.ReturnValueAllOld && existing != nil {
+		output.Attributes = existing
+	}
+	return output, nil
+}
+
+// UpdateItem updates the attributes of the item, creating the item if it does not exist
+func (c *sqlClient) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, hashKey, err := encodeKey(schema, input.Key)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if aws.StringValue(input.UpdateExpression) == "" {
+		return nil, validationError(errors.New("the SQL storage driver requires an update expression"))
+	}
+	actions, err := parseUpdate(*input.UpdateExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	var updatedItem item
+	var updatedNames []string
+	existing, err := c.write(tableName, key, hashKey, func(existing item) (item, error) {
+		if err := checkCondition(existing, input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues); err != nil {
+			return nil, err
+		}
+		updatedItem, updatedNames, err = updateItem(existing, input.Key, actions)
+		return updatedItem, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &dynamodb.UpdateItemOutput{}
+	switch aws.StringValue(input.ReturnValues) {
+	case dynamodb.ReturnValueAllNew:
+		output.Attributes = updatedItem
+	case dynamodb.ReturnValueAllOld:
+		output.Attributes = existing
+	case dynamodb.ReturnValueUpdatedNew:
+		output.Attributes = selectAttributes(updatedItem, updatedNames)
+	case dynamodb.ReturnValueUpdatedOld:
+		output.Attributes = selectAttributes(existing, updatedNames)
+	}
+	return output, nil
+}
+
+// updateItem returns the existing item, or a new item with the key, with the update actions applied and the names of
+// the updated attributes
+func updateItem(existing item, key map[string]*dynamodb.AttributeValue, actions []*updateAction) (item, []string, error) {
+	updated := cloneItem(existing)
+	for name, v := range key {
+		updated[name] = cloneValue(v)
+	}
+	names, err := applyUpdate(updated, actions)
+	if err != nil {
+		return nil, nil, validationError(err)
+	}
+	return updated, names, nil
+}
+
+// selectAttributes returns the named attributes of the item
+func selectAttributes(it item, names []string) item {
+	selected := item{}
+	for _, name := range names {
+		if v, ok := it[name]; ok {
+			selected[name] = v
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
+
+// DeleteItem deletes the item
+func (c *sqlClient) DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, hashKey, err := encodeKey(schema, input.Key)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	existing, err := c.write(tableName, key, hashKey, func(existing item) (item, error) {
+		return nil, checkCondition(existing, input.ConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := &dynamodb.DeleteItemOutput{}
+	if aws.StringValue(input.ReturnValues) == dynamodb.ReturnValueAllOld && existing != nil {
+		output.Attributes = existing
+	}
+	return output, nil
+}
+
+// transactWrite is a write of a transaction with its table and encoded key
+type transactWrite struct {
+	tableName string
+	key       string
+	hashKey   string
+	fn        func(existing item) (item, error)
+}
+
+// transactionCanceled returns the error of a transaction canceled by the failed conditions
+func transactionCanceled(failed []bool) error {
+	reasons := make([]*dynamodb.CancellationReason, len(failed))
+	for i := range failed {
+		reasons[i] = &dynamodb.CancellationReason{Code: aws.String("None")}
+		if failed[i] {
+			reasons[i] = &dynamodb.CancellationReason{Code: aws.String("ConditionalCheckFailed"), Message: aws.String("The conditional request failed")}
+		}
+	}
+	return &dynamodb.TransactionCanceledException{
+		Message_:            aws.String("Transaction cancelled, please refer cancellation reasons for specific reasons"),
+		CancellationReasons: reasons,
+	}
+}
+
+// transactWriteItem returns the write of the transaction item
+func (c *sqlClient) transactWriteItem(ti *dynamodb.TransactWriteItem) (*transactWrite, error) {
+	var tableName *string
+	var keyAttributes map[string]*dynamodb.AttributeValue
+	var fn func(existing item) (item, error)
+	switch {
+	case ti.Put != nil:
+		in := ti.Put
+		tableName, keyAttributes = in.TableName, in.Item
+		fn = func(existing item) (item, error) {
+			if err := checkCondition(existing, in.ConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues); err != nil {
+				return nil, err
+			}
+			return cloneItem(in.Item), nil
+		}
+	case ti.Update != nil:
+		in := ti.Update
+		actions, err := parseUpdate(aws.StringValue(in.UpdateExpression), in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+		if err != nil {
+			return nil, validationError(err)
+		}
+		tableName, keyAttributes = in.TableName, in.Key
+		fn = func(existing item) (item, error) {
+			if err := checkCondition(existing, in.ConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues); err != nil {
+				return nil, err
+			}
+			updated, _, err := updateItem(existing, in.Key, actions)
+			return updated, err
+		}
+	case ti.Delete != nil:
+		in := ti.Delete
+		tableName, keyAttributes = in.TableName, in.Key
+		fn = func(existing item) (item, error) {
+			return nil, checkCondition(existing, in.ConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+		}
+	case ti.ConditionCheck != nil:
+		in := ti.ConditionCheck
+		tableName, keyAttributes = in.TableName, in.Key
+		fn = func(existing item) (item, error) {
+			return existing, checkCondition(existing, in.ConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+		}
+	default:
+		return nil, validationError(errors.New("empty transaction item"))
+	}
+
+	name, schema, err := c.tableSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	key, hashKey, err := encodeKey(schema, keyAttributes)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	return &transactWrite{tableName: name, key: key, hashKey: hashKey, fn: fn}, nil
+}
+
+// TransactWriteItems applies the writes all or none - the transaction is canceled if a condition fails
+func (c *sqlClient) TransactWriteItems(input *dynamodb.TransactWriteItemsInput) (*dynamodb.TransactWriteItemsOutput, error) {
+	writes := make([]*transactWrite, 0, len(input.TransactItems))
+	for _, ti := range input.TransactItems {
+		w, err := c.transactWriteItem(ti)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, w)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	tx, err := c.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	failed := make([]bool, len(writes))
+	canceled := false
+	for i, w := range writes {
+		_, err = c.writeItem(tx, w.tableName, w.key, w.hashKey, w.fn)
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			failed[i], canceled = true, true
+			continue
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	if canceled {
+		_ = tx.Rollback()
+		return nil, transactionCanceled(failed)
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, tx.Commit()
+}
+
+// DescribeTable returns the item count of the table
+func (c *sqlClient) DescribeTable(input *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	var count int64
+	if err = c.db.QueryRowx(fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(tableName))).Scan(&count); err != nil {
+		return nil, err
+	}
+	keys := []*dynamodb.KeySchemaElement{{AttributeName: aws.String(schema.HashKey), KeyType: aws.String(dynamodb.KeyTypeHash)}}
+	if schema.RangeKey != "" {
+		keys = append(keys, &dynamodb.KeySchemaElement{AttributeName: aws.String(schema.RangeKey), KeyType: aws.String(dynamodb.KeyTypeRange)})
+	}
+	return &dynamodb.DescribeTableOutput{
+		Table: &dynamodb.TableDescription{
+			TableName:   aws.String(tableName),
+			TableStatus: aws.String(dynamodb.TableStatusActive),
+			ItemCount:   aws.Int64(count),
+			KeySchema:   keys,
+		},
+	}, nil
+}
+
+// queryKey is the hash and sort key of a query
+type queryKey struct {
+	hashKey   string
+	hashValue *dynamodb.AttributeValue
+	rangeKey  string
+}
+
+// keyConditionKeys returns the hash key of the key condition - the first equality condition - and the sort key
+func keyConditionKeys(c *condition) queryKey {
+	var key queryKey
+	var conditions []*condition
+	var flatten func(*condition)
+	flatten = func(c *condition) {
+		if c.operator == "AND" {
+			flatten(c.children[0])
+			flatten(c.children[1])
+			return
+		}
+		conditions = append(conditions, c)
+	}
+	flatten(c)
+
+	for _, kc := range conditions {
+		if len(kc.operands) == 0 || kc.operands[0].path == nil {
+			continue
+		}
+		name := kc.operands[0].path.topLevel()
+		if key.hashKey == "" && kc.operator == "=" && kc.operands[1].value != nil {
+			key.hashKey, key.hashValue = name, kc.operands[1].value
+		} else if name != key.hashKey {
+			key.rangeKey = name
+		}
+	}
+	return key
+}
+
+// legacyKeyCondition converts the legacy KeyConditions parameter into a key condition
+func legacyKeyCondition(schema keySchema, conditions map[string]*dynamodb.Condition) (*condition, error) {
+	names := make([]string, 0, len(conditions))
+	for name := range conditions {
+		names = append(names, name)
+	}
+	// the hash key condition first, as the key condition expressions are written
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == schema.HashKey) != (names[j] == schema.HashKey) {
+			return names[i] == schema.HashKey
+		}
+		return names[i] < names[j]
+	})
+
+	var result *condition
+	for _, name := range names {
+		kc := conditions[name]
+		path := &operand{path: attributePath{{name: name}}}
+		var values []*operand
+		for _, v := range kc.AttributeValueList {
+			values = append(values, &operand{value: v})
+		}
+		operators := map[string]string{"EQ": "=", "LE": "<=", "LT": "<", "GE": ">=", "GT": ">"}
+		var c *condition
+		switch operator := aws.StringValue(kc.ComparisonOperator); {
+		case operators[operator] != "" && len(values) == 1:
+			c = &condition{operator: operators[operator], operands: []*operand{path, values[0]}}
+		case operator == "BEGINS_WITH" && len(values) == 1:
+			c = &condition{operator: "begins_with", operands: []*operand{path, values[0]}}
+		case operator == "BETWEEN" && len(values) == 2:
+			c = &condition{operator: "BETWEEN", operands: []*operand{path, values[0], values[1]}}
+		default:
+			return nil, fmt.Errorf("unsupported key condition %s on %s", operator, name)
+		}
+		if result == nil {
+			result = c
+		} else {
+			result = &condition{operator: "AND", children: []*condition{result, c}}
+		}
+	}
+	if result == nil {
+		return nil, errors.New("missing key condition")
+	}
+	return result, nil
+}
+
+// Query returns the items matching the key condition, ordered by the sort key of the table or index
+func (c *sqlClient) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyCondition *condition
+	if aws.StringValue(input.KeyConditionExpression) != "" {
+		keyCondition, err = parseCondition(*input.KeyConditionExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues)
+	} else {
+		keyCondition, err = legacyKeyCondition(schema, input.KeyConditions)
+	}
+	if err != nil {
+		return nil, validationError(err)
+	}
+	key := keyConditionKeys(keyCondition)
+	if key.hashKey == "" {
+		return nil, validationError(errors.New("the key condition must contain an equality condition on the hash key"))
+	}
+	indexName := aws.StringValue(input.IndexName)
+	sortKey := key.rangeKey
+	switch {
+	case sortKey != "":
+	case indexName != "":
+		sortKey = indexRangeKeys[indexName]
+	default:
+		sortKey = schema.RangeKey
+	}
+
+	// queries on the primary hash key are answered by the database
+	hashKey := ""
+	if indexName == "" && key.hashKey == schema.HashKey {
+		if hashKey, err = encodeKeyValue(key.hashValue); err != nil {
+			return nil, validationError(err)
+		}
+	}
+	rows, err := c.loadRows(tableName, hashKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []row
+	for _, r := range rows {
+		ok, err := evaluateCondition(r.item, keyCondition)
+		if err != nil {
+			return nil, validationError(err)
+		}
+		// like the sparse secondary indexes, the items without the index sort key are not part of the index
+		if ok && (sortKey == "" || r.item[sortKey] != nil) {
+			matched = append(matched, r)
+		}
+	}
+
+	forward := input.ScanIndexForward == nil || *input.ScanIndexForward
+	compare := func(a, b row) int {
+		if sortKey != "" {
+			if cmp, ok := compareValues(a.item[sortKey], b.item[sortKey]); ok && cmp != 0 {
+				return cmp
+			}
+		}
+		return strings.Compare(a.key, b.key)
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if forward {
+			return compare(matched[i], matched[j]) < 0
+		}
+		return compare(matched[i], matched[j]) > 0
+	})
+
+	if len(input.ExclusiveStartKey) > 0 {
+		startKey, _, err := encodeKey(schema, input.ExclusiveStartKey)
+		if err != nil {
+			return nil, validationError(fmt.Errorf("invalid exclusive start key: %v", err))
+		}
+		start := row{key: startKey, item: input.ExclusiveStartKey}
+		position := len(matched)
+		for i, r := range matched {
+			cmp := compare(r, start)
+			if (forward && cmp > 0) || (!forward && cmp < 0) {
+				position = i
+				break
+			}
+		}
+		matched = matched[position:]
+	}
+
+	lastKeyNames := []string{schema.HashKey, schema.RangeKey, key.hashKey, sortKey}
+	return c.queryOutput(matched, input.Limit, input.FilterExpression, input.ProjectionExpression, input.Select,
+		input.ExpressionAttributeNames, input.ExpressionAttributeValues, lastKeyNames)
+}
+
+// Scan returns the items of the table matching the filter, ordered by primary key
+func (c *sqlClient) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	tableName, schema, err := c.tableSchema(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.loadRows(tableName, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(input.ExclusiveStartKey) > 0 {
+		startKey, _, err := encodeKey(schema, input.ExclusiveStartKey)
+		if err != nil {
+			return nil, validationError(fmt.Errorf("invalid exclusive start key: %v", err))
+		}
+		position := sort.Search(len(rows), func(i int) bool { return rows[i].key > startKey })
+		rows = rows[position:]
+	}
+
+	output, err := c.queryOutput(rows, input.Limit, input.FilterExpression, input.ProjectionExpression, input.Select,
+		input.ExpressionAttributeNames, input.ExpressionAttributeValues, []string{schema.HashKey, schema.RangeKey})
+	if err != nil {
+		return nil, err
+	}
+	return &dynamodb.ScanOutput{
+		Count:            output.Count,
+		Items:            output.Items,
+		LastEvaluatedKey: output.LastEvaluatedKey,
+		ScannedCount:     output.ScannedCount,
+	}, nil
+}
+
+// queryOutput applies the limit, the filter and the projection to the ordered rows. Like DynamoDB, the limit is the
+// number of items evaluated before the filter is applied.
+func (c *sqlClient) queryOutput(rows []row, limit *int64, filterExpression, projectionExpression, selectValue *string,
+	names map[string]*string, values map[string]*dynamodb.AttributeValue, lastKeyNames []string) (*dynamodb.QueryOutput, error) {
+	var filter *condition
+	if aws.StringValue(filterExpression) != "" {
+		var err error
+		if filter, err = parseCondition(*filterExpression, names, values); err != nil {
+			return nil, validationError(err)
+		}
+	}
+	var paths []attributePath
+	if aws.StringValue(projectionExpression) != "" {
+		var err error
+		if paths, err = parseProjection(*projectionExpression, names); err != nil {
+			return nil, validationError(err)
+		}
+	}
+
+	output := &dynamodb.QueryOutput{}
+	evaluated := rows
+	if limit != nil && *limit > 0 && int64(len(rows)) > *limit {
+		evaluated = rows[:*limit]
+		last := evaluated[len(evaluated)-1].item
+		output.LastEvaluatedKey = item{}
+		for _, name := range lastKeyNames {
+			if v, ok := last[name]; ok && name != "" {
+				output.LastEvaluatedKey[name] = v
+			}
+		}
+	}
+
+	var count int64
+	for _, r := range evaluated {
+		if filter != nil {
+			ok, err := evaluateCondition(r.item, filter)
+			if err != nil {
+				return nil, validationError(err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		count++
+		if aws.StringValue(selectValue) != dynamodb.SelectCount {
+			output.Items = append(output.Items, project(r.item, paths))
+		}
+	}
+	if output.Items == nil && aws.StringValue(selectValue) != dynamodb.SelectCount {
+		output.Items = []map[string]*dynamodb.AttributeValue{}
+	}
+	output.Count = aws.Int64(count)
+	output.ScannedCount = aws.Int64(int64(len(evaluated)))
+	return output, nil
+}
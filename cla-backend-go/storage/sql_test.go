@@ -0,0 +1,174 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T) DynamoDBAPI {
+	db, err := Open(config.StorageDriverSQLite, ":memory:")
+	if err != nil {
+		t.Fatalf("unable to open the test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return NewSQLClient(db)
+}
+
+func putSignature(t *testing.T, client DynamoDBAPI, id, projectID string, epoch string) {
+	_, err := client.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("cla-test-signatures"),
+		Item: map[string]*dynamodb.AttributeValue{
+			"signature_id":         {S: aws.String(id)},
+			"signature_project_id": {S: aws.String(projectID)},
+			"date_modified":        {N: aws.String(epoch)},
+			"signature_signed":     {BOOL: aws.Bool(true)},
+		},
+	})
+	assert.Nil(t, err)
+}
+
+func TestSQLClientItems(t *testing.T) {
+	client := newTestClient(t)
+	putSignature(t, client, "s1", "p1", "10")
+
+	out, err := client.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("cla-test-signatures"),
+		Key:       map[string]*dynamodb.AttributeValue{"signature_id": {S: aws.String("s1")}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "p1", aws.StringValue(out.Item["signature_project_id"].S))
+	assert.True(t, aws.BoolValue(out.Item["signature_signed"].BOOL))
+
+	_, err = client.PutItem(&dynamodb.PutItemInput{
+		TableName:           aws.String("cla-test-signatures"),
+		Item:                map[string]*dynamodb.AttributeValue{"signature_id": {S: aws.String("s1")}},
+		ConditionExpression: aws.String("attribute_not_exists(signature_id)"),
+	})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, dynamodb.ErrCodeConditionalCheckFailedException, err.(awserr.Error).Code())
+	}
+
+	update, err := client.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 aws.String("cla-test-signatures"),
+		Key:                       map[string]*dynamodb.AttributeValue{"signature_id": {S: aws.String("s1")}},
+		UpdateExpression:          aws.String("SET #M = #M + :one, note = :note REMOVE signature_signed"),
+		ConditionExpression:       aws.String("signature_project_id = :p"),
+		ExpressionAttributeNames:  map[string]*string{"#M": aws.String("date_modified")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":one": {N: aws.String("1")}, ":note": {S: aws.String("n")}, ":p": {S: aws.String("p1")}},
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "11", aws.StringValue(update.Attributes["date_modified"].N))
+	assert.Equal(t, "n", aws.StringValue(update.Attributes["note"].S))
+	assert.Nil(t, update.Attributes["signature_signed"])
+
+	_, err = client.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String("cla-test-signatures"),
+		Key:       map[string]*dynamodb.AttributeValue{"signature_id": {S: aws.String("s1")}},
+	})
+	assert.Nil(t, err)
+	out, err = client.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("cla-test-signatures"),
+		Key:       map[string]*dynamodb.AttributeValue{"signature_id": {S: aws.String("s1")}},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, out.Item)
+}
+
+func TestSQLClientQuery(t *testing.T) {
+	client := newTestClient(t)
+	putSignature(t, client, "s1", "p1", "30")
+	putSignature(t, client, "s2", "p1", "10")
+	putSignature(t, client, "s3", "p2", "20")
+	putSignature(t, client, "s4", "p1", "20")
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String("cla-test-signatures"),
+		IndexName:                 aws.String("project-signature-date-index"),
+		KeyConditionExpression:    aws.String("signature_project_id = :p AND date_modified > :d"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":p": {S: aws.String("p1")}, ":d": {N: aws.String("5")}},
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int64(2),
+	}
+	var ids []string
+	for {
+		out, err := client.Query(input)
+		assert.Nil(t, err)
+		for _, it := range out.Items {
+			ids = append(ids, aws.StringValue(it["signature_id"].S))
+		}
+		if out.LastEvaluatedKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+	assert.Equal(t, []string{"s1", "s4", "s2"}, ids)
+
+	scan, err := client.Scan(&dynamodb.ScanInput{
+		TableName:                 aws.String("cla-test-signatures"),
+		FilterExpression:          aws.String("begins_with(signature_project_id, :p) AND date_modified BETWEEN :low AND :high"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":p": {S: aws.String("p")}, ":low": {N: aws.String("15")}, ":high": {N: aws.String("25")}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), aws.Int64Value(scan.Count))
+	assert.Equal(t, int64(4), aws.Int64Value(scan.ScannedCount))
+
+	table, err := client.DescribeTable(&dynamodb.DescribeTableInput{TableName: aws.String("cla-test-signatures")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), aws.Int64Value(table.Table.ItemCount))
+}
+
+func TestSQLClientTransactWriteItems(t *testing.T) {
+	client := newTestClient(t)
+	putSignature(t, client, "s1", "p1", "10")
+
+	key := func(id string) map[string]*dynamodb.AttributeValue {
+		return map[string]*dynamodb.AttributeValue{"signature_id": {S: aws.String(id)}}
+	}
+	write := func(condition string) error {
+		_, err := client.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+			TransactItems: []*dynamodb.TransactWriteItem{
+				{Update: &dynamodb.Update{
+					TableName:                 aws.String("cla-test-signatures"),
+					Key:                       key("s2"),
+					UpdateExpression:          aws.String("SET signature_project_id = :p"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":p": {S: aws.String("p2")}},
+				}},
+				{Delete: &dynamodb.Delete{
+					TableName:           aws.String("cla-test-signatures"),
+					Key:                 key("s1"),
+					ConditionExpression: aws.String(condition),
+				}},
+			},
+		})
+		return err
+	}
+	get := func(id string) map[string]*dynamodb.AttributeValue {
+		out, err := client.GetItem(&dynamodb.GetItemInput{TableName: aws.String("cla-test-signatures"), Key: key(id)})
+		assert.Nil(t, err)
+		return out.Item
+	}
+
+	// a failed condition cancels all the writes
+	err := write("attribute_not_exists(signature_id)")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, dynamodb.ErrCodeTransactionCanceledException, err.(awserr.Error).Code())
+		reasons := err.(*dynamodb.TransactionCanceledException).CancellationReasons
+		assert.Equal(t, "None", aws.StringValue(reasons[0].Code))
+		assert.Equal(t, "ConditionalCheckFailed", aws.StringValue(reasons[1].Code))
+	}
+	assert.Nil(t, get("s2"))
+	assert.NotNil(t, get("s1"))
+
+	assert.Nil(t, write("attribute_exists(signature_id)"))
+	assert.Equal(t, "p2", aws.StringValue(get("s2")["signature_project_id"].S))
+	assert.Nil(t, get("s1"))
+}
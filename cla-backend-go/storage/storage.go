@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/jmoiron/sqlx"
+
+	// the database drivers of the SQL storage
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// DynamoDBAPI is the subset of the DynamoDB API used by the repositories - it is implemented by the DynamoDB client
+// and by the SQL storage driver
+type DynamoDBAPI interface {
+	GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error)
+	PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error)
+	UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error)
+	DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error)
+	TransactWriteItems(input *dynamodb.TransactWriteItemsInput) (*dynamodb.TransactWriteItemsOutput, error)
+	Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error)
+	Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error)
+	DescribeTable(input *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error)
+}
+
+// the SQL storage client, nil when the repositories use DynamoDB
+var sqlStorage *sqlClient
+
+// Init configures the storage driver used by the repositories - it must be called before the repositories are created
+func Init(cfg config.Storage) error {
+	switch cfg.Driver {
+	case "", config.StorageDriverDynamoDB:
+		sqlStorage = nil
+		return nil
+	case config.StorageDriverSQLite, config.StorageDriverPostgres:
+		db, err := Open(cfg.Driver, cfg.DataSource)
+		if err != nil {
+			return err
+		}
+		log.Infof("Using the %s storage driver", cfg.Driver)
+		sqlStorage = newSQLClient(db)
+		return nil
+	default:
+		return fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
+
+// Open opens the SQL database of the storage driver
+func Open(driver, dataSource string) (*sqlx.DB, error) {
+	if dataSource == "" {
+		return nil, fmt.Errorf("missing data source for the %s storage driver", driver)
+	}
+	db, err := sqlx.Connect(driver, dataSource)
+	if err != nil {
+		return nil, err
+	}
+	if driver == config.StorageDriverSQLite {
+		// a single connection - the in-memory databases are per connection and the writes are serialized anyway
+		db.SetMaxOpenConns(1)
+	}
+	return db, nil
+}
+
+// NewClient returns the client of the configured storage driver
+func NewClient(awsSession *session.Session) DynamoDBAPI {
+	if sqlStorage != nil {
+		return sqlStorage
+	}
+	return dynamodb.New(awsSession)
+}
+
+// NewSQLClient returns a client storing the tables in the SQL database
+func NewSQLClient(db *sqlx.DB) DynamoDBAPI {
+	return newSQLClient(db)
+}
@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// InstrumentAWSSession records the latency and the throttles of the DynamoDB calls made by the clients created from
+// the session
+func InstrumentAWSSession(awsSession *session.Session) {
+	awsSession.Handlers.Retry.PushBackNamed(request.NamedHandler{
+		Name: "easycla.telemetry.DynamoDBThrottles",
+		Fn: func(r *request.Request) {
+			if r.ClientInfo.ServiceName == dynamodb.ServiceName && r.IsErrorThrottle() {
+				IncDynamoDBThrottle(requestTable(r), r.Operation.Name)
+			}
+		},
+	})
+	awsSession.Handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "easycla.telemetry.DynamoDBLatency",
+		Fn: func(r *request.Request) {
+			if r.ClientInfo.ServiceName == dynamodb.ServiceName {
+				ObserveDynamoDB(requestTable(r), r.Operation.Name, time.Since(r.Time))
+			}
+		},
+	})
+}
+
+// requestTable returns the TableName of the request input, if any - batch operations have none
+func requestTable(r *request.Request) string {
+	v := reflect.Indirect(reflect.ValueOf(r.Params))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("TableName")
+	if !f.IsValid() {
+		return ""
+	}
+	if table, ok := f.Interface().(*string); ok {
+		return aws.StringValue(table)
+	}
+	return ""
+}
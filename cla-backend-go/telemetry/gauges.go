@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"sort"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CountsFunc returns the current business counts by name
+type CountsFunc func() (map[string]int64, error)
+
+// countsCollector exposes the business counts as gauges, read when scraped
+type countsCollector struct {
+	counts CountsFunc
+}
+
+// RegisterBusinessGauges exposes each of the counts as an easycla_<name> gauge
+func RegisterBusinessGauges(counts CountsFunc) {
+	registry.MustRegister(&countsCollector{counts: counts})
+}
+
+// Describe sends no descriptors - the gauges are only known once the counts are read
+func (c *countsCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect reads the counts and sends them as gauges
+func (c *countsCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.counts()
+	if err != nil {
+		log.Warnf("unable to read the business counts for the metrics endpoint, error: %v", err)
+		return
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		desc := prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), "EasyCLA total count of "+name+".", nil, nil)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(counts[name]))
+	}
+}
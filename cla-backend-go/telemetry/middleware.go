@@ -0,0 +1,38 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// statusRecorder captures the status code written to the response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// RequestMiddleware records the count and latency of the requests by swagger operation. It must run after the
+// swagger routing, i.e. from the api Serve middleware builder, to see the matched operation.
+func RequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := middleware.MatchedRouteFrom(r)
+		if route == nil || route.Operation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		ObserveRequest(route.BasePath, route.Operation.ID, r.Method, recorder.status, time.Since(start))
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsPath is the path of the OpenMetrics endpoint
+const MetricsPath = "/metrics"
+
+const namespace = "easycla"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of API requests by swagger operation and response code.",
+	}, []string{"api", "operation", "method", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "API request latency by swagger operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"api", "operation", "method"})
+
+	dynamoDBDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dynamodb_request_duration_seconds",
+		Help:      "DynamoDB call latency, including retries, by table and operation.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"table", "operation"})
+
+	dynamoDBThrottles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dynamodb_throttles_total",
+		Help:      "Number of throttled DynamoDB call attempts by table and operation.",
+	}, []string{"table", "operation"})
+
+	githubRateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "github_rate_limit_remaining",
+		Help:      "Last observed number of GitHub API requests remaining in the rate limit window by client.",
+	}, []string{"client"})
+
+	githubRateLimited = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "github_rate_limited_total",
+		Help:      "Number of GitHub API calls rejected by the rate limit or the abuse detection.",
+	})
+
+	emailSendFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "email_send_failures_total",
+		Help:      "Number of emails which could not be sent by transport.",
+	}, []string{"transport"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		prometheus.NewGoCollector(),
+		requestsTotal,
+		requestDuration,
+		dynamoDBDuration,
+		dynamoDBThrottles,
+		githubRateLimitRemaining,
+		githubRateLimited,
+		emailSendFailures,
+	)
+}
+
+// Handler returns the OpenMetrics endpoint handler - the scrape requests must send the bearer token in the
+// Authorization header, the endpoint is unavailable when the token is not configured
+func Handler(bearerToken string) http.Handler {
+	h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+	expected := []byte("Bearer " + bearerToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if bearerToken == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// ObserveRequest records an API request of the swagger operation
+func ObserveRequest(api, operation, method string, code int, duration time.Duration) {
+	requestsTotal.WithLabelValues(api, operation, method, strconv.Itoa(code)).Inc()
+	requestDuration.WithLabelValues(api, operation, method).Observe(duration.Seconds())
+}
+
+// ObserveDynamoDB records a DynamoDB call
+func ObserveDynamoDB(table, operation string, duration time.Duration) {
+	dynamoDBDuration.WithLabelValues(table, operation).Observe(duration.Seconds())
+}
+
+// IncDynamoDBThrottle records a throttled DynamoDB call attempt
+func IncDynamoDBThrottle(table, operation string) {
+	dynamoDBThrottles.WithLabelValues(table, operation).Inc()
+}
+
+// SetGithubRateLimitRemaining records the remaining GitHub API requests reported to the client
+func SetGithubRateLimitRemaining(client string, remaining int) {
+	githubRateLimitRemaining.WithLabelValues(client).Set(float64(remaining))
+}
+
+// IncGithubRateLimited records a GitHub API call rejected by the rate limit
+func IncGithubRateLimited() {
+	githubRateLimited.Inc()
+}
+
+// IncEmailSendFailure records an email which could not be sent
+func IncEmailSendFailure(transport string) {
+	emailSendFailures.WithLabelValues(transport).Inc()
+}
@@ -0,0 +1,52 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package telemetry
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerExposesBusinessGauges(t *testing.T) {
+	RegisterBusinessGauges(func() (map[string]int64, error) {
+		return map[string]int64{"contributors_count": 42}, nil
+	})
+	IncEmailSendFailure("smtp")
+
+	server := httptest.NewServer(Handler("s3cret"))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp.Body.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "easycla_contributors_count 42")
+	assert.Contains(t, string(body), `easycla_email_send_failures_total{transport="smtp"} 1`)
+}
+
+func TestHandlerUnavailableWithoutConfiguredToken(t *testing.T) {
+	server := httptest.NewServer(Handler(""))
+	defer server.Close()
+
+	for _, authorization := range []string{"", "Bearer "} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Authorization", authorization)
+		resp, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		resp.Body.Close()
+	}
+}
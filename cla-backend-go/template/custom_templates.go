@@ -0,0 +1,215 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidTemplate is returned when an uploaded custom template fails validation
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// supported field types of the signature tabs
+var fieldTypes = map[string]bool{
+	"sign":          true,
+	"date":          true,
+	"text":          true,
+	"text_unlocked": true,
+	"text_optional": true,
+}
+
+var templateVariableRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// CreateCustomTemplate validates the custom template by rendering it and generating the PDFs, then stores it for
+// the foundation so that it can be used by the CLA Groups of the foundation
+func (s service) CreateCustomTemplate(ctx context.Context, foundationSFID, createdBy string, template models.Template) (models.Template, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateCustomTemplate",
+		"foundationSFID": foundationSFID,
+		"templateName":   template.Name,
+		"createdBy":      createdBy,
+	}
+
+	if err := validateCustomTemplate(template); err != nil {
+		log.WithFields(f).Warnf("custom template validation failed, error: %v", err)
+		return models.Template{}, err
+	}
+
+	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, sampleMetaFields(template.MetaFields))
+	if err != nil {
+		log.WithFields(f).Warnf("unable to render custom template, error: %v", err)
+		return models.Template{}, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	if err = checkFieldAnchors(claTypeICLA, iclaTemplateHTML, template.IclaFields); err != nil {
+		return models.Template{}, err
+	}
+	if err = checkFieldAnchors(claTypeCCLA, cclaTemplateHTML, template.CclaFields); err != nil {
+		return models.Template{}, err
+	}
+
+	for claType, html := range map[string]string{claTypeICLA: iclaTemplateHTML, claTypeCCLA: cclaTemplateHTML} {
+		if html == "" {
+			continue
+		}
+		log.WithFields(f).Debugf("Creating PDF for %s", claType)
+		if err = s.renderPDF(html); err != nil {
+			log.WithFields(f).Warnf("unable to generate the %s PDF of the custom template, error: %v", claType, err)
+			return models.Template{}, err
+		}
+	}
+
+	templateID, err := uuid.NewV4()
+	if err != nil {
+		return models.Template{}, err
+	}
+	dbModel := &DBTemplateModel{
+		TemplateID:     templateID.String(),
+		FoundationSFID: foundationSFID,
+		Name:           strings.TrimSpace(template.Name),
+		Description:    template.Description,
+		IclaHTMLBody:   template.IclaHTMLBody,
+		CclaHTMLBody:   template.CclaHTMLBody,
+		MetaFields:     template.MetaFields,
+		IclaFields:     template.IclaFields,
+		CclaFields:     template.CclaFields,
+		CreatedBy:      createdBy,
+	}
+	if err = s.templateRepo.CreateCustomTemplate(dbModel); err != nil {
+		return models.Template{}, err
+	}
+
+	return buildTemplateModel(*dbModel), nil
+}
+
+// renderPDF generates the PDF of the rendered template HTML and verifies that a PDF was returned
+func (s service) renderPDF(html string) error {
+	pdf, err := s.pdfRenderer.CreatePDF(html)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := pdf.Close()
+		if closeErr != nil {
+			log.Warnf("error closing PDF, error: %v", closeErr)
+		}
+	}()
+
+	b, err := ioutil.ReadAll(pdf)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(b, []byte("%PDF")) {
+		return fmt.Errorf("%w: the template HTML could not be converted to a PDF", ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// validateCustomTemplate verifies the name, meta fields and signature tab fields of the custom template
+func validateCustomTemplate(template models.Template) error {
+	if strings.TrimSpace(template.Name) == "" {
+		return fmt.Errorf("%w: the template name is required", ErrInvalidTemplate)
+	}
+	if strings.TrimSpace(template.IclaHTMLBody) == "" && strings.TrimSpace(template.CclaHTMLBody) == "" {
+		return fmt.Errorf("%w: an ICLA or CCLA HTML body is required", ErrInvalidTemplate)
+	}
+
+	names := map[string]bool{}
+	variables := map[string]bool{}
+	for _, metaField := range template.MetaFields {
+		if metaField == nil || strings.TrimSpace(metaField.Name) == "" {
+			return fmt.Errorf("%w: meta field name is required", ErrInvalidTemplate)
+		}
+		if !templateVariableRegex.MatchString(metaField.TemplateVariable) {
+			return fmt.Errorf("%w: meta field %s has an invalid template variable: '%s'", ErrInvalidTemplate, metaField.Name, metaField.TemplateVariable)
+		}
+		if names[metaField.Name] || variables[metaField.TemplateVariable] {
+			return fmt.Errorf("%w: meta field %s is declared more than once", ErrInvalidTemplate, metaField.Name)
+		}
+		names[metaField.Name] = true
+		variables[metaField.TemplateVariable] = true
+	}
+
+	if err := validateFields(claTypeICLA, template.IclaHTMLBody, template.IclaFields); err != nil {
+		return err
+	}
+	return validateFields(claTypeCCLA, template.CclaHTMLBody, template.CclaFields)
+}
+
+// validateFields verifies the signature tab fields of the ICLA or CCLA body - a body requires at least one sign field
+func validateFields(claType, htmlBody string, fields []*models.Field) error {
+	if strings.TrimSpace(htmlBody) == "" {
+		if len(fields) > 0 {
+			return fmt.Errorf("%w: %s fields provided without an %s HTML body", ErrInvalidTemplate, claType, claType)
+		}
+		return nil
+	}
+
+	ids := map[string]bool{}
+	hasSignField := false
+	for _, field := range fields {
+		if field == nil || field.ID == "" || field.Name == "" {
+			return fmt.Errorf("%w: %s field ID and name are required", ErrInvalidTemplate, claType)
+		}
+		if ids[field.ID] {
+			return fmt.Errorf("%w: %s field %s is declared more than once", ErrInvalidTemplate, claType, field.ID)
+		}
+		ids[field.ID] = true
+		if !fieldTypes[field.FieldType] {
+			return fmt.Errorf("%w: %s field %s has an unsupported field type: '%s'", ErrInvalidTemplate, claType, field.ID, field.FieldType)
+		}
+		if field.AnchorString == "" {
+			return fmt.Errorf("%w: %s field %s requires an anchor string", ErrInvalidTemplate, claType, field.ID)
+		}
+		if field.Width < 0 || field.Height < 0 {
+			return fmt.Errorf("%w: %s field %s has a negative width or height", ErrInvalidTemplate, claType, field.ID)
+		}
+		if field.FieldType == "sign" {
+			hasSignField = true
+		}
+	}
+	if !hasSignField {
+		return fmt.Errorf("%w: the %s fields require a sign field", ErrInvalidTemplate, claType)
+	}
+	return nil
+}
+
+// checkFieldAnchors verifies that the anchor string of each field is present in the rendered HTML, otherwise the
+// signature tab can not be positioned
+func checkFieldAnchors(claType, html string, fields []*models.Field) error {
+	for _, field := range fields {
+		if !strings.Contains(html, field.AnchorString) {
+			return fmt.Errorf("%w: the anchor string '%s' of %s field %s was not found in the rendered %s HTML",
+				ErrInvalidTemplate, field.AnchorString, claType, field.ID, claType)
+		}
+	}
+	return nil
+}
+
+// sampleMetaFields returns the meta field values used to validate the template - the provided value or a placeholder
+func sampleMetaFields(metaFields []*models.MetaField) []*models.MetaField {
+	samples := make([]*models.MetaField, 0, len(metaFields))
+	for _, metaField := range metaFields {
+		value := metaField.Value
+		if value == "" {
+			value = fmt.Sprintf("[%s]", metaField.Name)
+		}
+		samples = append(samples, &models.MetaField{
+			Name:             metaField.Name,
+			TemplateVariable: metaField.TemplateVariable,
+			Value:            value,
+		})
+	}
+	return samples
+}
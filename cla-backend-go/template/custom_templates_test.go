@@ -0,0 +1,53 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func customTemplate() models.Template {
+	return models.Template{
+		Name:         "Custom Template",
+		IclaHTMLBody: "<p>{{ PROJECT_NAME }} Individual CLA</p><p>Signature:</p><p>Date:</p>",
+		MetaFields: []*models.MetaField{
+			{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+		},
+		IclaFields: []*models.Field{
+			{ID: "sign", Name: "Signature", FieldType: "sign", AnchorString: "Signature:"},
+			{ID: "date", Name: "Date", FieldType: "date", AnchorString: "Date:"},
+		},
+	}
+}
+
+func TestValidateCustomTemplate(t *testing.T) {
+	assert.NoError(t, validateCustomTemplate(customTemplate()))
+
+	noSignField := customTemplate()
+	noSignField.IclaFields = noSignField.IclaFields[1:]
+	assert.True(t, errors.Is(validateCustomTemplate(noSignField), ErrInvalidTemplate))
+
+	badVariable := customTemplate()
+	badVariable.MetaFields[0].TemplateVariable = "project name"
+	assert.True(t, errors.Is(validateCustomTemplate(badVariable), ErrInvalidTemplate))
+
+	fieldsWithoutBody := customTemplate()
+	fieldsWithoutBody.CclaFields = fieldsWithoutBody.IclaFields
+	assert.True(t, errors.Is(validateCustomTemplate(fieldsWithoutBody), ErrInvalidTemplate))
+}
+
+func TestRenderedTemplateAnchors(t *testing.T) {
+	template := customTemplate()
+	iclaHTML, _, err := service{}.InjectProjectInformationIntoTemplate(template, sampleMetaFields(template.MetaFields))
+	assert.NoError(t, err)
+	assert.Contains(t, iclaHTML, "[Project Name] Individual CLA")
+	assert.NoError(t, checkFieldAnchors(claTypeICLA, iclaHTML, template.IclaFields))
+
+	template.IclaFields[1].AnchorString = "Signed on:"
+	assert.True(t, errors.Is(checkFieldAnchors(claTypeICLA, iclaHTML, template.IclaFields), ErrInvalidTemplate))
+}
@@ -18,7 +18,7 @@ func Configure(api *operations.ClaAPI, service Service, eventsService events.Ser
 	// Retrieve a list of available templates
 	api.TemplateGetTemplatesHandler = template.GetTemplatesHandlerFunc(func(params template.GetTemplatesParams, claUser *user.CLAUser) middleware.Responder {
 
-		templates, err := service.GetTemplates(params.HTTPRequest.Context())
+		templates, err := service.GetTemplates(params.HTTPRequest.Context(), "")
 		if err != nil {
 			return template.NewGetTemplatesBadRequest().WithPayload(errorResponse(err))
 		}
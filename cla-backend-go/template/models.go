@@ -3,11 +3,14 @@
 
 package template
 
+import "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+
 // DBProjectModel data model
 type DBProjectModel struct {
 	DateCreated                      string                   `dynamodbav:"date_created"`
 	DateModified                     string                   `dynamodbav:"date_modified"`
 	ProjectExternalID                string                   `dynamodbav:"project_external_id"`
+	FoundationSFID                   string                   `dynamodbav:"foundation_sfid"`
 	ProjectID                        string                   `dynamodbav:"project_id"`
 	ProjectName                      string                   `dynamodbav:"project_name"`
 	Version                          string                   `dynamodbav:"version"`
@@ -20,6 +23,22 @@ type DBProjectModel struct {
 	ProjectACL                       []string                 `dynamodbav:"project_acl"`
 }
 
+// DBTemplateModel is a data model for the custom CLA templates uploaded for a foundation
+type DBTemplateModel struct {
+	TemplateID     string              `dynamodbav:"template_id"`
+	FoundationSFID string              `dynamodbav:"foundation_sfid"`
+	Name           string              `dynamodbav:"name"`
+	Description    string              `dynamodbav:"description"`
+	IclaHTMLBody   string              `dynamodbav:"icla_html_body"`
+	CclaHTMLBody   string              `dynamodbav:"ccla_html_body"`
+	MetaFields     []*models.MetaField `dynamodbav:"meta_fields"`
+	IclaFields     []*models.Field     `dynamodbav:"icla_fields"`
+	CclaFields     []*models.Field     `dynamodbav:"ccla_fields"`
+	CreatedBy      string              `dynamodbav:"created_by"`
+	DateCreated    string              `dynamodbav:"date_created"`
+	DateModified   string              `dynamodbav:"date_modified"`
+}
+
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
 type DBProjectDocumentModel struct {
 	DocumentName            string `dynamodbav:"document_name"`
@@ -16,7 +16,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 var (
@@ -24,6 +26,11 @@ var (
 	ErrTemplateNotFound = errors.New("template not found")
 )
 
+// indexes
+const (
+	FoundationSFIDIndex = "foundation-sfid-index"
+)
+
 var (
 	// ApacheStyleTemplateID is template ID of the Apache Style
 	ApacheStyleTemplateID = "fb4cc144-a76c-4c17-8a52-c648f158fded"
@@ -31,16 +38,18 @@ var (
 
 // Repository interface functions
 type Repository interface {
-	GetTemplates() ([]models.Template, error)
+	GetTemplates(foundationSFID string) ([]models.Template, error)
 	GetTemplate(templateID string) (models.Template, error)
+	CreateCustomTemplate(template *DBTemplateModel) error
 	GetCLAGroup(claGroupID string) (*models.Project, error)
 	GetCLADocuments(claGroupID string, claType string) ([]models.ProjectDocument, error)
 	UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error
 }
 
 type repository struct {
-	stage          string // The AWS stage (dev, staging, prod)
-	dynamoDBClient *dynamodb.DynamoDB
+	stage              string // The AWS stage (dev, staging, prod)
+	dynamoDBClient     storage.DynamoDBAPI
+	templatesTableName string
 }
 
 // CLAGroup structure
@@ -94,13 +103,15 @@ type DocumentTab struct {
 // NewRepository creates a new instance of the repository service
 func NewRepository(awsSession *session.Session, stage string) repository {
 	return repository{
-		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
+		stage:              stage,
+		dynamoDBClient:     storage.NewClient(awsSession),
+		templatesTableName: fmt.Sprintf("cla-%s-cla-templates", stage),
 	}
 }
 
-// GetTemplates returns a list containing all the template models
-func (r repository) GetTemplates() ([]models.Template, error) {
+// GetTemplates returns a list containing all the built-in template models along with the custom templates of the
+// foundation, if provided
+func (r repository) GetTemplates(foundationSFID string) ([]models.Template, error) {
 	templates := []models.Template{}
 	for _, template := range templateMap {
 		// DEBUG
@@ -112,17 +123,123 @@ func (r repository) GetTemplates() ([]models.Template, error) {
 		}
 	}
 
-	return templates, nil
+	if foundationSFID == "" {
+		return templates, nil
+	}
+
+	customTemplates, err := r.getCustomTemplates(foundationSFID)
+	if err != nil {
+		return nil, err
+	}
+	return append(templates, customTemplates...), nil
 }
 
-// GetTemplate returns the template based on the template ID
+// GetTemplate returns the template based on the template ID - either a built-in or a custom template
 func (r repository) GetTemplate(templateID string) (models.Template, error) {
 	template, ok := templateMap[templateID]
-	if !ok {
+	if ok {
+		return template, nil
+	}
+
+	result, err := r.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"template_id": {S: aws.String(templateID)},
+		},
+		TableName: aws.String(r.templatesTableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching custom template: %s, error: %v", templateID, err)
+		return models.Template{}, err
+	}
+	if len(result.Item) == 0 {
 		return models.Template{}, ErrTemplateNotFound
 	}
 
-	return template, nil
+	var dbModel DBTemplateModel
+	err = dynamodbattribute.UnmarshalMap(result.Item, &dbModel)
+	if err != nil {
+		log.Warnf("error unmarshalling custom template, error: %v", err)
+		return models.Template{}, err
+	}
+	return buildTemplateModel(dbModel), nil
+}
+
+// CreateCustomTemplate stores the custom template of the foundation
+func (r repository) CreateCustomTemplate(template *DBTemplateModel) error {
+	currentTime := time.Now().UTC().Format(time.RFC3339)
+	template.DateCreated = currentTime
+	template.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(template)
+	if err != nil {
+		return err
+	}
+	_, err = r.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(r.templatesTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing custom template for foundation: %s, error: %v", template.FoundationSFID, err)
+		return err
+	}
+	return nil
+}
+
+// getCustomTemplates returns the custom templates uploaded for the foundation
+func (r repository) getCustomTemplates(foundationSFID string) ([]models.Template, error) {
+	condition := expression.Key("foundation_sfid").Equal(expression.Value(foundationSFID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(r.templatesTableName),
+		IndexName:                 aws.String(FoundationSFIDIndex),
+	}
+
+	var templates []models.Template
+	for {
+		results, errQuery := r.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching custom templates for foundation: %s, error: %v", foundationSFID, errQuery)
+			return nil, errQuery
+		}
+
+		var dbModels []DBTemplateModel
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &dbModels)
+		if err != nil {
+			log.Warnf("error unmarshalling custom templates, error: %v", err)
+			return nil, err
+		}
+		for _, dbModel := range dbModels {
+			templates = append(templates, buildTemplateModel(dbModel))
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return templates, nil
+}
+
+// buildTemplateModel maps the custom template database model to the template model
+func buildTemplateModel(dbModel DBTemplateModel) models.Template {
+	return models.Template{
+		ID:             dbModel.TemplateID,
+		FoundationSFID: dbModel.FoundationSFID,
+		Name:           dbModel.Name,
+		Description:    dbModel.Description,
+		IclaHTMLBody:   dbModel.IclaHTMLBody,
+		CclaHTMLBody:   dbModel.CclaHTMLBody,
+		MetaFields:     dbModel.MetaFields,
+		IclaFields:     dbModel.IclaFields,
+		CclaFields:     dbModel.CclaFields,
+	}
 }
 
 // GetCLAGroup This method belongs in the contractgroup package. We are leaving it here
@@ -215,6 +332,7 @@ func (r repository) buildProjectModel(dbModel DBProjectModel) *models.Project {
 	return &models.Project{
 		ProjectID:               dbModel.ProjectID,
 		ProjectExternalID:       dbModel.ProjectExternalID,
+		FoundationSFID:          dbModel.FoundationSFID,
 		ProjectName:             dbModel.ProjectName,
 		ProjectACL:              dbModel.ProjectACL,
 		ProjectCCLAEnabled:      dbModel.ProjectCclaEnabled,
@@ -16,8 +16,8 @@ import (
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
-	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/renderer"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -32,32 +32,33 @@ const (
 
 // Service interface
 type Service interface {
-	GetTemplates(ctx context.Context) ([]models.Template, error)
+	GetTemplates(ctx context.Context, foundationSFID string) ([]models.Template, error)
+	CreateCustomTemplate(ctx context.Context, foundationSFID, createdBy string, template models.Template) (models.Template, error)
 	CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error)
 	CreateTemplatePreview(claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
 	GetCLATemplatePreview(ctx context.Context, claGroupID, claType string, watermark bool) ([]byte, error)
 }
 
 type service struct {
-	stage           string // The AWS stage (dev, staging, prod)
-	templateRepo    Repository
-	docraptorClient docraptor.Client
-	s3Client        *s3manager.Uploader
+	stage        string // The AWS stage (dev, staging, prod)
+	templateRepo Repository
+	pdfRenderer  renderer.PDFRenderer
+	s3Client     *s3manager.Uploader
 }
 
 // NewService API call
-func NewService(stage string, templateRepo Repository, docraptorClient docraptor.Client, awsSession *session.Session) service {
+func NewService(stage string, templateRepo Repository, pdfRenderer renderer.PDFRenderer, awsSession *session.Session) service {
 	return service{
-		stage:           stage,
-		templateRepo:    templateRepo,
-		docraptorClient: docraptorClient,
-		s3Client:        s3manager.NewUploader(awsSession),
+		stage:        stage,
+		templateRepo: templateRepo,
+		pdfRenderer:  pdfRenderer,
+		s3Client:     s3manager.NewUploader(awsSession),
 	}
 }
 
-// GetTemplates API call
-func (s service) GetTemplates(ctx context.Context) ([]models.Template, error) {
-	templates, err := s.templateRepo.GetTemplates()
+// GetTemplates API call - returns the built-in templates along with the custom templates of the foundation, if provided
+func (s service) GetTemplates(ctx context.Context, foundationSFID string) ([]models.Template, error) {
+	templates, err := s.templateRepo.GetTemplates(foundationSFID)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +109,7 @@ func (s service) CreateTemplatePreview(claGroupFields *models.CreateClaGroupTemp
 	default:
 		return nil, errors.New("invalid value of template_for")
 	}
-	pdf, err := s.docraptorClient.CreatePDF(templateHTML)
+	pdf, err := s.pdfRenderer.CreatePDF(templateHTML)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +142,13 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		return models.TemplatePdfs{}, err
 	}
 
+	// Custom templates may only be used by the CLA Groups of the foundation which uploaded them
+	if template.FoundationSFID != "" && template.FoundationSFID != claGroup.FoundationSFID {
+		log.WithFields(f).Warnf("template: %s belongs to foundation: %s, not to the CLA Group foundation: %s - returning empty template PDFs",
+			claGroupFields.TemplateID, template.FoundationSFID, claGroup.FoundationSFID)
+		return models.TemplatePdfs{}, ErrTemplateNotFound
+	}
+
 	// Apply template fields
 	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, claGroupFields.MetaFields)
 	if err != nil {
@@ -163,10 +171,10 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		// Invoke the go routine - any errors will be handled below
 		eg.Go(func() error {
 			log.WithFields(f).Debugf("Creating PDF for %s", claTypeICLA)
-			iclaPdf, iclaErr := s.docraptorClient.CreatePDF(iclaTemplateHTML)
+			iclaPdf, iclaErr := s.pdfRenderer.CreatePDF(iclaTemplateHTML)
 			if iclaErr != nil {
-				log.WithFields(f).Warnf("Problem generating ICLA template via docraptor client, error: %v - returning empty template PDFs", err)
-				return err
+				log.WithFields(f).Warnf("Problem generating ICLA template via the PDF renderer, error: %v - returning empty template PDFs", iclaErr)
+				return iclaErr
 			}
 			defer func() {
 				closeErr := iclaPdf.Close()
@@ -190,10 +198,10 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		// Invoke the go routine - any errors will be handled below
 		eg.Go(func() error {
 			log.WithFields(f).Debugf("Creating PDF for %s", claTypeCCLA)
-			cclaPdf, cclaErr := s.docraptorClient.CreatePDF(cclaTemplateHTML)
+			cclaPdf, cclaErr := s.pdfRenderer.CreatePDF(cclaTemplateHTML)
 			if cclaErr != nil {
-				log.WithFields(f).Warnf("Problem generating CCLA template via docraptor client, error: %v - returning empty template PDFs", err)
-				return err
+				log.WithFields(f).Warnf("Problem generating CCLA template via the PDF renderer, error: %v - returning empty template PDFs", cclaErr)
+				return cclaErr
 			}
 			defer func() {
 				closeErr := cclaPdf.Close()
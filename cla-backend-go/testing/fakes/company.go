@@ -0,0 +1,530 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/go-openapi/strfmt"
+	"github.com/gofrs/uuid"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// CompanyRepository is an in-memory implementation of the company repository
+type CompanyRepository struct {
+	mu        sync.RWMutex
+	companies map[string]company.DBModel
+	invites   map[string]company.Invite
+}
+
+var _ company.IRepository = (*CompanyRepository)(nil)
+
+// NewCompanyRepository creates a new, empty in-memory company repository
+func NewCompanyRepository() *CompanyRepository {
+	return &CompanyRepository{
+		companies: map[string]company.DBModel{},
+		invites:   map[string]company.Invite{},
+	}
+}
+
+// AddCompany adds or replaces the company record
+func (repo *CompanyRepository) AddCompany(dbModel company.DBModel) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.companies[dbModel.CompanyID] = copyCompany(dbModel)
+}
+
+// AddInvite adds or replaces the company invite record
+func (repo *CompanyRepository) AddInvite(invite company.Invite) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.invites[invite.CompanyInviteID] = invite
+}
+
+// GetCompanies returns all the companies
+func (repo *CompanyRepository) GetCompanies(ctx context.Context) (*models.Companies, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	companies := repo.buildCompanyModels(0, func(dbModel company.DBModel) bool { return true })
+	return &models.Companies{
+		ResultCount: int64(len(companies)),
+		TotalCount:  int64(len(repo.companies)),
+		Companies:   companies,
+	}, nil
+}
+
+// GetCompanyByExternalID returns a company based on the company external ID
+func (repo *CompanyRepository) GetCompanyByExternalID(ctx context.Context, companySFID string) (*models.Company, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, companyID := range repo.companyIDs() {
+		dbModel := repo.companies[companyID]
+		if companySFID != "" && dbModel.CompanyExternalID == companySFID {
+			return toCompanyModel(projectCompany(dbModel))
+		}
+	}
+	return nil, company.ErrCompanyDoesNotExist
+}
+
+// GetCompanyByName returns the company with the name, nil if no company matches
+func (repo *CompanyRepository) GetCompanyByName(ctx context.Context, companyName string) (*models.Company, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	for _, companyID := range repo.companyIDs() {
+		dbModel := repo.companies[companyID]
+		if companyName != "" && dbModel.CompanyName == companyName {
+			return toCompanyModel(projectCompany(dbModel))
+		}
+	}
+	return nil, nil
+}
+
+// GetCompany returns a company based on the company ID
+func (repo *CompanyRepository) GetCompany(ctx context.Context, companyID string) (*models.Company, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getCompany(companyID)
+}
+
+// getCompany looks up the company, the caller holds the lock
+func (repo *CompanyRepository) getCompany(companyID string) (*models.Company, error) {
+	dbModel, ok := repo.companies[companyID]
+	if !ok {
+		return nil, company.ErrCompanyDoesNotExist
+	}
+	return toCompanyModel(dbModel)
+}
+
+// SearchCompanyByName returns the companies after the next key with a name containing the search term
+func (repo *CompanyRepository) SearchCompanyByName(ctx context.Context, companyName string, nextKey string) (*models.Companies, error) {
+	if strings.TrimSpace(companyName) == "" {
+		return &models.Companies{
+			Companies:   []models.Company{},
+			SearchTerms: companyName,
+		}, nil
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	var startKey *string
+	if nextKey != "" {
+		startKey = &nextKey
+	}
+	companies := repo.buildCompanyModels(startAfter(repo.companyIDs(), startKey), func(dbModel company.DBModel) bool {
+		return strings.Contains(dbModel.CompanyName, companyName)
+	})
+	return &models.Companies{
+		ResultCount: int64(len(companies)),
+		TotalCount:  int64(len(repo.companies)),
+		Companies:   companies,
+	}, nil
+}
+
+// DeleteCompanyByID deletes the company by ID
+func (repo *CompanyRepository) DeleteCompanyByID(ctx context.Context, companyID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	delete(repo.companies, companyID)
+	return nil
+}
+
+// DeleteCompanyBySFID fails like the DynamoDB repository - the company external ID is not the key of the table
+func (repo *CompanyRepository) DeleteCompanyBySFID(ctx context.Context, companySFID string) error {
+	return awserr.New(storage.ErrCodeValidationException,
+		"The provided key element does not match the schema", nil)
+}
+
+// GetCompaniesByUserManager returns the companies with the user in the ACL
+func (repo *CompanyRepository) GetCompaniesByUserManager(ctx context.Context, userID string, userModel user.User) (*models.Companies, error) {
+	if strings.TrimSpace(userID) == "" {
+		return &models.Companies{Companies: []models.Company{}}, nil
+	}
+
+	var userName string
+	if userModel.LFUsername != "" {
+		userName = userModel.LFUsername
+	} else if userModel.UserName != "" {
+		userName = userModel.UserName
+	} else {
+		return &models.Companies{Companies: []models.Company{}}, nil
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	companies := repo.buildCompanyModels(0, func(dbModel company.DBModel) bool {
+		return containsString(dbModel.CompanyACL, userName)
+	})
+	return &models.Companies{
+		ResultCount: int64(len(companies)),
+		TotalCount:  int64(len(repo.companies)),
+		Companies:   companies,
+	}, nil
+}
+
+// GetCompaniesByUserManagerWithInvites returns the companies with the user in the ACL and the companies the user
+// requested to join
+func (repo *CompanyRepository) GetCompaniesByUserManagerWithInvites(ctx context.Context, userID string, userModel user.User) (*models.CompaniesWithInvites, error) {
+	companies, err := repo.GetCompaniesByUserManager(ctx, userID, userModel)
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := repo.GetUserInviteRequests(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	companiesWithInvites := models.CompaniesWithInvites{
+		ResultCount: int64(len(companies.Companies) + len(invites)),
+		TotalCount:  companies.TotalCount + int64(len(invites)),
+	}
+
+	var companyWithInvite []models.CompanyWithInvite
+	for _, c := range companies.Companies {
+		companyWithInvite = append(companyWithInvite, models.CompanyWithInvite{
+			CompanyName:       c.CompanyName,
+			CompanyID:         c.CompanyID,
+			CompanyExternalID: c.CompanyExternalID,
+			CompanyACL:        c.CompanyACL,
+			Created:           c.Created,
+			Updated:           c.Updated,
+			Status:            "Joined",
+		})
+	}
+
+	for _, invite := range invites {
+		c, err := repo.GetCompany(ctx, invite.RequestedCompanyID)
+		if err != nil {
+			continue
+		}
+
+		if invite.Status == "" {
+			invite.Status = company.StatusPending
+		}
+
+		companyWithInvite = append(companyWithInvite, models.CompanyWithInvite{
+			CompanyName: c.CompanyName,
+			CompanyID:   c.CompanyID,
+			CompanyACL:  c.CompanyACL,
+			Created:     c.Created,
+			Updated:     c.Updated,
+			Status:      invite.Status,
+		})
+	}
+
+	companiesWithInvites.CompaniesWithInvites = companyWithInvite
+	return &companiesWithInvites, nil
+}
+
+// GetCompanyInviteRequest returns the specified request, nil if the invite does not exist
+func (repo *CompanyRepository) GetCompanyInviteRequest(ctx context.Context, companyInviteID string) (*company.Invite, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getCompanyInviteRequest(companyInviteID), nil
+}
+
+// getCompanyInviteRequest looks up the invite, the caller holds the lock
+func (repo *CompanyRepository) getCompanyInviteRequest(companyInviteID string) *company.Invite {
+	invite, ok := repo.invites[companyInviteID]
+	if !ok {
+		return nil
+	}
+	invite = projectInvite(invite)
+	return &invite
+}
+
+// GetCompanyInviteRequests returns the invites of the company, optionally filtered by status
+func (repo *CompanyRepository) GetCompanyInviteRequests(ctx context.Context, companyID string, status *string) ([]company.Invite, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.findInvites(func(invite company.Invite) bool {
+		return invite.RequestedCompanyID == companyID && (status == nil || invite.Status == *status)
+	}), nil
+}
+
+// GetCompanyUserInviteRequests returns the invite of the user for the company, nil if the user has no invite
+func (repo *CompanyRepository) GetCompanyUserInviteRequests(ctx context.Context, companyID string, userID string) (*company.Invite, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getCompanyUserInviteRequests(companyID, userID), nil
+}
+
+// getCompanyUserInviteRequests looks up the invite of the user, the caller holds the lock
+func (repo *CompanyRepository) getCompanyUserInviteRequests(companyID string, userID string) *company.Invite {
+	invites := repo.findInvites(func(invite company.Invite) bool {
+		return invite.RequestedCompanyID == companyID && invite.UserID == userID
+	})
+	if len(invites) == 0 {
+		return nil
+	}
+	return &invites[0]
+}
+
+// GetUserInviteRequests returns the invites of the user
+func (repo *CompanyRepository) GetUserInviteRequests(ctx context.Context, userID string) ([]company.Invite, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.findInvites(func(invite company.Invite) bool {
+		return invite.UserID == userID
+	}), nil
+}
+
+// AddPendingCompanyInviteRequest adds a pending company invite when provided the company ID and user ID - an existing
+// invite is returned instead, a rejected one is set back to pending
+func (repo *CompanyRepository) AddPendingCompanyInviteRequest(ctx context.Context, companyID string, userModel user.User) (*company.Invite, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previousInvite := repo.getCompanyUserInviteRequests(companyID, userModel.UserID)
+	if previousInvite != nil {
+		if previousInvite.Status == "rejected" {
+			repo.updateInviteRequestStatus(previousInvite.CompanyInviteID, "pending")
+		}
+		return previousInvite, nil
+	}
+
+	companyInviteID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	_, now := utils.CurrentTime()
+	repo.invites[companyInviteID.String()] = company.Invite{
+		CompanyInviteID:    companyInviteID.String(),
+		RequestedCompanyID: companyID,
+		UserID:             userModel.UserID,
+		Status:             "pending",
+		Created:            now,
+		Updated:            now,
+	}
+
+	return repo.getCompanyInviteRequest(companyInviteID.String()), nil
+}
+
+// ApproveCompanyAccessRequest approves the specified company invite
+func (repo *CompanyRepository) ApproveCompanyAccessRequest(ctx context.Context, companyInviteID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.updateInviteRequestStatus(companyInviteID, "approved")
+	return nil
+}
+
+// RejectCompanyAccessRequest rejects the specified company invite
+func (repo *CompanyRepository) RejectCompanyAccessRequest(ctx context.Context, companyInviteID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.updateInviteRequestStatus(companyInviteID, "rejected")
+	return nil
+}
+
+// updateInviteRequestStatus updates the status of the invite, missing invites are ignored, the caller holds the lock
+func (repo *CompanyRepository) updateInviteRequestStatus(companyInviteID, status string) {
+	invite, ok := repo.invites[companyInviteID]
+	if !ok {
+		return
+	}
+	_, now := utils.CurrentTime()
+	invite.Status = status
+	invite.Updated = now
+	repo.invites[companyInviteID] = invite
+}
+
+// UpdateCompanyAccessList updates the company ACL when provided the company ID and ACL list
+func (repo *CompanyRepository) UpdateCompanyAccessList(ctx context.Context, companyID string, companyACL []string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, now := utils.CurrentTime()
+	dbModel := repo.companies[companyID]
+	dbModel.CompanyID = companyID
+	dbModel.CompanyACL = copyStrings(companyACL)
+	dbModel.Updated = now
+	repo.companies[companyID] = dbModel
+	return nil
+}
+
+// CreateCompany creates a new company record
+func (repo *CompanyRepository) CreateCompany(ctx context.Context, in *models.Company) (*models.Company, error) {
+	companyID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	_, now := utils.CurrentTime()
+	dbModel := company.DBModel{
+		CompanyID:         companyID.String(),
+		CompanyName:       in.CompanyName,
+		CompanyExternalID: in.CompanyExternalID,
+		CompanyACL:        copyStrings(in.CompanyACL),
+		CompanyManagerID:  in.CompanyManagerID,
+		Created:           now,
+		Updated:           now,
+		Note:              in.Note,
+		Version:           "v1",
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.companies[dbModel.CompanyID] = dbModel
+	return toCompanyModel(dbModel)
+}
+
+// GetCompanyDomainVerifications returns the company record with the domain verification challenges and verified domains
+func (repo *CompanyRepository) GetCompanyDomainVerifications(ctx context.Context, companyID string) (*company.DBModel, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	dbModel, ok := repo.companies[companyID]
+	if !ok {
+		return nil, company.ErrCompanyDoesNotExist
+	}
+	dbModel = copyCompany(dbModel)
+	return &dbModel, nil
+}
+
+// UpdateCompanyDomainVerifications stores the domain verification challenges and verified domains of the company
+func (repo *CompanyRepository) UpdateCompanyDomainVerifications(ctx context.Context, companyID string, verifications []*company.DomainVerification, verifiedDomains []string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, now := utils.CurrentTime()
+	dbModel := repo.companies[companyID]
+	dbModel.CompanyID = companyID
+	dbModel.DomainVerifications = verifications
+	dbModel.VerifiedDomains = verifiedDomains
+	dbModel.Updated = now
+	repo.companies[companyID] = copyCompany(dbModel)
+	return nil
+}
+
+// buildCompanyModels returns the list models of the companies from the start position accepted by the match function,
+// nil when no company matches - like the scans, dates which fail to parse are set to the current time, the caller
+// holds the lock
+func (repo *CompanyRepository) buildCompanyModels(start int, match func(dbModel company.DBModel) bool) []models.Company {
+	now, _ := utils.CurrentTime()
+
+	var companies []models.Company
+	companyIDs := repo.companyIDs()
+	for _, companyID := range companyIDs[start:] {
+		dbModel := repo.companies[companyID]
+		if !match(dbModel) {
+			continue
+		}
+
+		createdDateTime, err := utils.ParseDateTime(dbModel.Created)
+		if err != nil {
+			createdDateTime = now
+		}
+		modifiedDateTime, err := utils.ParseDateTime(dbModel.Updated)
+		if err != nil {
+			modifiedDateTime = now
+		}
+
+		companies = append(companies, models.Company{
+			CompanyACL:        copyStrings(dbModel.CompanyACL),
+			CompanyID:         dbModel.CompanyID,
+			CompanyName:       dbModel.CompanyName,
+			CompanyExternalID: dbModel.CompanyExternalID,
+			Created:           strfmt.DateTime(createdDateTime),
+			Updated:           strfmt.DateTime(modifiedDateTime),
+		})
+	}
+	return companies
+}
+
+// findInvites returns the invites in primary key order accepted by the match function, the caller holds the lock
+func (repo *CompanyRepository) findInvites(match func(invite company.Invite) bool) []company.Invite {
+	keys := make([]string, 0, len(repo.invites))
+	for key := range repo.invites {
+		keys = append(keys, key)
+	}
+
+	var invites []company.Invite
+	for _, key := range sortedKeys(keys) {
+		if match(repo.invites[key]) {
+			invites = append(invites, projectInvite(repo.invites[key]))
+		}
+	}
+	return invites
+}
+
+// companyIDs returns the ordered company IDs, the caller holds the lock
+func (repo *CompanyRepository) companyIDs() []string {
+	keys := make([]string, 0, len(repo.companies))
+	for key := range repo.companies {
+		keys = append(keys, key)
+	}
+	return sortedKeys(keys)
+}
+
+// projectCompany returns the company with the attributes of the company projection of the queries
+func projectCompany(dbModel company.DBModel) company.DBModel {
+	dbModel.VerifiedDomains = nil
+	dbModel.DomainVerifications = nil
+	return dbModel
+}
+
+// projectInvite returns the invite with the attributes of the invite projection of the queries
+func projectInvite(invite company.Invite) company.Invite {
+	invite.Note = ""
+	return invite
+}
+
+// copyCompany returns a copy of the company which shares no lists with the original
+func copyCompany(dbModel company.DBModel) company.DBModel {
+	dbModel.CompanyACL = copyStrings(dbModel.CompanyACL)
+	dbModel.VerifiedDomains = copyStrings(dbModel.VerifiedDomains)
+	if len(dbModel.DomainVerifications) > 0 {
+		verifications := make([]*company.DomainVerification, 0, len(dbModel.DomainVerifications))
+		for _, verification := range dbModel.DomainVerifications {
+			if verification == nil {
+				continue
+			}
+			v := *verification
+			verifications = append(verifications, &v)
+		}
+		dbModel.DomainVerifications = verifications
+	} else {
+		dbModel.DomainVerifications = nil
+	}
+	return dbModel
+}
+
+// toCompanyModel converts the database model into a service response model
+func toCompanyModel(dbModel company.DBModel) (*models.Company, error) {
+	createdDateTime, err := utils.ParseDateTime(dbModel.Created)
+	if err != nil {
+		return nil, err
+	}
+	updateDateTime, err := utils.ParseDateTime(dbModel.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Company{
+		CompanyACL:        copyStrings(dbModel.CompanyACL),
+		CompanyID:         dbModel.CompanyID,
+		CompanyName:       dbModel.CompanyName,
+		CompanyExternalID: dbModel.CompanyExternalID,
+		CompanyManagerID:  dbModel.CompanyManagerID,
+		Created:           strfmt.DateTime(createdDateTime),
+		Updated:           strfmt.DateTime(updateDateTime),
+		Note:              dbModel.Note,
+		Version:           dbModel.Version,
+		VerifiedDomains:   copyStrings(dbModel.VerifiedDomains),
+	}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyRepositoryConformance(t *testing.T) {
+	t.Run("fake", func(t *testing.T) {
+		repo := NewCompanyRepository()
+		testCompanyRepository(t, repo, repo.AddCompany, repo.AddInvite)
+	})
+	for _, target := range conformanceTargets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			awsSession, stage := target.newStage(t)
+			testCompanyRepository(t, company.NewRepository(awsSession, stage), func(dbModel company.DBModel) {
+				putItem(t, awsSession, stage, "companies", dbModel)
+			}, func(invite company.Invite) {
+				putItem(t, awsSession, stage, "company-invites", invite)
+			})
+		})
+	}
+}
+
+func testCompanyRepository(t *testing.T, repo company.IRepository, addCompany func(dbModel company.DBModel), addInvite func(invite company.Invite)) {
+	ctx := context.Background()
+	_, now := utils.CurrentTime()
+	addCompany(company.DBModel{CompanyID: "c1", CompanyName: "Acme Corp", CompanyExternalID: "sf-c1",
+		CompanyACL: []string{"alice"}, Created: now, Updated: now})
+	addCompany(company.DBModel{CompanyID: "c2", CompanyName: "Globex", CompanyExternalID: "sf-c2",
+		CompanyACL: []string{"bob", "alice"}, Created: now, Updated: now})
+	addInvite(company.Invite{CompanyInviteID: "i1", RequestedCompanyID: "c1", UserID: "u1", Status: "pending", Created: now, Updated: now})
+	addInvite(company.Invite{CompanyInviteID: "i2", RequestedCompanyID: "c1", UserID: "u2", Status: "rejected", Created: now, Updated: now})
+
+	companyModel, err := repo.GetCompany(ctx, "c1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Acme Corp", companyModel.CompanyName)
+		assert.Equal(t, []string{"alice"}, companyModel.CompanyACL)
+	}
+	_, err = repo.GetCompany(ctx, "missing")
+	assert.Equal(t, company.ErrCompanyDoesNotExist, err)
+
+	companyModel, err = repo.GetCompanyByExternalID(ctx, "sf-c2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "c2", companyModel.CompanyID)
+	}
+
+	companies, err := repo.SearchCompanyByName(ctx, "Acme", "")
+	if assert.NoError(t, err) && assert.Len(t, companies.Companies, 1) {
+		assert.Equal(t, "c1", companies.Companies[0].CompanyID)
+	}
+
+	companies, err = repo.GetCompaniesByUserManager(ctx, "u1", user.User{UserID: "u1", LFUsername: "alice"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), companies.ResultCount)
+	}
+
+	invites, err := repo.GetCompanyInviteRequests(ctx, "c1", nil)
+	assert.NoError(t, err)
+	assert.Len(t, invites, 2)
+
+	assert.NoError(t, repo.ApproveCompanyAccessRequest(ctx, "i1"))
+	invite, err := repo.GetCompanyInviteRequest(ctx, "i1")
+	if assert.NoError(t, err) && assert.NotNil(t, invite) {
+		assert.Equal(t, "approved", invite.Status)
+	}
+
+	invite, err = repo.AddPendingCompanyInviteRequest(ctx, "c1", user.User{UserID: "u2"})
+	if assert.NoError(t, err) && assert.NotNil(t, invite) {
+		assert.Equal(t, "i2", invite.CompanyInviteID)
+	}
+	invite, err = repo.GetCompanyUserInviteRequests(ctx, "c1", "u2")
+	if assert.NoError(t, err) && assert.NotNil(t, invite) {
+		assert.Equal(t, "pending", invite.Status)
+	}
+
+	assert.Error(t, repo.DeleteCompanyBySFID(ctx, "sf-c1"))
+	assert.NoError(t, repo.DeleteCompanyByID(ctx, "c1"))
+	_, err = repo.GetCompany(ctx, "c1")
+	assert.Equal(t, company.ErrCompanyDoesNotExist, err)
+}
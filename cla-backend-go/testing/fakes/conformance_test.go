@@ -0,0 +1,205 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+)
+
+// DynamoDBLocalEndpointEnv is the environment variable holding the endpoint of the DynamoDB Local instance the
+// conformance tests run the DynamoDB repositories against, e.g. http://localhost:8000
+const DynamoDBLocalEndpointEnv = "DYNAMODB_LOCAL_ENDPOINT"
+
+var stageCounter int64
+
+// conformanceTarget is a storage the DynamoDB repositories are run against by the conformance tests
+type conformanceTarget struct {
+	name     string
+	newStage func(t *testing.T) (*session.Session, string)
+}
+
+// conformanceTargets are the storages of the conformance tests, the fakes must behave like the repositories on each
+var conformanceTargets = []conformanceTarget{
+	{name: "sqlite-emulator", newStage: newSQLiteStage},
+	{name: "dynamodb-local", newStage: newDynamoDBLocalStage},
+}
+
+// skipTarget skips the test of a target which can not run - on CI the test fails instead, an unavailable target must
+// not turn the conformance tests into a silent pass
+func skipTarget(t *testing.T, format string, args ...interface{}) {
+	if os.Getenv("CI") != "" {
+		t.Fatalf(format, args...)
+	}
+	t.Skipf(format, args...)
+}
+
+// nextStage returns a stage of its own for the tables of the test
+func nextStage() string {
+	return fmt.Sprintf("conformance%d", atomic.AddInt64(&stageCounter, 1))
+}
+
+// newSQLiteStage returns a session and a stage of its own for the DynamoDB repositories of the test - the
+// repositories run on the in-memory SQLite storage driver, which needs cgo
+func newSQLiteStage(t *testing.T) (*session.Session, string) {
+	if err := storage.Init(config.Storage{Driver: config.StorageDriverSQLite, DataSource: ":memory:"}); err != nil {
+		skipTarget(t, "the SQLite storage driver is not available: %v", err)
+	}
+
+	awsSession := session.Must(session.NewSession(&aws.Config{Region: aws.String("us-east-1")}))
+	return awsSession, nextStage()
+}
+
+// newDynamoDBLocalStage returns a session and a stage of its own for the DynamoDB repositories of the test - the
+// repositories run on the DynamoDB Local instance of DYNAMODB_LOCAL_ENDPOINT, the tables of the stage are created
+func newDynamoDBLocalStage(t *testing.T) (*session.Session, string) {
+	endpoint := os.Getenv(DynamoDBLocalEndpointEnv)
+	if endpoint == "" {
+		skipTarget(t, "%s is not set, DynamoDB Local is not available", DynamoDBLocalEndpointEnv)
+	}
+	if err := storage.Init(config.Storage{Driver: config.StorageDriverDynamoDB}); err != nil {
+		t.Fatalf("unable to select the DynamoDB storage driver: %v", err)
+	}
+
+	awsSession := session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(endpoint),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("conformance", "conformance", ""),
+	}))
+	stage := nextStage()
+	client := dynamodb.New(awsSession)
+	for table, schema := range conformanceTables {
+		createTable(t, client, fmt.Sprintf("cla-%s-%s", stage, table), schema)
+	}
+	return awsSession, stage
+}
+
+// tableSchema is the primary key and the global secondary indexes of a table, the keys are string attributes
+type tableSchema struct {
+	hashKey  string
+	rangeKey string
+	indexes  map[string]tableSchema
+}
+
+// conformanceTables are the tables the repositories under test use, by the table name without the cla-<stage>-
+// prefix, with the indexes the repositories query
+var conformanceTables = map[string]tableSchema{
+	"companies": {hashKey: "company_id", indexes: map[string]tableSchema{
+		"company-name-index":     {hashKey: "company_name"},
+		"external-company-index": {hashKey: "company_external_id"},
+	}},
+	"company-invites": {hashKey: "company_invite_id", indexes: map[string]tableSchema{
+		"requested-company-index": {hashKey: "requested_company_id"},
+	}},
+	"gerrit-instances": {hashKey: "gerrit_id", indexes: map[string]tableSchema{
+		"gerrit-name-index": {hashKey: "gerrit_name"},
+		"gerrit-id-index":   {hashKey: "group_id_icla"},
+	}},
+	"projects": {hashKey: "project_id"},
+	"projects-cla-groups": {hashKey: "project_sfid", indexes: map[string]tableSchema{
+		"cla-group-id-index":    {hashKey: "cla_group_id"},
+		"foundation-sfid-index": {hashKey: "foundation_sfid"},
+	}},
+	"repositories": {hashKey: "repository_id", indexes: map[string]tableSchema{
+		"project-repository-index":                        {hashKey: "repository_project_id"},
+		"sfdc-repository-index":                           {hashKey: "repository_sfdc_id"},
+		"external-repository-index":                       {hashKey: "repository_external_id"},
+		"project-sfid-repository-organization-name-index": {hashKey: "project_sfid", rangeKey: "repository_organization_name"},
+	}},
+	"signatures": {hashKey: "signature_id", indexes: map[string]tableSchema{
+		"project-signature-index":                               {hashKey: "signature_project_id"},
+		"reference-signature-index":                             {hashKey: "signature_reference_id"},
+		"reference-signature-search-index":                      {hashKey: "signature_project_id", rangeKey: "signature_reference_name_lower"},
+		"signature-project-reference-index":                     {hashKey: "signature_project_id", rangeKey: "signature_reference_id"},
+		"signature-project-id-type-index":                       {hashKey: "signature_project_id", rangeKey: "signature_type"},
+		"signature-project-id-sigtype-signed-approved-id-index": {hashKey: "signature_project_id", rangeKey: "sigtype_signed_approved_id"},
+		"signature-user-ccla-company-index":                     {hashKey: "signature_user_ccla_company_id", rangeKey: "signature_project_id"},
+	}},
+	"users": {hashKey: "user_id", indexes: map[string]tableSchema{
+		"github-user-index":     {hashKey: "user_github_id"},
+		"github-username-index": {hashKey: "user_github_username"},
+		"lf-username-index":     {hashKey: "lf_username"},
+		"lf-email-index":        {hashKey: "lf_email"},
+	}},
+}
+
+// keySchema returns the key schema elements of the hash and range key
+func (s tableSchema) keySchema() []*dynamodb.KeySchemaElement {
+	keys := []*dynamodb.KeySchemaElement{{AttributeName: aws.String(s.hashKey), KeyType: aws.String(dynamodb.KeyTypeHash)}}
+	if s.rangeKey != "" {
+		keys = append(keys, &dynamodb.KeySchemaElement{AttributeName: aws.String(s.rangeKey), KeyType: aws.String(dynamodb.KeyTypeRange)})
+	}
+	return keys
+}
+
+// createTable creates the table with its indexes and waits until it is active
+func createTable(t *testing.T, client *dynamodb.DynamoDB, tableName string, schema tableSchema) {
+	attributes := map[string]bool{}
+	addAttributes := func(s tableSchema) {
+		attributes[s.hashKey] = true
+		if s.rangeKey != "" {
+			attributes[s.rangeKey] = true
+		}
+	}
+	addAttributes(schema)
+
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(tableName),
+		KeySchema:   schema.keySchema(),
+		BillingMode: aws.String(dynamodb.BillingModePayPerRequest),
+	}
+	for indexName, index := range schema.indexes {
+		addAttributes(index)
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, &dynamodb.GlobalSecondaryIndex{
+			IndexName:  aws.String(indexName),
+			KeySchema:  index.keySchema(),
+			Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeAll)},
+		})
+	}
+	for name := range attributes {
+		input.AttributeDefinitions = append(input.AttributeDefinitions, &dynamodb.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: aws.String(dynamodb.ScalarAttributeTypeS),
+		})
+	}
+
+	if _, err := client.CreateTable(input); err != nil {
+		t.Fatalf("unable to create the table %s on DynamoDB Local: %v", tableName, err)
+	}
+	if err := client.WaitUntilTableExists(&dynamodb.DescribeTableInput{TableName: aws.String(tableName)}); err != nil {
+		t.Fatalf("the table %s of DynamoDB Local did not become active: %v", tableName, err)
+	}
+}
+
+// putItem stores the database model in the table of the stage - like the records written by the repositories, the
+// empty attributes are left out
+func putItem(t *testing.T, awsSession *session.Session, stage, table string, dbModel interface{}) {
+	item, err := dynamodbattribute.MarshalMap(dbModel)
+	if err != nil {
+		t.Fatalf("unable to marshal the %s record: %v", table, err)
+	}
+	for name, value := range item {
+		if aws.BoolValue(value.NULL) {
+			delete(item, name)
+		}
+	}
+
+	_, err = storage.NewClient(awsSession).PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(fmt.Sprintf("cla-%s-%s", stage, table)),
+		Item:      item,
+	})
+	if err != nil {
+		t.Fatalf("unable to store the %s record: %v", table, err)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+// Package fakes provides thread-safe in-memory implementations of the repository interfaces for the unit tests of the
+// services. The fakes honour the pagination (nextKey), filtering and error semantics of the DynamoDB repositories -
+// the conformance tests of this package run the same suites against the fakes and the DynamoDB repositories running on
+// the SQLite storage driver, which emulates DynamoDB ("sqlite-emulator" subtests) - they do not run against DynamoDB.
+//
+// Like the DynamoDB repositories, the fakes return the items ordered by their primary key, a paginated query
+// evaluates up to page size items before the filter is applied and the last evaluated key is only returned when
+// more items remain.
+package fakes
+
+import "sort"
+
+// evaluatePages emulates the paginated DynamoDB queries of the repositories on the ordered keys: each page evaluates up
+// to limit keys (all of them when the limit is zero) and keeps the matching ones, the query continues with the next
+// page until done returns true or the keys are exhausted. It returns the indexes of the matching keys and the last
+// evaluated key, empty when no keys remain.
+func evaluatePages(keys []string, limit int64, match func(i int) bool, done func(matched int) bool) ([]int, string) {
+	var matched []int
+	position := 0
+	for {
+		end := len(keys)
+		if limit > 0 && int64(position)+limit < int64(end) {
+			end = position + int(limit)
+		}
+		for i := position; i < end; i++ {
+			if match(i) {
+				matched = append(matched, i)
+			}
+		}
+		position = end
+
+		lastEvaluatedKey := ""
+		if position < len(keys) {
+			lastEvaluatedKey = keys[position-1]
+		}
+		if lastEvaluatedKey == "" || (done != nil && done(len(matched))) {
+			return matched, lastEvaluatedKey
+		}
+	}
+}
+
+// startAfter returns the position of the first ordered key after the exclusive start key
+func startAfter(keys []string, nextKey *string) int {
+	if nextKey == nil {
+		return 0
+	}
+	return sort.SearchStrings(keys, *nextKey+"\x00")
+}
+
+// sortedKeys returns the keys of the map in order
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+// copyStrings returns a copy of the list, nil when the list is empty - like a missing attribute
+func copyStrings(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	return append([]string(nil), list...)
+}
+
+// containsString returns true if the value is in the list
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
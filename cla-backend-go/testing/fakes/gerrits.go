@@ -0,0 +1,165 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/gofrs/uuid"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// GerritRepository is an in-memory implementation of the gerrits repository
+type GerritRepository struct {
+	mu      sync.RWMutex
+	gerrits map[string]gerrits.Gerrit
+}
+
+var _ gerrits.Repository = (*GerritRepository)(nil)
+
+// NewGerritRepository creates a new, empty in-memory gerrits repository
+func NewGerritRepository() *GerritRepository {
+	return &GerritRepository{
+		gerrits: map[string]gerrits.Gerrit{},
+	}
+}
+
+// AddGerritInstance adds or replaces the gerrit instance record
+func (repo *GerritRepository) AddGerritInstance(gerrit gerrits.Gerrit) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.gerrits[gerrit.GerritID] = gerrit
+}
+
+// GetClaGroupGerrits returns the gerrit instances of the CLA group, optionally only the ones of the project
+func (repo *GerritRepository) GetClaGroupGerrits(projectID string, projectSFID *string) (*models.GerritList, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return &models.GerritList{List: repo.findGerrits(func(gerrit gerrits.Gerrit) bool {
+		return projectID != "" && gerrit.ProjectID == projectID &&
+			(projectSFID == nil || (*projectSFID != "" && gerrit.ProjectSFID == *projectSFID))
+	})}, nil
+}
+
+// DeleteGerrit deletes the gerrit instance
+func (repo *GerritRepository) DeleteGerrit(gerritID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	delete(repo.gerrits, gerritID)
+	return nil
+}
+
+// GetGerrit returns the gerrit instance
+func (repo *GerritRepository) GetGerrit(gerritID string) (*models.Gerrit, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getGerrit(gerritID)
+}
+
+// getGerrit looks up the gerrit instance, the caller holds the lock
+func (repo *GerritRepository) getGerrit(gerritID string) (*models.Gerrit, error) {
+	gerrit, ok := repo.gerrits[gerritID]
+	if !ok {
+		return nil, gerrits.ErrGerritNotFound
+	}
+	return toGerritModel(gerrit), nil
+}
+
+// AddGerrit creates a new gerrit instance
+func (repo *GerritRepository) AddGerrit(input *models.Gerrit) (*models.Gerrit, error) {
+	gerritID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.gerrits[gerritID.String()] = gerrits.Gerrit{
+		DateCreated:   currentTime,
+		DateModified:  currentTime,
+		GerritID:      gerritID.String(),
+		GerritName:    input.GerritName,
+		GerritURL:     input.GerritURL.String(),
+		GroupIDCcla:   input.GroupIDCcla,
+		GroupIDIcla:   input.GroupIDIcla,
+		GroupNameCcla: input.GroupNameCcla,
+		GroupNameIcla: input.GroupNameIcla,
+		ProjectID:     input.ProjectID,
+		ProjectSFID:   input.ProjectSFID,
+		Version:       input.Version,
+	}
+	return repo.getGerrit(gerritID.String())
+}
+
+// ExistsByName returns the gerrit instances with the name - like the DynamoDB projection, without the version
+func (repo *GerritRepository) ExistsByName(gerritName string) ([]*models.Gerrit, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	resultList := repo.findGerrits(func(gerrit gerrits.Gerrit) bool {
+		return gerritName != "" && gerrit.GerritName == gerritName
+	})
+	for _, gerrit := range resultList {
+		gerrit.Version = ""
+	}
+	return resultList, nil
+}
+
+// GetGerritsByID returns the gerrit instances with the ICLA or CCLA group ID
+func (repo *GerritRepository) GetGerritsByID(ID string, IDType string) (*models.GerritList, error) {
+	var match func(gerrit gerrits.Gerrit) bool
+	if IDType == "ICLA" {
+		match = func(gerrit gerrits.Gerrit) bool { return ID != "" && gerrit.GroupIDIcla == ID }
+	} else if IDType == "CCLA" {
+		match = func(gerrit gerrits.Gerrit) bool { return ID != "" && gerrit.GroupIDCcla == ID }
+	} else {
+		return nil, errors.New("invalid IDType")
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return &models.GerritList{List: repo.findGerrits(match)}, nil
+}
+
+// findGerrits returns the gerrit instances accepted by the match function sorted by name, the caller holds the lock
+func (repo *GerritRepository) findGerrits(match func(gerrit gerrits.Gerrit) bool) []*models.Gerrit {
+	resultList := make([]*models.Gerrit, 0)
+	for _, gerrit := range repo.gerrits {
+		if match(gerrit) {
+			resultList = append(resultList, toGerritModel(gerrit))
+		}
+	}
+	sort.Slice(resultList, func(i, j int) bool {
+		if resultList[i].GerritName == resultList[j].GerritName {
+			return resultList[i].GerritID < resultList[j].GerritID
+		}
+		return resultList[i].GerritName < resultList[j].GerritName
+	})
+	return resultList
+}
+
+// toGerritModel converts the gerrit structure into a response model
+func toGerritModel(g gerrits.Gerrit) *models.Gerrit {
+	return &models.Gerrit{
+		DateCreated:   g.DateCreated,
+		DateModified:  g.DateModified,
+		GerritID:      strfmt.UUID4(g.GerritID),
+		GerritName:    g.GerritName,
+		GerritURL:     strfmt.URI(g.GerritURL),
+		GroupIDCcla:   g.GroupIDCcla,
+		GroupIDIcla:   g.GroupIDIcla,
+		GroupNameCcla: g.GroupNameCcla,
+		GroupNameIcla: g.GroupNameIcla,
+		ProjectID:     g.ProjectID,
+		Version:       g.Version,
+		ProjectSFID:   g.ProjectSFID,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGerritRepositoryConformance(t *testing.T) {
+	t.Run("fake", func(t *testing.T) {
+		repo := NewGerritRepository()
+		testGerritRepository(t, repo, repo.AddGerritInstance)
+	})
+	for _, target := range conformanceTargets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			awsSession, stage := target.newStage(t)
+			testGerritRepository(t, gerrits.NewRepository(awsSession, stage), func(gerrit gerrits.Gerrit) {
+				putItem(t, awsSession, stage, "gerrit-instances", gerrit)
+			})
+		})
+	}
+}
+
+func gerritNames(list *models.GerritList) []string {
+	var names []string
+	for _, gerrit := range list.List {
+		names = append(names, gerrit.GerritName)
+	}
+	return names
+}
+
+func testGerritRepository(t *testing.T, repo gerrits.Repository, addGerrit func(gerrit gerrits.Gerrit)) {
+	addGerrit(gerrits.Gerrit{GerritID: "g1", GerritName: "review-b", ProjectID: "p1", ProjectSFID: "sf1", GroupIDIcla: "100"})
+	addGerrit(gerrits.Gerrit{GerritID: "g2", GerritName: "review-a", ProjectID: "p1", ProjectSFID: "sf2", GroupIDCcla: "200"})
+	addGerrit(gerrits.Gerrit{GerritID: "g3", GerritName: "review-c", ProjectID: "p2", ProjectSFID: "sf3", GroupIDIcla: "100"})
+
+	list, err := repo.GetClaGroupGerrits("p1", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"review-a", "review-b"}, gerritNames(list))
+	}
+	projectSFID := "sf1"
+	list, err = repo.GetClaGroupGerrits("p1", &projectSFID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"review-b"}, gerritNames(list))
+	}
+
+	gerrit, err := repo.GetGerrit("g2")
+	if assert.NoError(t, err) && assert.NotNil(t, gerrit) {
+		assert.Equal(t, "review-a", gerrit.GerritName)
+	}
+	_, err = repo.GetGerrit("missing")
+	assert.Error(t, err)
+
+	list, err = repo.GetGerritsByID("100", "ICLA")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"review-b", "review-c"}, gerritNames(list))
+	}
+	list, err = repo.GetGerritsByID("200", "CCLA")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"review-a"}, gerritNames(list))
+	}
+	_, err = repo.GetGerritsByID("100", "ECLA")
+	assert.Error(t, err)
+
+	assert.NoError(t, repo.DeleteGerrit("g1"))
+	list, err = repo.GetClaGroupGerrits("p1", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"review-a"}, gerritNames(list))
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+)
+
+// ProjectClaGroupRepository is an in-memory implementation of the projects cla groups repository
+type ProjectClaGroupRepository struct {
+	mu               sync.RWMutex
+	projectClaGroups map[string]projects_cla_groups.ProjectClaGroup
+	claGroupNames    map[string]string
+	projectNames     map[string]string
+}
+
+var _ projects_cla_groups.Repository = (*ProjectClaGroupRepository)(nil)
+
+// NewProjectClaGroupRepository creates a new, empty in-memory projects cla groups repository
+func NewProjectClaGroupRepository() *ProjectClaGroupRepository {
+	return &ProjectClaGroupRepository{
+		projectClaGroups: map[string]projects_cla_groups.ProjectClaGroup{},
+		claGroupNames:    map[string]string{},
+		projectNames:     map[string]string{},
+	}
+}
+
+// AddProjectClaGroup adds or replaces the mapping of the project
+func (repo *ProjectClaGroupRepository) AddProjectClaGroup(projectClaGroup projects_cla_groups.ProjectClaGroup) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.projectClaGroups[projectClaGroup.ProjectSFID] = projectClaGroup
+}
+
+// SetCLAGroupName sets the name of the CLA group used by new associations - the DynamoDB repository reads it from the
+// projects table
+func (repo *ProjectClaGroupRepository) SetCLAGroupName(claGroupID, claGroupName string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.claGroupNames[claGroupID] = claGroupName
+}
+
+// SetProjectName sets the name of the project or foundation used by new associations - the DynamoDB repository reads
+// it from the project service
+func (repo *ProjectClaGroupRepository) SetProjectName(projectSFID, projectName string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.projectNames[projectSFID] = projectName
+}
+
+// GetClaGroupIDForProject retrieves the CLA Group ID for the project, falling back to the first mapping of the
+// foundation
+func (repo *ProjectClaGroupRepository) GetClaGroupIDForProject(projectSFID string) (*projects_cla_groups.ProjectClaGroup, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	if projectClaGroup, ok := repo.projectClaGroups[projectSFID]; ok {
+		return &projectClaGroup, nil
+	}
+
+	pcgs := repo.findProjectClaGroups(func(pcg projects_cla_groups.ProjectClaGroup) bool {
+		return pcg.FoundationSFID == projectSFID
+	})
+	if len(pcgs) == 0 {
+		return nil, projects_cla_groups.ErrProjectNotAssociatedWithClaGroup
+	}
+	return pcgs[0], nil
+}
+
+// GetProjectsIdsForClaGroup returns the mappings of the CLA group
+func (repo *ProjectClaGroupRepository) GetProjectsIdsForClaGroup(claGroupID string) ([]*projects_cla_groups.ProjectClaGroup, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.findProjectClaGroups(func(pcg projects_cla_groups.ProjectClaGroup) bool {
+		return claGroupID != "" && pcg.ClaGroupID == claGroupID
+	}), nil
+}
+
+// GetProjectsIdsForFoundation returns the mappings of the foundation
+func (repo *ProjectClaGroupRepository) GetProjectsIdsForFoundation(foundationSFID string) ([]*projects_cla_groups.ProjectClaGroup, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.findProjectClaGroups(func(pcg projects_cla_groups.ProjectClaGroup) bool {
+		return foundationSFID != "" && pcg.FoundationSFID == foundationSFID
+	}), nil
+}
+
+// GetProjectsIdsForAllFoundation returns all the mappings
+func (repo *ProjectClaGroupRepository) GetProjectsIdsForAllFoundation() ([]*projects_cla_groups.ProjectClaGroup, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.findProjectClaGroups(func(pcg projects_cla_groups.ProjectClaGroup) bool { return true }), nil
+}
+
+// AssociateClaGroupWithProject creates the mapping of the project to the CLA group, it fails if the project is
+// already mapped
+func (repo *ProjectClaGroupRepository) AssociateClaGroupWithProject(claGroupID string, projectSFID string, foundationSFID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.projectClaGroups[projectSFID]; ok {
+		return projects_cla_groups.ErrAssociationAlreadyExist
+	}
+
+	repo.projectClaGroups[projectSFID] = projects_cla_groups.ProjectClaGroup{
+		ProjectSFID:    projectSFID,
+		ProjectName:    repo.nameOf(repo.projectNames, projectSFID),
+		ClaGroupID:     claGroupID,
+		ClaGroupName:   repo.nameOf(repo.claGroupNames, claGroupID),
+		FoundationSFID: foundationSFID,
+		FoundationName: repo.nameOf(repo.projectNames, foundationSFID),
+		Version:        "v1",
+	}
+	return nil
+}
+
+// RemoveProjectAssociatedWithClaGroup removes all the mappings of the CLA group, or only the ones of the listed projects
+func (repo *ProjectClaGroupRepository) RemoveProjectAssociatedWithClaGroup(claGroupID string, projectSFIDList []string, all bool) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for projectSFID, pcg := range repo.projectClaGroups {
+		if claGroupID == "" || pcg.ClaGroupID != claGroupID {
+			continue
+		}
+		if !all && !containsString(projectSFIDList, projectSFID) {
+			continue
+		}
+		delete(repo.projectClaGroups, projectSFID)
+	}
+	return nil
+}
+
+// IsExistingFoundationLevelCLAGroup returns true if the foundation is mapped to a CLA group as a project
+func (repo *ProjectClaGroupRepository) IsExistingFoundationLevelCLAGroup(foundationSFID string) (bool, error) {
+	pcgs, err := repo.GetProjectsIdsForFoundation(foundationSFID)
+	if err != nil {
+		return false, err
+	}
+	for _, pcg := range pcgs {
+		if pcg.FoundationSFID == foundationSFID && pcg.ProjectSFID == foundationSFID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsAssociated returns true if the project or foundation is mapped to the CLA group
+func (repo *ProjectClaGroupRepository) IsAssociated(projectSFID string, claGroupID string) (bool, error) {
+	pcgs, err := repo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil {
+		return false, err
+	}
+	if len(pcgs) == 0 {
+		return false, errors.New("no cla-group mapping found for cla-group")
+	}
+	for _, pcg := range pcgs {
+		if pcg.ProjectSFID == projectSFID || pcg.FoundationSFID == projectSFID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// UpdateRepositoriesCount adds the difference to the repositories count of the project, creating the record if needed
+func (repo *ProjectClaGroupRepository) UpdateRepositoriesCount(projectSFID string, diff int64) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	pcg := repo.projectClaGroups[projectSFID]
+	pcg.ProjectSFID = projectSFID
+	pcg.RepositoriesCount += diff
+	repo.projectClaGroups[projectSFID] = pcg
+	return nil
+}
+
+// findProjectClaGroups returns the mappings in primary key order accepted by the match function, nil when no mapping
+// matches, the caller holds the lock
+func (repo *ProjectClaGroupRepository) findProjectClaGroups(match func(pcg projects_cla_groups.ProjectClaGroup) bool) []*projects_cla_groups.ProjectClaGroup {
+	keys := make([]string, 0, len(repo.projectClaGroups))
+	for key := range repo.projectClaGroups {
+		keys = append(keys, key)
+	}
+
+	var pcgs []*projects_cla_groups.ProjectClaGroup
+	for _, key := range sortedKeys(keys) {
+		pcg := repo.projectClaGroups[key]
+		if match(pcg) {
+			pcgs = append(pcgs, &pcg)
+		}
+	}
+	return pcgs
+}
+
+// nameOf returns the name registered for the ID, Not Defined if the name is unknown, the caller holds the lock
+func (repo *ProjectClaGroupRepository) nameOf(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return projects_cla_groups.NotDefined
+}
@@ -0,0 +1,81 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectClaGroupRepositoryConformance(t *testing.T) {
+	t.Run("fake", func(t *testing.T) {
+		repo := NewProjectClaGroupRepository()
+		testProjectClaGroupRepository(t, repo, repo.AddProjectClaGroup)
+	})
+	for _, target := range conformanceTargets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			awsSession, stage := target.newStage(t)
+			testProjectClaGroupRepository(t, projects_cla_groups.NewRepository(awsSession, stage), func(projectClaGroup projects_cla_groups.ProjectClaGroup) {
+				putItem(t, awsSession, stage, "projects-cla-groups", projectClaGroup)
+			})
+		})
+	}
+}
+
+func testProjectClaGroupRepository(t *testing.T, repo projects_cla_groups.Repository, addProjectClaGroup func(projectClaGroup projects_cla_groups.ProjectClaGroup)) {
+	addProjectClaGroup(projects_cla_groups.ProjectClaGroup{ProjectSFID: "sp1", ClaGroupID: "g1", FoundationSFID: "f1"})
+	addProjectClaGroup(projects_cla_groups.ProjectClaGroup{ProjectSFID: "sp2", ClaGroupID: "g1", FoundationSFID: "f1"})
+	addProjectClaGroup(projects_cla_groups.ProjectClaGroup{ProjectSFID: "f2", ClaGroupID: "g2", FoundationSFID: "f2"})
+
+	pcg, err := repo.GetClaGroupIDForProject("sp1")
+	if assert.NoError(t, err) && assert.NotNil(t, pcg) {
+		assert.Equal(t, "g1", pcg.ClaGroupID)
+	}
+	// the foundation SFID is looked up in the foundation index when it is not a project of its own
+	pcg, err = repo.GetClaGroupIDForProject("f1")
+	if assert.NoError(t, err) && assert.NotNil(t, pcg) {
+		assert.Equal(t, "g1", pcg.ClaGroupID)
+	}
+	_, err = repo.GetClaGroupIDForProject("missing")
+	assert.Equal(t, projects_cla_groups.ErrProjectNotAssociatedWithClaGroup, err)
+
+	pcgs, err := repo.GetProjectsIdsForFoundation("f1")
+	assert.NoError(t, err)
+	assert.Len(t, pcgs, 2)
+	pcgs, err = repo.GetProjectsIdsForAllFoundation()
+	assert.NoError(t, err)
+	assert.Len(t, pcgs, 3)
+
+	isFoundationLevel, err := repo.IsExistingFoundationLevelCLAGroup("f2")
+	assert.NoError(t, err)
+	assert.True(t, isFoundationLevel)
+	isFoundationLevel, err = repo.IsExistingFoundationLevelCLAGroup("f1")
+	assert.NoError(t, err)
+	assert.False(t, isFoundationLevel)
+
+	associated, err := repo.IsAssociated("f1", "g1")
+	assert.NoError(t, err)
+	assert.True(t, associated)
+	associated, err = repo.IsAssociated("sp3", "g1")
+	assert.NoError(t, err)
+	assert.False(t, associated)
+	_, err = repo.IsAssociated("sp1", "g3")
+	assert.Error(t, err)
+
+	assert.NoError(t, repo.UpdateRepositoriesCount("sp1", 2))
+	assert.NoError(t, repo.UpdateRepositoriesCount("sp1", -1))
+	pcg, err = repo.GetClaGroupIDForProject("sp1")
+	if assert.NoError(t, err) && assert.NotNil(t, pcg) {
+		assert.Equal(t, int64(1), pcg.RepositoriesCount)
+	}
+
+	assert.NoError(t, repo.RemoveProjectAssociatedWithClaGroup("g1", []string{"sp2"}, false))
+	pcgs, err = repo.GetProjectsIdsForClaGroup("g1")
+	if assert.NoError(t, err) && assert.Len(t, pcgs, 1) {
+		assert.Equal(t, "sp1", pcgs[0].ProjectSFID)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// RepositoryRepository is an in-memory implementation of the GitHub repositories repository
+type RepositoryRepository struct {
+	mu           sync.RWMutex
+	repositories map[string]repositories.RepositoryDBModel
+}
+
+var _ repositories.Repository = (*RepositoryRepository)(nil)
+
+// NewRepositoryRepository creates a new, empty in-memory GitHub repositories repository
+func NewRepositoryRepository() *RepositoryRepository {
+	return &RepositoryRepository{
+		repositories: map[string]repositories.RepositoryDBModel{},
+	}
+}
+
+// AddRepository adds or replaces the GitHub repository record
+func (repo *RepositoryRepository) AddRepository(dbModel repositories.RepositoryDBModel) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.repositories[dbModel.RepositoryID] = dbModel
+}
+
+// AddGithubRepository adds the specified repository, it fails if an enabled repository with the same GitHub ID exists
+func (repo *RepositoryRepository) AddGithubRepository(externalProjectID string, projectSFID string, input *models.GithubRepositoryInput) (*models.GithubRepository, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, err := repo.getRepositoryByGithubID(utils.StringValue(input.RepositoryExternalID), true); err == nil {
+		return nil, errors.New("github repository already exist")
+	}
+
+	_, currentTime := utils.CurrentTime()
+	repoID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	dbModel := repositories.RepositoryDBModel{
+		DateCreated:                currentTime,
+		DateModified:               currentTime,
+		RepositoryExternalID:       utils.StringValue(input.RepositoryExternalID),
+		RepositoryID:               repoID.String(),
+		RepositoryName:             utils.StringValue(input.RepositoryName),
+		RepositoryOrganizationName: utils.StringValue(input.RepositoryOrganizationName),
+		RepositoryProjectID:        utils.StringValue(input.RepositoryProjectID),
+		RepositorySfdcID:           externalProjectID,
+		RepositoryType:             utils.StringValue(input.RepositoryType),
+		RepositoryURL:              utils.StringValue(input.RepositoryURL),
+		Enabled:                    true,
+		Note:                       fmt.Sprintf("created on %s", currentTime),
+		ProjectSFID:                projectSFID,
+		Version:                    "v1",
+	}
+	repo.repositories[dbModel.RepositoryID] = dbModel
+	return toRepositoryModel(dbModel), nil
+}
+
+// EnableRepository enables the repository entry
+func (repo *RepositoryRepository) EnableRepository(repositoryID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.updateGithubRepository(repositoryID, true)
+}
+
+// DisableRepository disables the repository entry
+func (repo *RepositoryRepository) DisableRepository(repositoryID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.updateGithubRepository(repositoryID, false)
+}
+
+// DisableRepositoriesByProjectID disables the enabled repositories of the CLA group
+func (repo *RepositoryRepository) DisableRepositoriesByProjectID(projectID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, repoModel := range repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+		return projectID != "" && dbModel.RepositoryProjectID == projectID && dbModel.Enabled
+	}) {
+		if err := repo.updateGithubRepository(repoModel.RepositoryID, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisableRepositoriesOfGithubOrganization disables the repositories under the GitHub organization
+func (repo *RepositoryRepository) DisableRepositoriesOfGithubOrganization(externalProjectID, githubOrgName string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, repoModel := range repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+		return githubOrgName != "" && dbModel.RepositoryOrganizationName == githubOrgName &&
+			(dbModel.RepositoryExternalID == externalProjectID || dbModel.RepositorySfdcID == externalProjectID)
+	}) {
+		if err := repo.updateGithubRepository(repoModel.RepositoryID, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRepository returns the repository
+func (repo *RepositoryRepository) GetRepository(repositoryID string) (*models.GithubRepository, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	dbModel, ok := repo.repositories[repositoryID]
+	if !ok {
+		return nil, repositories.ErrGithubRepositoryNotFound
+	}
+	return toRepositoryModel(dbModel), nil
+}
+
+// GetRepositoryByGithubID fetches the repository model by its external github id
+func (repo *RepositoryRepository) GetRepositoryByGithubID(externalID string, enabled bool) (*models.GithubRepository, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getRepositoryByGithubID(externalID, enabled)
+}
+
+// getRepositoryByGithubID looks up the repository by GitHub ID, the caller holds the lock
+func (repo *RepositoryRepository) getRepositoryByGithubID(externalID string, enabled bool) (*models.GithubRepository, error) {
+	result := repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+		return externalID != "" && dbModel.RepositoryExternalID == externalID && dbModel.Enabled == enabled
+	})
+	if len(result) == 0 {
+		return nil, repositories.ErrGithubRepositoryNotFound
+	}
+	return result[0], nil
+}
+
+// GetRepositoriesByCLAGroup returns the repositories of the CLA group, it fails if the CLA group has no repositories
+func (repo *RepositoryRepository) GetRepositoriesByCLAGroup(claGroupID string, enabled bool) ([]*models.GithubRepository, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	result := repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+		return claGroupID != "" && dbModel.RepositoryProjectID == claGroupID && dbModel.Enabled == enabled
+	})
+	if len(result) == 0 {
+		return nil, repositories.ErrGithubRepositoryNotFound
+	}
+	return result, nil
+}
+
+// GetCLAGroupRepositoriesGroupByOrgs returns the repositories of the CLA group grouped by GitHub organization
+func (repo *RepositoryRepository) GetCLAGroupRepositoriesGroupByOrgs(projectID string, enabled bool) ([]*models.GithubRepositoriesGroupByOrgs, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	out := make([]*models.GithubRepositoriesGroupByOrgs, 0)
+	outMap := make(map[string]*models.GithubRepositoriesGroupByOrgs)
+	for _, ghrepo := range repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+		return projectID != "" && dbModel.RepositoryProjectID == projectID && dbModel.Enabled == enabled
+	}) {
+		ghrepoGroup, ok := outMap[ghrepo.RepositoryOrganizationName]
+		if !ok {
+			ghrepoGroup = &models.GithubRepositoriesGroupByOrgs{
+				OrganizationName: ghrepo.RepositoryOrganizationName,
+			}
+			out = append(out, ghrepoGroup)
+			outMap[ghrepo.RepositoryOrganizationName] = ghrepoGroup
+		}
+		ghrepoGroup.List = append(ghrepoGroup.List, ghrepo)
+	}
+	return out, nil
+}
+
+// ListProjectRepositories returns the repositories of the salesforce project, or of the project SFID - like the
+// project SFID index, ordered by organization name and without the repositories with no organization
+func (repo *RepositoryRepository) ListProjectRepositories(externalProjectID string, projectSFID string, enabled bool) (*models.ListGithubRepositories, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	out := &models.ListGithubRepositories{
+		List: make([]*models.GithubRepository, 0),
+	}
+	if externalProjectID != "" {
+		out.List = append(out.List, repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+			return dbModel.RepositorySfdcID == externalProjectID && dbModel.Enabled == enabled
+		})...)
+		return out, nil
+	}
+
+	out.List = append(out.List, repo.findRepositories(func(dbModel repositories.RepositoryDBModel) bool {
+		return projectSFID != "" && dbModel.ProjectSFID == projectSFID && dbModel.RepositoryOrganizationName != "" &&
+			dbModel.Enabled == enabled
+	})...)
+	sort.SliceStable(out.List, func(i, j int) bool {
+		return out.List[i].RepositoryOrganizationName < out.List[j].RepositoryOrganizationName
+	})
+	return out, nil
+}
+
+// updateGithubRepository sets the enabled flag of the repository and appends the change to the note, the caller holds
+// the lock
+func (repo *RepositoryRepository) updateGithubRepository(repositoryID string, enabled bool) error {
+	dbModel, ok := repo.repositories[repositoryID]
+	if !ok {
+		return repositories.ErrGithubRepositoryNotFound
+	}
+
+	var enabledString = "disabled"
+	if enabled {
+		enabledString = "enabled"
+	}
+
+	var existingNote = ""
+	if dbModel.Note != "" {
+		existingNote = dbModel.Note + ". "
+	}
+
+	_, now := utils.CurrentTime()
+	dbModel.Enabled = enabled
+	dbModel.Note = fmt.Sprintf("%s%s on %s", existingNote, enabledString, now)
+	dbModel.DateModified = now
+	repo.repositories[repositoryID] = dbModel
+	return nil
+}
+
+// findRepositories returns the repositories in primary key order accepted by the match function, nil when no
+// repository matches, the caller holds the lock
+func (repo *RepositoryRepository) findRepositories(match func(dbModel repositories.RepositoryDBModel) bool) []*models.GithubRepository {
+	keys := make([]string, 0, len(repo.repositories))
+	for key := range repo.repositories {
+		keys = append(keys, key)
+	}
+
+	var result []*models.GithubRepository
+	for _, key := range sortedKeys(keys) {
+		if match(repo.repositories[key]) {
+			result = append(result, toRepositoryModel(repo.repositories[key]))
+		}
+	}
+	return result
+}
+
+// toRepositoryModel converts the database model into a service response model
+func toRepositoryModel(gr repositories.RepositoryDBModel) *models.GithubRepository {
+	return &models.GithubRepository{
+		DateCreated:                gr.DateCreated,
+		DateModified:               gr.DateModified,
+		RepositoryExternalID:       gr.RepositoryExternalID,
+		RepositoryID:               gr.RepositoryID,
+		RepositoryName:             gr.RepositoryName,
+		RepositoryOrganizationName: gr.RepositoryOrganizationName,
+		RepositoryProjectID:        gr.RepositoryProjectID,
+		RepositorySfdcID:           gr.RepositorySfdcID,
+		RepositoryType:             gr.RepositoryType,
+		RepositoryURL:              gr.RepositoryURL,
+		ProjectSFID:                gr.ProjectSFID,
+		Enabled:                    gr.Enabled,
+		Note:                       gr.Note,
+		Version:                    gr.Version,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryRepositoryConformance(t *testing.T) {
+	t.Run("fake", func(t *testing.T) {
+		repo := NewRepositoryRepository()
+		testRepositoryRepository(t, repo, repo.AddRepository)
+	})
+	for _, target := range conformanceTargets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			awsSession, stage := target.newStage(t)
+			testRepositoryRepository(t, repositories.NewRepository(awsSession, stage), func(dbModel repositories.RepositoryDBModel) {
+				putItem(t, awsSession, stage, "repositories", dbModel)
+			})
+		})
+	}
+}
+
+func testRepositoryRepository(t *testing.T, repo repositories.Repository, addRepository func(dbModel repositories.RepositoryDBModel)) {
+	addRepository(repositories.RepositoryDBModel{RepositoryID: "r1", RepositoryName: "org/one", RepositoryExternalID: "1001",
+		RepositoryOrganizationName: "org", RepositoryProjectID: "p1", ProjectSFID: "sf1", RepositoryType: "github", Enabled: true})
+	addRepository(repositories.RepositoryDBModel{RepositoryID: "r2", RepositoryName: "org/two", RepositoryExternalID: "1002",
+		RepositoryOrganizationName: "org", RepositoryProjectID: "p1", ProjectSFID: "sf1", RepositoryType: "github", Enabled: true})
+	addRepository(repositories.RepositoryDBModel{RepositoryID: "r3", RepositoryName: "org/three", RepositoryExternalID: "1003",
+		RepositoryOrganizationName: "org", RepositoryProjectID: "p1", ProjectSFID: "sf1", RepositoryType: "github", Enabled: false})
+
+	repository, err := repo.GetRepository("r1")
+	if assert.NoError(t, err) && assert.NotNil(t, repository) {
+		assert.Equal(t, "org/one", repository.RepositoryName)
+		assert.True(t, repository.Enabled)
+	}
+	_, err = repo.GetRepository("missing")
+	assert.Equal(t, repositories.ErrGithubRepositoryNotFound, err)
+
+	list, err := repo.GetRepositoriesByCLAGroup("p1", true)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	list, err = repo.GetRepositoriesByCLAGroup("p1", false)
+	if assert.NoError(t, err) && assert.Len(t, list, 1) {
+		assert.Equal(t, "r3", list[0].RepositoryID)
+	}
+	_, err = repo.GetRepositoriesByCLAGroup("p2", true)
+	assert.Equal(t, repositories.ErrGithubRepositoryNotFound, err)
+
+	assert.NoError(t, repo.DisableRepository("r1"))
+	repository, err = repo.GetRepository("r1")
+	if assert.NoError(t, err) && assert.NotNil(t, repository) {
+		assert.False(t, repository.Enabled)
+	}
+	list, err = repo.GetRepositoriesByCLAGroup("p1", true)
+	if assert.NoError(t, err) && assert.Len(t, list, 1) {
+		assert.Equal(t, "r2", list[0].RepositoryID)
+	}
+}
@@ -0,0 +1,1000 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	signatureOps "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// SignatureRepository is an in-memory implementation of the signatures repository. Like the DynamoDB repository, the
+// signature models are completed with the user and company details of the users and company repositories.
+type SignatureRepository struct {
+	mu          sync.RWMutex
+	signatures  map[string]signatures.ItemSignature
+	companyRepo company.IRepository
+	usersRepo   users.UserRepository
+}
+
+var _ signatures.SignatureRepository = (*SignatureRepository)(nil)
+
+// NewSignatureRepository creates a new, empty in-memory signatures repository
+func NewSignatureRepository(companyRepo company.IRepository, usersRepo users.UserRepository) *SignatureRepository {
+	return &SignatureRepository{
+		signatures:  map[string]signatures.ItemSignature{},
+		companyRepo: companyRepo,
+		usersRepo:   usersRepo,
+	}
+}
+
+// AddSignature adds or replaces the signature record - a nil list is a missing attribute, an empty GitHub
+// organization approval list is a stored NULL value
+func (repo *SignatureRepository) AddSignature(item signatures.ItemSignature) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.signatures[item.SignatureID] = item
+}
+
+// GetGithubOrganizationsFromWhitelist returns the GitHub organization approval list ordered by ID, nil if the
+// signature has no list
+func (repo *SignatureRepository) GetGithubOrganizationsFromWhitelist(ctx context.Context, signatureID string) ([]models.GithubOrg, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := repo.signatures[signatureID]
+	if !ok || item.GitHubOrgWhitelist == nil {
+		return nil, nil
+	}
+
+	orgs := buildGithubOrgs(item.GitHubOrgWhitelist)
+	sort.Slice(orgs, func(i, j int) bool {
+		return *orgs[i].ID < *orgs[j].ID
+	})
+	return orgs, nil
+}
+
+// AddGithubOrganizationToWhitelist adds the GitHub organization to the approval list, creating the signature record if
+// needed, and returns the list in the stored order
+func (repo *SignatureRepository) AddGithubOrganizationToWhitelist(ctx context.Context, signatureID, githubOrganizationID string) ([]models.GithubOrg, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item := repo.signatures[signatureID]
+	if containsString(item.GitHubOrgWhitelist, githubOrganizationID) {
+		return buildGithubOrgs(item.GitHubOrgWhitelist), nil
+	}
+
+	var orgs []string
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.GitHubOrgWhitelist = append(append([]string{}, item.GitHubOrgWhitelist...), githubOrganizationID)
+		orgs = item.GitHubOrgWhitelist
+	})
+	return buildGithubOrgs(orgs), nil
+}
+
+// DeleteGithubOrganizationFromWhitelist removes the GitHub organization from the approval list and returns the list in
+// the stored order - the emptied list is stored as a NULL value
+func (repo *SignatureRepository) DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID, githubOrganizationID string) ([]models.GithubOrg, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item, ok := repo.signatures[signatureID]
+	if !ok || item.GitHubOrgWhitelist == nil {
+		return nil, errors.New("no github_org_whitelist column")
+	}
+
+	orgs := []string{}
+	for _, org := range item.GitHubOrgWhitelist {
+		if org != githubOrganizationID {
+			orgs = append(orgs, org)
+		}
+	}
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.GitHubOrgWhitelist = orgs
+	})
+
+	if len(orgs) == 0 {
+		return []models.GithubOrg{}, nil
+	}
+	return buildGithubOrgs(orgs), nil
+}
+
+// InvalidateProjectRecord sets the signature approved flag to false and notes the CLA group deletion
+func (repo *SignatureRepository) InvalidateProjectRecord(ctx context.Context, signatureID, foundationSFID, projectName string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	_, currentTime := utils.CurrentTime()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.SignatureApproved = false
+		item.SignatureClaGroupDeletedOn = currentTime
+		if item.SignatureInvalidatedOn == "" {
+			item.SignatureInvalidatedOn = currentTime
+		}
+		item.DateModified = currentTime
+		if foundationSFID != "" {
+			item.SignatureFoundationSFID = foundationSFID
+		}
+	})
+	return nil
+}
+
+// GetSignature returns the signature, nil if the signature does not exist
+func (repo *SignatureRepository) GetSignature(ctx context.Context, signatureID string) (*models.Signature, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getSignature(ctx, signatureID), nil
+}
+
+// getSignature looks up the signature with the ACL details, the caller holds the lock
+func (repo *SignatureRepository) getSignature(ctx context.Context, signatureID string) *models.Signature {
+	item, ok := repo.signatures[signatureID]
+	if !ok {
+		return nil
+	}
+	return repo.buildSignatureModels(ctx, []signatures.ItemSignature{item}, signatures.LoadACLDetails)[0]
+}
+
+// GetIndividualSignature returns the signed and approved ICLA signature of the user with the highest document major
+// version, nil if the user has not signed
+func (repo *SignatureRepository) GetIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error) {
+	return repo.getLatestSignature(ctx, claGroupID, userID, signatures.SignatureTypeCLA, signatures.ReferenceTypeUser)
+}
+
+// GetCorporateSignature returns the signed and approved CCLA signature of the company with the highest document major
+// version, nil if the company has not signed
+func (repo *SignatureRepository) GetCorporateSignature(ctx context.Context, claGroupID, companyID string) (*models.Signature, error) {
+	return repo.getLatestSignature(ctx, claGroupID, companyID, signatures.SignatureTypeCCLA, signatures.ReferenceTypeCompany)
+}
+
+// getLatestSignature returns the latest signed and approved signature of the reference
+func (repo *SignatureRepository) getLatestSignature(ctx context.Context, claGroupID, referenceID, signatureType, referenceType string) (*models.Signature, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, _ := repo.querySignatures(nil, 100, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == claGroupID && item.SignatureReferenceID == referenceID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureType == signatureType && item.SignatureReferenceType == referenceType &&
+			item.SignatureApproved && item.SignatureSigned && item.SignatureUserCompanyID == ""
+	}, nil)
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return latestSignature(repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails)), nil
+}
+
+// GetSignatureACL returns the signature ACL, nil if the signature does not exist
+func (repo *SignatureRepository) GetSignatureACL(ctx context.Context, signatureID string) ([]string, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := repo.signatures[signatureID]
+	if !ok || item.SignatureACL == nil {
+		return nil, nil
+	}
+	return append([]string{}, item.SignatureACL...), nil
+}
+
+// GetProjectSignatures returns a page of the signatures of the CLA group, either of the CLA type or with the search
+// filters
+func (repo *SignatureRepository) GetProjectSignatures(ctx context.Context, params signatureOps.GetProjectSignaturesParams, pageSize int64) (*models.Signatures, error) {
+	keys := []func(item signatures.ItemSignature) bool{
+		func(item signatures.ItemSignature) bool { return item.SignatureProjectID == params.ProjectID },
+	}
+	var filters []func(item signatures.ItemSignature) bool
+
+	if params.ClaType != nil {
+		switch strings.ToLower(*params.ClaType) {
+		case signatures.ICLA, signatures.ECLA, signatures.CCLA:
+			claType := strings.ToLower(*params.ClaType)
+			filters = append(filters, func(item signatures.ItemSignature) bool {
+				return item.SignatureApproved && item.SignatureSigned && claTypeOf(item) == claType
+			})
+		default:
+			return nil, fmt.Errorf("unsupported cla type: %s", *params.ClaType)
+		}
+	} else {
+		fullMatch := params.FullMatch != nil && *params.FullMatch
+		if params.SearchField != nil {
+			searchField := *params.SearchField
+			filters = append(filters, func(item signatures.ItemSignature) bool {
+				return item.SignatureReferenceType == searchField
+			})
+		}
+
+		if params.SignatureType != nil {
+			signatureType := *params.SignatureType
+			if params.SearchTerm != nil && params.FullMatch != nil && !*params.FullMatch {
+				keys = append(keys, func(item signatures.ItemSignature) bool {
+					return item.SignatureType == strings.ToLower(signatureType)
+				})
+			} else {
+				filters = append(filters, func(item signatures.ItemSignature) bool {
+					return item.SignatureType == signatureType
+				})
+			}
+			if signatureType == signatures.CCLA {
+				filters = append(filters, func(item signatures.ItemSignature) bool {
+					return item.SignatureReferenceID != "" && item.SignatureUserCompanyID == ""
+				})
+			}
+		}
+
+		if params.SearchTerm != nil {
+			searchTerm := strings.ToLower(*params.SearchTerm)
+			if fullMatch {
+				keys = append(keys, func(item signatures.ItemSignature) bool {
+					return item.SignatureReferenceNameLower == searchTerm
+				})
+			} else {
+				filters = append(filters, func(item signatures.ItemSignature) bool {
+					return strings.Contains(item.SignatureReferenceNameLower, searchTerm)
+				})
+			}
+		}
+
+		filters = append(filters, func(item signatures.ItemSignature) bool {
+			return item.SignatureApproved && item.SignatureSigned
+		})
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, lastEvaluatedKey := repo.querySignatures(params.NextKey, pageSize, allOf(keys), allOf(filters), pageDone(pageSize))
+	sigs := append(make([]*models.Signature, 0), repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails)...)
+	if int64(len(sigs)) > pageSize {
+		sigs = sigs[0:pageSize]
+		lastEvaluatedKey = sigs[pageSize-1].SignatureID.String()
+	}
+
+	return &models.Signatures{
+		ProjectID:      params.ProjectID,
+		ResultCount:    int64(len(sigs)),
+		TotalCount:     int64(len(repo.signatures)),
+		LastKeyScanned: lastEvaluatedKey,
+		Signatures:     sigs,
+	}, nil
+}
+
+// GetProjectCompanySignature returns the first CCLA signature of the company for the CLA group, nil if the company
+// has not signed
+func (repo *SignatureRepository) GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signature, error) {
+	sigs, err := repo.GetProjectCompanySignatures(ctx, companyID, projectID, signed, approved, nextKey, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if sigs == nil || sigs.Signatures == nil {
+		return nil, nil
+	}
+	return sigs.Signatures[0], nil
+}
+
+// GetProjectCompanySignatures returns a page of the CCLA signatures of the company for the CLA group
+func (repo *SignatureRepository) GetProjectCompanySignatures(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signatures, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getProjectCompanySignatures(ctx, companyID, projectID, signed, approved, nextKey, pageSize), nil
+}
+
+// getProjectCompanySignatures queries the CCLA signatures of the company, the caller holds the lock
+func (repo *SignatureRepository) getProjectCompanySignatures(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) *models.Signatures {
+	limit := int64(10)
+	if pageSize != nil {
+		limit = *pageSize
+	}
+
+	items, lastEvaluatedKey := repo.querySignatures(nextKey, limit, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == projectID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureReferenceID == companyID && item.SignatureType == signatures.SignatureTypeCCLA &&
+			item.SignatureReferenceType == signatures.ReferenceTypeCompany &&
+			(signed == nil || item.SignatureSigned == *signed) && (approved == nil || item.SignatureApproved == *approved)
+	}, pageDone(limit))
+	sigs := repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails)
+
+	return &models.Signatures{
+		ProjectID:      projectID,
+		ResultCount:    int64(len(sigs)),
+		TotalCount:     int64(len(repo.signatures)),
+		LastKeyScanned: lastEvaluatedKey,
+		Signatures:     sigs,
+	}
+}
+
+// GetProjectCompanyEmployeeSignatures returns a page of the signed and approved employee signatures of the company for
+// the CLA group
+func (repo *SignatureRepository) GetProjectCompanyEmployeeSignatures(ctx context.Context, params signatureOps.GetProjectCompanyEmployeeSignaturesParams, pageSize int64) (*models.Signatures, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, lastEvaluatedKey := repo.querySignatures(params.NextKey, 0, func(item signatures.ItemSignature) bool {
+		return item.SignatureUserCompanyID == params.CompanyID && item.SignatureProjectID == params.ProjectID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureApproved && item.SignatureSigned
+	}, pageDone(pageSize))
+	sigs := append(make([]*models.Signature, 0), repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails)...)
+	if int64(len(sigs)) > pageSize {
+		sigs = sigs[0:pageSize]
+		lastEvaluatedKey = sigs[pageSize-1].SignatureID.String()
+	}
+
+	return &models.Signatures{
+		ProjectID:      params.ProjectID,
+		ResultCount:    int64(len(sigs)),
+		TotalCount:     int64(len(repo.signatures)),
+		LastKeyScanned: lastEvaluatedKey,
+		Signatures:     sigs,
+	}, nil
+}
+
+// GetCompanySignatures returns a page of the signed and approved signatures of the company, optionally of the
+// signature type
+func (repo *SignatureRepository) GetCompanySignatures(ctx context.Context, params signatureOps.GetCompanySignaturesParams, pageSize int64, loadACL bool) (*models.Signatures, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, lastEvaluatedKey := repo.querySignatures(params.NextKey, 0, func(item signatures.ItemSignature) bool {
+		return item.SignatureReferenceID == params.CompanyID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureApproved && item.SignatureSigned &&
+			(params.SignatureType == nil || item.SignatureType == *params.SignatureType)
+	}, pageDone(pageSize))
+	sigs := append(make([]*models.Signature, 0), repo.buildSignatureModels(ctx, items, loadACL)...)
+	if int64(len(sigs)) > pageSize {
+		sigs = sigs[0:pageSize]
+		lastEvaluatedKey = sigs[pageSize-1].SignatureID.String()
+	}
+
+	return &models.Signatures{
+		ProjectID:      "",
+		ResultCount:    int64(len(sigs)),
+		TotalCount:     int64(len(repo.signatures)),
+		LastKeyScanned: lastEvaluatedKey,
+		Signatures:     sigs,
+	}, nil
+}
+
+// GetCompanyIDsWithSignedCorporateSignatures returns the companies with a signed and approved CCLA signature for the
+// CLA group, completed with the company SFID and name when the company is known
+func (repo *SignatureRepository) GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]signatures.SignatureCompanyID, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, _ := repo.querySignatures(nil, 100, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == claGroupID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureType == signatures.SignatureTypeCCLA && item.SignatureReferenceType == signatures.ReferenceTypeCompany &&
+			item.SignatureSigned && item.SignatureApproved
+	}, nil)
+
+	var companyIDs []signatures.SignatureCompanyID
+	for _, item := range items {
+		signatureCompanyID := signatures.SignatureCompanyID{
+			SignatureID: item.SignatureID,
+			CompanyID:   item.SignatureReferenceID,
+		}
+		companyModel, err := repo.companyRepo.GetCompany(ctx, item.SignatureReferenceID)
+		if err == nil && companyModel != nil {
+			signatureCompanyID.CompanySFID = companyModel.CompanyExternalID
+			signatureCompanyID.CompanyName = companyModel.CompanyName
+		}
+		companyIDs = append(companyIDs, signatureCompanyID)
+	}
+	return companyIDs, nil
+}
+
+// GetClaGroupCorporateSignatureSummaries returns the signed and approved CCLA signatures of the CLA group with their
+// CLA managers
+func (repo *SignatureRepository) GetClaGroupCorporateSignatureSummaries(ctx context.Context, claGroupID string) ([]signatures.CorporateSignatureSummary, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, _ := repo.querySignatures(nil, 100, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == claGroupID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureType == signatures.SignatureTypeCCLA && item.SignatureReferenceType == signatures.ReferenceTypeCompany &&
+			item.SignatureSigned && item.SignatureApproved
+	}, nil)
+
+	var summaries []signatures.CorporateSignatureSummary
+	for _, item := range items {
+		summaries = append(summaries, signatures.CorporateSignatureSummary{
+			SignatureID:  item.SignatureID,
+			CompanyID:    item.SignatureReferenceID,
+			MajorVersion: item.SignatureDocumentMajorVersion,
+			ClaManagers:  item.SignatureACL,
+		})
+	}
+	return summaries, nil
+}
+
+// GetClaGroupEmployeeSignatureCounts returns the number of signed and approved employee signatures of the CLA group by
+// company ID
+func (repo *SignatureRepository) GetClaGroupEmployeeSignatureCounts(ctx context.Context, claGroupID string) (map[string]int64, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, _ := repo.querySignatures(nil, 100, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == claGroupID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureType == signatures.SignatureTypeCLA && item.SignatureReferenceType == signatures.ReferenceTypeUser &&
+			item.SignatureSigned && item.SignatureApproved && item.SignatureUserCompanyID != ""
+	}, nil)
+
+	counts := make(map[string]int64)
+	for _, item := range items {
+		counts[item.SignatureUserCompanyID]++
+	}
+	return counts, nil
+}
+
+// GetUserSignatures returns a page of the signatures of the user
+func (repo *SignatureRepository) GetUserSignatures(ctx context.Context, params signatureOps.GetUserSignaturesParams, pageSize int64) (*models.Signatures, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, lastEvaluatedKey := repo.querySignatures(params.NextKey, pageSize, func(item signatures.ItemSignature) bool {
+		return item.SignatureReferenceID == params.UserID
+	}, nil, pageDone(pageSize))
+	sigs := append(make([]*models.Signature, 0), repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails)...)
+
+	return &models.Signatures{
+		ProjectID:      "",
+		ResultCount:    int64(len(sigs)),
+		TotalCount:     int64(len(repo.signatures)),
+		LastKeyScanned: lastEvaluatedKey,
+		Signatures:     sigs,
+	}, nil
+}
+
+// ProjectSignatures returns all the signed and approved signatures of the CLA group
+func (repo *SignatureRepository) ProjectSignatures(ctx context.Context, projectID string) (*models.Signatures, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, _ := repo.querySignatures(nil, 0, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == projectID
+	}, func(item signatures.ItemSignature) bool {
+		return item.SignatureApproved && item.SignatureSigned
+	}, nil)
+
+	return &models.Signatures{
+		ProjectID:  projectID,
+		Signatures: append(make([]*models.Signature, 0), repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails)...),
+	}, nil
+}
+
+// UpdateApprovalList adds and removes the approval list entries of the signed and approved CCLA signature of the
+// company - an emptied approval list is removed from the signature
+func (repo *SignatureRepository) UpdateApprovalList(ctx context.Context, projectID, companyID string, params *models.ApprovalList) (*models.Signature, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	signed, approved := true, true
+	pageSize := int64(10)
+	sigs := repo.getProjectCompanySignatures(ctx, companyID, projectID, &signed, &approved, nil, &pageSize)
+	if sigs.Signatures == nil {
+		return nil, fmt.Errorf("unable to locate signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
+			companyID, projectID, signed, approved)
+	}
+
+	sig := sigs.Signatures[0]
+	approvalLists := []struct {
+		add, remove []string
+		existing    func(sig *models.Signature) []string
+		set         func(item *signatures.ItemSignature, list []string)
+	}{
+		{
+			add: params.AddEmailApprovalList, remove: params.RemoveEmailApprovalList,
+			existing: func(sig *models.Signature) []string { return sig.EmailApprovalList },
+			set:      func(item *signatures.ItemSignature, list []string) { item.EmailWhitelist = list },
+		},
+		{
+			add: params.AddDomainApprovalList, remove: params.RemoveDomainApprovalList,
+			existing: func(sig *models.Signature) []string { return sig.DomainApprovalList },
+			set:      func(item *signatures.ItemSignature, list []string) { item.DomainWhitelist = list },
+		},
+		{
+			add: params.AddGithubUsernameApprovalList, remove: params.RemoveGithubUsernameApprovalList,
+			existing: func(sig *models.Signature) []string { return sig.GithubUsernameApprovalList },
+			set:      func(item *signatures.ItemSignature, list []string) { item.GitHubWhitelist = list },
+		},
+		{
+			add: params.AddGithubOrgApprovalList, remove: params.RemoveGithubOrgApprovalList,
+			existing: func(sig *models.Signature) []string { return sig.GithubOrgApprovalList },
+			set:      func(item *signatures.ItemSignature, list []string) { item.GitHubOrgWhitelist = list },
+		},
+	}
+
+	var updates []func(item *signatures.ItemSignature)
+	for _, approvalList := range approvalLists {
+		if approvalList.add == nil && approvalList.remove == nil {
+			continue
+		}
+		set := approvalList.set
+		list := buildApprovalList(approvalList.existing(sig), approvalList.add, approvalList.remove)
+		if list == nil {
+			repo.update(sig.SignatureID.String(), func(item *signatures.ItemSignature) { set(item, nil) })
+			sig = repo.getSignature(ctx, sig.SignatureID.String())
+			continue
+		}
+		updates = append(updates, func(item *signatures.ItemSignature) { set(item, list) })
+	}
+
+	if len(updates) == 0 {
+		return sig, nil
+	}
+	repo.update(sig.SignatureID.String(), func(item *signatures.ItemSignature) {
+		for _, update := range updates {
+			update(item)
+		}
+	})
+
+	updatedSigs := repo.getProjectCompanySignatures(ctx, companyID, projectID, &signed, &approved, nil, &pageSize)
+	if updatedSigs.Signatures == nil {
+		return nil, fmt.Errorf("unable to locate signature after update for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
+			companyID, projectID, signed, approved)
+	}
+	return updatedSigs.Signatures[0], nil
+}
+
+// UpdateApprovalListEntries replaces the approval list entry metadata of the signature, removing it when the list is
+// empty, if it is still at the version it was loaded with
+func (repo *SignatureRepository) UpdateApprovalListEntries(ctx context.Context, signatureID string, version int64, entries []*models.ApprovalListEntry) error {
+	var dbEntries []signatures.ItemApprovalListEntry
+	for _, entry := range entries {
+		dbEntry := signatures.ItemApprovalListEntry{
+			ListType:     entry.ListType,
+			Value:        entry.Value,
+			AddedBy:      entry.AddedBy,
+			DateAdded:    entry.DateAdded,
+			ReminderSent: entry.ReminderSent,
+		}
+		if entry.ExpiresAt != nil {
+			dbEntry.ExpiresAt = entry.ExpiresAt.String()
+		}
+		dbEntries = append(dbEntries, dbEntry)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if item, ok := repo.signatures[signatureID]; ok && item.ApprovalListEntriesVersion != version {
+		return signatures.ErrApprovalListEntriesChanged
+	}
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.ApprovalListEntries = dbEntries
+		item.ApprovalListEntriesVersion = version + 1
+	})
+	return nil
+}
+
+// GetSignaturesWithApprovalListEntries returns the signed and approved CCLA signatures with approval list entry
+// metadata
+func (repo *SignatureRepository) GetSignaturesWithApprovalListEntries(ctx context.Context) ([]*models.Signature, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	items, _ := repo.querySignatures(nil, 0, func(item signatures.ItemSignature) bool {
+		return item.ApprovalListEntries != nil && item.SignatureType == signatures.SignatureTypeCCLA &&
+			item.SignatureSigned && item.SignatureApproved
+	}, nil, nil)
+	return repo.buildSignatureModels(ctx, items, signatures.LoadACLDetails), nil
+}
+
+// AddCLAManager adds the CLA manager to the signature ACL, it fails if the manager is already in the ACL
+func (repo *SignatureRepository) AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item, ok := repo.signatures[signatureID]
+	if !ok || item.SignatureACL == nil {
+		return nil, nil
+	}
+	if containsString(item.SignatureACL, claManagerID) {
+		return nil, errors.New("manager already in signature ACL")
+	}
+
+	_, now := utils.CurrentTime()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.SignatureACL = append(append([]string{}, item.SignatureACL...), claManagerID)
+		item.DateModified = now
+	})
+	return repo.getSignature(ctx, signatureID), nil
+}
+
+// RemoveCLAManager removes the CLA manager from the signature ACL, it fails if the manager is not in the ACL
+func (repo *SignatureRepository) RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item, ok := repo.signatures[signatureID]
+	if !ok || item.SignatureACL == nil {
+		return nil, nil
+	}
+	if !containsString(item.SignatureACL, claManagerID) {
+		return nil, fmt.Errorf("manager ID: %s not found in signature ACL", claManagerID)
+	}
+
+	acl := []string{}
+	for _, manager := range item.SignatureACL {
+		if manager != claManagerID {
+			acl = append(acl, manager)
+		}
+	}
+	_, now := utils.CurrentTime()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.SignatureACL = acl
+		item.DateModified = now
+	})
+	return repo.getSignature(ctx, signatureID), nil
+}
+
+// AddSigTypeSignedApprovedID sets the sort key of the signature type, signed and approved index
+func (repo *SignatureRepository) AddSigTypeSignedApprovedID(ctx context.Context, signatureID string, val string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.SigtypeSignedApprovedID = val
+	})
+	return nil
+}
+
+// AddUsersDetails copies the user name, email, LF and GitHub usernames of the user into the signature
+func (repo *SignatureRepository) AddUsersDetails(ctx context.Context, signatureID string, userID string) error {
+	userModel, err := repo.usersRepo.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	if userModel == nil {
+		return fmt.Errorf("invalid user id : %s for signature : %s", userID, signatureID)
+	}
+
+	email := userModel.LfEmail
+	if email == "" && len(userModel.Emails) > 0 {
+		email = userModel.Emails[0]
+	}
+	if userModel.GithubUsername == "" && userModel.LfUsername == "" && userModel.Username == "" && email == "" {
+		return nil
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		if userModel.GithubUsername != "" {
+			item.UserGithubUsername = userModel.GithubUsername
+		}
+		if userModel.LfUsername != "" {
+			item.UserLFUsername = userModel.LfUsername
+		}
+		if userModel.Username != "" {
+			item.UserName = userModel.Username
+		}
+		if email != "" {
+			item.UserEmail = email
+		}
+	})
+	return nil
+}
+
+// AddSignedOn sets the signed on date of the signature to now
+func (repo *SignatureRepository) AddSignedOn(ctx context.Context, signatureID string) error {
+	_, currentTime := utils.CurrentTime()
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.SignedOn = currentTime
+	})
+	return nil
+}
+
+// AddSignedDocumentHash sets the SHA-256 hash of the signed document and the hashed on date of the signature
+func (repo *SignatureRepository) AddSignedDocumentHash(ctx context.Context, signatureID string, sha256 string) error {
+	_, currentTime := utils.CurrentTime()
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.update(signatureID, func(item *signatures.ItemSignature) {
+		item.SignatureDocumentSha256 = sha256
+		item.SignatureDocumentHashedOn = currentTime
+	})
+	return nil
+}
+
+// GetClaGroupICLASignatures returns the signed and approved ICLA signatures of the CLA group, optionally only the ones
+// with the reference name containing the search term
+func (repo *SignatureRepository) GetClaGroupICLASignatures(ctx context.Context, claGroupID string, searchTerm *string) (*models.IclaSignatures, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	out := &models.IclaSignatures{List: make([]*models.IclaSignature, 0)}
+	sortKeyPrefix := fmt.Sprintf("%s#%v#%v", signatures.ICLA, true, true)
+	for _, sig := range repo.findBySigTypeSignedApprovedID(claGroupID, searchTerm, func(sortKey string) bool {
+		return strings.HasPrefix(sortKey, sortKeyPrefix)
+	}) {
+		signedOn := sig.DateCreated
+		if sig.SignedOn != "" {
+			signedOn = sig.SignedOn
+		}
+		out.List = append(out.List, &models.IclaSignature{
+			GithubUsername: sig.UserGithubUsername,
+			LfUsername:     sig.UserLFUsername,
+			SignatureID:    sig.SignatureID,
+			UserEmail:      sig.UserEmail,
+			UserName:       sig.UserName,
+			SignedOn:       signedOn,
+		})
+	}
+	return out, nil
+}
+
+// GetClaGroupCorporateContributors returns the signed and approved employee signatures of the CLA group sorted by
+// name, optionally only the ones of the company and with the reference name containing the search term
+func (repo *SignatureRepository) GetClaGroupCorporateContributors(ctx context.Context, claGroupID string, companyID *string, searchTerm *string) (*models.CorporateContributorList, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	sortKeyMatch := func(sortKey string) bool {
+		return strings.HasPrefix(sortKey, fmt.Sprintf("%s#%v#%v", signatures.ECLA, true, true))
+	}
+	if companyID != nil {
+		sortKeyMatch = func(sortKey string) bool {
+			return sortKey == fmt.Sprintf("%s#%v#%v#%v", signatures.ECLA, true, true, *companyID)
+		}
+	}
+
+	out := &models.CorporateContributorList{List: make([]*models.CorporateContributor, 0)}
+	for _, sig := range repo.findBySigTypeSignedApprovedID(claGroupID, searchTerm, sortKeyMatch) {
+		var sigCreatedTime = sig.DateCreated
+		t, err := utils.ParseDateTime(sig.DateCreated)
+		if err == nil {
+			sigCreatedTime = utils.TimeToString(t)
+		}
+		out.List = append(out.List, &models.CorporateContributor{
+			GithubID:          sig.UserGithubUsername,
+			LinuxFoundationID: sig.UserLFUsername,
+			Name:              sig.UserName,
+			SignatureVersion:  fmt.Sprintf("v%s.%s", sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion),
+			Email:             sig.UserEmail,
+			Timestamp:         sigCreatedTime,
+		})
+	}
+	sort.Slice(out.List, func(i, j int) bool {
+		return out.List[i].Name < out.List[j].Name
+	})
+	return out, nil
+}
+
+// findBySigTypeSignedApprovedID returns the signatures of the CLA group with the signature type, signed and approved
+// sort key accepted by the match function and the reference name containing the search term, ordered by the sort key
+// like the index, the caller holds the lock
+func (repo *SignatureRepository) findBySigTypeSignedApprovedID(claGroupID string, searchTerm *string, sortKeyMatch func(sortKey string) bool) []signatures.ItemSignature {
+	items, _ := repo.querySignatures(nil, 0, func(item signatures.ItemSignature) bool {
+		return item.SignatureProjectID == claGroupID && sortKeyMatch(item.SigtypeSignedApprovedID)
+	}, func(item signatures.ItemSignature) bool {
+		return searchTerm == nil || strings.Contains(item.SignatureReferenceNameLower, strings.ToLower(*searchTerm))
+	}, nil)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].SigtypeSignedApprovedID < items[j].SigtypeSignedApprovedID
+	})
+	return items
+}
+
+// querySignatures emulates a paginated query: the signatures accepted by the key function after the next key are
+// evaluated in signature ID order, limit at a time, and the ones accepted by the filter are returned along with the
+// last evaluated signature ID, the caller holds the lock
+func (repo *SignatureRepository) querySignatures(nextKey *string, limit int64, key, filter func(item signatures.ItemSignature) bool, done func(matched int) bool) ([]signatures.ItemSignature, string) {
+	ids := make([]string, 0, len(repo.signatures))
+	for id := range repo.signatures {
+		ids = append(ids, id)
+	}
+	ids = sortedKeys(ids)
+
+	var keys []string
+	for _, id := range ids[startAfter(ids, nextKey):] {
+		if key(repo.signatures[id]) {
+			keys = append(keys, id)
+		}
+	}
+
+	matched, lastEvaluatedKey := evaluatePages(keys, limit, func(i int) bool {
+		return filter == nil || filter(repo.signatures[keys[i]])
+	}, done)
+	var items []signatures.ItemSignature
+	for _, i := range matched {
+		items = append(items, repo.signatures[keys[i]])
+	}
+	return items, lastEvaluatedKey
+}
+
+// update applies the change to the signature, creating the signature record when missing like an update of the
+// DynamoDB item, the caller holds the lock
+func (repo *SignatureRepository) update(signatureID string, apply func(item *signatures.ItemSignature)) {
+	item, ok := repo.signatures[signatureID]
+	if !ok {
+		item = signatures.ItemSignature{SignatureID: signatureID}
+	}
+	apply(&item)
+	repo.signatures[signatureID] = item
+}
+
+// buildSignatureModels converts the signature records into response models completed with the user, company and
+// optionally the CLA manager details, nil when there are no records
+func (repo *SignatureRepository) buildSignatureModels(ctx context.Context, items []signatures.ItemSignature, loadACLDetails bool) []*models.Signature {
+	var sigs []*models.Signature
+	for _, item := range items {
+		sig := &models.Signature{
+			SignatureID:                 strfmt.UUID4(item.SignatureID),
+			ClaType:                     claTypeOf(item),
+			SignatureCreated:            item.DateCreated,
+			SignatureModified:           item.DateModified,
+			SignatureType:               item.SignatureType,
+			SignatureReferenceID:        strfmt.UUID4(item.SignatureReferenceID),
+			SignatureReferenceName:      item.SignatureReferenceName,
+			SignatureReferenceNameLower: item.SignatureReferenceNameLower,
+			SignatureSigned:             item.SignatureSigned,
+			SignatureApproved:           item.SignatureApproved,
+			SignatureMajorVersion:       item.SignatureDocumentMajorVersion,
+			SignatureMinorVersion:       item.SignatureDocumentMinorVersion,
+			Version:                     item.SignatureDocumentMajorVersion + "." + item.SignatureDocumentMinorVersion,
+			SignatureReferenceType:      item.SignatureReferenceType,
+			ProjectID:                   item.SignatureProjectID,
+			Created:                     item.DateCreated,
+			Modified:                    item.DateModified,
+			EmailApprovalList:           copyStrings(item.EmailWhitelist),
+			DomainApprovalList:          copyStrings(item.DomainWhitelist),
+			GithubUsernameApprovalList:  copyStrings(item.GitHubWhitelist),
+			GithubOrgApprovalList:       copyStrings(item.GitHubOrgWhitelist),
+			SignedOn:                    item.SignedOn,
+			SignatoryName:               item.SignatoryName,
+			SignatureDocumentSha256:     item.SignatureDocumentSha256,
+			SignatureDocumentHashedOn:   item.SignatureDocumentHashedOn,
+			ApprovalListEntries:         buildApprovalListEntryModels(item.ApprovalListEntries),
+			ApprovalListEntriesVersion:  item.ApprovalListEntriesVersion,
+		}
+
+		if item.SignatureReferenceType == signatures.ReferenceTypeUser {
+			userModel, err := repo.usersRepo.GetUser(item.SignatureReferenceID)
+			if err == nil && userModel != nil {
+				sig.UserName = userModel.Username
+				sig.UserLFID = userModel.LfUsername
+				sig.UserGHID = userModel.GithubID
+				sig.UserGHUsername = userModel.GithubUsername
+			}
+			if item.SignatureUserCompanyID != "" {
+				sig.CompanyName = repo.companyName(ctx, item.SignatureUserCompanyID)
+			}
+		} else if item.SignatureReferenceType == signatures.ReferenceTypeCompany {
+			sig.CompanyName = repo.companyName(ctx, item.SignatureReferenceID)
+		}
+
+		for _, userName := range item.SignatureACL {
+			if !loadACLDetails {
+				sig.SignatureACL = append(sig.SignatureACL, models.User{LfUsername: userName})
+				continue
+			}
+			userModel, err := repo.usersRepo.GetUserByUserName(userName, true)
+			if err == nil && userModel != nil {
+				sig.SignatureACL = append(sig.SignatureACL, *userModel)
+			}
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs
+}
+
+// companyName returns the name of the company, empty if the company is unknown
+func (repo *SignatureRepository) companyName(ctx context.Context, companyID string) string {
+	companyModel, err := repo.companyRepo.GetCompany(ctx, companyID)
+	if err != nil || companyModel == nil {
+		return ""
+	}
+	return companyModel.CompanyName
+}
+
+// claTypeOf returns the CLA type of the signature - icla, ecla or ccla - empty for other signatures
+func claTypeOf(item signatures.ItemSignature) string {
+	if item.SignatureReferenceType == signatures.ReferenceTypeCompany && item.SignatureType == signatures.SignatureTypeCCLA {
+		return signatures.CCLA
+	}
+	if item.SignatureReferenceType == signatures.ReferenceTypeUser && item.SignatureType == signatures.SignatureTypeCLA {
+		if item.SignatureUserCompanyID != "" {
+			return signatures.ECLA
+		}
+		return signatures.ICLA
+	}
+	return ""
+}
+
+// buildApprovalListEntryModels converts the approval list entry records, nil when there are no entries
+func buildApprovalListEntryModels(dbEntries []signatures.ItemApprovalListEntry) []*models.ApprovalListEntry {
+	if len(dbEntries) == 0 {
+		return nil
+	}
+	entries := make([]*models.ApprovalListEntry, 0, len(dbEntries))
+	for _, dbEntry := range dbEntries {
+		entry := &models.ApprovalListEntry{
+			ListType:     dbEntry.ListType,
+			Value:        dbEntry.Value,
+			AddedBy:      dbEntry.AddedBy,
+			DateAdded:    dbEntry.DateAdded,
+			ReminderSent: dbEntry.ReminderSent,
+		}
+		if dbEntry.ExpiresAt != "" {
+			if expiresAt, err := strfmt.ParseDateTime(dbEntry.ExpiresAt); err == nil {
+				entry.ExpiresAt = &expiresAt
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// buildApprovalList returns the existing entries with the added entries and without the removed entries, nil when
+// the list is empty
+func buildApprovalList(existingList, addEntries, removeEntries []string) []string {
+	var updatedList []string
+	for _, value := range append(append([]string{}, existingList...), addEntries...) {
+		if !utils.StringInSlice(value, updatedList) {
+			updatedList = append(updatedList, strings.TrimSpace(value))
+		}
+	}
+	return utils.RemoveDuplicates(utils.RemoveItemsFromList(updatedList, removeEntries))
+}
+
+// buildGithubOrgs converts the GitHub organization approval list into selected organization models, nil when the list
+// is empty
+func buildGithubOrgs(list []string) []models.GithubOrg {
+	var orgs []models.GithubOrg
+	for _, org := range list {
+		id, selected := org, true
+		orgs = append(orgs, models.GithubOrg{
+			ID:       &id,
+			Selected: &selected,
+		})
+	}
+	return orgs
+}
+
+// latestSignature returns the signature with the highest document major version, the first one on a tie
+func latestSignature(sigs []*models.Signature) *models.Signature {
+	var latest *models.Signature
+	for _, sig := range sigs {
+		if latest == nil || utils.SignatureMajorVersion(sig) > utils.SignatureMajorVersion(latest) {
+			latest = sig
+		}
+	}
+	return latest
+}
+
+// allOf returns a condition accepting the signatures accepted by all the conditions
+func allOf(conditions []func(item signatures.ItemSignature) bool) func(item signatures.ItemSignature) bool {
+	return func(item signatures.ItemSignature) bool {
+		for _, condition := range conditions {
+			if !condition(item) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// pageDone returns true once the query matched a full page
+func pageDone(pageSize int64) func(matched int) bool {
+	return func(matched int) bool {
+		return int64(matched) >= pageSize
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	signatureOps "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureRepositoryConformance(t *testing.T) {
+	t.Run("fake", func(t *testing.T) {
+		companyRepo, usersRepo := NewCompanyRepository(), NewUserRepository()
+		repo := NewSignatureRepository(companyRepo, usersRepo)
+		testSignatureRepository(t, repo, repo.AddSignature, usersRepo.AddUser, companyRepo.AddCompany)
+	})
+	for _, target := range conformanceTargets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			awsSession, stage := target.newStage(t)
+			repo := signatures.NewRepository(awsSession, stage, company.NewRepository(awsSession, stage), users.NewRepository(awsSession, stage))
+			testSignatureRepository(t, repo, func(item signatures.ItemSignature) {
+				putItem(t, awsSession, stage, "signatures", item)
+			}, func(user users.DBUser) {
+				putItem(t, awsSession, stage, "users", user)
+			}, func(dbModel company.DBModel) {
+				putItem(t, awsSession, stage, "companies", dbModel)
+			})
+		})
+	}
+}
+
+func signatureIDs(sigs []*models.Signature) []string {
+	var ids []string
+	for _, sig := range sigs {
+		ids = append(ids, sig.SignatureID.String())
+	}
+	return ids
+}
+
+func githubOrgIDs(orgs []models.GithubOrg) []string {
+	var ids []string
+	for _, org := range orgs {
+		ids = append(ids, aws.StringValue(org.ID))
+	}
+	return ids
+}
+
+func testSignatureRepository(t *testing.T, repo signatures.SignatureRepository, addSignature func(item signatures.ItemSignature),
+	addUser func(user users.DBUser), addCompany func(dbModel company.DBModel)) {
+	ctx := context.Background()
+	_, now := utils.CurrentTime()
+	addUser(users.DBUser{UserID: "u1", LFUsername: "alice", UserName: "Alice"})
+	addUser(users.DBUser{UserID: "u2", LFUsername: "bob", UserName: "Bob"})
+	addCompany(company.DBModel{CompanyID: "c1", CompanyName: "Acme Corp", Created: now, Updated: now})
+
+	signature := func(id, projectID, referenceID, referenceType, signatureType, name string, signed bool) signatures.ItemSignature {
+		return signatures.ItemSignature{SignatureID: id, SignatureProjectID: projectID, SignatureReferenceID: referenceID,
+			SignatureReferenceType: referenceType, SignatureType: signatureType, SignatureReferenceName: name,
+			SignatureReferenceNameLower: name, SignatureSigned: signed, SignatureApproved: true,
+			SignatureDocumentMajorVersion: "2", SignatureDocumentMinorVersion: "0", DateCreated: now, DateModified: now}
+	}
+	ccla := signature("s1", "p1", "c1", signatures.ReferenceTypeCompany, signatures.SignatureTypeCCLA, "acme corp", true)
+	ccla.SignatureACL = []string{"alice"}
+	addSignature(ccla)
+	icla := signature("s2", "p1", "u1", signatures.ReferenceTypeUser, signatures.SignatureTypeCLA, "alice", true)
+	icla.SigtypeSignedApprovedID = "icla#true#true#u1"
+	addSignature(icla)
+	ecla := signature("s3", "p1", "u2", signatures.ReferenceTypeUser, signatures.SignatureTypeCLA, "bob", true)
+	ecla.SignatureUserCompanyID, ecla.SigtypeSignedApprovedID, ecla.UserName = "c1", "ecla#true#true#c1", "Bob"
+	addSignature(ecla)
+	addSignature(signature("s4", "p1", "u3", signatures.ReferenceTypeUser, signatures.SignatureTypeCLA, "dave", false))
+	carol := signature("s5", "p1", "u4", signatures.ReferenceTypeUser, signatures.SignatureTypeCLA, "carol", true)
+	carol.SigtypeSignedApprovedID = "icla#true#true#u4"
+	addSignature(carol)
+	addSignature(signature("s6", "p2", "u1", signatures.ReferenceTypeUser, signatures.SignatureTypeCLA, "alice", true))
+
+	sig, err := repo.GetSignature(ctx, "s1")
+	if assert.NoError(t, err) && assert.NotNil(t, sig) {
+		assert.Equal(t, signatures.CCLA, sig.ClaType)
+		assert.Equal(t, "Acme Corp", sig.CompanyName)
+		if assert.Len(t, sig.SignatureACL, 1) {
+			assert.Equal(t, "u1", sig.SignatureACL[0].UserID)
+		}
+	}
+	sig, err = repo.GetSignature(ctx, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, sig)
+
+	sig, err = repo.GetIndividualSignature(ctx, "p1", "u1")
+	if assert.NoError(t, err) && assert.NotNil(t, sig) {
+		assert.Equal(t, "s2", sig.SignatureID.String())
+		assert.Equal(t, "Alice", sig.UserName)
+	}
+	sig, err = repo.GetIndividualSignature(ctx, "p1", "u3")
+	assert.NoError(t, err)
+	assert.Nil(t, sig)
+	sig, err = repo.GetCorporateSignature(ctx, "p1", "c1")
+	if assert.NoError(t, err) && assert.NotNil(t, sig) {
+		assert.Equal(t, "s1", sig.SignatureID.String())
+	}
+
+	// the pages evaluate page size signatures before the signed and approved filter is applied
+	page, err := repo.GetProjectSignatures(ctx, signatureOps.GetProjectSignaturesParams{ProjectID: "p1"}, 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"s1", "s2"}, signatureIDs(page.Signatures))
+		assert.Equal(t, "s2", page.LastKeyScanned)
+		assert.Equal(t, int64(6), page.TotalCount)
+	}
+	page, err = repo.GetProjectSignatures(ctx, signatureOps.GetProjectSignaturesParams{ProjectID: "p1", NextKey: aws.String("s2")}, 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"s3", "s5"}, signatureIDs(page.Signatures))
+		assert.Equal(t, "", page.LastKeyScanned)
+	}
+	page, err = repo.GetProjectSignatures(ctx, signatureOps.GetProjectSignaturesParams{ProjectID: "p1", ClaType: aws.String(signatures.ICLA)}, 10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"s2", "s5"}, signatureIDs(page.Signatures))
+	}
+	page, err = repo.GetProjectSignatures(ctx, signatureOps.GetProjectSignaturesParams{ProjectID: "p1", SearchTerm: aws.String("Bob"), FullMatch: aws.Bool(true)}, 10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"s3"}, signatureIDs(page.Signatures))
+	}
+
+	page, err = repo.GetProjectCompanySignatures(ctx, "c1", "p1", nil, nil, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"s1"}, signatureIDs(page.Signatures))
+	}
+	page, err = repo.GetProjectCompanyEmployeeSignatures(ctx, signatureOps.GetProjectCompanyEmployeeSignaturesParams{CompanyID: "c1", ProjectID: "p1"}, 10)
+	if assert.NoError(t, err) && assert.Len(t, page.Signatures, 1) {
+		assert.Equal(t, "s3", page.Signatures[0].SignatureID.String())
+		assert.Equal(t, signatures.ECLA, page.Signatures[0].ClaType)
+		assert.Equal(t, "Acme Corp", page.Signatures[0].CompanyName)
+	}
+	page, err = repo.GetUserSignatures(ctx, signatureOps.GetUserSignaturesParams{UserID: "u1"}, 10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"s2", "s6"}, signatureIDs(page.Signatures))
+	}
+
+	iclas, err := repo.GetClaGroupICLASignatures(ctx, "p1", aws.String("CAR"))
+	if assert.NoError(t, err) && assert.Len(t, iclas.List, 1) {
+		assert.Equal(t, "s5", iclas.List[0].SignatureID)
+	}
+	contributors, err := repo.GetClaGroupCorporateContributors(ctx, "p1", aws.String("c1"), nil)
+	if assert.NoError(t, err) && assert.Len(t, contributors.List, 1) {
+		assert.Equal(t, "Bob", contributors.List[0].Name)
+		assert.Equal(t, "v2.0", contributors.List[0].SignatureVersion)
+	}
+
+	orgs, err := repo.GetGithubOrganizationsFromWhitelist(ctx, "s1")
+	assert.NoError(t, err)
+	assert.Nil(t, orgs)
+	_, err = repo.DeleteGithubOrganizationFromWhitelist(ctx, "s1", "org-b")
+	assert.Error(t, err)
+	_, err = repo.AddGithubOrganizationToWhitelist(ctx, "s1", "org-b")
+	assert.NoError(t, err)
+	orgs, err = repo.AddGithubOrganizationToWhitelist(ctx, "s1", "org-a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"org-b", "org-a"}, githubOrgIDs(orgs))
+	}
+	orgs, err = repo.GetGithubOrganizationsFromWhitelist(ctx, "s1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"org-a", "org-b"}, githubOrgIDs(orgs))
+	}
+	orgs, err = repo.DeleteGithubOrganizationFromWhitelist(ctx, "s1", "org-b")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"org-a"}, githubOrgIDs(orgs))
+	}
+	orgs, err = repo.DeleteGithubOrganizationFromWhitelist(ctx, "s1", "org-a")
+	if assert.NoError(t, err) {
+		assert.Empty(t, orgs)
+	}
+	orgs, err = repo.GetGithubOrganizationsFromWhitelist(ctx, "s1")
+	assert.NoError(t, err)
+	assert.Nil(t, orgs)
+
+	sig, err = repo.UpdateApprovalList(ctx, "p1", "c1", &models.ApprovalList{AddEmailApprovalList: []string{"a@acme.org", " b@acme.org"}})
+	if assert.NoError(t, err) && assert.NotNil(t, sig) {
+		assert.Equal(t, []string{"a@acme.org", "b@acme.org"}, sig.EmailApprovalList)
+	}
+	sig, err = repo.UpdateApprovalList(ctx, "p1", "c1", &models.ApprovalList{RemoveEmailApprovalList: []string{"a@acme.org", "b@acme.org"}})
+	if assert.NoError(t, err) && assert.NotNil(t, sig) {
+		assert.Nil(t, sig.EmailApprovalList)
+	}
+	_, err = repo.UpdateApprovalList(ctx, "p1", "c2", &models.ApprovalList{AddEmailApprovalList: []string{"a@acme.org"}})
+	assert.Error(t, err)
+
+	sig, err = repo.AddCLAManager(ctx, "s1", "bob")
+	if assert.NoError(t, err) && assert.NotNil(t, sig) {
+		assert.Len(t, sig.SignatureACL, 2)
+	}
+	_, err = repo.AddCLAManager(ctx, "s1", "bob")
+	assert.Error(t, err)
+	_, err = repo.RemoveCLAManager(ctx, "s1", "carl")
+	assert.Error(t, err)
+	acl, err := repo.GetSignatureACL(ctx, "s1")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"alice", "bob"}, acl)
+
+	assert.NoError(t, repo.InvalidateProjectRecord(ctx, "s5", "f1", "Project"))
+	sig, err = repo.GetIndividualSignature(ctx, "p1", "u4")
+	assert.NoError(t, err)
+	assert.Nil(t, sig)
+}
@@ -0,0 +1,325 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/google/uuid"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+)
+
+// UserRepository is an in-memory implementation of the users repository
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[string]users.DBUser
+}
+
+var _ users.UserRepository = (*UserRepository)(nil)
+
+// NewUserRepository creates a new, empty in-memory users repository
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users: map[string]users.DBUser{},
+	}
+}
+
+// AddUser adds or replaces the user record
+func (repo *UserRepository) AddUser(user users.DBUser) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	user.UserEmails = copyStrings(user.UserEmails)
+	repo.users[user.UserID] = user
+}
+
+// CreateUser creates a new user
+func (repo *UserRepository) CreateUser(user *models.User) (*models.User, error) {
+	theUUID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	user.UserID = theUUID.String()
+	user.DateCreated = now
+	user.DateModified = now
+	user.Version = "v1"
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.users[user.UserID] = users.DBUser{
+		UserID:             user.UserID,
+		UserExternalID:     user.UserExternalID,
+		Admin:              user.Admin,
+		LFEmail:            user.LfEmail,
+		LFUsername:         user.LfUsername,
+		UserName:           user.Username,
+		UserGithubID:       user.GithubID,
+		UserGithubUsername: user.GithubUsername,
+		DateCreated:        now,
+		DateModified:       now,
+		Version:            "v1",
+	}
+	return user, nil
+}
+
+// Save saves the user model to the data store
+func (repo *UserRepository) Save(user *models.UserUpdate) (*models.User, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	oldUserModel, err := repo.getUserByUpdateModel(user)
+	if err != nil || oldUserModel == nil {
+		return nil, err
+	}
+
+	dbUser := repo.users[oldUserModel.UserID]
+	if user.LfEmail != "" {
+		dbUser.LFEmail = user.LfEmail
+	}
+	if user.LfUsername != "" {
+		dbUser.LFUsername = user.LfUsername
+	}
+	if user.CompanyID != "" {
+		dbUser.UserCompanyID = user.CompanyID
+	}
+	if user.GithubUsername != "" {
+		dbUser.UserGithubUsername = user.GithubUsername
+	}
+	if user.GithubID != "" {
+		dbUser.UserGithubID = user.GithubID
+	}
+	if user.PreferredLocale != "" {
+		dbUser.UserPreferredLocale = user.PreferredLocale
+	}
+	dbUser.DateModified = time.Now().UTC().Format(time.RFC3339)
+	repo.users[dbUser.UserID] = dbUser
+
+	return repo.getUserByUpdateModel(user)
+}
+
+// getUserByUpdateModel looks up the user by LF username and then by GitHub username, the caller holds the lock
+func (repo *UserRepository) getUserByUpdateModel(user *models.UserUpdate) (*models.User, error) {
+	var err error
+	var existingUserModel *models.User
+	if user.LfUsername != "" {
+		existingUserModel, err = repo.getUserByUserName(user.LfUsername)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if existingUserModel == nil && user.GithubUsername != "" {
+		existingUserModel, err = repo.getUserByGitHubUsername(user.GithubUsername)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return existingUserModel, nil
+}
+
+// Delete deletes the specified user
+func (repo *UserRepository) Delete(userID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	delete(repo.users, userID)
+	return nil
+}
+
+// GetUser retrieves the specified user using the user id
+func (repo *UserRepository) GetUser(userID string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	dbUser, ok := repo.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return convertDBUserModel(dbUser), nil
+}
+
+// GetUserByLFUserName retrieves the user by the LF username
+func (repo *UserRepository) GetUserByLFUserName(lfUserName string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.findUser(func(dbUser users.DBUser) bool {
+		return dbUser.LFUsername == lfUserName
+	}), nil
+}
+
+// GetUserByUserName retrieves the user by the LF username or by the GitHub ID when the user name has the form
+// github:<id> - like the DynamoDB repository, the full match flag is ignored
+func (repo *UserRepository) GetUserByUserName(userName string, fullMatch bool) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getUserByUserName(userName)
+}
+
+// getUserByUserName looks up the user by user name, the caller holds the lock
+func (repo *UserRepository) getUserByUserName(userName string) (*models.User, error) {
+	if strings.Contains(userName, "github:") {
+		githubID, err := strconv.Atoi(strings.Replace(userName, "github:", "", 1))
+		if err != nil {
+			return nil, err
+		}
+		return repo.findUser(func(dbUser users.DBUser) bool {
+			return dbUser.UserGithubID == strconv.Itoa(githubID)
+		}), nil
+	}
+
+	return repo.findUser(func(dbUser users.DBUser) bool {
+		return dbUser.LFUsername == userName
+	}), nil
+}
+
+// GetUserByEmail fetches the user record by email
+func (repo *UserRepository) GetUserByEmail(userEmail string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	user := repo.findUser(func(dbUser users.DBUser) bool {
+		return userEmail != "" && dbUser.LFEmail == userEmail
+	})
+	if user == nil {
+		return nil, errors.NotFound("user not found when searching by lf_email: %s", userEmail)
+	}
+	return user, nil
+}
+
+// GetUserByGitHubUsername fetches the user record by github username
+func (repo *UserRepository) GetUserByGitHubUsername(gitHubUsername string) (*models.User, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.getUserByGitHubUsername(gitHubUsername)
+}
+
+// getUserByGitHubUsername looks up the user by GitHub username, the caller holds the lock
+func (repo *UserRepository) getUserByGitHubUsername(gitHubUsername string) (*models.User, error) {
+	user := repo.findUser(func(dbUser users.DBUser) bool {
+		return gitHubUsername != "" && dbUser.UserGithubUsername == gitHubUsername
+	})
+	if user == nil {
+		return nil, errors.NotFound("user not found when searching by user_github_username: %s", gitHubUsername)
+	}
+	return user, nil
+}
+
+// SearchUsers returns the users with the database attribute matching the search term - a full match compares the
+// attribute, otherwise the attribute must contain the term
+func (repo *UserRepository) SearchUsers(searchField string, searchTerm string, fullMatch bool) (*models.Users, error) {
+	if strings.TrimSpace(searchTerm) == "" || strings.TrimSpace(searchField) == "" {
+		return &models.Users{
+			Users:      []models.User{},
+			SearchTerm: searchTerm,
+		}, nil
+	}
+
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	var result []models.User
+	for _, userID := range repo.userIDs() {
+		dbUser := repo.users[userID]
+		if userAttributeMatches(dbUser, searchField, searchTerm, fullMatch) {
+			result = append(result, *convertDBUserModel(dbUser))
+		}
+	}
+
+	return &models.Users{
+		ResultCount: int64(len(result)),
+		TotalCount:  int64(len(repo.users)),
+		Users:       result,
+	}, nil
+}
+
+// findUser returns the first user in primary key order accepted by the match function, the caller holds the lock
+func (repo *UserRepository) findUser(match func(dbUser users.DBUser) bool) *models.User {
+	for _, userID := range repo.userIDs() {
+		if match(repo.users[userID]) {
+			return convertDBUserModel(repo.users[userID])
+		}
+	}
+	return nil
+}
+
+// userIDs returns the ordered user IDs, the caller holds the lock
+func (repo *UserRepository) userIDs() []string {
+	keys := make([]string, 0, len(repo.users))
+	for key := range repo.users {
+		keys = append(keys, key)
+	}
+	return sortedKeys(keys)
+}
+
+// userAttributeMatches evaluates the search filter on the user attribute - like a DynamoDB filter, a full match never
+// matches a list attribute and a missing attribute never matches
+func userAttributeMatches(dbUser users.DBUser, field, term string, fullMatch bool) bool {
+	var value string
+	switch field {
+	case "user_id":
+		value = dbUser.UserID
+	case "user_external_id":
+		value = dbUser.UserExternalID
+	case "user_company_id":
+		value = dbUser.UserCompanyID
+	case "lf_email":
+		value = dbUser.LFEmail
+	case "lf_username":
+		value = dbUser.LFUsername
+	case "user_name":
+		value = dbUser.UserName
+	case "user_github_username":
+		value = dbUser.UserGithubUsername
+	case "user_github_id":
+		value = dbUser.UserGithubID
+	case "date_created":
+		value = dbUser.DateCreated
+	case "date_modified":
+		value = dbUser.DateModified
+	case "version":
+		value = dbUser.Version
+	case "note":
+		value = dbUser.Note
+	case "user_preferred_locale":
+		value = dbUser.UserPreferredLocale
+	case "user_emails":
+		return !fullMatch && containsString(dbUser.UserEmails, term)
+	default:
+		return false
+	}
+
+	if value == "" {
+		return false
+	}
+	if fullMatch {
+		return value == term
+	}
+	return strings.Contains(value, term)
+}
+
+// convertDBUserModel translates the database model into a service response model
+func convertDBUserModel(user users.DBUser) *models.User {
+	return &models.User{
+		UserID:          user.UserID,
+		UserExternalID:  user.UserExternalID,
+		Admin:           user.Admin,
+		LfEmail:         user.LFEmail,
+		LfUsername:      user.LFUsername,
+		DateCreated:     user.DateCreated,
+		DateModified:    user.DateModified,
+		Username:        user.UserName,
+		Version:         user.Version,
+		Emails:          copyStrings(user.UserEmails),
+		GithubID:        user.UserGithubID,
+		CompanyID:       user.UserCompanyID,
+		GithubUsername:  user.UserGithubUsername,
+		Note:            user.Note,
+		PreferredLocale: user.UserPreferredLocale,
+	}
+}
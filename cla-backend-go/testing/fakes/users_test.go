@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package fakes
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepositoryConformance(t *testing.T) {
+	t.Run("fake", func(t *testing.T) {
+		repo := NewUserRepository()
+		testUserRepository(t, repo, repo.AddUser)
+	})
+	for _, target := range conformanceTargets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			awsSession, stage := target.newStage(t)
+			testUserRepository(t, users.NewRepository(awsSession, stage), func(user users.DBUser) {
+				putItem(t, awsSession, stage, "users", user)
+			})
+		})
+	}
+}
+
+func testUserRepository(t *testing.T, repo users.UserRepository, addUser func(user users.DBUser)) {
+	addUser(users.DBUser{UserID: "u1", LFUsername: "alice", LFEmail: "alice@acme.org", UserName: "Alice",
+		UserGithubID: "101", UserGithubUsername: "alice-gh", UserEmails: []string{"alice@example.org"}, Version: "v1"})
+	addUser(users.DBUser{UserID: "u2", LFUsername: "bob", UserName: "Bob", UserGithubID: "102", UserGithubUsername: "bob-gh"})
+	addUser(users.DBUser{UserID: "u3", UserName: "Carol", UserEmails: []string{"carol@acme.org"}})
+
+	user, err := repo.GetUser("u1")
+	if assert.NoError(t, err) && assert.NotNil(t, user) {
+		assert.Equal(t, "alice", user.LfUsername)
+		assert.Equal(t, "alice@acme.org", user.LfEmail)
+		assert.Equal(t, []string{"alice@example.org"}, user.Emails)
+		assert.Equal(t, "alice-gh", user.GithubUsername)
+	}
+	user, err = repo.GetUser("missing")
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+
+	user, err = repo.GetUserByUserName("bob", true)
+	if assert.NoError(t, err) && assert.NotNil(t, user) {
+		assert.Equal(t, "u2", user.UserID)
+	}
+	user, err = repo.GetUserByUserName("nobody", true)
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+
+	user, err = repo.GetUserByGitHubUsername("bob-gh")
+	if assert.NoError(t, err) && assert.NotNil(t, user) {
+		assert.Equal(t, "u2", user.UserID)
+	}
+	_, err = repo.GetUserByGitHubUsername("nobody")
+	assert.Error(t, err)
+
+	user, err = repo.GetUserByEmail("alice@acme.org")
+	if assert.NoError(t, err) && assert.NotNil(t, user) {
+		assert.Equal(t, "u1", user.UserID)
+	}
+	_, err = repo.GetUserByEmail("carol@acme.org")
+	assert.Error(t, err)
+
+	result, err := repo.SearchUsers("user_name", "o", false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), result.ResultCount)
+		assert.Equal(t, int64(3), result.TotalCount)
+	}
+	result, err = repo.SearchUsers("user_name", "Bob", true)
+	if assert.NoError(t, err) && assert.Len(t, result.Users, 1) {
+		assert.Equal(t, "u2", result.Users[0].UserID)
+	}
+
+	assert.NoError(t, repo.Delete("u2"))
+	user, err = repo.GetUser("u2")
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+}
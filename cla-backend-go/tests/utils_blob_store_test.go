@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemBlobStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easycla-blobs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	store, err := utils.NewFilesystemBlobStore(dir, "http://localhost:8080/", "secret")
+	assert.Nil(t, err)
+
+	key := utils.SignedCLAFilename("p1", "icla", "u1", "s1")
+	assert.Nil(t, store.Put(key, []byte("%PDF-1.4")))
+	assert.Nil(t, store.Put(utils.SignedCLAFilename("p1", "ccla", "c1", "s2"), []byte("%PDF-1.4")))
+
+	content, err := store.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("%PDF-1.4"), content)
+
+	keys, err := store.List("contract-group/p1/icla/")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key}, keys)
+
+	exists, err := store.Exists(key)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	assert.Nil(t, store.Delete(key))
+	exists, err = store.Exists(key)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	_, err = store.Get(key)
+	assert.Equal(t, utils.ErrBlobNotFound, err)
+	assert.Nil(t, store.Delete(key))
+
+	assert.Equal(t, utils.ErrInvalidBlobKey, store.Put("../outside.pdf", []byte("x")))
+}
+
+func TestFilesystemBlobStoreDownloadURL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easycla-blobs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	store, err := utils.NewFilesystemBlobStore(dir, "http://localhost:8080", "secret")
+	assert.Nil(t, err)
+	key := utils.SignedClaGroupZipFilename("p1", "icla")
+	assert.Nil(t, store.Put(key, []byte("zip")))
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	url, err := store.GetPresignedURL(key)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(url, "http://localhost:8080"+utils.BlobDownloadPath+key+"?expires="))
+
+	rec := get(url)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "zip", rec.Body.String())
+
+	// the signature does not cover another key
+	rec = get(strings.Replace(url, "/p1/", "/p2/", 1))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// correctly signed but expired
+	expires := fmt.Sprintf("%d", time.Now().Add(-time.Minute).Unix())
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(key + "\n" + expires)) // nolint
+	rec = get(fmt.Sprintf("%s%s?expires=%s&signature=%s", utils.BlobDownloadPath, key, expires, hex.EncodeToString(mac.Sum(nil))))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// correctly signed but deleted
+	assert.Nil(t, store.Delete(key))
+	rec = get(url)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
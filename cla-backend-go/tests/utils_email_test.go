@@ -0,0 +1,41 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaildirEmailSender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "easycla-maildir")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	assert.Nil(t, utils.SetMaildirEmailSender(dir, "noreply@example.org"))
+	assert.Nil(t, utils.SendEmail("Approval Request", "<p>Hello</p>", []string{"a@example.org", "b@example.org"}))
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "new"))
+	assert.Nil(t, err)
+	if assert.Len(t, files, 1) {
+		content, readErr := ioutil.ReadFile(filepath.Join(dir, "new", files[0].Name()))
+		assert.Nil(t, readErr)
+		msg := string(content)
+		assert.True(t, strings.Contains(msg, "From: noreply@example.org\r\n"))
+		assert.True(t, strings.Contains(msg, "To: a@example.org, b@example.org\r\n"))
+		assert.True(t, strings.Contains(msg, "Subject: Approval Request\r\n"))
+		assert.True(t, strings.HasSuffix(msg, "\r\n\r\n<p>Hello</p>"))
+	}
+
+	tmpFiles, err := ioutil.ReadDir(filepath.Join(dir, "tmp"))
+	assert.Nil(t, err)
+	assert.Empty(t, tmpFiles)
+}
@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSha256Hex tests the Sha256Hex utility function
+func TestSha256Hex(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", utils.Sha256Hex([]byte{}))
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", utils.Sha256Hex([]byte("abc")))
+}
+
+// TestBuildTextPdf tests the BuildTextPdf utility function produces a well formed, paginated document
+func TestBuildTextPdf(t *testing.T) {
+	var lines []string
+	for i := 0; i < 60; i++ {
+		lines = append(lines, fmt.Sprintf("event %d (approved) by user\\name", i))
+	}
+	pdf := utils.BuildTextPdf("Audit Trail", lines)
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Count 2")
+	assert.Contains(t, string(pdf), `(event 0 \(approved\) by user\\name) Tj`)
+
+	// the startxref offset must point at the cross reference table
+	matches := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	assert.Len(t, matches, 2)
+	offset, err := strconv.Atoi(string(matches[1]))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(pdf[offset:]), "xref\n"))
+}
@@ -15,12 +15,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // RepositoryDynamo data model
 type RepositoryDynamo struct {
 	Stage          string
-	DynamoDBClient *dynamodb.DynamoDB
+	DynamoDBClient storage.DynamoDBAPI
 }
 
 // RepositoryService interface methods
@@ -52,7 +53,7 @@ type User struct {
 func NewDynamoRepository(awsSession *session.Session, stage string) RepositoryService {
 	return RepositoryDynamo{
 		Stage:          stage,
-		DynamoDBClient: dynamodb.New(awsSession),
+		DynamoDBClient: storage.NewClient(awsSession),
 	}
 }
 
@@ -171,12 +172,16 @@ func (repo RepositoryDynamo) GetUser(userID string) (User, error) {
 	return user, err
 }
 
-// SetCompanyID sets the specified user's company id
+// SetCompanyID sets the specified user's company id - an empty company id removes the user's company association
 func (repo RepositoryDynamo) SetCompanyID(userID, companyID string) (*User, error) {
 	tableName := fmt.Sprintf("cla-%s-users", repo.Stage)
 
 	_, now := utils.CurrentTime()
 
+	if companyID == "" {
+		return repo.clearCompanyID(tableName, userID, now)
+	}
+
 	input := &dynamodb.UpdateItemInput{
 		ExpressionAttributeNames: map[string]*string{
 			"#C": aws.String("company_id"),
@@ -215,3 +220,40 @@ func (repo RepositoryDynamo) SetCompanyID(userID, companyID string) (*User, erro
 
 	return &user, nil
 }
+
+// clearCompanyID removes the company id attributes from the specified user record
+func (repo RepositoryDynamo) clearCompanyID(tableName, userID, now string) (*User, error) {
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#C": aws.String("company_id"),
+			"#U": aws.String("user_company_id"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {
+				S: aws.String(userID),
+			},
+		},
+		UpdateExpression: aws.String("REMOVE #C, #U SET #M = :m"),
+	}
+
+	_, err := repo.DynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.Warnf("Error removing the Company ID from User: %s, error: %v", userID, err)
+		return nil, err
+	}
+
+	user, getErr := repo.GetUser(userID)
+	if getErr != nil {
+		log.Warnf("Error fetching user record by ID: %s, error: %v", userID, getErr)
+		return nil, getErr
+	}
+
+	return &user, nil
+}
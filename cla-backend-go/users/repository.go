@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // UserRepository interface defines the functions for the users repository
@@ -43,7 +44,7 @@ type UserRepository interface {
 // repository data model
 type repository struct {
 	stage          string
-	dynamoDBClient *dynamodb.DynamoDB
+	dynamoDBClient storage.DynamoDBAPI
 	tableName      string
 }
 
@@ -51,7 +52,7 @@ type repository struct {
 func NewRepository(awsSession *session.Session, stage string) UserRepository {
 	return repository{
 		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
+		dynamoDBClient: storage.NewClient(awsSession),
 		tableName:      fmt.Sprintf("cla-%s-users", stage),
 	}
 }
@@ -287,6 +288,13 @@ func (repo repository) Save(user *models.UserUpdate) (*models.User, error) {
 		updateExpression = updateExpression + " #GI = :gi, "
 	}
 
+	if user.PreferredLocale != "" && oldUserModel.PreferredLocale != user.PreferredLocale {
+		log.WithFields(f).Debugf("building query - adding user_preferred_locale: %s", user.PreferredLocale)
+		expressionAttributeNames["#PL"] = aws.String("user_preferred_locale")
+		expressionAttributeValues[":pl"] = &dynamodb.AttributeValue{S: aws.String(user.PreferredLocale)}
+		updateExpression = updateExpression + " #PL = :pl, "
+	}
+
 	log.Debugf("building query - updating date_modified: %s", updatedDateTime.Format(time.RFC3339))
 	expressionAttributeNames["#D"] = aws.String("date_modified")
 	expressionAttributeValues[":d"] = &dynamodb.AttributeValue{S: aws.String(updatedDateTime.Format(time.RFC3339))}
@@ -742,20 +750,21 @@ func (repo repository) SearchUsers(searchField string, searchTerm string, fullMa
 // convertDBUserModel translates a dyanamoDB data model into a service response model
 func convertDBUserModel(user DBUser) *models.User {
 	return &models.User{
-		UserID:         user.UserID,
-		UserExternalID: user.UserExternalID,
-		Admin:          user.Admin,
-		LfEmail:        user.LFEmail,
-		LfUsername:     user.LFUsername,
-		DateCreated:    user.DateCreated,
-		DateModified:   user.DateModified,
-		Username:       user.UserName,
-		Version:        user.Version,
-		Emails:         user.UserEmails,
-		GithubID:       user.UserGithubID,
-		CompanyID:      user.UserCompanyID,
-		GithubUsername: user.UserGithubUsername,
-		Note:           user.Note,
+		UserID:          user.UserID,
+		UserExternalID:  user.UserExternalID,
+		Admin:           user.Admin,
+		LfEmail:         user.LFEmail,
+		LfUsername:      user.LFUsername,
+		DateCreated:     user.DateCreated,
+		DateModified:    user.DateModified,
+		Username:        user.UserName,
+		Version:         user.Version,
+		Emails:          user.UserEmails,
+		GithubID:        user.UserGithubID,
+		CompanyID:       user.UserCompanyID,
+		GithubUsername:  user.UserGithubUsername,
+		Note:            user.Note,
+		PreferredLocale: user.UserPreferredLocale,
 	}
 }
 
@@ -776,6 +785,7 @@ func buildUserProjection() expression.ProjectionBuilder {
 		expression.Name("date_modified"),
 		expression.Name("version"),
 		expression.Name("note"),
+		expression.Name("user_preferred_locale"),
 	)
 }
 
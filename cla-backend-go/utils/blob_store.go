@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// ErrBlobNotFound is returned when the object does not exist in the blob store
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores the signed documents and the zip files of the signed documents by key, e.g.
+// contract-group/<project-ID>/<claType>/<identifier>/<signatureID>.pdf
+type BlobStore interface {
+	Put(key string, content []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+	Exists(key string) (bool, error)
+	// List returns the keys of the objects starting with the prefix
+	List(prefix string) ([]string, error)
+	// GetPresignedURL returns a download URL of the object valid for PresignedURLValidity
+	GetPresignedURL(key string) (string, error)
+}
+
+var blobStore BlobStore
+
+// SetBlobStore sets up the default blob store
+func SetBlobStore(store BlobStore) {
+	blobStore = store
+}
+
+// GetBlobStore returns the default blob store, nil if not set
+func GetBlobStore() BlobStore {
+	return blobStore
+}
+
+// InitBlobStore sets up the default blob store of the configured type, the s3 type stores the objects in the signature
+// files bucket
+func InitBlobStore(awsSession *session.Session, cfg config.BlobStore, bucketName string) error {
+	switch cfg.Type {
+	case "", config.BlobStoreS3:
+		SetS3Storage(awsSession, bucketName)
+		return nil
+	case config.BlobStoreS3Compatible:
+		store, err := NewS3CompatibleBlobStore(cfg.Endpoint, cfg.Region, cfg.AccessKeyID, cfg.SecretAccessKey, bucketName)
+		if err != nil {
+			return err
+		}
+		log.Infof("Storing the signed documents in bucket: %s of the S3 compatible endpoint: %s", bucketName, cfg.Endpoint)
+		blobStore = store
+		return nil
+	case config.BlobStoreFilesystem:
+		store, err := NewFilesystemBlobStore(cfg.Path, cfg.BaseURL, cfg.URLSigningKey)
+		if err != nil {
+			return err
+		}
+		log.Infof("Storing the signed documents in directory: %s", cfg.Path)
+		blobStore = store
+		return nil
+	default:
+		return fmt.Errorf("unsupported blob store type: %s", cfg.Type)
+	}
+}
+
+// BlobDownloadHandler serves the signed download URLs of the filesystem blob store, the other blob stores serve their
+// own download URLs
+func BlobDownloadHandler() http.Handler {
+	if store, ok := blobStore.(*FilesystemBlobStore); ok {
+		return store
+	}
+	return http.NotFoundHandler()
+}
+
+// UploadToS3 uploads file to the blob store at path contract-group/<project-ID>/<claType>/<identifier>/<signatureID>.pdf
+// claType should be cla or ccla
+// identifier can be user-id or company-id
+func UploadToS3(body []byte, projectID string, claType string, identifier string, signatureID string) error {
+	if blobStore == nil {
+		return errors.New("blob store not set")
+	}
+	return blobStore.Put(SignedCLAFilename(projectID, claType, identifier, signatureID), body)
+}
+
+// DownloadFromS3 downloads file from the blob store
+func DownloadFromS3(filename string) ([]byte, error) {
+	if blobStore == nil {
+		return nil, errors.New("blob store not set")
+	}
+	return blobStore.Get(filename)
+}
+
+// DeleteFromS3 deletes file from the blob store
+func DeleteFromS3(filename string) error {
+	if blobStore == nil {
+		return errors.New("blob store not set")
+	}
+	return blobStore.Delete(filename)
+}
+
+// ExistsInS3 returns true if the file exists in the blob store
+func ExistsInS3(filename string) (bool, error) {
+	if blobStore == nil {
+		return false, errors.New("blob store not set")
+	}
+	return blobStore.Exists(filename)
+}
+
+// GetDownloadLink provides a time-limited download URL of the file
+func GetDownloadLink(filename string) (string, error) {
+	if blobStore == nil {
+		return "", errors.New("blob store not set")
+	}
+	return blobStore.GetPresignedURL(filename)
+}
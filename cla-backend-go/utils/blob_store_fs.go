@@ -0,0 +1,226 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlobDownloadPath is the path prefix of the signed download URLs served by the filesystem blob store
+const BlobDownloadPath = "/blobs/"
+
+// errors
+var (
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+)
+
+// FilesystemBlobStore stores the objects as files below the root directory and serves time-limited download URLs
+// signed with HMAC-SHA256, it is used to run without AWS
+type FilesystemBlobStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewFilesystemBlobStore creates the root directory if needed and returns the filesystem blob store, the download URLs
+// are built from the base URL of the API server and signed with the signing key
+func NewFilesystemBlobStore(root, baseURL, signingKey string) (*FilesystemBlobStore, error) {
+	if root == "" {
+		return nil, errors.New("missing path for the filesystem blob store")
+	}
+	if signingKey == "" {
+		return nil, errors.New("missing url signing key for the filesystem blob store")
+	}
+	if err := os.MkdirAll(root, 0750); err != nil {
+		return nil, err
+	}
+	return &FilesystemBlobStore{
+		root:       root,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+// filename returns the file of the key, keys escaping the root directory are rejected
+func (fs *FilesystemBlobStore) filename(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || strings.Contains(key, "\\") {
+		return "", ErrInvalidBlobKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." {
+			return "", ErrInvalidBlobKey
+		}
+	}
+	return filepath.Join(fs.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the object to a temporary file renamed to the file of the key, readers never see a partial object
+func (fs *FilesystemBlobStore) Put(key string, content []byte) error {
+	filename, err := fs.filename(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()           // nolint
+		os.Remove(tmp.Name()) // nolint
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name()) // nolint
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// Get reads the object
+func (fs *FilesystemBlobStore) Get(key string) ([]byte, error) {
+	filename, err := fs.filename(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(filename) // nolint gosec - the key is checked not to escape the root
+	if os.IsNotExist(err) {
+		return nil, ErrBlobNotFound
+	}
+	return content, err
+}
+
+// Delete deletes the object, deleting a missing object is not an error
+func (fs *FilesystemBlobStore) Delete(key string) error {
+	filename, err := fs.filename(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Exists returns true if the object exists
+func (fs *FilesystemBlobStore) Exists(key string) (bool, error) {
+	filename, err := fs.filename(key)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(filename)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !info.IsDir(), nil
+}
+
+// List returns the keys of the objects starting with the prefix
+func (fs *FilesystemBlobStore) List(prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(fs.root, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(fs.root, filename)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetPresignedURL returns the download URL of the object signed for PresignedURLValidity
+func (fs *FilesystemBlobStore) GetPresignedURL(key string) (string, error) {
+	if _, err := fs.filename(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(PresignedURLValidity).Unix(), 10)
+	return fmt.Sprintf("%s%s%s?expires=%s&signature=%s", fs.baseURL, BlobDownloadPath, key, expires, fs.sign(key, expires)), nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the key and the expiry
+func (fs *FilesystemBlobStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, fs.signingKey)
+	mac.Write([]byte(key + "\n" + expires)) // nolint
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeHTTP serves the objects of the signed download URLs
+func (fs *FilesystemBlobStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	key, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), BlobDownloadPath))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	expires := r.URL.Query().Get("expires")
+	signature := r.URL.Query().Get("signature")
+	if !hmac.Equal([]byte(signature), []byte(fs.sign(key, expires))) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	content, err := fs.Get(key)
+	if err == ErrBlobNotFound || err == ErrInvalidBlobKey {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	switch path.Ext(key) {
+	case ".pdf":
+		w.Header().Set("Content-Type", "application/pdf")
+	case ".zip":
+		w.Header().Set("Content-Type", "application/zip")
+	default:
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	if r.Method == http.MethodGet {
+		w.Write(content) // nolint
+	}
+}
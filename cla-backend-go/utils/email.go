@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/telemetry"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -20,6 +21,11 @@ type EmailSender interface {
 	SendEmail(subject string, body string, recipients []string) error
 }
 
+// AlternativeEmailSender contains method to send an email with a plain text alternative of the HTML body
+type AlternativeEmailSender interface {
+	SendEmailWithAlternative(subject string, htmlBody string, textBody string, recipients []string) error
+}
+
 var emailSender EmailSender
 
 // SetEmailSender sets up default email sender
@@ -42,6 +48,25 @@ func SetSnsEmailSender(awsSession *session.Session, snsEventTopicARN string, sen
 	}
 }
 
+// InitEmailSender sets up the email sender of the configured transport, the sns transport publishes the emails to the
+// SNS event topic
+func InitEmailSender(awsSession *session.Session, cfg config.Email, snsEventTopicARN string, senderEmailAddress string) error {
+	switch cfg.Transport {
+	case "", config.EmailTransportSNS:
+		SetSnsEmailSender(awsSession, snsEventTopicARN, senderEmailAddress)
+		return nil
+	case config.EmailTransportSMTP:
+		log.Infof("Sending emails using SMTP server: %s:%d", cfg.SMTP.Host, cfg.SMTP.Port)
+		SetSMTPEmailSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.StartTLS, senderEmailAddress)
+		return nil
+	case config.EmailTransportMaildir:
+		log.Infof("Writing emails to maildir: %s", cfg.MaildirPath)
+		return SetMaildirEmailSender(cfg.MaildirPath, senderEmailAddress)
+	default:
+		return fmt.Errorf("unsupported email transport: %s", cfg.Transport)
+	}
+}
+
 // SendEmail sends an email to the specified recipients
 func (s *snsEmail) SendEmail(subject string, body string, recipients []string) error {
 	event := CreateEventWrapper("cla-email-event")
@@ -74,7 +99,37 @@ func SendEmail(subject string, body string, recipients []string) error {
 	if emailSender == nil {
 		return errors.New("email sender not set")
 	}
-	return emailSender.SendEmail(subject, body, recipients)
+	return recordEmailSendFailure(emailSender.SendEmail(subject, body, recipients))
+}
+
+// SendEmailWithAlternative sends the email with the plain text alternative when supported by the emailSender,
+// otherwise only the HTML body is sent
+func SendEmailWithAlternative(subject string, htmlBody string, textBody string, recipients []string) error {
+	if emailSender == nil {
+		return errors.New("email sender not set")
+	}
+	if sender, ok := emailSender.(AlternativeEmailSender); ok && textBody != "" {
+		return recordEmailSendFailure(sender.SendEmailWithAlternative(subject, htmlBody, textBody, recipients))
+	}
+	return recordEmailSendFailure(emailSender.SendEmail(subject, htmlBody, recipients))
+}
+
+// recordEmailSendFailure counts the send error, if any, against the transport of the emailSender
+func recordEmailSendFailure(err error) error {
+	if err == nil {
+		return nil
+	}
+	transport := "other"
+	switch emailSender.(type) {
+	case *snsEmail:
+		transport = config.EmailTransportSNS
+	case *smtpEmail:
+		transport = config.EmailTransportSMTP
+	case *maildirEmail:
+		transport = config.EmailTransportMaildir
+	}
+	telemetry.IncEmailSendFailure(transport)
+	return err
 }
 
 // GetCorporateURL returns the corporate URL based on the specified flag
@@ -86,6 +141,11 @@ func GetCorporateURL(isV2Project bool) string {
 	return fmt.Sprintf("https://%s", config.GetConfig().CorporateConsoleURL)
 }
 
+// GetContributorURL returns the v2 contributor console URL
+func GetContributorURL() string {
+	return fmt.Sprintf("https://%s", config.GetConfig().ContributorConsoleV2URL)
+}
+
 // GetEmailHelpContent returns the standard email help paragraph details.
 func GetEmailHelpContent(showV2HelpLink bool) string {
 	if showV2HelpLink {
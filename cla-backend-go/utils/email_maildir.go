@@ -0,0 +1,72 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// maildirEmail writes each email as a file into a maildir - used for local development and tests
+type maildirEmail struct {
+	path               string
+	senderEmailAddress string
+	counter            uint64
+}
+
+// SetMaildirEmailSender set a local maildir as mechanism to send email
+func SetMaildirEmailSender(path string, senderEmailAddress string) error {
+	for _, dir := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(path, dir), 0750); err != nil {
+			return err
+		}
+	}
+	emailSender = &maildirEmail{
+		path:               path,
+		senderEmailAddress: senderEmailAddress,
+	}
+	return nil
+}
+
+// SendEmail writes the email to the maildir
+func (m *maildirEmail) SendEmail(subject string, body string, recipients []string) error {
+	return m.deliver(subject, recipients, buildEmailMessage(m.senderEmailAddress, subject, body, recipients))
+}
+
+// SendEmailWithAlternative writes the email with HTML and plain text bodies to the maildir
+func (m *maildirEmail) SendEmailWithAlternative(subject string, htmlBody string, textBody string, recipients []string) error {
+	msg, err := buildAlternativeEmailMessage(m.senderEmailAddress, subject, htmlBody, textBody, recipients)
+	if err != nil {
+		return err
+	}
+	return m.deliver(subject, recipients, msg)
+}
+
+// deliver writes the message to tmp and moves it to new once complete
+func (m *maildirEmail) deliver(subject string, recipients []string, msg []byte) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	name := fmt.Sprintf("%d.%d_%d.%s", time.Now().UnixNano(), os.Getpid(), atomic.AddUint64(&m.counter, 1), hostname)
+
+	tmpFile := filepath.Join(m.path, "tmp", name)
+	if err := ioutil.WriteFile(tmpFile, msg, 0640); err != nil {
+		log.Warnf("unable to write email to maildir: %s, error: %v", m.path, err)
+		return err
+	}
+	if err := os.Rename(tmpFile, filepath.Join(m.path, "new", name)); err != nil {
+		log.Warnf("unable to deliver email to maildir: %s, error: %v", m.path, err)
+		return err
+	}
+
+	log.Debugf("Successfully wrote email '%s' to %v into maildir: %s", subject, recipients, m.path)
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+type smtpEmail struct {
+	host               string
+	port               int
+	username           string
+	password           string
+	startTLS           bool
+	senderEmailAddress string
+}
+
+// SetSMTPEmailSender set direct SMTP delivery as mechanism to send email
+func SetSMTPEmailSender(host string, port int, username, password string, startTLS bool, senderEmailAddress string) {
+	emailSender = &smtpEmail{
+		host:               host,
+		port:               port,
+		username:           username,
+		password:           password,
+		startTLS:           startTLS,
+		senderEmailAddress: senderEmailAddress,
+	}
+}
+
+// SendEmail sends an email to the specified recipients
+func (s *smtpEmail) SendEmail(subject string, body string, recipients []string) error {
+	return s.send(subject, recipients, buildEmailMessage(s.senderEmailAddress, subject, body, recipients))
+}
+
+// SendEmailWithAlternative sends an email with HTML and plain text bodies to the specified recipients
+func (s *smtpEmail) SendEmailWithAlternative(subject string, htmlBody string, textBody string, recipients []string) error {
+	msg, err := buildAlternativeEmailMessage(s.senderEmailAddress, subject, htmlBody, textBody, recipients)
+	if err != nil {
+		return err
+	}
+	return s.send(subject, recipients, msg)
+}
+
+// send delivers the message to the SMTP server
+func (s *smtpEmail) send(subject string, recipients []string, msg []byte) error {
+	if len(recipients) == 0 {
+		return errors.New("no email recipients")
+	}
+
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		log.Warnf("unable to connect to SMTP server: %s, error: %v", addr, err)
+		return err
+	}
+	defer client.Close() // nolint
+
+	if s.startTLS {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			log.Warnf("unable to start TLS with SMTP server: %s, error: %v", addr, err)
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			log.Warnf("unable to authenticate with SMTP server: %s, error: %v", addr, err)
+			return err
+		}
+	}
+
+	if err = client.Mail(s.senderEmailAddress); err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		if err = client.Rcpt(recipient); err != nil {
+			log.Warnf("SMTP server: %s rejected recipient: %s, error: %v", addr, recipient, err)
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	log.Debugf("Successfully sent email '%s' to %v using SMTP server: %s", subject, recipients, addr)
+	return client.Quit()
+}
+
+// buildEmailMessage returns the RFC 5322 message for the HTML email body
+func buildEmailMessage(sender, subject, body string, recipients []string) []byte {
+	var msg bytes.Buffer
+	writeEmailHeaders(&msg, sender, subject, recipients)
+	msg.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+	return msg.Bytes()
+}
+
+// buildAlternativeEmailMessage returns the RFC 5322 multipart/alternative message for the plain text and HTML bodies
+func buildAlternativeEmailMessage(sender, subject, htmlBody, textBody string, recipients []string) ([]byte, error) {
+	var msg bytes.Buffer
+	writeEmailHeaders(&msg, sender, subject, recipients)
+
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=\"%s\"\r\n", mw.Boundary()))
+	msg.WriteString("\r\n")
+
+	// Per RFC 2046 the preferred format - HTML - is the last part
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=\"utf-8\"", textBody},
+		{"text/html; charset=\"utf-8\"", htmlBody},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	msg.Write(parts.Bytes())
+	return msg.Bytes(), nil
+}
+
+// writeEmailHeaders writes the common email headers
+func writeEmailHeaders(msg *bytes.Buffer, sender, subject string, recipients []string) {
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", sender))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(recipients, ", ")))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
+	msg.WriteString(fmt.Sprintf("Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+}
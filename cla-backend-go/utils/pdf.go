@@ -6,7 +6,11 @@ package utils
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -32,3 +36,134 @@ func WatermarkPdf(pdf []byte, text string) ([]byte, error) {
 
 	return b.Bytes(), nil
 }
+
+// text pdf page layout in points - US letter with a 50 point margin
+const (
+	textPdfPageWidth  = 612
+	textPdfPageHeight = 792
+	textPdfMargin     = 50
+	textPdfFontSize   = 10
+	textPdfTitleSize  = 14
+	textPdfLeading    = 14
+	textPdfLineLength = 100
+)
+
+// Sha256Hex returns the hex encoded SHA-256 of the given blob
+func Sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// MergePdfs concatenates the pages of the given pdf blobs into a single pdf
+func MergePdfs(pdfs ...[]byte) ([]byte, error) {
+	readSeekers := make([]io.ReadSeeker, 0, len(pdfs))
+	for _, pdf := range pdfs {
+		readSeekers = append(readSeekers, bytes.NewReader(pdf))
+	}
+
+	var b bytes.Buffer
+	err := api.Merge(readSeekers, &b, nil)
+	if err != nil {
+		return nil, fmt.Errorf("merging pdf failed : %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// BuildTextPdf returns a plain pdf with the title followed by the lines of text, one per row. Long lines are wrapped
+// and the text flows over as many pages as required.
+func BuildTextPdf(title string, lines []string) []byte {
+	var rows []string
+	for _, line := range lines {
+		rows = append(rows, wrapPdfLine(line, textPdfLineLength)...)
+	}
+
+	rowsPerPage := (textPdfPageHeight-2*textPdfMargin)/textPdfLeading - 2
+	var pages []string
+	for first := true; first || len(rows) > 0; first = false {
+		n := rowsPerPage
+		if n > len(rows) {
+			n = len(rows)
+		}
+		pages = append(pages, textPdfPageContent(title, rows[:n]))
+		rows = rows[n:]
+	}
+
+	// objects 1-3 are the catalog, page tree and font, followed by a page and content stream object per page
+	var objects []string
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	)
+	for i, content := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				textPdfPageWidth, textPdfPageHeight, 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, 0, len(objects))
+	for i, object := range objects {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+// textPdfPageContent returns the content stream of a single text page
+func textPdfPageContent(title string, rows []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n(%s) Tj\n", textPdfTitleSize, textPdfLeading,
+		textPdfMargin, textPdfPageHeight-textPdfMargin, escapePdfText(title))
+	fmt.Fprintf(&b, "/F1 %d Tf\nT*\n", textPdfFontSize)
+	for _, row := range rows {
+		fmt.Fprintf(&b, "T* (%s) Tj\n", escapePdfText(row))
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+// wrapPdfLine splits the line into rows of at most width characters, breaking on spaces where possible
+func wrapPdfLine(line string, width int) []string {
+	var rows []string
+	for len(line) > width {
+		cut := strings.LastIndex(line[:width], " ")
+		if cut <= 0 {
+			cut = width
+		}
+		rows = append(rows, line[:cut])
+		line = strings.TrimLeft(line[cut:], " ")
+	}
+	return append(rows, line)
+}
+
+// escapePdfText escapes the pdf string delimiters - characters outside of printable ASCII are replaced as the
+// standard Helvetica font cannot render them
+func escapePdfText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -13,6 +13,8 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
@@ -20,119 +22,141 @@ import (
 // PresignedURLValidity is time for which s3 url will remain valid
 const PresignedURLValidity = 15 * time.Minute
 
-// S3Storage provides methods to handle s3 storage
-type S3Storage interface {
-	Upload(fileContent []byte, projectID string, claType string, identifier string, signatureID string) error
-	Download(filename string) ([]byte, error)
-	Delete(filename string) error
-	GetPresignedURL(filename string) (string, error)
-}
-
-var s3Storage S3Storage
-
-// S3Client struct provide methods to interact with s3
+// S3Client struct provide methods to interact with s3, it implements the BlobStore interface
 type S3Client struct {
 	s3         *s3.S3
 	BucketName string
 }
 
-// SetS3Storage set default S3Storage
+// SetS3Storage set the s3 bucket as default BlobStore
 func SetS3Storage(awsSession *session.Session, bucketName string) {
-	s3Storage = &S3Client{
+	blobStore = NewS3BlobStore(awsSession, bucketName)
+}
+
+// NewS3BlobStore returns a BlobStore storing the objects in the AWS S3 bucket
+func NewS3BlobStore(awsSession *session.Session, bucketName string) *S3Client {
+	return &S3Client{
 		s3:         s3.New(awsSession),
 		BucketName: bucketName,
 	}
 }
 
-// Upload file to s3 storage at path contract-group/<project-ID>/<claType>/<identifier>/<signatureID>.pdf
-// claType should be cla or ccla
-// identifier can be user-id or company-id
-func (s3c *S3Client) Upload(fileContent []byte, projectID string, claType string, identifier string, signatureID string) error {
-	filename := strings.Join([]string{"contract-group", projectID, claType, identifier, signatureID}, "/") + ".pdf"
+// NewS3CompatibleBlobStore returns a BlobStore storing the objects in the bucket of a S3 compatible endpoint, e.g. a
+// MinIO server - the bucket is addressed by path as the endpoints usually have no per bucket host names
+func NewS3CompatibleBlobStore(endpoint, region, accessKeyID, secretAccessKey, bucketName string) (*S3Client, error) {
+	if endpoint == "" {
+		return nil, errors.New("missing endpoint for the s3-compatible blob store")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	awsSession, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String(region),
+		Credentials:      credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewS3BlobStore(awsSession, bucketName), nil
+}
+
+// Put uploads the object to the s3 bucket
+func (s3c *S3Client) Put(key string, content []byte) error {
 	_, err := s3c.s3.PutObject(&s3.PutObjectInput{
 		Bucket: aws.String(s3c.BucketName),
-		Key:    aws.String(filename),
-		Body:   bytes.NewReader(fileContent),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(content),
 	})
 	return err
 }
 
-// Download file from s3
-func (s3c *S3Client) Download(filename string) ([]byte, error) {
+// Get downloads the object from the s3 bucket
+func (s3c *S3Client) Get(key string) ([]byte, error) {
 	ou, err := s3c.s3.GetObject(&s3.GetObjectInput{
 		Bucket: aws.String(s3c.BucketName),
-		Key:    aws.String(filename),
+		Key:    aws.String(key),
 	})
 	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrBlobNotFound
+		}
 		log.Warnf("problem downloading from s3 bucket: %s resource: %s, error: %+v",
-			s3c.BucketName, filename, err)
+			s3c.BucketName, key, err)
 		return nil, err
 	}
+	defer ou.Body.Close() // nolint
 
 	body, err := ioutil.ReadAll(ou.Body)
 	if err != nil {
 		log.Warnf("problem reading file from s3 bucket: %s resource: %s, error: %+v",
-			s3c.BucketName, filename, err)
+			s3c.BucketName, key, err)
 		return nil, err
 	}
 
 	return body, err
 }
 
-// Delete file from s3
-func (s3c *S3Client) Delete(filename string) error {
+// Delete deletes the object from the s3 bucket
+func (s3c *S3Client) Delete(key string) error {
 	_, err := s3c.s3.DeleteObject(&s3.DeleteObjectInput{
-		Bucket: &s3c.BucketName,
-		Key:    &filename,
+		Bucket: aws.String(s3c.BucketName),
+		Key:    aws.String(key),
 	})
 	return err
 }
 
-// GetPresignedURL provided presigned url for download
-func (s3c *S3Client) GetPresignedURL(filename string) (string, error) {
-	req, _ := s3c.s3.GetObjectRequest(&s3.GetObjectInput{
-		Bucket: &s3c.BucketName,
-		Key:    &filename,
+// Exists returns true if the object exists in the s3 bucket
+func (s3c *S3Client) Exists(key string) (bool, error) {
+	_, err := s3c.s3.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(s3c.BucketName),
+		Key:    aws.String(key),
 	})
-	url, err := req.Presign(PresignedURLValidity)
 	if err != nil {
-		return "", err
-	}
-	return url, nil
-}
-
-// UploadToS3 uploads file to s3 storage at path contract-group/<project-ID>/<claType>/<identifier>/<signatureID>.pdf
-// claType should be cla or ccla
-// identifier can be user-id or company-id
-func UploadToS3(body []byte, projectID string, claType string, identifier string, signatureID string) error {
-	if s3Storage == nil {
-		return errors.New("s3Storage not set")
+		if isNoSuchKey(err) {
+			return false, nil
+		}
+		return false, err
 	}
-	return s3Storage.Upload(body, projectID, claType, identifier, signatureID)
+	return true, nil
 }
 
-// DownloadFromS3 downloads file from s3
-func DownloadFromS3(filename string) ([]byte, error) {
-	if s3Storage == nil {
-		return nil, errors.New("s3Storage not set")
+// List returns the keys of the objects of the s3 bucket starting with the prefix
+func (s3c *S3Client) List(prefix string) ([]string, error) {
+	var keys []string
+	err := s3c.s3.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(s3c.BucketName),
+		Prefix: aws.String(prefix),
+	}, func(output *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, obj := range output.Contents {
+			keys = append(keys, aws.StringValue(obj.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
-	return s3Storage.Download(filename)
+	return keys, nil
 }
 
-// DeleteFromS3 deletes file from s3
-func DeleteFromS3(filename string) error {
-	if s3Storage == nil {
-		return errors.New("s3Storage not set")
+// GetPresignedURL provided presigned url for download
+func (s3c *S3Client) GetPresignedURL(key string) (string, error) {
+	req, _ := s3c.s3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s3c.BucketName),
+		Key:    aws.String(key),
+	})
+	url, err := req.Presign(PresignedURLValidity)
+	if err != nil {
+		return "", err
 	}
-	return s3Storage.Delete(filename)
+	return url, nil
 }
 
-// GetDownloadLink provides presigned s3 url
-func GetDownloadLink(filename string) (string, error) {
-	if s3Storage == nil {
-		return "", errors.New("s3Storage not set")
-	}
-	return s3Storage.GetPresignedURL(filename)
+// isNoSuchKey returns true if the error reports a missing object - HeadObject has no error body and reports NotFound
+func isNoSuchKey(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && (aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound")
 }
 
 // SignedCLAFilename provide s3 bucket url
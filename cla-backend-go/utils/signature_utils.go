@@ -4,6 +4,8 @@
 package utils
 
 import (
+	"strconv"
+
 	"github.com/LF-Engineering/lfx-kit/auth"
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
@@ -22,3 +24,12 @@ func CurrentUserInACL(authUser *auth.User, managers []v1Models.User) bool {
 
 	return inACL
 }
+
+// SignatureMajorVersion returns the document major version of the signature, 0 when unknown
+func SignatureMajorVersion(sig *v1Models.Signature) int64 {
+	major, err := strconv.ParseInt(sig.SignatureMajorVersion, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return major
+}
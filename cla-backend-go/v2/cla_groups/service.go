@@ -829,7 +829,7 @@ func (s *service) DeleteCLAGroup(ctx context.Context, claGroupModel *v1Models.Pr
 	// Invalidate project signatures
 	go func(claGroup *v1Models.Project, authUser *auth.User) {
 		log.WithFields(f).Debug("invalidating all signatures for CLA Group...")
-		numInvalidated, invalidateErr := s.signatureService.InvalidateProjectRecords(ctx, claGroup.ProjectID, claGroup.ProjectName)
+		numInvalidated, invalidateErr := s.signatureService.InvalidateProjectRecords(ctx, claGroup.ProjectID, claGroup.FoundationSFID, claGroup.ProjectName)
 		if invalidateErr != nil {
 			log.WithFields(f).Warn(invalidateErr)
 			errChan <- invalidateErr
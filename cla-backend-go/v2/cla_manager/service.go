@@ -31,6 +31,7 @@ import (
 	v1ClaManager "github.com/communitybridge/easycla/cla-backend-go/cla_manager"
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 	v1User "github.com/communitybridge/easycla/cla-backend-go/user"
 	easyCLAUser "github.com/communitybridge/easycla/cla-backend-go/users"
 	v2AcsService "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
@@ -191,7 +192,7 @@ func (s *service) CreateCLAManager(ctx context.Context, claGroupID string, param
 		designeeEmail := params.Body.UserEmail.String()
 		msg := fmt.Sprintf("User does not have an LF Login account and has been sent an email invite: %s.", *params.Body.UserEmail)
 		log.WithFields(f).Warn(msg)
-		sendEmailErr := sendEmailToUserWithNoLFID(claGroup.ProjectName, authUsername, *managerUser.Emails[0].EmailAddress, designeeName, designeeEmail, organizationSF.ID, &params.ProjectSFID, utils.CLAManagerRole)
+		sendEmailErr := sendEmailToUserWithNoLFID(ctx, claGroupID, claGroup.ProjectName, authUsername, *managerUser.Emails[0].EmailAddress, designeeName, designeeEmail, organizationSF.ID, &params.ProjectSFID, utils.CLAManagerRole)
 		if sendEmailErr != nil {
 			emailMessage := fmt.Sprintf("Failed to send email to user : %s ", designeeEmail)
 			return nil, &models.ErrorResponse{
@@ -810,9 +811,15 @@ func (s *service) CreateCLAManagerRequest(ctx context.Context, contactAdmin bool
 			return nil, ErrNoOrgAdmins
 		}
 
+		// The CLA Group notification template overrides apply when the project is associated with a CLA Group
+		var claGroupID string
+		if projectCLAGroup, cgErr := s.projectCGRepo.GetClaGroupIDForProject(projectID); cgErr == nil && projectCLAGroup != nil {
+			claGroupID = projectCLAGroup.ClaGroupID
+		}
+
 		for _, admin := range scopes.Userroles {
 			log.WithFields(f).Debugf("sending email to organization admin: %+v", admin)
-			sendEmailToOrgAdmin(admin.Contact.EmailAddress, admin.Contact.Name, v1CompanyModel.CompanyName, []string{projectSF.Name}, authUser.Email, authUser.UserName, LfxPortalURL)
+			sendEmailToOrgAdmin(ctx, claGroupID, admin.Contact.EmailAddress, admin.Contact.Name, v1CompanyModel.CompanyName, []string{projectSF.Name}, authUser.Email, authUser.UserName, LfxPortalURL)
 			// Make a note in the event log
 			s.eventService.LogEvent(&events.LogEventArgs{
 				EventType:         events.ContributorNotifyCompanyAdminType,
@@ -840,7 +847,7 @@ func (s *service) CreateCLAManagerRequest(ctx context.Context, contactAdmin bool
 		msg := fmt.Sprintf("User: %s does not have an LF Login", userEmail)
 		log.WithFields(f).Warn(msg)
 		// Send email
-		sendEmailErr := sendEmailToUserWithNoLFID(projectSF.Name, authUser.UserName, authUser.Email, fullName, userEmail, companySFID, &projectSF.ID, utils.CLADesigneeRole)
+		sendEmailErr := sendEmailToUserWithNoLFID(ctx, "", projectSF.Name, authUser.UserName, authUser.Email, fullName, userEmail, companySFID, &projectSF.ID, utils.CLADesigneeRole)
 		if sendEmailErr != nil {
 			log.WithFields(f).Warnf("Error sending email: %+v", sendEmailErr)
 			return nil, sendEmailErr
@@ -879,7 +886,7 @@ func (s *service) CreateCLAManagerRequest(ctx context.Context, contactAdmin bool
 
 	log.WithFields(f).Debugf("sending Email to CLA Manager Designee email: %s ", userEmail)
 	designeeName := fmt.Sprintf("%s %s", lfxUser.FirstName, lfxUser.LastName)
-	sendEmailToCLAManagerDesignee(LfxPortalURL, v1CompanyModel.CompanyName, []string{projectSF.Name}, userEmail, designeeName, authUser.Email, authUser.UserName)
+	sendEmailToCLAManagerDesignee(ctx, "", LfxPortalURL, v1CompanyModel.CompanyName, []string{projectSF.Name}, userEmail, designeeName, authUser.Email, authUser.UserName)
 
 	log.WithFields(f).Debug("creating a contributor notify CLA designee log event...")
 	// Make a note in the event log
@@ -968,7 +975,7 @@ func (s *service) InviteCompanyAdmin(ctx context.Context, contactAdmin bool, com
 
 		// Use FoundationSFID
 		foundationSFID := projectCLAGroups[0].FoundationSFID
-		sendErr := sendEmailToUserWithNoLFID(project.ProjectName, contributor.UserName, *contributorEmail, name, userEmail, organization.ID, &foundationSFID, "cla-manager-designee")
+		sendErr := sendEmailToUserWithNoLFID(ctx, projectID, project.ProjectName, contributor.UserName, *contributorEmail, name, userEmail, organization.ID, &foundationSFID, "cla-manager-designee")
 		if sendErr != nil {
 			msg := fmt.Sprintf("Problem sending email to user: %s , error: %+v", userEmail, sendErr)
 			log.Warn(msg)
@@ -1013,7 +1020,7 @@ func (s *service) InviteCompanyAdmin(ctx context.Context, contactAdmin bool, com
 
 		for _, admin := range scopes.Userroles {
 			// Check if is Gerrit User or GH User
-			contributorEmailToOrgAdmin(admin.Contact.EmailAddress, admin.Contact.Name, organization.Name, projectSFs, userModel, LfxPortalURL)
+			contributorEmailToOrgAdmin(ctx, projectID, admin.Contact.EmailAddress, admin.Contact.Name, organization.Name, projectSFs, userModel, LfxPortalURL)
 			designeeScope := models.ClaManagerDesignee{
 				Email: strfmt.Email(admin.Contact.EmailAddress),
 				Name:  admin.Contact.Name,
@@ -1050,10 +1057,10 @@ func (s *service) InviteCompanyAdmin(ctx context.Context, contactAdmin bool, com
 	log.Debugf("Sending Email to CLA Manager Designee email: %s ", userEmail)
 
 	if contributor.LFUsername != "" && contributor.LFEmail != "" && len(projectSFs) > 0 {
-		sendEmailToCLAManagerDesignee(LfxPortalURL, organization.Name, projectSFs, userEmail, user.Name, contributor.LFEmail, contributor.LFUsername)
+		sendEmailToCLAManagerDesignee(ctx, projectID, LfxPortalURL, organization.Name, projectSFs, userEmail, user.Name, contributor.LFEmail, contributor.LFUsername)
 	} else {
 		contributorUserName, contributorEmail := getContributorPublicEmail(contributor)
-		sendEmailToCLAManagerDesignee(LfxPortalURL, organization.Name, projectSFs, userEmail, user.Name, contributorUserName, contributorEmail)
+		sendEmailToCLAManagerDesignee(ctx, projectID, LfxPortalURL, organization.Name, projectSFs, userEmail, user.Name, contributorUserName, contributorEmail)
 	}
 
 	log.Debugf("CLA Manager designee created : %+v", designeeScopes)
@@ -1073,31 +1080,26 @@ func (s *service) NotifyCLAManagers(ctx context.Context, notifyCLAManagers *mode
 
 	log.Debugf("Sending notification emails to claManagers: %+v", notifyCLAManagers.List)
 	for _, claManager := range notifyCLAManagers.List {
-		sendEmailToCLAManager(claManager.Name, claManager.Email.String(), userModel, notifyCLAManagers.CompanyName, notifyCLAManagers.ClaGroupName)
+		sendEmailToCLAManager(ctx, claManager.Name, claManager.Email.String(), userModel, notifyCLAManagers.CompanyName, notifyCLAManagers.ClaGroupName)
 	}
 
 	return nil
 }
 
-func sendEmailToCLAManager(manager string, managerEmail string, userModel *v1Models.User, company string, claGroupName string) {
-	subject := fmt.Sprintf("EasyCLA: Approval Request for contributor: %s", getBestUserName(userModel))
+// sendEmailToCLAManager asks the CLA Manager to approve the contributor
+func sendEmailToCLAManager(ctx context.Context, manager string, managerEmail string, userModel *v1Models.User, company string, claGroupName string) {
 	recipients := []string{managerEmail}
-	body := fmt.Sprintf(`
-	<p>Hello %s,</p>
-	<p>This is a notification email from EasyCLA regarding the organization %s.</p>
-	<p>The following contributor would like to submit a contribution to the %s CLA Group
-	   and is requesting to be approved as a contributor for your organization: </p>
-	<p>%s</p>
-	<p>Please notify the contributor once they are added so that they may complete the contribution process.</p>
-	%s
-    %s`,
-		manager, company, claGroupName, getFormattedUserDetails(userModel),
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, "", notifications.ContributorApprovalRequestTemplate, recipients, notifications.ContributorApprovalRequestData{
+		RecipientName:      manager,
+		CompanyName:        company,
+		ClaGroupName:       claGroupName,
+		ContributorName:    getBestUserName(userModel),
+		ContributorDetails: getFormattedUserDetails(userModel),
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.ContributorApprovalRequestTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.ContributorApprovalRequestTemplate, recipients)
 	}
 }
 
@@ -1202,101 +1204,51 @@ func (s *service) isSigned(ctx context.Context, companyModel *v1Models.Company,
 	return false, nil
 }
 
-func sendEmailToOrgAdmin(adminEmail string, admin string, company string, projectNames []string, contributorID string, contributorName string, corporateConsole string) {
-	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA and add to approved list %s ", company, contributorID)
+func sendEmailToOrgAdmin(ctx context.Context, claGroupID string, adminEmail string, admin string, company string, projectNames []string, contributorID string, contributorName string, corporateConsole string) {
 	recipients := []string{adminEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project(s) %s.</p>
-<p>The following contributor is requesting to sign CLA for organization: </p>
-<p> %s %s </p>
-<p>Before the user contribution can be accepted, your organization must sign a CLA.
-<p>Kindly login to this portal %s and sign the CLA for any of the projects %s. </p>
-<p>Please notify the contributor once they are added so that they may complete the contribution process.</p>
-%s
-%s`,
-		admin, projectNames, contributorName, contributorID, corporateConsole, projectNames,
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, claGroupID, notifications.OrgAdminSignatureRequestTemplate, recipients, notifications.OrgAdminSignatureRequestData{
+		RecipientName:       admin,
+		CompanyName:         company,
+		ProjectNames:        projectNames,
+		ContributorID:       contributorID,
+		ContributorName:     contributorName,
+		CorporateConsoleURL: corporateConsole,
+		V2:                  true,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.OrgAdminSignatureRequestTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.OrgAdminSignatureRequestTemplate, recipients)
 	}
 }
 
-func contributorEmailToOrgAdmin(adminEmail string, admin string, company string, projectNames []string, contributor *v1Models.User, corporateConsole string) {
-	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA and add to approved list %s ", company, getBestUserName(contributor))
-	recipients := []string{adminEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project(s) %s.</p>
-<p>The following contributor is requesting to sign CLA for organization: </p>
-<p>%s</p>
-<p>Before the user contribution can be accepted, your organization must sign a CLA.
-<p>Kindly login to this portal %s and sign the CLA for any of the projects %s. </p>
-<p>Please notify the contributor once they are added so that they may complete the contribution process.</p>
-%s
-%s`,
-		admin, projectNames, getFormattedUserDetails(contributor), corporateConsole, projectNames,
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// contributorEmailToOrgAdmin asks the organization admin to sign the CCLA for the contributor
+func contributorEmailToOrgAdmin(ctx context.Context, claGroupID string, adminEmail string, admin string, company string, projectNames []string, contributor *v1Models.User, corporateConsole string) {
+	sendEmailToOrgAdmin(ctx, claGroupID, adminEmail, admin, company, projectNames, getBestUserName(contributor), getFormattedUserDetails(contributor), corporateConsole)
 }
 
-func sendEmailToCLAManagerDesignee(corporateConsole string, companyName string, projectNames []string, designeeEmail string, designeeName string, contributorID string, contributorName string) {
-	subject := fmt.Sprintf("EasyCLA:  Invitation to Sign the %s Corporate CLA and add to approved list %s ",
-		companyName, contributorID)
-	recipients := []string{designeeEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project(s) %s.</p>
-<p>The following contributor is requesting to sign CLA for organization: </p>
-<p> %s (%s) </p>
-<p>Before the user contribution can be accepted, your organization must sign a CLA.
-<p>Kindly login to this portal %s and sign the CLA for one of the project(s) %s. </p>
-<p>Please notify the contributor once they are added so that they may complete the contribution process.</p>
-%s
-%s`,
-		designeeName, projectNames, contributorID, contributorName, corporateConsole, projectNames,
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-
-	err := utils.SendEmail(subject, body, recipients)
-	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
-	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
-	}
+// sendEmailToCLAManagerDesignee asks the CLA Manager designee to sign the CCLA for the contributor
+func sendEmailToCLAManagerDesignee(ctx context.Context, claGroupID string, corporateConsole string, companyName string, projectNames []string, designeeEmail string, designeeName string, contributorID string, contributorName string) {
+	sendEmailToOrgAdmin(ctx, claGroupID, designeeEmail, designeeName, companyName, projectNames, contributorID, contributorName, corporateConsole)
 }
 
 // sendEmailToUserWithNoLFID helper function to send email to a given user with no LFID
-func sendEmailToUserWithNoLFID(projectName, requesterUsername, requesterEmail, userWithNoLFIDName, userWithNoLFIDEmail, organizationID string, projectID *string, role string) error {
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Invitation to create LF Login and complete process of becoming CLA Manager with %s role", role)
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the Project %s in the EasyCLA system.</p>
-<p>User %s (%s) was trying to add you as a CLA Manager for Project %s but was unable to identify your account details in
-the EasyCLA system. In order to become a CLA Manager for Project %s, you will need to accept invite below.
-Once complete, notify the user %s and they will be able to add you as a CLA Manager.</p>
-<p> <a href="USERACCEPTLINK">Accept Invite</a> </p>
-%s
-%s`,
-		userWithNoLFIDName, projectName,
-		requesterUsername, requesterEmail, projectName, projectName,
-		requesterUsername,
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
+func sendEmailToUserWithNoLFID(ctx context.Context, claGroupID, projectName, requesterUsername, requesterEmail, userWithNoLFIDName, userWithNoLFIDEmail, organizationID string, projectID *string, role string) error {
+	msg, err := notifications.Render(ctx, claGroupID, notifications.CLAManagerInviteTemplate, notifications.DefaultLocale, notifications.CLAManagerInviteData{
+		RecipientName:  userWithNoLFIDName,
+		ProjectName:    projectName,
+		RequesterName:  requesterUsername,
+		RequesterEmail: requesterEmail,
+		Role:           role,
+	})
+	if err != nil {
+		return err
+	}
 
 	acsClient := v2AcsService.GetClient()
 	automate := false
 
-	return acsClient.SendUserInvite(&userWithNoLFIDEmail, role, "project|organization", projectID, organizationID, "userinvite", &subject, &body, automate)
+	return acsClient.SendUserInvite(&userWithNoLFIDEmail, role, "project|organization", projectID, organizationID, "userinvite", &msg.Subject, &msg.HTMLBody, automate)
 }
 
 // buildErrorMessage helper function to build an error message
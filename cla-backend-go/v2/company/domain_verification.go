@@ -0,0 +1,70 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"context"
+
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// GetCompanyDomainVerifications returns the domain verification challenges and the verified domains of the company
+func (s *service) GetCompanyDomainVerifications(ctx context.Context, companyID string) (*models.DomainVerificationList, error) {
+	verifications, err := s.v1CompanyService.GetDomainVerifications(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.DomainVerificationList{
+		Verifications: []*models.DomainVerification{},
+	}
+	for _, verification := range verifications {
+		result.Verifications = append(result.Verifications, toDomainVerificationModel(verification))
+		if verification.Status == v1Company.DomainVerificationStatusVerified {
+			result.VerifiedDomains = append(result.VerifiedDomains, verification.Domain)
+		}
+	}
+	return result, nil
+}
+
+// CreateCompanyDomainVerification issues a domain verification challenge for the company
+func (s *service) CreateCompanyDomainVerification(ctx context.Context, companyID, domain, method, requestedBy string) (*models.DomainVerification, error) {
+	verification, err := s.v1CompanyService.CreateDomainVerification(ctx, companyID, domain, method, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainVerificationModel(verification), nil
+}
+
+// VerifyCompanyDomain completes the domain verification challenge of the company
+func (s *service) VerifyCompanyDomain(ctx context.Context, companyID, domain, token string) (*models.DomainVerification, error) {
+	verification, err := s.v1CompanyService.VerifyDomain(ctx, companyID, domain, token)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainVerificationModel(verification), nil
+}
+
+// toDomainVerificationModel converts the domain verification to the response model - the token of the email
+// challenge is only known to the postmaster and is never returned
+func toDomainVerificationModel(verification *v1Company.DomainVerification) *models.DomainVerification {
+	result := &models.DomainVerification{
+		Domain:           verification.Domain,
+		Method:           verification.Method,
+		Status:           verification.Status,
+		RequestedBy:      verification.RequestedBy,
+		DateCreated:      verification.Created,
+		DateExpires:      verification.Expires,
+		DateVerified:     verification.Verified,
+		LastCheckMessage: verification.LastCheckMsg,
+	}
+	switch verification.Method {
+	case v1Company.DomainVerificationMethodDNS:
+		result.TxtRecordName = v1Company.DomainVerificationTXTPrefix + verification.Domain
+		result.TxtRecordValue = v1Company.DomainVerificationTXTValuePrefix + verification.Token
+	case v1Company.DomainVerificationMethodEmail:
+		result.PostmasterEmail = "postmaster@" + verification.Domain
+	}
+	return result
+}
@@ -436,6 +436,106 @@ func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Comp
 			log.WithFields(f).Debugf("processed Assigning Company owner role to user")
 			return company.NewAssignCompanyOwnerOK().WithXRequestID(reqID).WithPayload(companyOwner)
 		})
+	api.CompanyListCompanyDomainVerificationsHandler = company.ListCompanyDomainVerificationsHandlerFunc(
+		func(params company.ListCompanyDomainVerificationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewListCompanyDomainVerificationsForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to List Company Domain Verifications with Organization scope of %s",
+						authUser.UserName, params.CompanySFID),
+				})
+			}
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewListCompanyDomainVerificationsNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return company.NewListCompanyDomainVerificationsBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result, err := service.GetCompanyDomainVerifications(ctx, comp.CompanyID)
+			if err != nil {
+				return company.NewListCompanyDomainVerificationsBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return company.NewListCompanyDomainVerificationsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.CompanyCreateCompanyDomainVerificationHandler = company.CreateCompanyDomainVerificationHandlerFunc(
+		func(params company.CreateCompanyDomainVerificationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName": "CompanyCreateCompanyDomainVerificationHandler",
+				"CompanySFID":  params.CompanySFID,
+				"domain":       params.Body.Domain,
+				"method":       params.Body.Method,
+			}
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewCreateCompanyDomainVerificationForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Create Company Domain Verification with Organization scope of %s",
+						authUser.UserName, params.CompanySFID),
+				})
+			}
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewCreateCompanyDomainVerificationNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return company.NewCreateCompanyDomainVerificationBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result, err := service.CreateCompanyDomainVerification(ctx, comp.CompanyID, params.Body.Domain, params.Body.Method, authUser.UserName)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to create the domain verification, error: %+v", err)
+				if err == v1Company.ErrDomainAlreadyVerified {
+					return company.NewCreateCompanyDomainVerificationConflict().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    Conflict,
+						Message: fmt.Sprintf("EasyCLA - 409 Conflict - domain %s is already verified", params.Body.Domain),
+					})
+				}
+				return company.NewCreateCompanyDomainVerificationBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return company.NewCreateCompanyDomainVerificationOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.CompanyVerifyCompanyDomainHandler = company.VerifyCompanyDomainHandlerFunc(
+		func(params company.VerifyCompanyDomainParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewVerifyCompanyDomainForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Verify Company Domain with Organization scope of %s",
+						authUser.UserName, params.CompanySFID),
+				})
+			}
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewVerifyCompanyDomainNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return company.NewVerifyCompanyDomainBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			token := ""
+			if params.Body != nil {
+				token = params.Body.Token
+			}
+			result, err := service.VerifyCompanyDomain(ctx, comp.CompanyID, params.Domain, token)
+			if err != nil {
+				if err == v1Company.ErrDomainVerificationNotFound {
+					return company.NewVerifyCompanyDomainNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return company.NewVerifyCompanyDomainBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return company.NewVerifyCompanyDomainOK().WithXRequestID(reqID).WithPayload(result)
+		})
 }
 
 type codedResponse interface {
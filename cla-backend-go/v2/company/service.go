@@ -32,6 +32,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -93,6 +94,9 @@ type Service interface {
 	AssociateContributorByGroup(ctx context.Context, companySFID, userEmail string, projectCLAGroups []*projects_cla_groups.ProjectClaGroup, ClaGroupID string) ([]*models.Contributor, string, error)
 	GetCompanyAdmins(ctx context.Context, companyID string) (*models.CompanyAdminList, error)
 	AssignCompanyOwner(ctx context.Context, companySFID string, userEmail string, LFXPortalURL string) (*models.CompanyOwner, error)
+	GetCompanyDomainVerifications(ctx context.Context, companyID string) (*models.DomainVerificationList, error)
+	CreateCompanyDomainVerification(ctx context.Context, companyID, domain, method, requestedBy string) (*models.DomainVerification, error)
+	VerifyCompanyDomain(ctx context.Context, companyID, domain, token string) (*models.DomainVerification, error)
 }
 
 // ProjectRepo contains project repo methods
@@ -836,7 +840,7 @@ func (s *service) AssignCompanyOwner(ctx context.Context, companySFID string, us
 		msg := fmt.Sprintf("Failed searching user by email :%s ", userEmail)
 		log.Warn(msg)
 		// Send user invite for company owner
-		emailErr := sendEmailToUserWithNoLFID(userEmail, assignOrg.Name, assignOrg.ID, "company-owner")
+		emailErr := sendEmailToUserWithNoLFID(ctx, userEmail, assignOrg.Name, assignOrg.ID, "company-owner")
 		if emailErr != nil {
 			msg := fmt.Sprintf("error %+v", emailErr)
 			log.WithFields(f).Debug(msg)
@@ -891,7 +895,7 @@ func (s *service) AssignCompanyOwner(ctx context.Context, companySFID string, us
 				}
 				//Send Email to User with instructions to complete Company profile
 				log.WithFields(f).Debugf("Sending Email to user :%s to complete setup for newly created Org: %s ", userEmail, org.Name)
-				sendEmailToUserCompanyProfile(org.Name, userEmail, user.Username, LFXPortalURL)
+				sendEmailToUserCompanyProfile(ctx, org.Name, userEmail, user.Username, LFXPortalURL)
 				return &models.CompanyOwner{
 					LfUsername:  user.Username,
 					Name:        user.Name,
@@ -1344,44 +1348,36 @@ func (s service) autoCreateCompany(ctx context.Context, companySFID string) (*v1
 	return companyModel, nil
 }
 
-func sendEmailToUserCompanyProfile(orgName string, userEmail string, username string, LFXPortalURL string) {
-	subject := "EasyCLA: Company Profile "
+// sendEmailToUserCompanyProfile notifies the owner of the newly created organization
+func sendEmailToUserCompanyProfile(ctx context.Context, orgName string, userEmail string, username string, LFXPortalURL string) {
 	recipients := []string{userEmail}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the newly created Salesforce Organization %s.</p>
-<p> You have been assigned as the company owner for this new organization </p>
-<p>The organization profile can be completed via <a href="%s/company/manage/" target="_blank">clicking this link</a>
-%s
-%s`,
-		username, orgName, LFXPortalURL,
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-	err := utils.SendEmail(subject, body, recipients)
+	err := notifications.Send(ctx, "", notifications.CompanyProfileTemplate, recipients, notifications.CompanyProfileData{
+		RecipientName:    username,
+		OrganizationName: orgName,
+		LFXPortalURL:     LFXPortalURL,
+	})
 	if err != nil {
-		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		log.Warnf("problem sending %s email to recipients: %+v, error: %+v", notifications.CompanyProfileTemplate, recipients, err)
 	} else {
-		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+		log.Debugf("sent %s email to recipients: %+v", notifications.CompanyProfileTemplate, recipients)
 	}
 }
 
 // sendEmailToUserWithNoLFID helper function to send email to a given user with no LFID
-func sendEmailToUserWithNoLFID(userWithNoLFIDEmail, organizationName string, organizationID string, role string) error {
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Invitation to create LF Login and complete process of becoming CLA Manager with %s role", role)
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the organization %s.</p>
-<p> You have been identified as company owner </p>
-<p> <a href="USERACCEPTLINK">Accept Invite</a> </p>
-%s
-%s`,
-		userWithNoLFIDEmail, organizationName,
-		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
+func sendEmailToUserWithNoLFID(ctx context.Context, userWithNoLFIDEmail, organizationName string, organizationID string, role string) error {
+	msg, err := notifications.Render(ctx, "", notifications.CompanyOwnerInviteTemplate, notifications.DefaultLocale, notifications.CompanyOwnerInviteData{
+		RecipientName:    userWithNoLFIDEmail,
+		OrganizationName: organizationName,
+		Role:             role,
+	})
+	if err != nil {
+		return err
+	}
 
 	acsClient := acs_service.GetClient()
 	automate := false
 
-	acsErr := acsClient.SendUserInvite(&userWithNoLFIDEmail, role, "organization", nil, organizationID, "userinvite", &subject, &body, automate)
+	acsErr := acsClient.SendUserInvite(&userWithNoLFIDEmail, role, "organization", nil, organizationID, "userinvite", &msg.Subject, &msg.HTMLBody, automate)
 	if acsErr != nil {
 		msg := fmt.Sprintf("Error sending email to user: %s, error : %+v", userWithNoLFIDEmail, acsErr)
 		log.Debug(msg)
@@ -0,0 +1,277 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// signature types of the contributor coverage
+const (
+	SignatureTypeICLA = "ICLA"
+	SignatureTypeCCLA = "CCLA"
+)
+
+// how long the CI systems may cache the contributor coverage - a contributor who is not covered is expected to sign
+// shortly after, so that decision is cached for less time
+const (
+	CoveredMaxAge    = 5 * time.Minute
+	NotCoveredMaxAge = 1 * time.Minute
+)
+
+// ErrContributorCoverageInput is returned when the contributor coverage input is incomplete
+var ErrContributorCoverageInput = errors.New("missing input - expecting the projectSFID or claGroupID and any of email, githubUsername or lfUsername")
+
+// ErrContributorIdentityMismatch is returned when the contributor identities do not belong to the same EasyCLA user
+var ErrContributorIdentityMismatch = errors.New("the email, githubUsername and lfUsername do not belong to the same EasyCLA user")
+
+// ContributorCoverageInput identifies the contributor and the project or CLA Group of the coverage check
+type ContributorCoverageInput struct {
+	ProjectSFID    string
+	ClaGroupID     string
+	Email          string
+	GitHubUsername string
+	LFUsername     string
+	Redirect       string
+}
+
+// Validate checks that the project or CLA Group and at least one contributor identity are provided
+func (in *ContributorCoverageInput) Validate() error {
+	if in == nil || (in.ProjectSFID == "" && in.ClaGroupID == "") {
+		return ErrContributorCoverageInput
+	}
+	if in.Email == "" && in.GitHubUsername == "" && in.LFUsername == "" {
+		return ErrContributorCoverageInput
+	}
+	return nil
+}
+
+// GetContributorCoverageScope returns the project SFID used to authorize the contributor coverage check - the
+// project itself, or the foundation of the CLA Group
+func (s *service) GetContributorCoverageScope(ctx context.Context, input *ContributorCoverageInput) (string, error) {
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+	if input.ProjectSFID != "" {
+		return input.ProjectSFID, nil
+	}
+	claGroup, err := s.projectService.GetCLAGroupByID(ctx, input.ClaGroupID)
+	if err != nil {
+		return "", err
+	}
+	return claGroup.FoundationSFID, nil
+}
+
+// GetContributorCoverage decides if the contributor is covered by a signed ICLA or by the approval list of a signed
+// CCLA of the CLA Group associated with the project
+func (s *service) GetContributorCoverage(ctx context.Context, input *ContributorCoverageInput) (*models.ContributorCoverage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	f := logrus.Fields{
+		"functionName":   "GetContributorCoverage",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    input.ProjectSFID,
+		"claGroupID":     input.ClaGroupID,
+		"email":          input.Email,
+		"githubUsername": input.GitHubUsername,
+		"lfUsername":     input.LFUsername,
+	}
+
+	claGroupID := input.ClaGroupID
+	if input.ProjectSFID != "" {
+		projectClaGroup, err := s.projectClaGroupRepo.GetClaGroupIDForProject(input.ProjectSFID)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to lookup CLA Group associated with project, error: %+v", err)
+			return nil, err
+		}
+		claGroupID = projectClaGroup.ClaGroupID
+		f["claGroupID"] = claGroupID
+	}
+
+	claGroup, err := s.projectService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup CLA Group, error: %+v", err)
+		return nil, err
+	}
+
+	result := &models.ContributorCoverage{
+		ClaGroupID:   claGroupID,
+		ClaGroupName: claGroup.ProjectName,
+		ProjectSFID:  input.ProjectSFID,
+	}
+
+	userModel := s.lookupUser(input)
+	if userModel == nil {
+		result.Reason = fmt.Sprintf("no EasyCLA user record found for contributor %s", contributorName(input))
+		result.SignURL = contributorSignURL(utils.GetContributorURL(), claGroupID, "", input.Redirect)
+		return result, nil
+	}
+	if !ownsIdentities(input, userModel) {
+		log.WithFields(f).Warnf("contributor identities do not belong to user: %s", userModel.UserID)
+		return nil, ErrContributorIdentityMismatch
+	}
+	result.UserID = userModel.UserID
+
+	icla, err := s.signatureService.GetHonoredIndividualSignature(ctx, claGroupID, userModel.UserID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup ICLA signature for user: %s, error: %+v", userModel.UserID, err)
+		return nil, err
+	}
+	if icla != nil {
+		result.Covered = true
+		result.SignatureType = SignatureTypeICLA
+		result.SignatureID = icla.SignatureID.String()
+		result.Reason = "contributor is covered by a signed ICLA"
+		return result, nil
+	}
+
+	if userModel.CompanyID == "" {
+		result.Reason = "contributor has not signed an ICLA and is not affiliated with a company"
+		result.SignURL = contributorSignURL(utils.GetContributorURL(), claGroupID, userModel.UserID, input.Redirect)
+		return result, nil
+	}
+
+	result.CompanyID = userModel.CompanyID
+	companyModel, err := s.companyRepo.GetCompany(ctx, userModel.CompanyID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup company: %s, error: %+v", userModel.CompanyID, err)
+	} else {
+		result.CompanyName = companyModel.CompanyName
+	}
+
+	decision, err := s.signatureService.EvaluateApprovalList(ctx, claGroupID, userModel.CompanyID, approvalListCandidate(userModel))
+	if err != nil {
+		log.WithFields(f).Warnf("unable to evaluate the CCLA approval list for company: %s, error: %+v", userModel.CompanyID, err)
+		return nil, err
+	}
+	if !decision.Approved {
+		result.Reason = fmt.Sprintf("contributor is not covered by a signed CLA - %s", decision.Reason)
+		result.SignURL = contributorSignURL(utils.GetContributorURL(), claGroupID, userModel.UserID, input.Redirect)
+		return result, nil
+	}
+
+	result.Covered = true
+	result.SignatureType = SignatureTypeCCLA
+	result.SignatureID = decision.SignatureID
+	result.ApprovalRule = fmt.Sprintf("%s:%s", decision.RuleType, decision.Rule)
+	result.Reason = fmt.Sprintf("contributor is covered by a signed CCLA by the %s approval list entry %s", decision.RuleType, decision.Rule)
+	return result, nil
+}
+
+// lookupUser returns the EasyCLA user record of the contributor, or nil if not found
+func (s *service) lookupUser(input *ContributorCoverageInput) *v1Models.User {
+	if input.LFUsername != "" {
+		userModel, err := s.usersService.GetUserByLFUserName(input.LFUsername)
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	if input.GitHubUsername != "" {
+		userModel, err := s.usersService.GetUserByGitHubUsername(input.GitHubUsername)
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	if input.Email != "" {
+		userModel, err := s.usersService.GetUserByEmail(input.Email)
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	return nil
+}
+
+// ownsIdentities returns true if every identity of the input is one of the stored identities of the user
+func ownsIdentities(input *ContributorCoverageInput, userModel *v1Models.User) bool {
+	if input.LFUsername != "" && !strings.EqualFold(input.LFUsername, userModel.LfUsername) {
+		return false
+	}
+	if input.GitHubUsername != "" && !strings.EqualFold(input.GitHubUsername, userModel.GithubUsername) {
+		return false
+	}
+	if input.Email != "" && !utils.StringInSlice(strings.ToLower(input.Email), userEmails(userModel)) {
+		return false
+	}
+	return true
+}
+
+// userEmails returns the lower case stored emails of the user
+func userEmails(userModel *v1Models.User) []string {
+	var emails []string
+	for _, email := range append([]string{userModel.LfEmail}, userModel.Emails...) {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// approvalListCandidate returns the stored identities of the contributor checked against the CCLA approval lists
+func approvalListCandidate(userModel *v1Models.User) *signatures.ApprovalListCandidate {
+	return &signatures.ApprovalListCandidate{
+		LFUsername:     userModel.LfUsername,
+		Emails:         userEmails(userModel),
+		GitHubUsername: userModel.GithubUsername,
+	}
+}
+
+// contributorSignURL returns the contributor console URL to sign the CLA of the CLA Group. Known contributors are sent
+// to their user page, unknown contributors to the LF login flow of the console.
+func contributorSignURL(consoleURL, claGroupID, userID, redirect string) string {
+	signURL := fmt.Sprintf("%s/#/cla/gerrit/project/%s/individual", strings.TrimSuffix(consoleURL, "/"), claGroupID)
+	if userID != "" {
+		signURL = fmt.Sprintf("%s/#/cla/project/%s/user/%s", strings.TrimSuffix(consoleURL, "/"), claGroupID, userID)
+	}
+	if redirect != "" {
+		signURL = fmt.Sprintf("%s?redirect=%s", signURL, url.QueryEscape(redirect))
+	}
+	return signURL
+}
+
+// contributorName returns the contributor identifier used in the reason messages
+func contributorName(input *ContributorCoverageInput) string {
+	switch {
+	case input.LFUsername != "":
+		return input.LFUsername
+	case input.GitHubUsername != "":
+		return input.GitHubUsername
+	default:
+		return input.Email
+	}
+}
+
+// ContributorCoverageETag returns the entity tag of the contributor coverage
+func ContributorCoverageETag(coverage *models.ContributorCoverage) string {
+	content, err := json.Marshal(coverage)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:16]))
+}
+
+// ContributorCoverageMaxAge returns how long the contributor coverage may be cached
+func ContributorCoverageMaxAge(coverage *models.ContributorCoverage) time.Duration {
+	if coverage.Covered {
+		return CoveredMaxAge
+	}
+	return NotCoveredMaxAge
+}
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+var coverageColumns = []interface{}{"Company Name", "Company SFID", "CLA Group", "Projects", "CCLA Status", "CLA Managers", "Employee Signatures"}
+
+var gapColumns = []interface{}{"Company Name", "Company SFID", "Project Name", "Project SFID", "CLA Group"}
+
+var uncoveredColumns = []interface{}{"Project Name", "Project SFID"}
+
+// coverageRows returns a row for each company and CLA Group of the report
+func coverageRows(report *models.FoundationCoverageReport) [][]interface{} {
+	rows := [][]interface{}{coverageColumns}
+	for _, company := range report.Companies {
+		for _, claGroup := range company.ClaGroups {
+			rows = append(rows, []interface{}{
+				company.CompanyName,
+				company.CompanySFID,
+				claGroup.ClaGroupName,
+				strings.Join(claGroup.Projects, "; "),
+				claGroup.CclaStatus,
+				strings.Join(claGroup.ClaManagers, "; "),
+				claGroup.EmployeeSignatureCount,
+			})
+		}
+	}
+	return rows
+}
+
+// gapRows returns a row for each company and project not covered by a corporate CLA of the company
+func gapRows(report *models.FoundationCoverageReport) [][]interface{} {
+	rows := [][]interface{}{gapColumns}
+	for _, company := range report.Companies {
+		for _, project := range company.Gaps {
+			rows = append(rows, []interface{}{company.CompanyName, company.CompanySFID, project.ProjectName, project.ProjectSFID, project.ClaGroupName})
+		}
+	}
+	return rows
+}
+
+// uncoveredRows returns a row for each project of the foundation which is not associated with a CLA Group
+func uncoveredRows(report *models.FoundationCoverageReport) [][]interface{} {
+	rows := [][]interface{}{uncoveredColumns}
+	for _, project := range report.UncoveredProjects {
+		rows = append(rows, []interface{}{project.ProjectName, project.ProjectSFID})
+	}
+	return rows
+}
+
+// ReportCSV returns the report as a CSV document with a row for each company and CLA Group
+func ReportCSV(report *models.FoundationCoverageReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, row := range coverageRows(report) {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = cellString(value)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReportXLSX returns the report as an Excel workbook with the coverage, gaps and uncovered projects sheets
+func ReportXLSX(report *models.FoundationCoverageReport) ([]byte, error) {
+	var buf bytes.Buffer
+	err := writeXLSX(&buf, []xlsxSheet{
+		{Name: "Coverage", Rows: coverageRows(report)},
+		{Name: "Gaps", Rows: gapRows(report)},
+		{Name: "Uncovered Projects", Rows: uncoveredRows(report)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
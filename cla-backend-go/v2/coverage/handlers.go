@@ -0,0 +1,187 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/coverage"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// XLSXMime is the content type of the Excel workbooks
+const XLSXMime = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Configure setup the foundation coverage report and contributor coverage API handlers
+func Configure(api *operations.EasyclaAPI, service Service) {
+	api.CoverageGetFoundationCoverageReportHandler = coverage.GetFoundationCoverageReportHandlerFunc(
+		func(params coverage.GetFoundationCoverageReportParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return coverage.NewGetFoundationCoverageReportForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, params.FoundationSFID))
+			}
+
+			report, err := service.GetFoundationCoverageReport(ctx, params.FoundationSFID)
+			if err != nil {
+				return coverage.NewGetFoundationCoverageReportInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return coverage.NewGetFoundationCoverageReportOK().WithXRequestID(reqID).WithPayload(report)
+		})
+
+	api.CoverageGetFoundationCoverageReportAsCSVHandler = coverage.GetFoundationCoverageReportAsCSVHandlerFunc(
+		func(params coverage.GetFoundationCoverageReportAsCSVParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return coverage.NewGetFoundationCoverageReportAsCSVForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, params.FoundationSFID))
+			}
+
+			report, err := service.GetFoundationCoverageReport(ctx, params.FoundationSFID)
+			if err != nil {
+				return coverage.NewGetFoundationCoverageReportAsCSVInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			result, err := ReportCSV(report)
+			if err != nil {
+				return coverage.NewGetFoundationCoverageReportAsCSVInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return fileResponse(reqID, runtime.CSVMime, fmt.Sprintf("coverage-report-%s.csv", params.FoundationSFID), result)
+		})
+
+	api.CoverageGetFoundationCoverageReportAsXLSXHandler = coverage.GetFoundationCoverageReportAsXLSXHandlerFunc(
+		func(params coverage.GetFoundationCoverageReportAsXLSXParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return coverage.NewGetFoundationCoverageReportAsXLSXForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, params.FoundationSFID))
+			}
+
+			report, err := service.GetFoundationCoverageReport(ctx, params.FoundationSFID)
+			if err != nil {
+				return coverage.NewGetFoundationCoverageReportAsXLSXInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			result, err := ReportXLSX(report)
+			if err != nil {
+				return coverage.NewGetFoundationCoverageReportAsXLSXInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return fileResponse(reqID, XLSXMime, fmt.Sprintf("coverage-report-%s.xlsx", params.FoundationSFID), result)
+		})
+
+	api.CoverageGetContributorCoverageHandler = coverage.GetContributorCoverageHandlerFunc(
+		func(params coverage.GetContributorCoverageParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+			input := &ContributorCoverageInput{
+				ProjectSFID:    strings.TrimSpace(utils.StringValue(params.ProjectSFID)),
+				ClaGroupID:     strings.TrimSpace(utils.StringValue(params.ClaGroupID)),
+				Email:          strings.TrimSpace(utils.StringValue(params.Email)),
+				GitHubUsername: strings.TrimSpace(utils.StringValue(params.GithubUsername)),
+				LFUsername:     strings.TrimSpace(utils.StringValue(params.LfUsername)),
+				Redirect:       strings.TrimSpace(utils.StringValue(params.Redirect)),
+			}
+			if err := input.Validate(); err != nil {
+				return coverage.NewGetContributorCoverageBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: err.Error(),
+				})
+			}
+
+			projectSFID, err := service.GetContributorCoverageScope(ctx, input)
+			if err != nil {
+				if err == v1Project.ErrProjectDoesNotExist {
+					return coverage.NewGetContributorCoverageNotFound().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    "404",
+						Message: fmt.Sprintf("no CLA Group found for claGroupID: %s", input.ClaGroupID),
+					})
+				}
+				return coverage.NewGetContributorCoverageInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			if !utils.IsUserAuthorizedForProjectTree(authUser, projectSFID) {
+				return coverage.NewGetContributorCoverageForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Contributor Coverage with Project scope of %s",
+						authUser.UserName, projectSFID),
+				})
+			}
+
+			result, err := service.GetContributorCoverage(ctx, input)
+			if err != nil {
+				if err == ErrContributorIdentityMismatch {
+					return coverage.NewGetContributorCoverageBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    "400",
+						Message: err.Error(),
+					})
+				}
+				if err == projects_cla_groups.ErrProjectNotAssociatedWithClaGroup || err == v1Project.ErrProjectDoesNotExist {
+					return coverage.NewGetContributorCoverageNotFound().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    "404",
+						Message: fmt.Sprintf("no CLA Group found for projectSFID: %s, claGroupID: %s", input.ProjectSFID, input.ClaGroupID),
+					})
+				}
+				return coverage.NewGetContributorCoverageInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			eTag := ContributorCoverageETag(result)
+			cacheControl := fmt.Sprintf("private, max-age=%d", int64(ContributorCoverageMaxAge(result).Seconds()))
+			if eTag != "" && utils.StringValue(params.IfNoneMatch) == eTag {
+				return coverage.NewGetContributorCoverageNotModified().WithXRequestID(reqID).WithETag(eTag).WithCacheControl(cacheControl)
+			}
+			return coverage.NewGetContributorCoverageOK().WithXRequestID(reqID).WithETag(eTag).WithCacheControl(cacheControl).WithPayload(result)
+		})
+}
+
+// fileResponse writes the document as an attachment
+func fileResponse(reqID, contentType, filename string, content []byte) middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+		rw.Header().Set("Content-Type", contentType)
+		rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		rw.Header().Set(utils.XREQUESTID, reqID)
+		rw.WriteHeader(http.StatusOK)
+		if _, err := rw.Write(content); err != nil {
+			log.Warnf("Error writing the coverage report %s, error: %v", filename, err)
+		}
+	})
+}
+
+func forbidden(authUser *auth.User, foundationSFID string) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code: "403",
+		Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Foundation Coverage Report with Project scope of %s",
+			authUser.UserName, foundationSFID),
+	}
+}
+
+type codedResponse interface {
+	Code() string
+}
+
+func errorResponse(err error) *models.ErrorResponse {
+	code := ""
+	if e, ok := err.(codedResponse); ok {
+		code = e.Code()
+	}
+
+	e := models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	return &e
+}
@@ -0,0 +1,355 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// corporate CLA status values
+const (
+	CCLAStatusSigned    = "signed"
+	CCLAStatusNotSigned = "not-signed"
+)
+
+// constants
+const (
+	// maxConcurrentLookups bounds the number of CLA Groups and companies loaded in parallel
+	maxConcurrentLookups = 10
+)
+
+// ProjectClaGroupRepo contains the project CLA Group mapping repo methods
+type ProjectClaGroupRepo interface {
+	GetProjectsIdsForFoundation(foundationSFID string) ([]*projects_cla_groups.ProjectClaGroup, error)
+	GetClaGroupIDForProject(projectSFID string) (*projects_cla_groups.ProjectClaGroup, error)
+}
+
+// SignatureRepo contains the signature repo methods
+type SignatureRepo interface {
+	GetClaGroupCorporateSignatureSummaries(ctx context.Context, claGroupID string) ([]signatures.CorporateSignatureSummary, error)
+	GetClaGroupEmployeeSignatureCounts(ctx context.Context, claGroupID string) (map[string]int64, error)
+}
+
+// ProjectService contains the project service methods
+type ProjectService interface {
+	SignedAtFoundationLevel(ctx context.Context, foundationSFID string) (bool, error)
+	GetCLAGroupByID(ctx context.Context, claGroupID string) (*v1Models.Project, error)
+}
+
+// CompanyRepo contains the company repo methods
+type CompanyRepo interface {
+	GetCompany(ctx context.Context, companyID string) (*v1Models.Company, error)
+}
+
+// Service defines the foundation coverage report and contributor coverage functions
+type Service interface {
+	GetFoundationCoverageReport(ctx context.Context, foundationSFID string) (*models.FoundationCoverageReport, error)
+	GetContributorCoverageScope(ctx context.Context, input *ContributorCoverageInput) (string, error)
+	GetContributorCoverage(ctx context.Context, input *ContributorCoverageInput) (*models.ContributorCoverage, error)
+}
+
+type service struct {
+	projectClaGroupRepo ProjectClaGroupRepo
+	signatureRepo       SignatureRepo
+	projectService      ProjectService
+	companyRepo         CompanyRepo
+	signatureService    signatures.SignatureService
+	usersService        users.Service
+}
+
+// NewService creates a new coverage service
+func NewService(projectClaGroupRepo ProjectClaGroupRepo, signatureRepo SignatureRepo, projectService ProjectService, companyRepo CompanyRepo, signatureService signatures.SignatureService, usersService users.Service) Service {
+	return &service{
+		projectClaGroupRepo: projectClaGroupRepo,
+		signatureRepo:       signatureRepo,
+		projectService:      projectService,
+		companyRepo:         companyRepo,
+		signatureService:    signatureService,
+		usersService:        usersService,
+	}
+}
+
+// claGroupProjects is a CLA Group of the foundation and the projects associated with it
+type claGroupProjects struct {
+	ClaGroupID   string
+	ClaGroupName string
+	Projects     []*models.FoundationCoverageProject
+}
+
+// corporateSignature is the corporate signature of a company for a CLA Group
+type corporateSignature struct {
+	SignatureID            string
+	MajorVersion           int64
+	ClaManagers            []string
+	EmployeeSignatureCount int64
+}
+
+// GetFoundationCoverageReport returns the corporate CLA coverage of the companies across the projects of the foundation
+func (s *service) GetFoundationCoverageReport(ctx context.Context, foundationSFID string) (*models.FoundationCoverageReport, error) {
+	f := logrus.Fields{
+		"functionName":   "GetFoundationCoverageReport",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
+	}
+
+	log.WithFields(f).Debug("looking up foundation in platform project service...")
+	foundation, err := v2ProjectService.GetClient().GetProject(foundationSFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup foundation, error: %+v", err)
+		return nil, err
+	}
+
+	log.WithFields(f).Debug("loading the CLA Group mappings of the foundation...")
+	mappings, err := s.projectClaGroupRepo.GetProjectsIdsForFoundation(foundationSFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the CLA Group mappings of the foundation, error: %+v", err)
+		return nil, err
+	}
+
+	signedAtFoundationLevel, err := s.projectService.SignedAtFoundationLevel(ctx, foundationSFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to determine if the foundation has a foundation level CLA Group, error: %+v", err)
+		return nil, err
+	}
+
+	var children []*models.FoundationCoverageProject
+	for _, child := range foundation.Projects {
+		children = append(children, &models.FoundationCoverageProject{ProjectSFID: child.ID, ProjectName: child.Name})
+	}
+	if len(children) == 0 {
+		// a standalone project is its own only project
+		children = append(children, &models.FoundationCoverageProject{ProjectSFID: foundation.ID, ProjectName: foundation.Name})
+	}
+	projects, uncovered, claGroups := mapFoundationProjects(foundationSFID, children, mappings)
+
+	// the signatures are loaded once per CLA Group and the companies once, not per company and CLA Group
+	signed, err := s.getCorporateSignatures(ctx, claGroups)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the corporate signatures of the CLA Groups, error: %+v", err)
+		return nil, err
+	}
+	companies, err := s.getSignedCompanies(ctx, signed)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the companies of the corporate signatures, error: %+v", err)
+		return nil, err
+	}
+	log.WithFields(f).Debugf("building the coverage of %d companies across %d CLA Groups", len(companies), len(claGroups))
+
+	companyCoverage := make([]*models.FoundationCoverageCompany, 0, len(companies))
+	for _, company := range companies {
+		companyCoverage = append(companyCoverage, buildCompanyCoverage(company, claGroups, signed[company.CompanyID]))
+	}
+
+	_, currentTime := utils.CurrentTime()
+	return &models.FoundationCoverageReport{
+		FoundationSFID:          foundationSFID,
+		FoundationName:          foundation.Name,
+		SignedAtFoundationLevel: signedAtFoundationLevel,
+		DateGenerated:           currentTime,
+		Projects:                projects,
+		UncoveredProjects:       uncovered,
+		Companies:               companyCoverage,
+	}, nil
+}
+
+// mapFoundationProjects associates the projects of the foundation with their CLA Group and returns the projects, the
+// projects without a CLA Group and the CLA Groups of the foundation sorted by name. The projects of a foundation level
+// CLA Group which are missing a mapping of their own are covered by the foundation level CLA Group.
+func mapFoundationProjects(foundationSFID string, children []*models.FoundationCoverageProject, mappings []*projects_cla_groups.ProjectClaGroup) ([]*models.FoundationCoverageProject, []*models.FoundationCoverageProject, []*claGroupProjects) {
+	mappingByProject := make(map[string]*projects_cla_groups.ProjectClaGroup, len(mappings))
+	for _, mapping := range mappings {
+		mappingByProject[mapping.ProjectSFID] = mapping
+	}
+	foundationMapping := mappingByProject[foundationSFID]
+
+	claGroupsByID := make(map[string]*claGroupProjects)
+	projects := make([]*models.FoundationCoverageProject, 0, len(children))
+	uncovered := make([]*models.FoundationCoverageProject, 0)
+	addProject := func(project *models.FoundationCoverageProject, mapping *projects_cla_groups.ProjectClaGroup) {
+		projects = append(projects, project)
+		if mapping == nil {
+			uncovered = append(uncovered, project)
+			return
+		}
+		project.ClaGroupID = mapping.ClaGroupID
+		project.ClaGroupName = mapping.ClaGroupName
+		claGroup, ok := claGroupsByID[mapping.ClaGroupID]
+		if !ok {
+			claGroup = &claGroupProjects{ClaGroupID: mapping.ClaGroupID, ClaGroupName: mapping.ClaGroupName}
+			claGroupsByID[mapping.ClaGroupID] = claGroup
+		}
+		claGroup.Projects = append(claGroup.Projects, project)
+	}
+
+	seen := utils.NewStringSet()
+	for _, child := range children {
+		seen.Add(child.ProjectSFID)
+		mapping, ok := mappingByProject[child.ProjectSFID]
+		if !ok {
+			mapping = foundationMapping
+		}
+		addProject(child, mapping)
+	}
+	// mappings of projects the platform project service did not return, e.g. moved since
+	for _, mapping := range mappings {
+		if seen.Include(mapping.ProjectSFID) || (mapping.ProjectSFID == foundationSFID && len(children) > 0) {
+			continue
+		}
+		seen.Add(mapping.ProjectSFID)
+		addProject(&models.FoundationCoverageProject{ProjectSFID: mapping.ProjectSFID, ProjectName: mapping.ProjectName}, mapping)
+	}
+
+	claGroups := make([]*claGroupProjects, 0, len(claGroupsByID))
+	for _, claGroup := range claGroupsByID {
+		claGroups = append(claGroups, claGroup)
+	}
+	sort.Slice(claGroups, func(i, j int) bool {
+		return strings.ToLower(claGroups[i].ClaGroupName) < strings.ToLower(claGroups[j].ClaGroupName)
+	})
+	return projects, uncovered, claGroups
+}
+
+// getCorporateSignatures returns the corporate signatures of the CLA Groups by company ID and CLA Group ID - the latest
+// document major version when a company signed several, along with the number of employee signatures of the company
+func (s *service) getCorporateSignatures(ctx context.Context, claGroups []*claGroupProjects) (map[string]map[string]*corporateSignature, error) {
+	var mutex sync.Mutex
+	signed := make(map[string]map[string]*corporateSignature)
+	sem := make(chan struct{}, maxConcurrentLookups)
+	var eg errgroup.Group
+	for _, claGroup := range claGroups {
+		claGroupID := claGroup.ClaGroupID
+		eg.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			summaries, err := s.signatureRepo.GetClaGroupCorporateSignatureSummaries(ctx, claGroupID)
+			if err != nil {
+				return err
+			}
+			if len(summaries) == 0 {
+				return nil
+			}
+			employeeCounts, err := s.signatureRepo.GetClaGroupEmployeeSignatureCounts(ctx, claGroupID)
+			if err != nil {
+				return err
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, summary := range summaries {
+				major, parseErr := strconv.ParseInt(summary.MajorVersion, 10, 64)
+				if parseErr != nil {
+					major = 0
+				}
+				byClaGroup, ok := signed[summary.CompanyID]
+				if !ok {
+					byClaGroup = make(map[string]*corporateSignature)
+					signed[summary.CompanyID] = byClaGroup
+				}
+				if current, found := byClaGroup[claGroupID]; found && current.MajorVersion >= major {
+					continue
+				}
+				byClaGroup[claGroupID] = &corporateSignature{
+					SignatureID:            summary.SignatureID,
+					MajorVersion:           major,
+					ClaManagers:            summary.ClaManagers,
+					EmployeeSignatureCount: employeeCounts[summary.CompanyID],
+				}
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// getSignedCompanies returns the companies of the corporate signatures sorted by name, completed with the company SFID
+// and name when the company is known
+func (s *service) getSignedCompanies(ctx context.Context, signed map[string]map[string]*corporateSignature) ([]signatures.SignatureCompanyID, error) {
+	f := logrus.Fields{
+		"functionName":   "getSignedCompanies",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	companies := make([]signatures.SignatureCompanyID, 0, len(signed))
+	for companyID := range signed {
+		companies = append(companies, signatures.SignatureCompanyID{CompanyID: companyID})
+	}
+
+	sem := make(chan struct{}, maxConcurrentLookups)
+	var eg errgroup.Group
+	for i := range companies {
+		company := &companies[i]
+		eg.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			companyModel, err := s.companyRepo.GetCompany(ctx, company.CompanyID)
+			if err != nil || companyModel == nil {
+				log.WithFields(f).Warnf("problem looking up company using id: %s, error: %+v", company.CompanyID, err)
+				return nil
+			}
+			company.CompanySFID = companyModel.CompanyExternalID
+			company.CompanyName = companyModel.CompanyName
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(companies, func(i, j int) bool {
+		return strings.ToLower(companies[i].CompanyName) < strings.ToLower(companies[j].CompanyName)
+	})
+	return companies, nil
+}
+
+// buildCompanyCoverage returns the coverage of the company for each CLA Group - the projects of the CLA Groups the
+// company did not sign are the gaps of the company
+func buildCompanyCoverage(company signatures.SignatureCompanyID, claGroups []*claGroupProjects, signed map[string]*corporateSignature) *models.FoundationCoverageCompany {
+	coverage := &models.FoundationCoverageCompany{
+		CompanyID:   company.CompanyID,
+		CompanySFID: company.CompanySFID,
+		CompanyName: company.CompanyName,
+		ClaGroups:   make([]*models.FoundationCoverageClaGroup, 0, len(claGroups)),
+		Gaps:        make([]*models.FoundationCoverageProject, 0),
+	}
+	for _, claGroup := range claGroups {
+		projectNames := make([]string, 0, len(claGroup.Projects))
+		for _, project := range claGroup.Projects {
+			projectNames = append(projectNames, project.ProjectName)
+		}
+		claGroupCoverage := &models.FoundationCoverageClaGroup{
+			ClaGroupID:   claGroup.ClaGroupID,
+			ClaGroupName: claGroup.ClaGroupName,
+			Projects:     projectNames,
+			CclaStatus:   CCLAStatusNotSigned,
+		}
+		if signature, ok := signed[claGroup.ClaGroupID]; ok {
+			claGroupCoverage.CclaStatus = CCLAStatusSigned
+			claGroupCoverage.SignatureID = signature.SignatureID
+			claGroupCoverage.ClaManagers = signature.ClaManagers
+			claGroupCoverage.EmployeeSignatureCount = signature.EmployeeSignatureCount
+			coverage.SignedClaGroupCount++
+		} else {
+			coverage.Gaps = append(coverage.Gaps, claGroup.Projects...)
+		}
+		coverage.ClaGroups = append(coverage.ClaGroups, claGroupCoverage)
+	}
+	return coverage
+}
@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoundationCoverageGaps(t *testing.T) {
+	children := []*models.FoundationCoverageProject{
+		{ProjectSFID: "p1", ProjectName: "Project One"},
+		{ProjectSFID: "p2", ProjectName: "Project Two"},
+		{ProjectSFID: "p3", ProjectName: "Project Three"},
+	}
+	mappings := []*projects_cla_groups.ProjectClaGroup{
+		{ProjectSFID: "p1", ClaGroupID: "cg-b", ClaGroupName: "Beta"},
+		{ProjectSFID: "p2", ClaGroupID: "cg-a", ClaGroupName: "Alpha"},
+	}
+
+	projects, uncovered, claGroups := mapFoundationProjects("foundation", children, mappings)
+	assert.Len(t, projects, 3)
+	assert.Len(t, uncovered, 1)
+	assert.Equal(t, "p3", uncovered[0].ProjectSFID)
+	assert.Len(t, claGroups, 2)
+	assert.Equal(t, "Alpha", claGroups[0].ClaGroupName)
+
+	company := signatures.SignatureCompanyID{CompanyID: "c1", CompanySFID: "sf-c1", CompanyName: "Acme"}
+	coverage := buildCompanyCoverage(company, claGroups, map[string]*corporateSignature{
+		"cg-a": {SignatureID: "s1", ClaManagers: []string{"manager"}, EmployeeSignatureCount: 4},
+	})
+	assert.Equal(t, int64(1), coverage.SignedClaGroupCount)
+	assert.Equal(t, CCLAStatusSigned, coverage.ClaGroups[0].CclaStatus)
+	assert.Equal(t, CCLAStatusNotSigned, coverage.ClaGroups[1].CclaStatus)
+	assert.Len(t, coverage.Gaps, 1)
+	assert.Equal(t, "p1", coverage.Gaps[0].ProjectSFID)
+
+	report := &models.FoundationCoverageReport{Companies: []*models.FoundationCoverageCompany{coverage}, UncoveredProjects: uncovered}
+	csvReport, err := ReportCSV(report)
+	assert.NoError(t, err)
+	assert.Equal(t, "Company Name,Company SFID,CLA Group,Projects,CCLA Status,CLA Managers,Employee Signatures\n"+
+		"Acme,sf-c1,Alpha,Project Two,signed,manager,4\n"+
+		"Acme,sf-c1,Beta,Project One,not-signed,,0\n", string(csvReport))
+
+	xlsxReport, err := ReportXLSX(report)
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(xlsxReport), int64(len(xlsxReport)))
+	assert.NoError(t, err)
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+	}
+	assert.Contains(t, names, "xl/workbook.xml")
+	assert.Contains(t, names, "xl/worksheets/sheet3.xml")
+}
+
+func TestFoundationLevelCLAGroupCoversProjects(t *testing.T) {
+	children := []*models.FoundationCoverageProject{{ProjectSFID: "p1", ProjectName: "Project One"}}
+	mappings := []*projects_cla_groups.ProjectClaGroup{{ProjectSFID: "foundation", ClaGroupID: "cg", ClaGroupName: "Foundation"}}
+
+	projects, uncovered, claGroups := mapFoundationProjects("foundation", children, mappings)
+	assert.Len(t, projects, 1)
+	assert.Empty(t, uncovered)
+	assert.Equal(t, "cg", projects[0].ClaGroupID)
+	assert.Len(t, claGroups, 1)
+}
+
+func TestContributorCoverageHelpers(t *testing.T) {
+	assert.Equal(t, ErrContributorCoverageInput, (&ContributorCoverageInput{Email: "user@example.org"}).Validate())
+	assert.Equal(t, ErrContributorCoverageInput, (&ContributorCoverageInput{ProjectSFID: "p1"}).Validate())
+	assert.NoError(t, (&ContributorCoverageInput{ClaGroupID: "cg", GitHubUsername: "user"}).Validate())
+
+	assert.Equal(t, "https://contributor.example.org/#/cla/project/cg/user/u1?redirect=https%3A%2F%2Fgitlab.example.org%2Fmr%2F1",
+		contributorSignURL("https://contributor.example.org/", "cg", "u1", "https://gitlab.example.org/mr/1"))
+	assert.Equal(t, "https://contributor.example.org/#/cla/gerrit/project/cg/individual",
+		contributorSignURL("https://contributor.example.org", "cg", "", ""))
+
+	userModel := &v1Models.User{LfUsername: "lfuser", LfEmail: "User@Example.org", Emails: []string{"user@other.org"}, GithubUsername: "ghuser"}
+	assert.True(t, ownsIdentities(&ContributorCoverageInput{ClaGroupID: "cg", Email: "user@example.org", GitHubUsername: "GHUser"}, userModel))
+	assert.True(t, ownsIdentities(&ContributorCoverageInput{ClaGroupID: "cg", Email: "user@other.org", LFUsername: "lfuser"}, userModel))
+	assert.False(t, ownsIdentities(&ContributorCoverageInput{ClaGroupID: "cg", Email: "someone@example.org", LFUsername: "lfuser"}, userModel))
+	assert.False(t, ownsIdentities(&ContributorCoverageInput{ClaGroupID: "cg", GitHubUsername: "someone", LFUsername: "lfuser"}, userModel))
+	candidate := approvalListCandidate(userModel)
+	assert.Equal(t, []string{"user@example.org", "user@other.org"}, candidate.Emails)
+	assert.Equal(t, "ghuser", candidate.GitHubUsername)
+
+	covered := &models.ContributorCoverage{Covered: true, ClaGroupID: "cg", SignatureType: SignatureTypeICLA}
+	notCovered := &models.ContributorCoverage{ClaGroupID: "cg"}
+	assert.Equal(t, CoveredMaxAge, ContributorCoverageMaxAge(covered))
+	assert.Equal(t, NotCoveredMaxAge, ContributorCoverageMaxAge(notCovered))
+	assert.Equal(t, ContributorCoverageETag(covered), ContributorCoverageETag(&models.ContributorCoverage{Covered: true, ClaGroupID: "cg", SignatureType: SignatureTypeICLA}))
+	assert.NotEqual(t, ContributorCoverageETag(covered), ContributorCoverageETag(notCovered))
+}
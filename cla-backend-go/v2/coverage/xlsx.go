@@ -0,0 +1,163 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package coverage
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The workbooks are written as the minimal set of SpreadsheetML parts Excel, LibreOffice and Google Sheets open:
+// the content types, the package and workbook relationships, the workbook, a style sheet with a bold header style
+// and one worksheet per sheet using inline strings, so no shared string table is needed.
+
+const (
+	xlsxMainNamespace = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNamespace  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPkgNamespace  = "http://schemas.openxmlformats.org/package/2006/relationships"
+	xlsxXMLHeader     = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+)
+
+const xlsxStyles = xlsxXMLHeader + `<styleSheet xmlns="` + xlsxMainNamespace + `">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// xlsxSheet is a worksheet - the first row is the header, the cells are strings or integers
+type xlsxSheet struct {
+	Name string
+	Rows [][]interface{}
+}
+
+// writeXLSX writes the sheets as an Excel workbook
+func writeXLSX(w io.Writer, sheets []xlsxSheet) error {
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", xlsxXMLHeader + `<Relationships xmlns="` + xlsxPkgNamespace + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelNamespace + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", xlsxWorkbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxWorksheet(sheet)})
+	}
+
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(fw, part.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func xlsxContentTypes(sheetCount int) string {
+	var b bytes.Buffer
+	b.WriteString(xlsxXMLHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func xlsxWorkbook(sheets []xlsxSheet) string {
+	var b bytes.Buffer
+	b.WriteString(xlsxXMLHeader)
+	b.WriteString(`<workbook xmlns="` + xlsxMainNamespace + `" xmlns:r="` + xlsxRelNamespace + `"><sheets>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.Name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func xlsxWorkbookRels(sheetCount int) string {
+	var b bytes.Buffer
+	b.WriteString(xlsxXMLHeader)
+	b.WriteString(`<Relationships xmlns="` + xlsxPkgNamespace + `">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i, xlsxRelNamespace, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, sheetCount+1, xlsxRelNamespace)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func xlsxWorksheet(sheet xlsxSheet) string {
+	var b bytes.Buffer
+	b.WriteString(xlsxXMLHeader)
+	b.WriteString(`<worksheet xmlns="` + xlsxMainNamespace + `"><sheetData>`)
+	for r, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, value := range row {
+			ref := xlsxColumn(c) + strconv.Itoa(r+1)
+			style := ""
+			if r == 0 {
+				style = ` s="1"`
+			}
+			switch v := value.(type) {
+			case int, int64:
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%d</v></c>`, ref, style, v)
+			default:
+				fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(cellString(v)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// xlsxColumn returns the column letters of the zero based column index, e.g. A, Z, AA
+func xlsxColumn(index int) string {
+	column := ""
+	for index >= 0 {
+		column = string(rune('A'+index%26)) + column
+		index = index/26 - 1
+	}
+	return column
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s)) // writing to a buffer does not fail
+	return b.String()
+}
+
+// cellString returns the text of the cell value
+func cellString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
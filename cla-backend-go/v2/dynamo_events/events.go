@@ -8,14 +8,21 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 	"github.com/sirupsen/logrus"
 )
 
 // Event data model
 type Event struct {
-	EventID        string `json:"event_id"`
-	EventProjectID string `json:"event_project_id"`
-	EventCompanyID string `json:"event_company_id"`
+	EventID         string `json:"event_id"`
+	EventType       string `json:"event_type"`
+	EventProjectID  string `json:"event_project_id"`
+	EventCompanyID  string `json:"event_company_id"`
+	EventUserID     string `json:"event_user_id"`
+	EventLfUsername string `json:"event_lf_username"`
+	EventTime       string `json:"event_time"`
+	EventSummary    string `json:"event_summary"`
+	EventData       string `json:"event_data"`
 }
 
 // should be called when we insert Event
@@ -58,5 +65,25 @@ func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
 	if err != nil {
 		return err
 	}
+
+	if s.webhooksService != nil {
+		err = s.webhooksService.Dispatch(ctx, &webhooks.Event{
+			EventID:        newEvent.EventID,
+			EventType:      newEvent.EventType,
+			EventTime:      newEvent.EventTime,
+			FoundationSFID: foundationSFID,
+			ProjectSFID:    projectSFID,
+			ClaGroupID:     newEvent.EventProjectID,
+			CompanyID:      newEvent.EventCompanyID,
+			CompanySFID:    companySFID,
+			UserID:         newEvent.EventUserID,
+			LfUsername:     newEvent.EventLfUsername,
+			Summary:        newEvent.EventSummary,
+			Data:           newEvent.EventData,
+		})
+		if err != nil {
+			log.WithFields(f).Warnf("unable to dispatch event to webhook subscriptions, error: %+v", err)
+		}
+	}
 	return nil
 }
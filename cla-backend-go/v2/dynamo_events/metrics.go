@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// SignatureMetricsEvent updates the metrics when a signature is inserted or modified
+func (s *service) SignatureMetricsEvent(event events.DynamoDBEventRecord) error {
+	var oldSig, newSig *metrics.ItemSignature
+	if err := decodeImages(event, &oldSig, &newSig); err != nil {
+		return err
+	}
+	return s.metricsRepo.UpdateSignatureMetrics(oldSig, newSig)
+}
+
+// RepositoryMetricsEvent updates the metrics when a GitHub repository is added or removed
+func (s *service) RepositoryMetricsEvent(event events.DynamoDBEventRecord) error {
+	var oldRepo, newRepo *metrics.ItemRepository
+	if err := decodeImages(event, &oldRepo, &newRepo); err != nil {
+		return err
+	}
+	return s.metricsRepo.UpdateRepositoryMetrics(oldRepo, newRepo)
+}
+
+// GerritInstanceMetricsEvent updates the metrics when a gerrit instance is added or removed
+func (s *service) GerritInstanceMetricsEvent(event events.DynamoDBEventRecord) error {
+	var oldInstance, newInstance *metrics.ItemGerritInstance
+	if err := decodeImages(event, &oldInstance, &newInstance); err != nil {
+		return err
+	}
+	return s.metricsRepo.UpdateGerritInstanceMetrics(oldInstance, newInstance)
+}
+
+// CompanyMetricsEvent updates the metrics when a company is added
+func (s *service) CompanyMetricsEvent(event events.DynamoDBEventRecord) error {
+	var oldCompany, newCompany *metrics.ItemCompany
+	if err := decodeImages(event, &oldCompany, &newCompany); err != nil {
+		return err
+	}
+	return s.metricsRepo.UpdateCompanyMetrics(oldCompany, newCompany)
+}
+
+// CLAGroupMetricsEvent updates the metrics when a CLA Group is added
+func (s *service) CLAGroupMetricsEvent(event events.DynamoDBEventRecord) error {
+	var oldProject, newProject *metrics.ItemProject
+	if err := decodeImages(event, &oldProject, &newProject); err != nil {
+		return err
+	}
+	return s.metricsRepo.UpdateProjectMetrics(oldProject, newProject)
+}
+
+// decodeImages decodes the old and new images of the record into the pointers - an image absent from the record,
+// e.g. the old image of an insert, leaves its pointer nil
+func decodeImages(event events.DynamoDBEventRecord, oldImage interface{}, newImage interface{}) error {
+	f := logrus.Fields{
+		"functionName": "decodeImages",
+		"eventID":      event.EventID,
+		"eventName":    event.EventName,
+	}
+	if len(event.Change.OldImage) > 0 {
+		if err := unmarshalStreamImage(event.Change.OldImage, oldImage); err != nil {
+			log.WithFields(f).Warnf("problem decoding the old image, error: %+v", err)
+			return err
+		}
+	}
+	if len(event.Change.NewImage) > 0 {
+		if err := unmarshalStreamImage(event.Change.NewImage, newImage); err != nil {
+			log.WithFields(f).Warnf("problem decoding the new image, error: %+v", err)
+			return err
+		}
+	}
+	return nil
+}
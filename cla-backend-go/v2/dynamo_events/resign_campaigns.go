@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/resign_campaigns"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ResignCampaignSignatureEvent records the ICLA or CCLA signed on a new document major version with the active
+// re-sign campaigns of the CLA Group
+func (s *service) ResignCampaignSignatureEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "ResignCampaignSignatureEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+	if oldSignature.SignatureSigned || !newSignature.SignatureSigned {
+		return nil
+	}
+
+	var claType string
+	switch {
+	case newSignature.SignatureType == CCLASignatureType:
+		claType = resign_campaigns.ClaTypeCCLA
+	case newSignature.SignatureType == CLASignatureType && newSignature.SignatureUserCompanyID == "":
+		claType = resign_campaigns.ClaTypeICLA
+	default:
+		// employee acknowledgements are not part of the re-sign campaigns
+		return nil
+	}
+
+	f["signatureID"] = newSignature.SignatureID
+	f["claGroupID"] = newSignature.SignatureProjectID
+	f["majorVersion"] = newSignature.SignatureDocumentMajorVersion
+	majorVersion, err := strconv.ParseInt(newSignature.SignatureDocumentMajorVersion, 10, 64)
+	if err != nil {
+		log.WithFields(f).Warnf("invalid signature document major version, error: %+v", err)
+		return nil
+	}
+
+	err = s.resignCampaignsService.RecordSignature(ctx, newSignature.SignatureProjectID, claType,
+		newSignature.SignatureReferenceID, newSignature.SignatureID, majorVersion)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to record the signature with the re-sign campaigns, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -16,8 +16,11 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/resign_campaigns"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 
 	"github.com/sirupsen/logrus"
 
@@ -49,11 +52,16 @@ type service struct {
 	projectService           project.Service
 	claManagerRequestsRepo   cla_manager.IRepository
 	approvalListRequestsRepo approval_list.IRepository
+	webhooksService          webhooks.Service
+	metricsRepo              metrics.Repository
+	resignCampaignsService   resign_campaigns.Service
+	// failOnError returns the handler errors so the batch is retried, it is only set for the idempotent handlers
+	failOnError bool
 }
 
 // Service implements DynamoDB stream event handler service
 type Service interface {
-	ProcessEvents(event events.DynamoDBEvent)
+	ProcessEvents(event events.DynamoDBEvent) error
 }
 
 // NewService creates DynamoDB stream event handler service
@@ -65,7 +73,9 @@ func NewService(stage string,
 	projectRepo project.ProjectRepository,
 	projService project.Service,
 	claManagerRequestsRepo cla_manager.IRepository,
-	approvalListRequestsRepo approval_list.IRepository) Service {
+	approvalListRequestsRepo approval_list.IRepository,
+	webhooksService webhooks.Service,
+	resignCampaignsService resign_campaigns.Service) Service {
 	SignaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
 	eventsTable := fmt.Sprintf("cla-%s-events", stage)
 	projectsCLAGroupsTable := fmt.Sprintf("cla-%s-projects-cla-groups", stage)
@@ -82,12 +92,15 @@ func NewService(stage string,
 		projectService:           projService,
 		claManagerRequestsRepo:   claManagerRequestsRepo,
 		approvalListRequestsRepo: approvalListRequestsRepo,
+		webhooksService:          webhooksService,
+		resignCampaignsService:   resignCampaignsService,
 	}
 
 	s.registerCallback(SignaturesTable, Modify, s.SignatureSignedEvent)
 	s.registerCallback(SignaturesTable, Modify, s.SignatureAddSigTypeSignedApprovedID)
 	s.registerCallback(SignaturesTable, Insert, s.SignatureAddSigTypeSignedApprovedID)
 	s.registerCallback(SignaturesTable, Insert, s.SignatureAddUsersDetails)
+	s.registerCallback(SignaturesTable, Modify, s.ResignCampaignSignatureEvent)
 
 	s.registerCallback(eventsTable, Insert, s.EventAddedEvent)
 
@@ -102,6 +115,35 @@ func NewService(stage string,
 	return s
 }
 
+// NewMetricsService creates the DynamoDB stream event handler service maintaining the metrics incrementally - it is
+// a separate consumer of the streams as its handlers are idempotent, a failed batch is retried without repeating the
+// side effects of the other handlers
+func NewMetricsService(stage string, metricsRepo metrics.Repository) Service {
+	signaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
+	repositoryTableName := fmt.Sprintf("cla-%s-repositories", stage)
+	claGroupsTable := fmt.Sprintf("cla-%s-projects", stage)
+	companiesTable := fmt.Sprintf("cla-%s-companies", stage)
+	gerritInstancesTable := fmt.Sprintf("cla-%s-gerrit-instances", stage)
+
+	s := &service{
+		functions:   make(map[string][]EventHandlerFunc),
+		metricsRepo: metricsRepo,
+		failOnError: true,
+	}
+
+	for _, eventName := range []string{Insert, Modify, Remove} {
+		s.registerCallback(signaturesTable, eventName, s.SignatureMetricsEvent)
+		s.registerCallback(companiesTable, eventName, s.CompanyMetricsEvent)
+		s.registerCallback(claGroupsTable, eventName, s.CLAGroupMetricsEvent)
+	}
+	s.registerCallback(repositoryTableName, Insert, s.RepositoryMetricsEvent)
+	s.registerCallback(repositoryTableName, Remove, s.RepositoryMetricsEvent)
+	s.registerCallback(gerritInstancesTable, Insert, s.GerritInstanceMetricsEvent)
+	s.registerCallback(gerritInstancesTable, Remove, s.GerritInstanceMetricsEvent)
+
+	return s
+}
+
 func (s *service) registerCallback(tableName, eventName string, callbackFunction EventHandlerFunc) {
 	key := fmt.Sprintf("%s:%s", tableName, eventName)
 	funcArr := s.functions[key]
@@ -109,7 +151,9 @@ func (s *service) registerCallback(tableName, eventName string, callbackFunction
 	s.functions[key] = funcArr
 }
 
-func (s *service) ProcessEvents(events events.DynamoDBEvent) {
+// ProcessEvents invokes the handlers of the records - the handler errors are logged, or returned to retry the batch
+// when the handlers are idempotent
+func (s *service) ProcessEvents(events events.DynamoDBEvent) error {
 	for _, event := range events.Records {
 		tableName := strings.Split(event.EventSourceArn, "/")[1]
 		fields := logrus.Fields{
@@ -132,9 +176,13 @@ func (s *service) ProcessEvents(events events.DynamoDBEvent) {
 			err := f(event)
 			if err != nil {
 				log.WithFields(fields).WithField("event", event).Error("unable to process event", err)
+				if s.failOnError {
+					return err
+				}
 			}
 		}
 	}
+	return nil
 }
 
 // UnmarshalStreamImage converts events.DynamoDBAttributeValue to struct
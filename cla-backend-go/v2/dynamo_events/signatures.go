@@ -4,6 +4,7 @@
 package dynamo_events
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -51,6 +52,7 @@ type Signature struct {
 	UserName                      string   `json:"user_name"`
 	UserEmail                     string   `json:"user_email"`
 	SignedOn                      string   `json:"signed_on"`
+	SignatureDocumentSha256       string   `json:"signature_document_sha256"`
 }
 
 // should be called when we modify signature
@@ -84,6 +86,12 @@ func (s *service) SignatureSignedEvent(event events.DynamoDBEventRecord) error {
 	f["approved"] = newSignature.SignatureApproved
 	f["signed"] = newSignature.SignatureSigned
 
+	// The signed document is stored after the signature is marked signed and its hash is recorded on upload - the
+	// documents of signatures without a hash are hashed on their next update once stored
+	if newSignature.SignatureSigned && newSignature.SignatureDocumentSha256 == "" {
+		s.recordSignedDocumentHash(ctx, newSignature)
+	}
+
 	// check if signature signed event is received
 	if !oldSignature.SignatureSigned && newSignature.SignatureSigned {
 		log.WithFields(f).Debugf("processing signature signed event for signature type: %s...", newSignature.SignatureType)
@@ -246,3 +254,38 @@ func (s *service) SignatureAddUsersDetails(event events.DynamoDBEventRecord) err
 	}
 	return nil
 }
+
+// recordSignedDocumentHash records the SHA-256 of the signed ICLA or CCLA document on the signature - employee
+// acknowledgements have no signed document
+func (s *service) recordSignedDocumentHash(ctx context.Context, sig Signature) {
+	f := logrus.Fields{
+		"functionName":   "recordSignedDocumentHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+	}
+
+	var claType string
+	switch {
+	case sig.SignatureType == CCLASignatureType:
+		claType = CCLASignatureType
+	case sig.SignatureType == CLASignatureType && sig.SignatureUserCompanyID == "":
+		claType = ICLASignatureType
+	default:
+		return
+	}
+
+	pdf, err := utils.DownloadFromS3(utils.SignedCLAFilename(sig.SignatureProjectID, claType, sig.SignatureReferenceID, sig.SignatureID))
+	if err == utils.ErrBlobNotFound {
+		log.WithFields(f).Debug("signed document not stored yet")
+		return
+	}
+	if err != nil {
+		log.WithFields(f).Warnf("unable to download the signed document, error: %+v", err)
+		return
+	}
+
+	err = s.signatureRepo.AddSignedDocumentHash(ctx, sig.SignatureID, utils.Sha256Hex(pdf))
+	if err != nil {
+		log.WithFields(f).Warnf("unable to record the signed document hash, error: %+v", err)
+	}
+}
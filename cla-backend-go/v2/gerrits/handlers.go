@@ -5,6 +5,7 @@ package gerrits
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gerrits"
 	v1Gerrits "github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/jinzhu/copier"
@@ -27,7 +29,11 @@ type ProjectService interface { //nolint
 }
 
 // Configure the Gerrit api
-func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectService ProjectService, eventService events.Service, projectsClaGroupsRepo projects_cla_groups.Repository) {
+func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectService ProjectService, eventService events.Service, projectsClaGroupsRepo projects_cla_groups.Repository, service Service, hookSecret string) {
+	if hookSecret == "" {
+		log.Warn("the Gerrit hook secret is not set - the Gerrit contributor agreement check is turned off")
+	}
+
 	api.GerritsDeleteGerritHandler = gerrits.DeleteGerritHandlerFunc(
 		func(params gerrits.DeleteGerritParams, authUser *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -214,6 +220,52 @@ func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectS
 
 			return gerrits.NewGetGerritReposOK().WithXRequestID(reqID).WithPayload(&response)
 		})
+
+	api.GerritsCheckGerritContributorAgreementHandler = gerrits.CheckGerritContributorAgreementHandlerFunc(
+		func(params gerrits.CheckGerritContributorAgreementParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+			// Invoked by the Gerrit hooks - the check syncs the LDAP group membership, so the caller must know the
+			// shared secret of the hooks
+			if !validHookSecret(hookSecret, params.XGERRITHOOKSECRET) {
+				return gerrits.NewCheckGerritContributorAgreementForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:    "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - invalid Gerrit hook secret for CheckGerritContributorAgreement on gerrit %s", params.GerritName),
+				})
+			}
+
+			// Validate input
+			if params.Body == nil || (strings.TrimSpace(params.Body.Username) == "" && strings.TrimSpace(params.Body.Email) == "") {
+				return gerrits.NewCheckGerritContributorAgreementBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "missing username or email - expecting the Gerrit account username or email",
+				})
+			}
+
+			result, err := service.CheckContributorAgreement(ctx, params.GerritName, params.Body)
+			if err != nil {
+				if err == v1Gerrits.ErrGerritNotFound {
+					return gerrits.NewCheckGerritContributorAgreementNotFound().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    "404",
+						Message: fmt.Sprintf("gerrit instance %s not found", params.GerritName),
+					})
+				}
+				return gerrits.NewCheckGerritContributorAgreementInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return gerrits.NewCheckGerritContributorAgreementOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
+
+// validHookSecret returns true if the secret sent by the caller matches the configured Gerrit hook secret - the check
+// is denied when no secret is configured
+func validHookSecret(hookSecret, sent string) bool {
+	if hookSecret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hookSecret), []byte(sent)) == 1
 }
 
 type codedResponse interface {
@@ -0,0 +1,172 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrits
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	v1Gerrits "github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// agreement types
+const (
+	AgreementTypeICLA = "ICLA"
+	AgreementTypeCCLA = "CCLA"
+)
+
+// LDAPGroupService provides the LDAP group membership functions used by the Gerrit contributor agreement groups
+type LDAPGroupService interface {
+	AddUserToGroup(groupID, username string) error
+}
+
+// Service contains the Gerrit contributor agreement enforcement functions
+type Service interface {
+	CheckContributorAgreement(ctx context.Context, gerritName string, input *models.GerritContributorAgreementCheckInput) (*models.GerritContributorAgreementCheck, error)
+}
+
+type service struct {
+	gerritRepo       v1Gerrits.Repository
+	usersService     users.Service
+	signatureService signatures.SignatureService
+	ldapGroupService LDAPGroupService
+}
+
+// NewService creates a new Gerrit contributor agreement service
+func NewService(gerritRepo v1Gerrits.Repository, usersService users.Service, signatureService signatures.SignatureService, ldapGroupService LDAPGroupService) Service {
+	return service{
+		gerritRepo:       gerritRepo,
+		usersService:     usersService,
+		signatureService: signatureService,
+		ldapGroupService: ldapGroupService,
+	}
+}
+
+// CheckContributorAgreement decides if the Gerrit account is allowed to push to the Gerrit instance based on the
+// ICLA and CCLA signatures of the CLA Group. When allowed, the user is added to the LDAP group of the agreement.
+func (s service) CheckContributorAgreement(ctx context.Context, gerritName string, input *models.GerritContributorAgreementCheckInput) (*models.GerritContributorAgreementCheck, error) {
+	f := logrus.Fields{
+		"functionName":   "CheckContributorAgreement",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritName":     gerritName,
+		"username":       input.Username,
+		"email":          input.Email,
+	}
+
+	gerritList, err := s.gerritRepo.ExistsByName(gerritName)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup gerrit instance by name, error: %+v", err)
+		return nil, err
+	}
+	if len(gerritList) == 0 {
+		return nil, v1Gerrits.ErrGerritNotFound
+	}
+	gerrit := gerritList[0]
+	f["claGroupID"] = gerrit.ProjectID
+
+	result := &models.GerritContributorAgreementCheck{
+		GerritName: gerritName,
+		ClaGroupID: gerrit.ProjectID,
+	}
+
+	userModel := s.lookupUser(input)
+	if userModel == nil {
+		result.Reason = fmt.Sprintf("no EasyCLA user record found for account %s", accountName(input))
+		return result, nil
+	}
+
+	icla, err := s.signatureService.GetHonoredIndividualSignature(ctx, gerrit.ProjectID, userModel.UserID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup ICLA signature for user: %s, error: %+v", userModel.UserID, err)
+		return nil, err
+	}
+	if icla != nil {
+		result.Allowed = true
+		result.AgreementType = AgreementTypeICLA
+		result.Reason = "account is authorized under a signed ICLA"
+		s.syncGroupMembership(ctx, result, gerrit.GroupIDIcla, userModel)
+		return result, nil
+	}
+
+	if userModel.CompanyID == "" {
+		result.Reason = "account is not authorized under a signed ICLA and is not affiliated with a company"
+		return result, nil
+	}
+
+	// only the emails of the user record - the email sent by the caller is not verified
+	decision, err := s.signatureService.EvaluateApprovalList(ctx, gerrit.ProjectID, userModel.CompanyID, &signatures.ApprovalListCandidate{
+		LFUsername:     userModel.LfUsername,
+		Emails:         append([]string{userModel.LfEmail}, userModel.Emails...),
+		GitHubUsername: userModel.GithubUsername,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to evaluate the CCLA approval list for company: %s, error: %+v", userModel.CompanyID, err)
+		return nil, err
+	}
+	if !decision.Approved {
+		result.Reason = fmt.Sprintf("account is not authorized under a signed CLA - %s", decision.Reason)
+		return result, nil
+	}
+
+	result.Allowed = true
+	result.AgreementType = AgreementTypeCCLA
+	result.Reason = fmt.Sprintf("account is authorized under a signed CCLA by the %s approval list entry %s", decision.RuleType, decision.Rule)
+	s.syncGroupMembership(ctx, result, gerrit.GroupIDCcla, userModel)
+	return result, nil
+}
+
+// lookupUser returns the EasyCLA user record for the Gerrit account, or nil if not found
+func (s service) lookupUser(input *models.GerritContributorAgreementCheckInput) *v1Models.User {
+	if strings.TrimSpace(input.Username) != "" {
+		userModel, err := s.usersService.GetUserByLFUserName(strings.TrimSpace(input.Username))
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	if strings.TrimSpace(input.Email) != "" {
+		userModel, err := s.usersService.GetUserByEmail(strings.TrimSpace(input.Email))
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	return nil
+}
+
+// syncGroupMembership adds the user to the LDAP group which grants the Gerrit contributor agreement
+func (s service) syncGroupMembership(ctx context.Context, result *models.GerritContributorAgreementCheck, groupID string, userModel *v1Models.User) {
+	f := logrus.Fields{
+		"functionName":   "syncGroupMembership",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"groupID":        groupID,
+		"lfUsername":     userModel.LfUsername,
+	}
+
+	result.GroupID = groupID
+	if groupID == "" || userModel.LfUsername == "" || s.ldapGroupService == nil {
+		log.WithFields(f).Debug("skipping LDAP group membership sync")
+		return
+	}
+
+	if err := s.ldapGroupService.AddUserToGroup(groupID, userModel.LfUsername); err != nil {
+		log.WithFields(f).Warnf("unable to add user to LDAP group, error: %+v", err)
+		return
+	}
+	result.GroupSynced = true
+}
+
+// accountName returns the account identifier used in the reason messages
+func accountName(input *models.GerritContributorAgreementCheckInput) string {
+	if input.Username != "" {
+		return input.Username
+	}
+	return input.Email
+}
@@ -0,0 +1,74 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"net/http"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookPath is the path where the GitHub App delivers its webhook events
+const WebhookPath = "/v4/github/activity"
+
+// NewWebhookHandler returns the http handler which receives the GitHub App webhook deliveries. Deliveries are
+// rejected unless their payload signature matches the configured webhook secret.
+func NewWebhookHandler(webhookSecret string, service Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID := r.Header.Get(utils.XREQUESTID)
+		if reqID == "" {
+			reqID = github.DeliveryID(r)
+		}
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		f := logrus.Fields{
+			"functionName":   "NewWebhookHandler",
+			utils.XREQUESTID: reqID,
+			"eventType":      github.WebHookType(r),
+			"deliveryID":     github.DeliveryID(r),
+		}
+
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if webhookSecret == "" {
+			log.WithFields(f).Warn("github webhook secret is not configured - rejecting delivery")
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		payload, err := github.ValidatePayload(r, []byte(webhookSecret))
+		if err != nil {
+			log.WithFields(f).Warnf("unable to validate github webhook payload, error: %+v", err)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		event, err := github.ParseWebHook(github.WebHookType(r), payload)
+		if err != nil {
+			// Unknown event types are acknowledged so GitHub does not flag the delivery as failed
+			log.WithFields(f).Debugf("ignoring github webhook delivery, error: %+v", err)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		switch e := event.(type) {
+		case *github.PullRequestEvent:
+			if err := service.ProcessPullRequestEvent(ctx, e); err != nil {
+				log.WithFields(f).Warnf("unable to process pull request event, error: %+v", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		default:
+			log.WithFields(f).Debug("ignoring github webhook event")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // nolint
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret = "s3cr3t"
+
+type fakeRepositories struct {
+	repositories.Repository
+}
+
+func (r fakeRepositories) GetRepositoryByGithubID(externalID string, enabled bool) (*models.GithubRepository, error) {
+	if externalID != "42" {
+		return nil, repositories.ErrGithubRepositoryNotFound
+	}
+	return &models.GithubRepository{RepositoryExternalID: externalID, RepositoryProjectID: "cla-group-1"}, nil
+}
+
+type fakeUsers struct {
+	users.Service
+}
+
+func (u fakeUsers) GetUserByGitHubUsername(gitHubUsername string) (*models.User, error) {
+	switch gitHubUsername {
+	case "signer":
+		return &models.User{UserID: "user-signer"}, nil
+	case "employee":
+		return &models.User{UserID: "user-employee", CompanyID: "company-1", LfEmail: "employee@example.com"}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (u fakeUsers) GetUserByEmail(userEmail string) (*models.User, error) {
+	return nil, errors.New("not found")
+}
+
+type fakeSignatures struct {
+	signatures.SignatureService
+	approvalList []string
+}
+
+func (s fakeSignatures) GetHonoredIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error) {
+	if userID == "user-signer" {
+		return &models.Signature{SignatureID: "icla-1"}, nil
+	}
+	return nil, nil
+}
+
+func (s fakeSignatures) EvaluateApprovalList(ctx context.Context, claGroupID, companyID string, candidate *signatures.ApprovalListCandidate) (*signatures.ApprovalListDecision, error) {
+	return signatures.EvaluateApprovalList(&models.Signature{SignatureID: "ccla-1", EmailApprovalList: s.approvalList}, candidate), nil
+}
+
+// fakeGitHub records the statuses and check runs posted by the service
+type fakeGitHub struct {
+	sync.Mutex
+	server    *httptest.Server
+	commits   []*github.RepositoryCommit
+	statuses  []github.RepoStatus
+	checkRuns []github.CreateCheckRunOptions
+}
+
+func newFakeGitHub(logins ...string) *fakeGitHub {
+	fake := &fakeGitHub{}
+	for i, login := range logins {
+		fake.commits = append(fake.commits, &github.RepositoryCommit{
+			SHA:    github.String(string(rune('a' + i))),
+			Author: &github.User{ID: github.Int64(int64(i + 1)), Login: github.String(login)},
+			// the commit author email is set by the committer
+			Commit: &github.Commit{Author: &github.CommitAuthor{Email: github.String(login + "@committer.example.org")}},
+		})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/repo/pulls/7/commits", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(fake.commits)
+	})
+	mux.HandleFunc("/repos/org/repo/statuses/head-sha", func(w http.ResponseWriter, r *http.Request) {
+		var status github.RepoStatus
+		_ = json.NewDecoder(r.Body).Decode(&status)
+		fake.Lock()
+		fake.statuses = append(fake.statuses, status)
+		fake.Unlock()
+		_ = json.NewEncoder(w).Encode(status)
+	})
+	mux.HandleFunc("/repos/org/repo/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		var checkRun github.CreateCheckRunOptions
+		_ = json.NewDecoder(r.Body).Decode(&checkRun)
+		fake.Lock()
+		fake.checkRuns = append(fake.checkRuns, checkRun)
+		fake.Unlock()
+		_ = json.NewEncoder(w).Encode(github.CheckRun{})
+	})
+	fake.server = httptest.NewServer(mux)
+	return fake
+}
+
+func (fake *fakeGitHub) client(installationID int64) (*github.Client, error) {
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(fake.server.URL + "/")
+	if err != nil {
+		return nil, err
+	}
+	client.BaseURL = baseURL
+	return client, nil
+}
+
+func pullRequestDelivery(t *testing.T, secret string) *http.Request {
+	payload, err := json.Marshal(github.PullRequestEvent{
+		Action: github.String("opened"),
+		Number: github.Int(7),
+		PullRequest: &github.PullRequest{
+			Head: &github.PullRequestBranch{SHA: github.String("head-sha"), Ref: github.String("feature")},
+		},
+		Repo: &github.Repository{
+			ID:    github.Int64(42),
+			Name:  github.String("repo"),
+			Owner: &github.User{Login: github.String("org")},
+		},
+		Installation: &github.Installation{ID: github.Int64(99)},
+	})
+	assert.NoError(t, err)
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(payload) // nolint
+	r := httptest.NewRequest(http.MethodPost, WebhookPath, bytes.NewReader(payload))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-GitHub-Event", "pull_request")
+	r.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+// TestWebhookRejectsInvalidSignature tests that deliveries signed with the wrong secret are rejected
+func TestWebhookRejectsInvalidSignature(t *testing.T) {
+	fake := newFakeGitHub("signer")
+	defer fake.server.Close()
+
+	handler := NewWebhookHandler(testWebhookSecret, NewService(fakeRepositories{}, fakeSignatures{}, fakeUsers{}, "https://api.example.org", fake.client))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, pullRequestDelivery(t, "wrong"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, fake.statuses)
+}
+
+// TestWebhookPostsStatus tests the commit status and check run posted for the pull request commit authors
+func TestWebhookPostsStatus(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		logins        []string
+		approvalList  []string
+		expectedState string
+	}{
+		{
+			Name:          "icla signer",
+			logins:        []string{"signer"},
+			expectedState: statusSuccess,
+		},
+		{
+			Name:          "approved employee",
+			logins:        []string{"signer", "employee"},
+			approvalList:  []string{"Employee@example.com"},
+			expectedState: statusSuccess,
+		},
+		{
+			Name:          "employee approved by the unverified commit email",
+			logins:        []string{"employee"},
+			approvalList:  []string{"employee@committer.example.org"},
+			expectedState: statusFailure,
+		},
+		{
+			Name:          "employee not on approval list",
+			logins:        []string{"employee"},
+			expectedState: statusFailure,
+		},
+		{
+			Name:          "unknown contributor",
+			logins:        []string{"signer", "stranger"},
+			expectedState: statusFailure,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			fake := newFakeGitHub(tc.logins...)
+			defer fake.server.Close()
+
+			service := NewService(fakeRepositories{}, fakeSignatures{approvalList: tc.approvalList}, fakeUsers{}, "https://api.example.org", fake.client)
+			w := httptest.NewRecorder()
+			NewWebhookHandler(testWebhookSecret, service).ServeHTTP(w, pullRequestDelivery(t, testWebhookSecret))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			if assert.Len(t, fake.statuses, 1) {
+				assert.Equal(t, tc.expectedState, fake.statuses[0].GetState())
+				assert.Equal(t, StatusContext, fake.statuses[0].GetContext())
+				assert.Equal(t, "https://api.example.org/v2/repository-provider/github/sign/99/42/7", fake.statuses[0].GetTargetURL())
+			}
+			if assert.Len(t, fake.checkRuns, 1) {
+				assert.Equal(t, CheckRunName, fake.checkRuns[0].Name)
+				assert.Equal(t, "head-sha", fake.checkRuns[0].HeadSHA)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/github"
+	"github.com/sirupsen/logrus"
+)
+
+// constants
+const (
+	// StatusContext is the commit status context name - this must match the required branch protection check
+	StatusContext = "EasyCLA"
+	// CheckRunName is the name of the check run created on the pull request head commit
+	CheckRunName = "CLA check"
+
+	statusSuccess = "success"
+	statusFailure = "failure"
+
+	checkRunStatusCompleted   = "completed"
+	checkRunConclusionSuccess = "success"
+	checkRunConclusionAction  = "action_required"
+)
+
+// pull request actions which trigger a CLA check
+var pullRequestActions = map[string]bool{
+	"opened":      true,
+	"reopened":    true,
+	"synchronize": true,
+}
+
+// GithubClientFunc returns a GitHub client for the specified GitHub App installation
+type GithubClientFunc func(installationID int64) (*github.Client, error)
+
+// Service contains functions of the GitHub activity service
+type Service interface {
+	ProcessPullRequestEvent(ctx context.Context, event *github.PullRequestEvent) error
+}
+
+type service struct {
+	repositoriesRepo repositories.Repository
+	signatureService signatures.SignatureService
+	usersService     users.Service
+	claV1ApiURL      string
+	newGithubClient  GithubClientFunc
+}
+
+// NewService creates a new GitHub activity service
+func NewService(repositoriesRepo repositories.Repository, signatureService signatures.SignatureService, usersService users.Service, claV1ApiURL string, newGithubClient GithubClientFunc) Service {
+	return service{
+		repositoriesRepo: repositoriesRepo,
+		signatureService: signatureService,
+		usersService:     usersService,
+		claV1ApiURL:      claV1ApiURL,
+		newGithubClient:  newGithubClient,
+	}
+}
+
+// commitAuthor represents the author of a single commit on the pull request
+type commitAuthor struct {
+	SHA      string
+	ID       int64
+	Username string
+	Name     string
+	Email    string
+}
+
+// displayName returns a human readable name for the commit author
+func (a commitAuthor) displayName() string {
+	if a.Username != "" {
+		return a.Username
+	}
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Email
+}
+
+// ProcessPullRequestEvent checks the commit authors of the pull request against the CLA group signatures and
+// reports the result back to GitHub as a commit status and a check run
+func (s service) ProcessPullRequestEvent(ctx context.Context, event *github.PullRequestEvent) error {
+	f := logrus.Fields{
+		"functionName":   "ProcessPullRequestEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"action":         event.GetAction(),
+		"repositoryID":   event.GetRepo().GetID(),
+		"repositoryName": event.GetRepo().GetFullName(),
+		"pullRequest":    event.GetNumber(),
+		"installationID": event.GetInstallation().GetID(),
+	}
+
+	if !pullRequestActions[event.GetAction()] {
+		log.WithFields(f).Debugf("ignoring pull request action: %s", event.GetAction())
+		return nil
+	}
+
+	repoModel, err := s.repositoriesRepo.GetRepositoryByGithubID(strconv.FormatInt(event.GetRepo().GetID(), 10), true)
+	if err != nil {
+		if err == repositories.ErrGithubRepositoryNotFound {
+			log.WithFields(f).Debug("repository is not enabled for EasyCLA - ignoring pull request event")
+			return nil
+		}
+		log.WithFields(f).Warnf("unable to load repository by github id, error: %+v", err)
+		return err
+	}
+	f["claGroupID"] = repoModel.RepositoryProjectID
+
+	client, err := s.newGithubClient(event.GetInstallation().GetID())
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create github client for installation, error: %+v", err)
+		return err
+	}
+
+	owner := event.GetRepo().GetOwner().GetLogin()
+	repoName := event.GetRepo().GetName()
+	authors, err := listCommitAuthors(ctx, client, owner, repoName, event.GetNumber())
+	if err != nil {
+		log.WithFields(f).Warnf("unable to list pull request commits, error: %+v", err)
+		return err
+	}
+
+	// the signature version policy is evaluated once for all the commit authors
+	ctx = signatures.WithVersionPolicyCache(ctx)
+	var missing []string
+	for _, author := range authors {
+		authorized, reason := s.isAuthorized(ctx, repoModel.RepositoryProjectID, author)
+		if !authorized {
+			log.WithFields(f).Debugf("commit %s author %s is missing a CLA: %s", author.SHA, author.displayName(), reason)
+			missing = append(missing, fmt.Sprintf("%s %s (commit %s)", author.displayName(), reason, author.SHA))
+		}
+	}
+
+	signURL := s.getSignURL(event.GetInstallation().GetID(), event.GetRepo().GetID(), event.GetNumber())
+	headSHA := event.GetPullRequest().GetHead().GetSHA()
+
+	state, description := statusSuccess, "The EasyCLA check passed. All contributors are authorized."
+	conclusion := checkRunConclusionSuccess
+	if len(missing) > 0 {
+		state, description = statusFailure, "Missing CLA Authorization."
+		conclusion = checkRunConclusionAction
+	}
+
+	_, _, err = client.Repositories.CreateStatus(ctx, owner, repoName, headSHA, &github.RepoStatus{
+		State:       &state,
+		TargetURL:   &signURL,
+		Description: &description,
+		Context:     github.String(StatusContext),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create commit status, error: %+v", err)
+		return err
+	}
+
+	_, _, err = client.Checks.CreateCheckRun(ctx, owner, repoName, github.CreateCheckRunOptions{
+		Name:        CheckRunName,
+		HeadBranch:  event.GetPullRequest().GetHead().GetRef(),
+		HeadSHA:     headSHA,
+		DetailsURL:  &signURL,
+		Status:      github.String(checkRunStatusCompleted),
+		Conclusion:  &conclusion,
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output: &github.CheckRunOutput{
+			Title:   &description,
+			Summary: &description,
+			Text:    github.String(checkRunText(missing)),
+		},
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create check run, error: %+v", err)
+		return err
+	}
+
+	log.WithFields(f).Debugf("posted CLA status %s for commit %s", state, headSHA)
+	return nil
+}
+
+// isAuthorized returns true if the commit author is covered by a signed ICLA or is on the approval list of a
+// signed CCLA, otherwise false along with a reason
+func (s service) isAuthorized(ctx context.Context, claGroupID string, author *commitAuthor) (bool, string) {
+	f := logrus.Fields{
+		"functionName":   "isAuthorized",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"commitSHA":      author.SHA,
+	}
+
+	if author.ID == 0 {
+		return false, "is not linked to this commit"
+	}
+
+	userModel := s.lookupUser(author)
+	if userModel == nil {
+		return false, "is not authorized under a signed CLA"
+	}
+
+	icla, err := s.signatureService.GetHonoredIndividualSignature(ctx, claGroupID, userModel.UserID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup ICLA signature for user: %s, error: %+v", userModel.UserID, err)
+	}
+	if icla != nil {
+		return true, ""
+	}
+
+	if userModel.CompanyID == "" {
+		return false, "is not authorized under a signed CLA"
+	}
+
+	githubUsername := author.Username
+	if githubUsername == "" {
+		githubUsername = userModel.GithubUsername
+	}
+	decision, err := s.signatureService.EvaluateApprovalList(ctx, claGroupID, userModel.CompanyID, &signatures.ApprovalListCandidate{
+		Emails:         append([]string{userModel.LfEmail}, userModel.Emails...),
+		GitHubUsername: githubUsername,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to evaluate CCLA approval list for company: %s, error: %+v", userModel.CompanyID, err)
+		return false, "is not authorized under a signed CLA"
+	}
+	if decision.SignatureID == "" {
+		return false, "is not authorized under a signed CLA"
+	}
+	if decision.Approved {
+		log.WithFields(f).Debugf("commit author approved by %s rule: %s", decision.RuleType, decision.Rule)
+		return true, ""
+	}
+
+	return false, "must confirm corporate affiliation"
+}
+
+// lookupUser returns the EasyCLA user record for the commit author, or nil if not found
+func (s service) lookupUser(author *commitAuthor) *models.User {
+	if author.Username != "" {
+		userModel, err := s.usersService.GetUserByGitHubUsername(author.Username)
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	if author.Email != "" {
+		userModel, err := s.usersService.GetUserByEmail(author.Email)
+		if err == nil && userModel != nil {
+			return userModel
+		}
+	}
+	return nil
+}
+
+// getSignURL returns the URL the contributor follows to sign the CLA
+func (s service) getSignURL(installationID, repositoryID int64, pullRequestID int) string {
+	return fmt.Sprintf("%s/v2/repository-provider/github/sign/%d/%d/%d",
+		strings.TrimSuffix(s.claV1ApiURL, "/"), installationID, repositoryID, pullRequestID)
+}
+
+// listCommitAuthors returns the author of each commit on the pull request
+func listCommitAuthors(ctx context.Context, client *github.Client, owner, repo string, number int) ([]*commitAuthor, error) {
+	var authors []*commitAuthor
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		commits, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			authors = append(authors, &commitAuthor{
+				SHA:      commit.GetSHA(),
+				ID:       commit.GetAuthor().GetID(),
+				Username: commit.GetAuthor().GetLogin(),
+				Name:     commit.GetCommit().GetAuthor().GetName(),
+				Email:    commit.GetCommit().GetAuthor().GetEmail(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return authors, nil
+}
+
+// checkRunText returns the markdown text of the check run output
+func checkRunText(missing []string) string {
+	if len(missing) == 0 {
+		return "All committers are authorized under a signed CLA."
+	}
+	var sb strings.Builder
+	sb.WriteString("The following committers are not authorized under a signed CLA:\n\n")
+	for _, m := range missing {
+		sb.WriteString(fmt.Sprintf("* %s\n", m))
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// CSVMetricTrendResponse creates a new response handler for metric trend CSV files
+func CSVMetricTrendResponse(filename string, trend *models.MetricTrend) middleware.Responder {
+	return &CSVMetricTrendResponderFunc{
+		Filename: filename,
+		Trend:    trend,
+	}
+}
+
+// CSVMetricTrendResponderFunc wraps a func as a Responder interface
+type CSVMetricTrendResponderFunc struct {
+	Filename string
+	Trend    *models.MetricTrend
+}
+
+// WriteResponse writes to the response
+func (fn CSVMetricTrendResponderFunc) WriteResponse(rw http.ResponseWriter, pr runtime.Producer) {
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", fn.Filename))
+	rw.Header().Set(runtime.HeaderContentType, runtime.CSVMime)
+	if err := pr.Produce(rw, fn.convertToCSV(fn.Trend, ",")); err != nil {
+		msg := fmt.Sprintf("issue converting metric trend models to CSV format - error: %+v.", err)
+		log.Warn(msg)
+		rw.Header().Del("Content-Disposition")
+		rw.Header().Set(runtime.HeaderContentType, runtime.JSONMime)
+		rw.WriteHeader(http.StatusInternalServerError)
+		_, writeErr := rw.Write([]byte("{\"error\":\"" + msg + "\"}"))
+		if writeErr != nil {
+			log.Warnf("issue writing error response response - error: %+v", writeErr)
+		}
+		return
+	}
+}
+
+func (fn CSVMetricTrendResponderFunc) convertToCSV(trend *models.MetricTrend, delimiter string) []string {
+	csvLines := []string{fmt.Sprintf("Period%s%s%sChange", delimiter, trend.Metric, delimiter)}
+	for _, point := range trend.Points {
+		csvLines = append(csvLines, fmt.Sprintf("%s%s%d%s%d", point.Period, delimiter, point.Value, delimiter, point.Change))
+	}
+	return csvLines
+}
+
+// writeResponse writes the data with the content type - used for the errors of the CSV endpoints
+func writeResponse(httpStatus int, contentType string, contentProducer runtime.Producer, data interface{}) middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+		rw.Header().Set(runtime.HeaderContentType, contentType)
+		rw.WriteHeader(httpStatus)
+		err := contentProducer.Produce(rw, data)
+		if err != nil {
+			log.Warnf("failed to write data. error = %v", err)
+		}
+	})
+}
@@ -6,18 +6,25 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
 	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/metrics"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository) {
+func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository, projectsClaGroupsRepo projects_cla_groups.Repository) {
 	api.MetricsGetClaManagerDistributionHandler = metrics.GetClaManagerDistributionHandlerFunc(
 		func(params metrics.GetClaManagerDistributionParams, user *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -115,6 +122,175 @@ func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Comp
 			}
 			return metrics.NewListCompanyProjectMetricsOK().WithXRequestID(reqID).WithPayload(result)
 		})
+
+	api.MetricsGetClaGroupMetricTrendHandler = metrics.GetClaGroupMetricTrendHandlerFunc(
+		func(params metrics.GetClaGroupMetricTrendParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			status, errResp := authorizeClaGroupTrend(authUser, projectsClaGroupsRepo, params.ClaGroupID)
+			switch status {
+			case http.StatusForbidden:
+				return metrics.NewGetClaGroupMetricTrendForbidden().WithXRequestID(reqID).WithPayload(errResp)
+			case http.StatusNotFound:
+				return metrics.NewGetClaGroupMetricTrendNotFound().WithXRequestID(reqID).WithPayload(errResp)
+			}
+			from, to := trendRange(params.From, params.To)
+			result, err := service.GetMetricTrend(ScopeClaGroup, params.ClaGroupID, params.Metric, aws.StringValue(params.Granularity), from, to)
+			if err != nil {
+				return metrics.NewGetClaGroupMetricTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return metrics.NewGetClaGroupMetricTrendOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.MetricsGetClaGroupMetricTrendAsCSVHandler = metrics.GetClaGroupMetricTrendAsCSVHandlerFunc(
+		func(params metrics.GetClaGroupMetricTrendAsCSVParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			status, errResp := authorizeClaGroupTrend(authUser, projectsClaGroupsRepo, params.ClaGroupID)
+			if errResp != nil {
+				return writeResponse(status, runtime.JSONMime, runtime.JSONProducer(), errResp)
+			}
+			from, to := trendRange(params.From, params.To)
+			result, err := service.GetMetricTrend(ScopeClaGroup, params.ClaGroupID, params.Metric, aws.StringValue(params.Granularity), from, to)
+			if err != nil {
+				return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), errorResponse(err))
+			}
+			return CSVMetricTrendResponse(trendFilename(ScopeClaGroup, params.ClaGroupID, result), result)
+		})
+
+	api.MetricsGetProjectMetricTrendHandler = metrics.GetProjectMetricTrendHandlerFunc(
+		func(params metrics.GetProjectMetricTrendParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				return metrics.NewGetProjectMetricTrendForbidden().WithXRequestID(reqID).WithPayload(projectTrendForbidden(authUser, params.ProjectSFID))
+			}
+			from, to := trendRange(params.From, params.To)
+			result, err := service.GetMetricTrend(ScopeProject, params.ProjectSFID, params.Metric, aws.StringValue(params.Granularity), from, to)
+			if err != nil {
+				return metrics.NewGetProjectMetricTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return metrics.NewGetProjectMetricTrendOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.MetricsGetProjectMetricTrendAsCSVHandler = metrics.GetProjectMetricTrendAsCSVHandlerFunc(
+		func(params metrics.GetProjectMetricTrendAsCSVParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				return writeResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), projectTrendForbidden(authUser, params.ProjectSFID))
+			}
+			from, to := trendRange(params.From, params.To)
+			result, err := service.GetMetricTrend(ScopeProject, params.ProjectSFID, params.Metric, aws.StringValue(params.Granularity), from, to)
+			if err != nil {
+				return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), errorResponse(err))
+			}
+			return CSVMetricTrendResponse(trendFilename(ScopeProject, params.ProjectSFID, result), result)
+		})
+
+	api.MetricsGetCompanyMetricTrendHandler = metrics.GetCompanyMetricTrendHandlerFunc(
+		func(params metrics.GetCompanyMetricTrendParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			companyID, status, errResp := authorizeCompanyTrend(ctx, authUser, v1CompanyRepo, params.CompanySFID)
+			switch status {
+			case http.StatusForbidden:
+				return metrics.NewGetCompanyMetricTrendForbidden().WithXRequestID(reqID).WithPayload(errResp)
+			case http.StatusNotFound:
+				return metrics.NewGetCompanyMetricTrendNotFound().WithXRequestID(reqID).WithPayload(errResp)
+			case http.StatusBadRequest:
+				return metrics.NewGetCompanyMetricTrendBadRequest().WithXRequestID(reqID).WithPayload(errResp)
+			}
+			from, to := trendRange(params.From, params.To)
+			result, err := service.GetMetricTrend(ScopeCompany, companyID, params.Metric, aws.StringValue(params.Granularity), from, to)
+			if err != nil {
+				return metrics.NewGetCompanyMetricTrendBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return metrics.NewGetCompanyMetricTrendOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.MetricsGetCompanyMetricTrendAsCSVHandler = metrics.GetCompanyMetricTrendAsCSVHandlerFunc(
+		func(params metrics.GetCompanyMetricTrendAsCSVParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			companyID, status, errResp := authorizeCompanyTrend(ctx, authUser, v1CompanyRepo, params.CompanySFID)
+			if errResp != nil {
+				return writeResponse(status, runtime.JSONMime, runtime.JSONProducer(), errResp)
+			}
+			from, to := trendRange(params.From, params.To)
+			result, err := service.GetMetricTrend(ScopeCompany, companyID, params.Metric, aws.StringValue(params.Granularity), from, to)
+			if err != nil {
+				return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), errorResponse(err))
+			}
+			return CSVMetricTrendResponse(trendFilename(ScopeCompany, params.CompanySFID, result), result)
+		})
+}
+
+// authorizeClaGroupTrend checks the user has access to the foundation of the CLA group, returning the error status
+// and payload when not
+func authorizeClaGroupTrend(authUser *auth.User, projectsClaGroupsRepo projects_cla_groups.Repository, claGroupID string) (int, *models.ErrorResponse) {
+	pcgs, err := projectsClaGroupsRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil || len(pcgs) == 0 {
+		return http.StatusNotFound, &models.ErrorResponse{
+			Code:    "404",
+			Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla group %s is not associated with any project", claGroupID),
+		}
+	}
+	if !utils.IsUserAuthorizedForProjectTree(authUser, pcgs[0].FoundationSFID) {
+		return http.StatusForbidden, &models.ErrorResponse{
+			Code: "403",
+			Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get CLA Group Metric Trend with Project scope of %s",
+				authUser.UserName, pcgs[0].FoundationSFID),
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// authorizeCompanyTrend checks the user has access to the company, returning its internal ID - or the error status
+// and payload when not
+func authorizeCompanyTrend(ctx context.Context, authUser *auth.User, v1CompanyRepo v1Company.IRepository, companySFID string) (string, int, *models.ErrorResponse) {
+	if !utils.IsUserAuthorizedForOrganization(authUser, companySFID) {
+		return "", http.StatusForbidden, &models.ErrorResponse{
+			Code: "403",
+			Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Company Metric Trend with Organization scope of %s",
+				authUser.UserName, companySFID),
+		}
+	}
+	comp, err := v1CompanyRepo.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil {
+		if err == v1Company.ErrCompanyDoesNotExist {
+			return "", http.StatusNotFound, &models.ErrorResponse{
+				Code:    "404",
+				Message: fmt.Sprintf("EasyCLA - 404 Not Found - company %s not found", companySFID),
+			}
+		}
+		return "", http.StatusBadRequest, errorResponse(err)
+	}
+	return comp.CompanyID, http.StatusOK, nil
+}
+
+func projectTrendForbidden(authUser *auth.User, projectSFID string) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code: "403",
+		Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Project Metric Trend with Project scope of %s",
+			authUser.UserName, projectSFID),
+	}
+}
+
+// trendRange converts the optional from and to query parameters - a missing date is left zero for the service default
+func trendRange(from, to *strfmt.Date) (time.Time, time.Time) {
+	var fromTime, toTime time.Time
+	if from != nil {
+		fromTime = time.Time(*from)
+	}
+	if to != nil {
+		toTime = time.Time(*to)
+	}
+	return fromTime, toTime
+}
+
+func trendFilename(scope, id string, trend *models.MetricTrend) string {
+	return fmt.Sprintf("%s-%s-%s-%s.csv", strings.Replace(scope, "_", "-", -1), id, trend.Metric, trend.Granularity)
 }
 
 type codedResponse interface {
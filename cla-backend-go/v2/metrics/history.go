@@ -0,0 +1,209 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// Rollup scope constants
+const (
+	ScopeClaGroup = "cla_group"
+	ScopeCompany  = "company"
+	ScopeProject  = "project"
+)
+
+// RollupDateFormat is the format of the daily rollup date
+const RollupDateFormat = "2006-01-02"
+
+// DailyRollup is the snapshot of the metrics of a CLA group, company or SF project at the end of a day
+type DailyRollup struct {
+	ID                string `json:"id"`
+	Date              string `json:"date"`
+	Scope             string `json:"scope"`
+	ScopeID           string `json:"scope_id"`
+	IclaCount         int64  `json:"icla_count"`
+	CclaCount         int64  `json:"ccla_count"`
+	EclaCount         int64  `json:"ecla_count"`
+	ContributorsCount int64  `json:"contributors_count"`
+	ClaManagersCount  int64  `json:"cla_managers_count"`
+	RepositoriesCount int64  `json:"repositories_count"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// rollupID returns the history table key of the scope
+func rollupID(scope, scopeID string) string {
+	return fmt.Sprintf("%s#%s", scope, scopeID)
+}
+
+func newDailyRollup(scope, scopeID, date string) *DailyRollup {
+	return &DailyRollup{
+		ID:      rollupID(scope, scopeID),
+		Date:    date,
+		Scope:   scope,
+		ScopeID: scopeID,
+	}
+}
+
+// add adds the counts of the CLA group metric to the rollup
+func (r *DailyRollup) add(pm *ProjectMetric) {
+	r.IclaCount += pm.IndividualContributorsCount
+	r.CclaCount += pm.CompaniesCount
+	r.EclaCount += pm.CorporateContributorsCount
+	r.ContributorsCount += pm.TotalContributorsCount
+	r.ClaManagersCount += pm.ClaManagersCount
+	r.RepositoriesCount += pm.RepositoriesCount
+}
+
+// SaveDailyRollups stores the snapshot of the current CLA group, company and SF project metrics for the day.
+// Saving the same day again overwrites its rollups.
+func (repo *repo) SaveDailyRollups(date string) error {
+	t := time.Now()
+	var claGroupMetrics []*ProjectMetric
+	var nextKey string
+	for ok := true; ok; ok = nextKey != "" {
+		var result []*ProjectMetric
+		var err error
+		result, nextKey, err = repo.GetProjectMetrics(1000, nextKey)
+		if err != nil {
+			return err
+		}
+		claGroupMetrics = append(claGroupMetrics, result...)
+	}
+	companyMetrics, err := repo.GetCompanyMetrics()
+	if err != nil {
+		return err
+	}
+	pcgs, err := repo.projectsClaGroupsRepo.GetProjectsIdsForAllFoundation()
+	if err != nil {
+		return err
+	}
+
+	rollups := claGroupRollups(claGroupMetrics, date)
+	rollups = append(rollups, projectRollups(claGroupMetrics, pcgs, date)...)
+	for _, cm := range companyMetrics {
+		rollup := newDailyRollup(ScopeCompany, cm.ID, date)
+		rollup.CclaCount = cm.ProjectCount
+		rollup.EclaCount = cm.CorporateContributorsCount
+		rollup.ContributorsCount = cm.CorporateContributorsCount
+		rollup.ClaManagersCount = cm.ClaManagersCount
+		rollups = append(rollups, rollup)
+	}
+
+	_, now := utils.CurrentTime()
+	for _, rollup := range rollups {
+		rollup.CreatedAt = now
+		av, err := dynamodbattribute.MarshalMap(rollup)
+		if err != nil {
+			return err
+		}
+		_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+			Item:      av,
+			TableName: aws.String(repo.historyTableName),
+		})
+		if err != nil {
+			log.Warnf("unable to save daily rollup id: %s, date: %s, error: %v", rollup.ID, date, err)
+			return err
+		}
+	}
+	log.Printf("saved %d daily rollups for %s, took :%s \n", len(rollups), date, time.Since(t).String())
+	return nil
+}
+
+// claGroupRollups returns the rollups of the CLA group metrics
+func claGroupRollups(claGroupMetrics []*ProjectMetric, date string) []*DailyRollup {
+	rollups := make([]*DailyRollup, 0, len(claGroupMetrics))
+	for _, pm := range claGroupMetrics {
+		rollup := newDailyRollup(ScopeClaGroup, pm.ID, date)
+		rollup.add(pm)
+		rollups = append(rollups, rollup)
+	}
+	return rollups
+}
+
+// projectRollups returns the rollups of the SF projects and foundations - a project has the metrics of its CLA group,
+// a foundation the sum of the metrics of the CLA groups of its projects
+func projectRollups(claGroupMetrics []*ProjectMetric, pcgs []*projects_cla_groups.ProjectClaGroup, date string) []*DailyRollup {
+	metricsByClaGroup := make(map[string]*ProjectMetric, len(claGroupMetrics))
+	for _, pm := range claGroupMetrics {
+		metricsByClaGroup[pm.ID] = pm
+	}
+	claGroupsBySFID := make(map[string]map[string]bool)
+	var sfids []string
+	addClaGroup := func(sfid, claGroupID string) {
+		if sfid == "" {
+			return
+		}
+		if _, ok := claGroupsBySFID[sfid]; !ok {
+			claGroupsBySFID[sfid] = make(map[string]bool)
+			sfids = append(sfids, sfid)
+		}
+		claGroupsBySFID[sfid][claGroupID] = true
+	}
+	for _, pcg := range pcgs {
+		addClaGroup(pcg.ProjectSFID, pcg.ClaGroupID)
+		if pcg.FoundationSFID != pcg.ProjectSFID {
+			addClaGroup(pcg.FoundationSFID, pcg.ClaGroupID)
+		}
+	}
+
+	rollups := make([]*DailyRollup, 0, len(sfids))
+	for _, sfid := range sfids {
+		rollup := newDailyRollup(ScopeProject, sfid, date)
+		for claGroupID := range claGroupsBySFID[sfid] {
+			if pm, ok := metricsByClaGroup[claGroupID]; ok {
+				rollup.add(pm)
+			}
+		}
+		rollups = append(rollups, rollup)
+	}
+	return rollups
+}
+
+// GetDailyRollups returns the daily rollups of the scope between the from and to dates, inclusive, ordered by date
+func (repo *repo) GetDailyRollups(scope, scopeID, fromDate, toDate string) ([]*DailyRollup, error) {
+	keyCondition := expression.Key("id").Equal(expression.Value(rollupID(scope, scopeID))).
+		And(expression.Key("date").Between(expression.Value(fromDate), expression.Value(toDate)))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		log.Warnf("error building expression for daily rollups query, error: %v", err)
+		return nil, err
+	}
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.historyTableName),
+	}
+
+	rollups := make([]*DailyRollup, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error retrieving daily rollups of %s %s, error: %v", scope, scopeID, errQuery)
+			return nil, errQuery
+		}
+		var rollupsTmp []*DailyRollup
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &rollupsTmp)
+		if err != nil {
+			log.Warnf("error unmarshalling daily rollups from database. error: %v", err)
+			return nil, err
+		}
+		rollups = append(rollups, rollupsTmp...)
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return rollups, nil
+}
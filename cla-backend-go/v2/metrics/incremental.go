@@ -0,0 +1,615 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// MetricTypeMember is the metric type of the member records backing the incrementally maintained counts. A member
+// record holds the source items, e.g. signatures, which contribute the member so the member is counted once and is
+// only uncounted when the last source goes away.
+const MetricTypeMember = "member"
+
+// count attributes of the metric items
+const (
+	attrCorporateContributorsCount        = "corporate_contributors_count"
+	attrIndividualContributorsCount       = "individual_contributors_count"
+	attrClaManagersCount                  = "cla_managers_count"
+	attrContributorsCount                 = "contributors_count"
+	attrProjectsCount                     = "projects_count"
+	attrGithubRepositoriesCount           = "github_repositories_count"
+	attrGerritRepositoriesCount           = "gerrit_repositories_count"
+	attrRepositoriesCount                 = "repositories_count"
+	attrCompaniesCount                    = "companies_count"
+	attrCompaniesProjectContributionCount = "companies_project_contribution_count"
+	attrLfMembersCLACount                 = "lf_members_cla_count"
+	attrNonLfMembersCLACount              = "non_lf_members_cla_count"
+	attrProjectCount                      = "project_count"
+	attrTotalContributorsCount            = "total_contributors_count"
+	attrOneClaManager                     = "one_cla_manager"
+	attrTwoClaManager                     = "two_cla_manager"
+	attrThreeClaManager                   = "three_cla_manager"
+	attrFourOrMoreClaManager              = "four_or_more_cla_manager"
+)
+
+// metricAttributes lists the count attributes of each metric type
+var metricAttributes = map[string][]string{
+	MetricTypeTotalCount: {attrCorporateContributorsCount, attrIndividualContributorsCount, attrClaManagersCount,
+		attrContributorsCount, attrProjectsCount, attrGithubRepositoriesCount, attrGerritRepositoriesCount,
+		attrRepositoriesCount, attrCompaniesCount, attrCompaniesProjectContributionCount, attrLfMembersCLACount,
+		attrNonLfMembersCLACount},
+	MetricTypeCompany: {attrProjectCount, attrCorporateContributorsCount, attrClaManagersCount},
+	MetricTypeProject: {attrCompaniesCount, attrClaManagersCount, attrCorporateContributorsCount,
+		attrIndividualContributorsCount, attrTotalContributorsCount, attrRepositoriesCount},
+	MetricTypeCompanyProject:         {attrClaManagersCount, attrContributorsCount},
+	MetricTypeClaManagerDistribution: {attrOneClaManager, attrTwoClaManager, attrThreeClaManager, attrFourOrMoreClaManager},
+}
+
+// counter identifies a count attribute of a metric item
+type counter struct {
+	id         string
+	metricType string
+	attribute  string
+}
+
+// contribution is a member counted by a counter and the source item contributing it, e.g. an individual contributor
+// user ID counted by the project individual contributors count, contributed by the ICLA signature
+type contribution struct {
+	counter
+	member string
+	source string
+}
+
+// memberID returns the id of the member record
+func (c contribution) memberID() string {
+	return strings.Join([]string{c.metricType, c.id, c.attribute, c.member}, "#")
+}
+
+// signatureContributions returns the contributions of the signature, mirroring the full calculation in
+// Metrics.processSignature. Only signed and approved signatures contribute.
+func signatureContributions(sig *ItemSignature, isUser func(lfUsername string) bool, isLfMember func(sig *ItemSignature) bool) []contribution {
+	if sig == nil || !sig.SignatureSigned || !sig.SignatureApproved {
+		return nil
+	}
+	source := sig.SignatureID
+	projectID := sig.SignatureProjectID
+	add := func(out []contribution, id, metricType, attribute, member string) []contribution {
+		return append(out, contribution{counter: counter{id: id, metricType: metricType, attribute: attribute}, member: member, source: source})
+	}
+
+	var out []contribution
+	switch signatureType(sig) {
+	case CclaSignature:
+		companyID := sig.SignatureReferenceID
+		companyProjectID := fmt.Sprintf("%s#%s", companyID, projectID)
+		out = add(out, IDTotalCount, MetricTypeTotalCount, attrCompaniesProjectContributionCount, companyProjectID)
+		if isLfMember(sig) {
+			out = add(out, IDTotalCount, MetricTypeTotalCount, attrLfMembersCLACount, source)
+		} else {
+			out = add(out, IDTotalCount, MetricTypeTotalCount, attrNonLfMembersCLACount, source)
+		}
+		out = add(out, companyID, MetricTypeCompany, attrProjectCount, source)
+		out = add(out, projectID, MetricTypeProject, attrCompaniesCount, companyID)
+		for _, claManagerLfusername := range sig.SignatureACL {
+			// only count cla managers present in the database
+			if !isUser(claManagerLfusername) {
+				continue
+			}
+			out = add(out, IDTotalCount, MetricTypeTotalCount, attrClaManagersCount, claManagerLfusername)
+			out = add(out, companyID, MetricTypeCompany, attrClaManagersCount, claManagerLfusername)
+			out = add(out, projectID, MetricTypeProject, attrClaManagersCount, claManagerLfusername)
+			out = add(out, companyProjectID, MetricTypeCompanyProject, attrClaManagersCount, claManagerLfusername)
+		}
+	case EmployeeSignature:
+		userID := sig.SignatureReferenceID
+		companyID := sig.SignatureUserCompanyID
+		out = add(out, IDTotalCount, MetricTypeTotalCount, attrCorporateContributorsCount, userID)
+		out = add(out, IDTotalCount, MetricTypeTotalCount, attrContributorsCount, userID)
+		out = add(out, companyID, MetricTypeCompany, attrCorporateContributorsCount, userID)
+		out = add(out, projectID, MetricTypeProject, attrCorporateContributorsCount, userID)
+		// the project total is the sum of the corporate and individual contributor counts
+		out = add(out, projectID, MetricTypeProject, attrTotalContributorsCount, "corporate#"+userID)
+		out = add(out, fmt.Sprintf("%s#%s", companyID, projectID), MetricTypeCompanyProject, attrContributorsCount, userID)
+	case IclaSignature:
+		userID := sig.SignatureReferenceID
+		out = add(out, IDTotalCount, MetricTypeTotalCount, attrIndividualContributorsCount, userID)
+		out = add(out, IDTotalCount, MetricTypeTotalCount, attrContributorsCount, userID)
+		out = add(out, projectID, MetricTypeProject, attrIndividualContributorsCount, userID)
+		out = add(out, projectID, MetricTypeProject, attrTotalContributorsCount, "individual#"+userID)
+	}
+	return out
+}
+
+// purgedSignatureContributions returns the contributions of the signature as if it was signed and approved, with
+// every cla manager and under both the LF member and the non LF member counts
+func purgedSignatureContributions(sig *ItemSignature) []contribution {
+	if sig == nil {
+		return nil
+	}
+	counted := *sig
+	counted.SignatureSigned = true
+	counted.SignatureApproved = true
+	isUser := func(lfUsername string) bool { return true }
+	out := signatureContributions(&counted, isUser, func(*ItemSignature) bool { return true })
+	for _, c := range signatureContributions(&counted, isUser, func(*ItemSignature) bool { return false }) {
+		if c.attribute == attrNonLfMembersCLACount {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// repositoryContributions returns the contributions of the GitHub repository
+func repositoryContributions(r *ItemRepository) []contribution {
+	if r == nil {
+		return nil
+	}
+	return []contribution{
+		{counter: counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrGithubRepositoriesCount}, member: r.RepositoryID, source: r.RepositoryID},
+		{counter: counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrRepositoriesCount}, member: "github#" + r.RepositoryID, source: r.RepositoryID},
+		{counter: counter{id: r.RepositoryProjectID, metricType: MetricTypeProject, attribute: attrRepositoriesCount}, member: "github#" + r.RepositoryID, source: r.RepositoryID},
+	}
+}
+
+// gerritInstanceContributions returns the contributions of the gerrit instance
+func gerritInstanceContributions(gi *ItemGerritInstance) []contribution {
+	if gi == nil {
+		return nil
+	}
+	return []contribution{
+		{counter: counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrGerritRepositoriesCount}, member: gi.GerritID, source: gi.GerritID},
+		{counter: counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrRepositoriesCount}, member: "gerrit#" + gi.GerritID, source: gi.GerritID},
+		{counter: counter{id: gi.ProjectID, metricType: MetricTypeProject, attribute: attrRepositoriesCount}, member: "gerrit#" + gi.GerritID, source: gi.GerritID},
+	}
+}
+
+// companyContributions returns the contributions of the company
+func companyContributions(company *ItemCompany) []contribution {
+	if company == nil {
+		return nil
+	}
+	return []contribution{
+		{counter: counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrCompaniesCount}, member: company.CompanyID, source: company.CompanyID},
+	}
+}
+
+// projectContributions returns the contributions of the CLA Group
+func projectContributions(project *ItemProject) []contribution {
+	if project == nil {
+		return nil
+	}
+	return []contribution{
+		{counter: counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrProjectsCount}, member: project.ProjectID, source: project.ProjectID},
+	}
+}
+
+// diffContributions returns the contributions only present in the new list and the contributions only present in the
+// old list
+func diffContributions(oldList, newList []contribution) (added []contribution, removed []contribution) {
+	oldSet := make(map[contribution]bool, len(oldList))
+	for _, c := range oldList {
+		oldSet[c] = true
+	}
+	newSet := make(map[contribution]bool, len(newList))
+	for _, c := range newList {
+		if newSet[c] {
+			continue
+		}
+		newSet[c] = true
+		if !oldSet[c] {
+			added = append(added, c)
+		}
+	}
+	for _, c := range oldList {
+		if !newSet[c] {
+			removed = append(removed, c)
+			newSet[c] = true
+		}
+	}
+	return added, removed
+}
+
+// claManagerDistributionAttribute returns the distribution bucket of the company cla manager count
+func claManagerDistributionAttribute(count int64) string {
+	switch {
+	case count <= 0:
+		return ""
+	case count == 1:
+		return attrOneClaManager
+	case count == 2:
+		return attrTwoClaManager
+	case count == 3:
+		return attrThreeClaManager
+	default:
+		return attrFourOrMoreClaManager
+	}
+}
+
+// UpdateSignatureMetrics applies a change of the signature to the metrics - the old or new image is nil when the
+// signature was inserted or removed
+func (repo *repo) UpdateSignatureMetrics(oldSig, newSig *ItemSignature) error {
+	return repo.applyContributions(repo.signatureContributions(oldSig, nil), repo.signatureContributions(newSig, nil))
+}
+
+// PurgeSignatureMetrics removes the signature from the metrics ahead of the deletion of its record - every
+// contribution the signature could have made is removed whatever its current state, so the counts no longer include a
+// purged signature whose earlier change was missed. Removing a contribution which is not counted has no effect.
+func (repo *repo) PurgeSignatureMetrics(sig *ItemSignature) error {
+	return repo.applyContributions(purgedSignatureContributions(sig), nil)
+}
+
+// UpdateRepositoryMetrics applies a change of the GitHub repository to the metrics
+func (repo *repo) UpdateRepositoryMetrics(oldRepo, newRepo *ItemRepository) error {
+	return repo.applyContributions(repositoryContributions(oldRepo), repositoryContributions(newRepo))
+}
+
+// UpdateGerritInstanceMetrics applies a change of the gerrit instance to the metrics
+func (repo *repo) UpdateGerritInstanceMetrics(oldInstance, newInstance *ItemGerritInstance) error {
+	return repo.applyContributions(gerritInstanceContributions(oldInstance), gerritInstanceContributions(newInstance))
+}
+
+// UpdateCompanyMetrics applies a change of the company to the metrics
+func (repo *repo) UpdateCompanyMetrics(oldCompany, newCompany *ItemCompany) error {
+	err := repo.applyContributions(companyContributions(oldCompany), companyContributions(newCompany))
+	if err != nil {
+		return err
+	}
+	if newCompany == nil || newCompany.CompanyName == "" {
+		return nil
+	}
+	return repo.setMetricFields(newCompany.CompanyID, MetricTypeCompany, map[string]string{"company_name": newCompany.CompanyName})
+}
+
+// UpdateProjectMetrics applies a change of the CLA Group to the metrics
+func (repo *repo) UpdateProjectMetrics(oldProject, newProject *ItemProject) error {
+	err := repo.applyContributions(projectContributions(oldProject), projectContributions(newProject))
+	if err != nil {
+		return err
+	}
+	if newProject == nil {
+		return nil
+	}
+	fields := map[string]string{"project_name": newProject.ProjectName}
+	if newProject.ProjectExternalID != "" {
+		// salesforce_id is an index key so it is only set when present
+		fields["external_project_id"] = newProject.ProjectExternalID
+		fields["salesforce_id"] = newProject.ProjectExternalID
+	}
+	return repo.setMetricFields(newProject.ProjectID, MetricTypeProject, fields)
+}
+
+// signatureContributions returns the contributions of the signature, looking up the cla managers and the LF
+// membership of the company. The users cache is used when provided, otherwise the users table is queried.
+func (repo *repo) signatureContributions(sig *ItemSignature, usersCache map[string]*ItemUser) []contribution {
+	isUser := func(lfUsername string) bool {
+		if usersCache != nil {
+			_, ok := usersCache[lfUsername]
+			return ok
+		}
+		return repo.userExists(lfUsername)
+	}
+	isLfMember := func(sig *ItemSignature) bool {
+		repo.cacheClaGroupMembership(sig.SignatureProjectID)
+		_, ok := LfMembers[sig.SignatureReferenceName]
+		return ok
+	}
+	return signatureContributions(sig, isUser, isLfMember)
+}
+
+// applyContributions removes the contributions no longer present and adds the new ones
+func (repo *repo) applyContributions(oldList, newList []contribution) error {
+	added, removed := diffContributions(oldList, newList)
+	for _, c := range removed {
+		if err := repo.removeContribution(c); err != nil {
+			return err
+		}
+	}
+	for _, c := range added {
+		if err := repo.addContribution(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxCountAttempts bounds the attempts of a count update which conflicts with concurrent updates of the same count
+const maxCountAttempts = 5
+
+// memberKey returns the key of the member record of the contribution
+func memberKey(c contribution) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"id":          {S: aws.String(c.memberID())},
+		"metric_type": {S: aws.String(MetricTypeMember)},
+	}
+}
+
+// cancellationReasons returns the cancellation reasons of a canceled transaction, nil for other errors
+func cancellationReasons(err error) []*dynamodb.CancellationReason {
+	if tce, ok := err.(*dynamodb.TransactionCanceledException); ok {
+		return tce.CancellationReasons
+	}
+	return nil
+}
+
+// conditionFailed returns true if the cancellation reason is a failed condition
+func conditionFailed(reason *dynamodb.CancellationReason) bool {
+	return reason != nil && aws.StringValue(reason.Code) == "ConditionalCheckFailed"
+}
+
+// addContribution records the source on the member record and, if the member is not counted yet, marks it counted
+// and increments the count in the same transaction - replaying a change is safe as a counted member is not counted
+// again
+func (repo *repo) addContribution(c contribution) error {
+	for attempt := 0; attempt < maxCountAttempts; attempt++ {
+		countWrites, err := repo.countWrites(c.counter, 1)
+		if err != nil {
+			return err
+		}
+		_, err = repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+			TransactItems: append([]*dynamodb.TransactWriteItem{{Update: &dynamodb.Update{
+				TableName:           aws.String(repo.metricTableName),
+				Key:                 memberKey(c),
+				UpdateExpression:    aws.String("ADD sources :source SET counted = :counted"),
+				ConditionExpression: aws.String("attribute_not_exists(counted)"),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":source":  {SS: aws.StringSlice([]string{c.source})},
+					":counted": {BOOL: aws.Bool(true)},
+				},
+			}}}, countWrites...),
+		})
+		if err == nil {
+			return nil
+		}
+		reasons := cancellationReasons(err)
+		if reasons == nil {
+			log.Warnf("unable to add metric member: %s, source: %s, error: %v", c.memberID(), c.source, err)
+			return err
+		}
+		if conditionFailed(reasons[0]) {
+			// the member is already counted, only the source is recorded
+			_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+				TableName:        aws.String(repo.metricTableName),
+				Key:              memberKey(c),
+				UpdateExpression: aws.String("ADD sources :source"),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":source": {SS: aws.StringSlice([]string{c.source})},
+				},
+			})
+			if err != nil {
+				log.Warnf("unable to add metric member: %s, source: %s, error: %v", c.memberID(), c.source, err)
+			}
+			return err
+		}
+		// the count was changed concurrently, the count writes are rebuilt from the new count
+	}
+	return fmt.Errorf("unable to add metric member: %s, source: %s, concurrent count updates", c.memberID(), c.source)
+}
+
+// removeContribution removes the source from the member record and, once no source is left, deletes the member record
+// and decrements the count in the same transaction - replaying a change is safe as a deleted member is not uncounted
+// again
+func (repo *repo) removeContribution(c contribution) error {
+	result, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:           aws.String(repo.metricTableName),
+		Key:                 memberKey(c),
+		UpdateExpression:    aws.String("DELETE sources :source"),
+		ConditionExpression: aws.String("attribute_exists(id)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":source": {SS: aws.StringSlice([]string{c.source})},
+		},
+		ReturnValues: aws.String(dynamodb.ReturnValueAllNew),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			// member was never counted or is already uncounted
+			return nil
+		}
+		log.Warnf("unable to remove metric member: %s, source: %s, error: %v", c.memberID(), c.source, err)
+		return err
+	}
+	if sources, ok := result.Attributes["sources"]; ok && len(sources.SS) > 0 {
+		// other sources still contribute the member
+		return nil
+	}
+
+	for attempt := 0; attempt < maxCountAttempts; attempt++ {
+		countWrites, err := repo.countWrites(c.counter, -1)
+		if err != nil {
+			return err
+		}
+		_, err = repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+			TransactItems: append([]*dynamodb.TransactWriteItem{{Delete: &dynamodb.Delete{
+				TableName:           aws.String(repo.metricTableName),
+				Key:                 memberKey(c),
+				ConditionExpression: aws.String("attribute_exists(counted) AND attribute_not_exists(sources)"),
+			}}}, countWrites...),
+		})
+		if err == nil {
+			return nil
+		}
+		reasons := cancellationReasons(err)
+		if reasons == nil {
+			log.Warnf("unable to remove metric member: %s, source: %s, error: %v", c.memberID(), c.source, err)
+			return err
+		}
+		if conditionFailed(reasons[0]) {
+			// a concurrent add recorded a new source, or the member is already uncounted
+			return nil
+		}
+		// the count was changed concurrently, the count writes are rebuilt from the new count
+	}
+	return fmt.Errorf("unable to remove metric member: %s, source: %s, concurrent count updates", c.memberID(), c.source)
+}
+
+// countWrites returns the transaction writes adding the delta to the count attribute of the metric item. The company
+// cla manager counts also move the company between the buckets of the cla manager distribution, so their writes are
+// conditioned on the current count.
+func (repo *repo) countWrites(c counter, delta int64) ([]*dynamodb.TransactWriteItem, error) {
+	_, currentTime := utils.CurrentTime()
+	key := map[string]*dynamodb.AttributeValue{
+		"id":          {S: aws.String(c.id)},
+		"metric_type": {S: aws.String(c.metricType)},
+	}
+	update := &dynamodb.Update{
+		TableName:                aws.String(repo.metricTableName),
+		Key:                      key,
+		UpdateExpression:         aws.String("ADD #count :delta SET created_at = :created_at"),
+		ExpressionAttributeNames: map[string]*string{"#count": aws.String(c.attribute)},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":delta":      {N: aws.String(strconv.FormatInt(delta, 10))},
+			":created_at": {S: aws.String(currentTime)},
+		},
+	}
+	if c.metricType == MetricTypeCompanyProject {
+		ids := strings.SplitN(c.id, "#", 2)
+		if len(ids) == 2 {
+			update.UpdateExpression = aws.String(aws.StringValue(update.UpdateExpression) + ", company_id = :company_id, project_id = :project_id")
+			update.ExpressionAttributeValues[":company_id"] = &dynamodb.AttributeValue{S: aws.String(ids[0])}
+			update.ExpressionAttributeValues[":project_id"] = &dynamodb.AttributeValue{S: aws.String(ids[1])}
+		}
+	}
+	writes := []*dynamodb.TransactWriteItem{{Update: update}}
+	if c.metricType != MetricTypeCompany || c.attribute != attrClaManagersCount {
+		return writes, nil
+	}
+
+	// the cla manager distribution is derived from the company cla manager counts
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName:                aws.String(repo.metricTableName),
+		Key:                      key,
+		ConsistentRead:           aws.Bool(true),
+		ProjectionExpression:     aws.String("#count"),
+		ExpressionAttributeNames: map[string]*string{"#count": aws.String(c.attribute)},
+	})
+	if err != nil {
+		log.Warnf("unable to load metric id: %s, metric_type: %s, error: %v", c.id, c.metricType, err)
+		return nil, err
+	}
+	var oldCount int64
+	if value, ok := result.Item[c.attribute]; ok && value.N != nil {
+		oldCount, err = strconv.ParseInt(*value.N, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	update.ExpressionAttributeValues[":old_count"] = &dynamodb.AttributeValue{N: aws.String(strconv.FormatInt(oldCount, 10))}
+	if oldCount == 0 {
+		update.ConditionExpression = aws.String("attribute_not_exists(#count) OR #count = :old_count")
+	} else {
+		update.ConditionExpression = aws.String("#count = :old_count")
+	}
+
+	oldAttribute := claManagerDistributionAttribute(oldCount)
+	newAttribute := claManagerDistributionAttribute(oldCount + delta)
+	if oldAttribute == newAttribute {
+		return writes, nil
+	}
+	var adds []string
+	names := make(map[string]*string)
+	values := map[string]*dynamodb.AttributeValue{":created_at": {S: aws.String(currentTime)}}
+	if oldAttribute != "" {
+		adds = append(adds, "#old :minus")
+		names["#old"] = aws.String(oldAttribute)
+		values[":minus"] = &dynamodb.AttributeValue{N: aws.String("-1")}
+	}
+	if newAttribute != "" {
+		adds = append(adds, "#new :plus")
+		names["#new"] = aws.String(newAttribute)
+		values[":plus"] = &dynamodb.AttributeValue{N: aws.String("1")}
+	}
+	return append(writes, &dynamodb.TransactWriteItem{Update: &dynamodb.Update{
+		TableName: aws.String(repo.metricTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"id":          {S: aws.String(IDClaManagerDistribution)},
+			"metric_type": {S: aws.String(MetricTypeClaManagerDistribution)},
+		},
+		UpdateExpression:          aws.String("ADD " + strings.Join(adds, ", ") + " SET created_at = :created_at"),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}}), nil
+}
+
+// setMetricFields sets the descriptive attributes of the metric item
+func (repo *repo) setMetricFields(id, metricType string, fields map[string]string) error {
+	names := make(map[string]*string)
+	values := make(map[string]*dynamodb.AttributeValue)
+	var sets []string
+	i := 0
+	for name, value := range fields {
+		names[fmt.Sprintf("#f%d", i)] = aws.String(name)
+		values[fmt.Sprintf(":f%d", i)] = &dynamodb.AttributeValue{S: aws.String(value)}
+		sets = append(sets, fmt.Sprintf("#f%d = :f%d", i, i))
+		i++
+	}
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.metricTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"id":          {S: aws.String(id)},
+			"metric_type": {S: aws.String(metricType)},
+		},
+		UpdateExpression:          aws.String("SET " + strings.Join(sets, ", ")),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		log.Warnf("unable to update metric id: %s, metric_type: %s, error: %v", id, metricType, err)
+		return err
+	}
+	return nil
+}
+
+// userExists returns true if a user with the LF username is present in the users table
+func (repo *repo) userExists(lfUsername string) bool {
+	condition := expression.Key("lf_username").Equal(expression.Value(lfUsername))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return false
+	}
+	results, err := repo.dynamoDBClient.Query(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(fmt.Sprintf("cla-%s-users", repo.stage)),
+		IndexName:                 aws.String("lf-username-index"),
+		Limit:                     aws.Int64(1),
+	})
+	if err != nil {
+		log.Warnf("unable to query user by lf_username: %s, error: %v", lfUsername, err)
+		return false
+	}
+	return len(results.Items) > 0
+}
+
+// cacheClaGroupMembership caches the LF membership of the project of the CLA Group, see cacheProjectMembership
+func (repo *repo) cacheClaGroupMembership(projectID string) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(fmt.Sprintf("cla-%s-projects", repo.stage)),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(projectID)},
+		},
+		ProjectionExpression: aws.String("project_external_id"),
+	})
+	if err != nil {
+		log.Warnf("unable to load project: %s, error: %v", projectID, err)
+		return
+	}
+	externalID := result.Item["project_external_id"]
+	if externalID == nil || aws.StringValue(externalID.S) == "" {
+		return
+	}
+	if _, ok := processedSFProjectsMembership[*externalID.S]; !ok {
+		processedSFProjectsMembership[*externalID.S] = nil
+		cacheProjectMembership(*externalID.S, repo.apiGatewayURL)
+	}
+}
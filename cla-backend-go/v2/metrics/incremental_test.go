@@ -0,0 +1,180 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func countersOf(contributions []contribution) map[counter][]string {
+	out := make(map[counter][]string)
+	for _, c := range contributions {
+		out[c.counter] = append(out[c.counter], c.member)
+	}
+	return out
+}
+
+func TestSignatureContributions(t *testing.T) {
+	isUser := func(lfUsername string) bool { return lfUsername != "unknown" }
+	isLfMember := func(sig *ItemSignature) bool { return sig.SignatureReferenceName == "Member Co" }
+
+	ccla := &ItemSignature{
+		SignatureID:            "sig-1",
+		SignatureReferenceID:   "company-1",
+		SignatureReferenceName: "Member Co",
+		SignatureReferenceType: "company",
+		SignatureType:          "ccla",
+		SignatureProjectID:     "project-1",
+		SignatureACL:           []string{"manager", "unknown"},
+		SignatureSigned:        true,
+		SignatureApproved:      true,
+	}
+	counters := countersOf(signatureContributions(ccla, isUser, isLfMember))
+	assert.Equal(t, []string{"manager"}, counters[counter{id: "company-1", metricType: MetricTypeCompany, attribute: attrClaManagersCount}])
+	assert.Equal(t, []string{"manager"}, counters[counter{id: "company-1#project-1", metricType: MetricTypeCompanyProject, attribute: attrClaManagersCount}])
+	assert.Equal(t, []string{"company-1"}, counters[counter{id: "project-1", metricType: MetricTypeProject, attribute: attrCompaniesCount}])
+	assert.Equal(t, []string{"sig-1"}, counters[counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrLfMembersCLACount}])
+	assert.Nil(t, counters[counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrNonLfMembersCLACount}])
+
+	employee := &ItemSignature{
+		SignatureID:            "sig-2",
+		SignatureReferenceID:   "user-1",
+		SignatureReferenceType: "user",
+		SignatureType:          "cla",
+		SignatureUserCompanyID: "company-1",
+		SignatureProjectID:     "project-1",
+		SignatureSigned:        true,
+		SignatureApproved:      true,
+	}
+	counters = countersOf(signatureContributions(employee, isUser, isLfMember))
+	assert.Equal(t, []string{"user-1"}, counters[counter{id: "company-1#project-1", metricType: MetricTypeCompanyProject, attribute: attrContributorsCount}])
+	assert.Equal(t, []string{"corporate#user-1"}, counters[counter{id: "project-1", metricType: MetricTypeProject, attribute: attrTotalContributorsCount}])
+
+	employee.SignatureApproved = false
+	assert.Len(t, signatureContributions(employee, isUser, isLfMember), 0)
+}
+
+func TestDiffContributions(t *testing.T) {
+	managers := counter{id: "company-1", metricType: MetricTypeCompany, attribute: attrClaManagersCount}
+	kept := contribution{counter: managers, member: "kept", source: "sig-1"}
+	dropped := contribution{counter: managers, member: "dropped", source: "sig-1"}
+	added := contribution{counter: managers, member: "added", source: "sig-1"}
+
+	a, r := diffContributions([]contribution{kept, dropped}, []contribution{kept, added, added})
+	assert.Equal(t, []contribution{added}, a)
+	assert.Equal(t, []contribution{dropped}, r)
+}
+
+func TestCompareCounts(t *testing.T) {
+	total := counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrContributorsCount}
+	project := counter{id: "project-1", metricType: MetricTypeProject, attribute: attrRepositoriesCount}
+	company := counter{id: "company-1", metricType: MetricTypeCompany, attribute: attrProjectCount}
+
+	drifts := compareCounts(
+		map[counter]int64{total: 10, project: 2, company: 0},
+		map[counter]int64{total: 10, project: 3})
+	assert.Equal(t, []*MetricDrift{
+		{ID: "project-1", MetricType: MetricTypeProject, Attribute: attrRepositoriesCount, Expected: 2, Actual: 3},
+	}, drifts)
+}
+
+func TestClaManagerDistributionAttribute(t *testing.T) {
+	assert.Equal(t, "", claManagerDistributionAttribute(0))
+	assert.Equal(t, attrOneClaManager, claManagerDistributionAttribute(1))
+	assert.Equal(t, attrThreeClaManager, claManagerDistributionAttribute(3))
+	assert.Equal(t, attrFourOrMoreClaManager, claManagerDistributionAttribute(7))
+}
+
+func TestContributionCounts(t *testing.T) {
+	db, err := storage.Open(config.StorageDriverSQLite, ":memory:")
+	assert.Nil(t, err)
+	defer db.Close() // nolint
+	r := &repo{dynamoDBClient: storage.NewSQLClient(db), metricTableName: "cla-test-metrics"}
+
+	count := func(c counter) int64 {
+		counts, err := r.loadCounts()
+		assert.Nil(t, err)
+		return counts[c]
+	}
+	managers := counter{id: "company-1", metricType: MetricTypeCompany, attribute: attrClaManagersCount}
+	distribution := func(attribute string) int64 {
+		return count(counter{id: IDClaManagerDistribution, metricType: MetricTypeClaManagerDistribution, attribute: attribute})
+	}
+
+	// the member is counted once for both sources, replaying a change has no effect
+	assert.Nil(t, r.addContribution(contribution{counter: managers, member: "m1", source: "sig-1"}))
+	assert.Nil(t, r.addContribution(contribution{counter: managers, member: "m1", source: "sig-1"}))
+	assert.Nil(t, r.addContribution(contribution{counter: managers, member: "m1", source: "sig-2"}))
+	assert.Nil(t, r.addContribution(contribution{counter: managers, member: "m2", source: "sig-1"}))
+	assert.Equal(t, int64(2), count(managers))
+	assert.Equal(t, int64(0), distribution(attrOneClaManager))
+	assert.Equal(t, int64(1), distribution(attrTwoClaManager))
+
+	// the member is uncounted with its last source
+	assert.Nil(t, r.removeContribution(contribution{counter: managers, member: "m1", source: "sig-1"}))
+	assert.Equal(t, int64(2), count(managers))
+	assert.Nil(t, r.removeContribution(contribution{counter: managers, member: "m1", source: "sig-2"}))
+	assert.Nil(t, r.removeContribution(contribution{counter: managers, member: "m1", source: "sig-2"}))
+	assert.Equal(t, int64(1), count(managers))
+	assert.Equal(t, int64(1), distribution(attrOneClaManager))
+	assert.Equal(t, int64(0), distribution(attrTwoClaManager))
+
+	// a seeded member is not counted again
+	assert.Nil(t, r.seedContributions([]contribution{{counter: managers, member: "m3", source: "sig-3"}}))
+	assert.Nil(t, r.addContribution(contribution{counter: managers, member: "m3", source: "sig-3"}))
+	assert.Equal(t, int64(1), count(managers))
+
+	// the drift is corrected unless the count changed since it was loaded
+	corrected, err := r.correctDrift(&MetricDrift{ID: "company-1", MetricType: MetricTypeCompany, Attribute: attrClaManagersCount, Expected: 2, Actual: 5})
+	assert.Nil(t, err)
+	assert.False(t, corrected)
+	corrected, err = r.correctDrift(&MetricDrift{ID: "company-1", MetricType: MetricTypeCompany, Attribute: attrClaManagersCount, Expected: 2, Actual: 1})
+	assert.Nil(t, err)
+	assert.True(t, corrected)
+	assert.Equal(t, int64(2), count(managers))
+}
+
+func TestPurgeSignatureMetrics(t *testing.T) {
+	db, err := storage.Open(config.StorageDriverSQLite, ":memory:")
+	assert.Nil(t, err)
+	defer db.Close() // nolint
+	r := &repo{dynamoDBClient: storage.NewSQLClient(db), metricTableName: "cla-test-metrics"}
+
+	ccla := &ItemSignature{
+		SignatureID:            "sig-1",
+		SignatureReferenceID:   "company-1",
+		SignatureReferenceName: "Non Member Co",
+		SignatureReferenceType: "company",
+		SignatureType:          "ccla",
+		SignatureProjectID:     "project-1",
+		SignatureACL:           []string{"manager"},
+		SignatureSigned:        true,
+		SignatureApproved:      true,
+	}
+	isUser := func(lfUsername string) bool { return true }
+	isLfMember := func(sig *ItemSignature) bool { return false }
+	// the contributions of a signature whose invalidation was missed
+	assert.Nil(t, r.applyContributions(nil, signatureContributions(ccla, isUser, isLfMember)))
+	// another signature of the company keeps the cla manager counted
+	managers := counter{id: "company-1", metricType: MetricTypeCompany, attribute: attrClaManagersCount}
+	assert.Nil(t, r.addContribution(contribution{counter: managers, member: "manager", source: "sig-2"}))
+
+	ccla.SignatureApproved = false
+	assert.Nil(t, r.PurgeSignatureMetrics(ccla))
+	counts, err := r.loadCounts()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), counts[counter{id: IDTotalCount, metricType: MetricTypeTotalCount, attribute: attrNonLfMembersCLACount}])
+	assert.Equal(t, int64(0), counts[counter{id: "project-1", metricType: MetricTypeProject, attribute: attrCompaniesCount}])
+	assert.Equal(t, int64(1), counts[managers])
+
+	// purging again has no effect
+	assert.Nil(t, r.PurgeSignatureMetrics(ccla))
+	counts, err = r.loadCounts()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), counts[managers])
+}
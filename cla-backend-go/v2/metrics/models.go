@@ -13,10 +13,13 @@ type ItemSignature struct {
 	SignatureType          string   `json:"signature_type"`
 	SignatureReferenceType string   `json:"signature_reference_type"`
 	SignatureProjectID     string   `json:"signature_project_id"`
+	SignatureSigned        bool     `json:"signature_signed"`
+	SignatureApproved      bool     `json:"signature_approved"`
 }
 
 // ItemRepository represent item of repositories table
 type ItemRepository struct {
+	RepositoryID        string `json:"repository_id"`
 	RepositoryProjectID string `json:"repository_project_id"`
 }
 
@@ -28,6 +31,7 @@ type ItemCompany struct {
 
 // ItemGerritInstance represent item of gerrit instance table
 type ItemGerritInstance struct {
+	GerritID  string `json:"gerrit_id"`
 	ProjectID string `json:"project_id"`
 }
 
@@ -76,6 +80,24 @@ type TotalCountMetrics struct {
 	companiesProjectContribution map[string]interface{}
 }
 
+// Counts returns the total counts by name
+func (tcm *TotalCountMetrics) Counts() map[string]int64 {
+	return map[string]int64{
+		"corporate_contributors_count":         tcm.CorporateContributorsCount,
+		"individual_contributors_count":        tcm.IndividualContributorsCount,
+		"cla_managers_count":                   tcm.ClaManagersCount,
+		"contributors_count":                   tcm.ContributorsCount,
+		"projects_count":                       tcm.ProjectsCount,
+		"github_repositories_count":            tcm.GithubRepositoriesCount,
+		"gerrit_repositories_count":            tcm.GerritRepositoriesCount,
+		"repositories_count":                   tcm.RepositoriesCount,
+		"companies_count":                      tcm.CompaniesCount,
+		"companies_project_contribution_count": tcm.CompaniesProjectContributionCount,
+		"lf_members_cla_count":                 tcm.LfMembersCLACount,
+		"non_lf_members_cla_count":             tcm.NonLfMembersCLACount,
+	}
+}
+
 // CompanyMetric contains all metrics related with particular company
 type CompanyMetric struct {
 	ID                         string `json:"id"`
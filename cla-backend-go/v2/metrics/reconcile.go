@@ -0,0 +1,337 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// MetricDrift is a count which differs between the full calculation and the incrementally maintained metrics
+type MetricDrift struct {
+	ID         string `json:"id"`
+	MetricType string `json:"metric_type"`
+	Attribute  string `json:"attribute"`
+	Expected   int64  `json:"expected"`
+	Actual     int64  `json:"actual"`
+}
+
+// DriftReport is the result of a metrics reconciliation
+type DriftReport struct {
+	CheckedAt       string         `json:"checked_at"`
+	CountersChecked int            `json:"counters_checked"`
+	Drifts          []*MetricDrift `json:"drifts"`
+	Corrected       int            `json:"corrected"`
+}
+
+// ReconcileMetrics runs the full metrics calculation and corrects the counts which drifted from the incrementally
+// maintained metrics, refreshing the company-project names on the way. With backfill, the member records are first
+// seeded from the tables as already counted - this migrates the metrics of the full calculation snapshots, whose
+// counts are kept as the starting values.
+func (repo *repo) ReconcileMetrics(backfill bool) (*DriftReport, error) {
+	t := time.Now()
+	if backfill {
+		err := repo.seedMetrics()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m, err := repo.calculateMetrics()
+	if err != nil {
+		return nil, err
+	}
+	err = repo.updateCompanyProjectDetails(m.CompanyProjectMetrics, m.ProjectMetrics.ProjectMetrics, m.CompanyMetrics.CompanyMetrics)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := repo.loadCounts()
+	if err != nil {
+		return nil, err
+	}
+	expected := expectedCounts(m)
+
+	report := &DriftReport{
+		CountersChecked: len(expected),
+		Drifts:          compareCounts(expected, actual),
+	}
+	_, report.CheckedAt = utils.CurrentTime()
+	for _, drift := range report.Drifts {
+		log.Warnf("metric drift id: %s, metric_type: %s, attribute: %s, expected: %d, actual: %d",
+			drift.ID, drift.MetricType, drift.Attribute, drift.Expected, drift.Actual)
+		corrected, err := repo.correctDrift(drift)
+		if err != nil {
+			return nil, err
+		}
+		if corrected {
+			report.Corrected++
+		}
+	}
+	log.Printf("reconcile metrics corrected %d of %d drifts in %d counters, took :%s \n", report.Corrected, len(report.Drifts), report.CountersChecked, time.Since(t).String())
+	return report, nil
+}
+
+// correctDrift sets the drifted count to the expected count, unless the count was changed since it was loaded - the
+// next reconciliation corrects it then
+func (repo *repo) correctDrift(drift *MetricDrift) (bool, error) {
+	_, currentTime := utils.CurrentTime()
+	updateExpression := "SET #count = :expected, created_at = :created_at"
+	values := map[string]*dynamodb.AttributeValue{
+		":expected":   {N: aws.String(strconv.FormatInt(drift.Expected, 10))},
+		":actual":     {N: aws.String(strconv.FormatInt(drift.Actual, 10))},
+		":created_at": {S: aws.String(currentTime)},
+	}
+	if drift.MetricType == MetricTypeCompanyProject {
+		ids := strings.SplitN(drift.ID, "#", 2)
+		if len(ids) == 2 {
+			updateExpression += ", company_id = :company_id, project_id = :project_id"
+			values[":company_id"] = &dynamodb.AttributeValue{S: aws.String(ids[0])}
+			values[":project_id"] = &dynamodb.AttributeValue{S: aws.String(ids[1])}
+		}
+	}
+	condition := "#count = :actual"
+	if drift.Actual == 0 {
+		condition = "attribute_not_exists(#count) OR #count = :actual"
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.metricTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"id":          {S: aws.String(drift.ID)},
+			"metric_type": {S: aws.String(drift.MetricType)},
+		},
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  map[string]*string{"#count": aws.String(drift.Attribute)},
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.Debugf("metric id: %s, metric_type: %s, attribute: %s changed since loaded, not corrected", drift.ID, drift.MetricType, drift.Attribute)
+			return false, nil
+		}
+		log.Warnf("unable to correct metric id: %s, metric_type: %s, attribute: %s, error: %v", drift.ID, drift.MetricType, drift.Attribute, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// expectedCounts returns the counts of the fully calculated metrics
+func expectedCounts(m *Metrics) map[counter]int64 {
+	counts := make(map[counter]int64)
+	m.TotalCountMetrics.RepositoriesCount = m.TotalCountMetrics.GithubRepositoriesCount + m.TotalCountMetrics.GerritRepositoriesCount
+	addCounts(counts, IDTotalCount, MetricTypeTotalCount, m.TotalCountMetrics)
+	for id, cm := range m.CompanyMetrics.CompanyMetrics {
+		addCounts(counts, id, MetricTypeCompany, cm)
+	}
+	for id, pm := range m.ProjectMetrics.ProjectMetrics {
+		addCounts(counts, id, MetricTypeProject, pm)
+	}
+	for id, cpm := range m.CompanyProjectMetrics.CompanyProjectMetrics {
+		addCounts(counts, id, MetricTypeCompanyProject, cpm)
+	}
+	addCounts(counts, IDClaManagerDistribution, MetricTypeClaManagerDistribution, m.ClaManagersDistribution)
+	return counts
+}
+
+// addCounts adds the count attributes of the metric to the counts
+func addCounts(counts map[counter]int64, id, metricType string, metric interface{}) {
+	item, err := dynamodbattribute.MarshalMap(metric)
+	if err != nil {
+		log.Warnf("unable to marshal metric id: %s, metric_type: %s, error: %v", id, metricType, err)
+		return
+	}
+	addItemCounts(counts, id, metricType, item)
+}
+
+// addItemCounts adds the count attributes of the metric item to the counts
+func addItemCounts(counts map[counter]int64, id, metricType string, item map[string]*dynamodb.AttributeValue) {
+	for _, attribute := range metricAttributes[metricType] {
+		value, ok := item[attribute]
+		if !ok || value.N == nil {
+			continue
+		}
+		count, err := strconv.ParseInt(*value.N, 10, 64)
+		if err != nil {
+			continue
+		}
+		counts[counter{id: id, metricType: metricType, attribute: attribute}] = count
+	}
+}
+
+// compareCounts returns the drift between the expected and the actual counts - a missing count is zero
+func compareCounts(expected, actual map[counter]int64) []*MetricDrift {
+	drifts := make([]*MetricDrift, 0)
+	checked := make(map[counter]bool)
+	for _, counts := range []map[counter]int64{expected, actual} {
+		for c := range counts {
+			if checked[c] {
+				continue
+			}
+			checked[c] = true
+			if expected[c] != actual[c] {
+				drifts = append(drifts, &MetricDrift{
+					ID:         c.id,
+					MetricType: c.metricType,
+					Attribute:  c.attribute,
+					Expected:   expected[c],
+					Actual:     actual[c],
+				})
+			}
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].MetricType != drifts[j].MetricType {
+			return drifts[i].MetricType < drifts[j].MetricType
+		}
+		if drifts[i].ID != drifts[j].ID {
+			return drifts[i].ID < drifts[j].ID
+		}
+		return drifts[i].Attribute < drifts[j].Attribute
+	})
+	return drifts
+}
+
+// loadCounts returns the counts of the stored metrics
+func (repo *repo) loadCounts() (map[counter]int64, error) {
+	counts := make(map[counter]int64)
+	for metricType := range metricAttributes {
+		keyCondition := expression.Key("metric_type").Equal(expression.Value(metricType))
+		expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+		if err != nil {
+			return nil, err
+		}
+		queryInput := &dynamodb.QueryInput{
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			KeyConditionExpression:    expr.KeyCondition(),
+			TableName:                 aws.String(repo.metricTableName),
+		}
+		for {
+			results, errQuery := repo.dynamoDBClient.Query(queryInput)
+			if errQuery != nil {
+				log.Warnf("error retrieving %s metrics, error: %v", metricType, errQuery)
+				return nil, errQuery
+			}
+			for _, item := range results.Items {
+				addItemCounts(counts, aws.StringValue(item["id"].S), metricType, item)
+			}
+			if len(results.LastEvaluatedKey) == 0 {
+				break
+			}
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		}
+	}
+	return counts, nil
+}
+
+// seedMetrics seeds the member records of the projects, companies, signatures, repositories and gerrit instances as
+// already counted, without changing the counts
+func (repo *repo) seedMetrics() error {
+	t := time.Now()
+	usersCache, err := repo.cacheUsersByLfUsername()
+	if err != nil {
+		return err
+	}
+
+	var projects []*ItemProject
+	err = repo.scanTable(fmt.Sprintf("cla-%s-projects", repo.stage), expression.NamesList(
+		expression.Name("project_id"), expression.Name("project_external_id"), expression.Name("project_name")), nil, &projects)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if err = repo.seedContributions(projectContributions(project)); err != nil {
+			return err
+		}
+	}
+
+	var companies []*ItemCompany
+	err = repo.scanTable(fmt.Sprintf("cla-%s-companies", repo.stage), expression.NamesList(
+		expression.Name("company_id"), expression.Name("company_name")), nil, &companies)
+	if err != nil {
+		return err
+	}
+	for _, company := range companies {
+		if err = repo.seedContributions(companyContributions(company)); err != nil {
+			return err
+		}
+	}
+
+	filter := expression.Name("signature_signed").Equal(expression.Value(true)).
+		And(expression.Name("signature_approved").Equal(expression.Value(true)))
+	var sigs []*ItemSignature
+	err = repo.scanTable(fmt.Sprintf("cla-%s-signatures", repo.stage), expression.NamesList(
+		expression.Name("signature_id"), expression.Name("signature_reference_id"), expression.Name("signature_reference_name"),
+		expression.Name("signature_acl"), expression.Name("signature_user_ccla_company_id"), expression.Name("signature_type"),
+		expression.Name("signature_reference_type"), expression.Name("signature_project_id"), expression.Name("signature_signed"),
+		expression.Name("signature_approved")), &filter, &sigs)
+	if err != nil {
+		return err
+	}
+	for _, sig := range sigs {
+		if err = repo.seedContributions(repo.signatureContributions(sig, usersCache)); err != nil {
+			return err
+		}
+	}
+
+	var repos []*ItemRepository
+	err = repo.scanTable(fmt.Sprintf("cla-%s-repositories", repo.stage), expression.NamesList(
+		expression.Name("repository_id"), expression.Name("repository_project_id")), nil, &repos)
+	if err != nil {
+		return err
+	}
+	for _, r := range repos {
+		if err = repo.seedContributions(repositoryContributions(r)); err != nil {
+			return err
+		}
+	}
+
+	var gerritInstances []*ItemGerritInstance
+	err = repo.scanTable(fmt.Sprintf("cla-%s-gerrit-instances", repo.stage), expression.NamesList(
+		expression.Name("gerrit_id"), expression.Name("project_id")), nil, &gerritInstances)
+	if err != nil {
+		return err
+	}
+	for _, gi := range gerritInstances {
+		if err = repo.seedContributions(gerritInstanceContributions(gi)); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("seed metrics took :%s \n", time.Since(t).String())
+	return nil
+}
+
+// seedContributions records the sources on the member records and marks the members counted without changing the
+// counts
+func (repo *repo) seedContributions(list []contribution) error {
+	for _, c := range list {
+		_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+			TableName:        aws.String(repo.metricTableName),
+			Key:              memberKey(c),
+			UpdateExpression: aws.String("ADD sources :source SET counted = :counted"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":source":  {SS: aws.StringSlice([]string{c.source})},
+				":counted": {BOOL: aws.Bool(true)},
+			},
+		})
+		if err != nil {
+			log.Warnf("unable to seed metric member: %s, source: %s, error: %v", c.memberID(), c.source, err)
+			return err
+		}
+	}
+	return nil
+}
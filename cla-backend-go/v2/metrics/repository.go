@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
 )
 
 // errors
@@ -41,9 +42,17 @@ const (
 	IndexMetricTypeSalesforceID = "metric-type-salesforce-id-index"
 )
 
-// Repository provides methods for calculation,storage and retrieval of metrics
+// Repository provides methods for incremental maintenance, reconciliation and retrieval of metrics
 type Repository interface {
-	CalculateAndSaveMetrics() error
+	ReconcileMetrics(backfill bool) (*DriftReport, error)
+	UpdateSignatureMetrics(oldSig, newSig *ItemSignature) error
+	PurgeSignatureMetrics(sig *ItemSignature) error
+	UpdateRepositoryMetrics(oldRepo, newRepo *ItemRepository) error
+	UpdateGerritInstanceMetrics(oldInstance, newInstance *ItemGerritInstance) error
+	UpdateCompanyMetrics(oldCompany, newCompany *ItemCompany) error
+	UpdateProjectMetrics(oldProject, newProject *ItemProject) error
+	SaveDailyRollups(date string) error
+
 	GetClaManagerDistribution() (*ClaManagersDistribution, error)
 	GetTotalCountMetrics() (*TotalCountMetrics, error)
 	GetCompanyMetrics() ([]*CompanyMetric, error)
@@ -52,11 +61,13 @@ type Repository interface {
 	GetProjectMetric(projectID string) (*ProjectMetric, error)
 	GetProjectMetricBySalesForceID(salesforceID string) ([]*ProjectMetric, error)
 	ListCompanyProjectMetrics(companyID string) ([]*CompanyProjectMetric, error)
+	GetDailyRollups(scope, scopeID, fromDate, toDate string) ([]*DailyRollup, error)
 }
 
 type repo struct {
 	metricTableName       string
-	dynamoDBClient        *dynamodb.DynamoDB
+	historyTableName      string
+	dynamoDBClient        storage.DynamoDBAPI
 	stage                 string
 	apiGatewayURL         string
 	projectsClaGroupsRepo projects_cla_groups.Repository
@@ -65,8 +76,9 @@ type repo struct {
 // NewRepository creates new metrics repository
 func NewRepository(awsSession *session.Session, stage string, apiGwURL string, pcgRepo projects_cla_groups.Repository) Repository {
 	return &repo{
-		dynamoDBClient:        dynamodb.New(awsSession),
+		dynamoDBClient:        storage.NewClient(awsSession),
 		metricTableName:       fmt.Sprintf("cla-%s-metrics", stage),
+		historyTableName:      fmt.Sprintf("cla-%s-metrics-history", stage),
 		stage:                 stage,
 		apiGatewayURL:         apiGwURL,
 		projectsClaGroupsRepo: pcgRepo,
@@ -560,6 +572,8 @@ func (repo *repo) processSignaturesTable(metrics *Metrics, usersCache map[string
 		expression.Name("signature_type"),                 // ccla or cla
 		expression.Name("signature_reference_type"),       // user or company
 		expression.Name("signature_project_id"),           // project id
+		expression.Name("signature_signed"),
+		expression.Name("signature_approved"),
 	)
 	signatureTableName := fmt.Sprintf("cla-%s-signatures", repo.stage)
 	var sigs []*ItemSignature
@@ -576,6 +590,7 @@ func (repo *repo) processSignaturesTable(metrics *Metrics, usersCache map[string
 func (repo *repo) processRepositoriesTable(metrics *Metrics) error {
 	log.Println("processing repositories table")
 	projection := expression.NamesList(
+		expression.Name("repository_id"),
 		expression.Name("repository_project_id"),
 	)
 	repositoriesTableName := fmt.Sprintf("cla-%s-repositories", repo.stage)
@@ -594,6 +609,7 @@ func (repo *repo) processRepositoriesTable(metrics *Metrics) error {
 func (repo *repo) processGerritInstancesTable(metrics *Metrics) error {
 	log.Println("processing gerrit instances table")
 	projection := expression.NamesList(
+		expression.Name("gerrit_id"),
 		expression.Name("project_id"),
 	)
 	var gerritInstances []*ItemGerritInstance
@@ -768,184 +784,6 @@ func (repo *repo) calculateMetrics() (*Metrics, error) {
 	return metrics, nil
 }
 
-func (repo *repo) saveMetrics(metrics *Metrics) error {
-	t := time.Now()
-	err := repo.saveTotalMetris(metrics.TotalCountMetrics)
-	if err != nil {
-		return err
-	}
-	err = repo.saveCompaniesMetrics(metrics.CompanyMetrics)
-	if err != nil {
-		return err
-	}
-	err = repo.saveProjectMetrics(metrics.ProjectMetrics)
-	if err != nil {
-		return err
-	}
-	err = repo.saveClaManagerDistribution(metrics.ClaManagersDistribution)
-	if err != nil {
-		return err
-	}
-	err = repo.saveCompanyProjectMetrics(metrics.CompanyProjectMetrics, metrics.ProjectMetrics.ProjectMetrics, metrics.CompanyMetrics.CompanyMetrics)
-	if err != nil {
-		return err
-	}
-	log.Printf("save metrics took :%s \n", time.Since(t).String())
-	return nil
-}
-
-func (repo *repo) clearOldMetrics(beforeTime time.Time) error {
-	t := time.Now()
-	filter := expression.Name("created_at").LessThan(expression.Value(utils.TimeToString(beforeTime)))
-	type ItemMetric struct {
-		ID         string `json:"id"`
-		MetricType string `json:"metric_type"`
-	}
-	projection := expression.NamesList(
-		expression.Name("id"),
-		expression.Name("metric_type"),
-	)
-	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
-	if err != nil {
-		log.Warnf("error building expression for metric scan, error: %v", err)
-		return err
-	}
-
-	// Assemble the query input parameters
-	scanInput := &dynamodb.ScanInput{
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
-		TableName:                 aws.String(repo.metricTableName),
-	}
-
-	for {
-		results, err := repo.dynamoDBClient.Scan(scanInput)
-		if err != nil {
-			log.Warnf("error retrieving metrics, error: %v", err)
-			return err
-		}
-
-		var metrics []*ItemMetric
-
-		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &metrics)
-		if err != nil {
-			log.Warnf("error unmarshalling metrics from database. error: %v", err)
-			return err
-		}
-
-		for _, m := range metrics {
-			_, err = repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
-				Key: map[string]*dynamodb.AttributeValue{
-					"id":          {S: aws.String(m.ID)},
-					"metric_type": {S: aws.String(m.MetricType)},
-				},
-				TableName: aws.String(repo.metricTableName),
-			})
-			if err != nil {
-				log.Error(fmt.Sprintf("error deleting outdated metric with id:%s, metric_type:%s", m.ID, m.MetricType), err)
-			}
-		}
-
-		if len(results.LastEvaluatedKey) != 0 {
-			scanInput.ExclusiveStartKey = results.LastEvaluatedKey
-		} else {
-			break
-		}
-	}
-	log.Printf("clear old metrics took :%s \n", time.Since(t).String())
-	return nil
-}
-
-func addIDTypeTime(item map[string]*dynamodb.AttributeValue, id string, metricType string) {
-	_, ctime := utils.CurrentTime()
-	utils.AddStringAttribute(item, "id", id)
-	utils.AddStringAttribute(item, "metric_type", metricType)
-	utils.AddStringAttribute(item, "created_at", ctime)
-}
-
-func (repo *repo) saveTotalMetris(tm *TotalCountMetrics) error {
-	log.Println("saving total count metrics")
-	tm.RepositoriesCount = tm.GithubRepositoriesCount + tm.GerritRepositoriesCount
-	av, err := dynamodbattribute.MarshalMap(tm)
-	if err != nil {
-		return err
-	}
-	addIDTypeTime(av, IDTotalCount, MetricTypeTotalCount)
-	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
-		Item:      av,
-		TableName: aws.String(repo.metricTableName),
-	})
-	if err != nil {
-		log.Error("cannot put total_metrics in dynamodb", err)
-		return err
-	}
-	return nil
-}
-
-func (repo *repo) saveCompaniesMetrics(companyMetrics *CompanyMetrics) error {
-	t := time.Now()
-	log.Println("saving company_metrics")
-	for id, cm := range companyMetrics.CompanyMetrics {
-		av, err := dynamodbattribute.MarshalMap(cm)
-		if err != nil {
-			return err
-		}
-		addIDTypeTime(av, id, MetricTypeCompany)
-		_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
-			Item:      av,
-			TableName: aws.String(repo.metricTableName),
-		})
-		if err != nil {
-			log.Printf("cannot put company_metric in dynamodb, metric = %v, error = %s\n", cm, err.Error())
-			return err
-		}
-	}
-	log.Printf("saving company_metrics took :%s \n", time.Since(t).String())
-	return nil
-}
-
-func (repo *repo) saveClaManagerDistribution(cmd *ClaManagersDistribution) error {
-	log.Println("saving cla_managers_distribution")
-	av, err := dynamodbattribute.MarshalMap(cmd)
-	if err != nil {
-		return err
-	}
-	addIDTypeTime(av, IDClaManagerDistribution, MetricTypeClaManagerDistribution)
-	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
-		Item:      av,
-		TableName: aws.String(repo.metricTableName),
-	})
-	if err != nil {
-		log.Printf("cannot put cla_managers_distribution in dynamodb, metric = %v, error = %s\n", cmd, err.Error())
-		return err
-	}
-	return nil
-}
-
-func (repo *repo) saveProjectMetrics(projectMetrics *ProjectMetrics) error {
-	t := time.Now()
-	log.Println("saving project_metrics")
-	for id, cm := range projectMetrics.ProjectMetrics {
-		av, err := dynamodbattribute.MarshalMap(cm)
-		if err != nil {
-			return err
-		}
-		addIDTypeTime(av, id, MetricTypeProject)
-		_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
-			Item:      av,
-			TableName: aws.String(repo.metricTableName),
-		})
-		if err != nil {
-			log.Printf("cannot put project_metric in dynamodb, metric = %v, error = %s\n", cm, err.Error())
-			return err
-		}
-	}
-	log.Printf("saving project_metrics took :%s \n", time.Since(t).String())
-	return nil
-}
-
 type claGroup struct {
 	claGroupID      string
 	projectSFIDList []string
@@ -974,9 +812,11 @@ func (repo *repo) getClaGroupProjectsMapping() (map[string]*claGroup, error) {
 	return r, nil
 }
 
-func (repo *repo) saveCompanyProjectMetrics(in *CompanyProjectMetrics, pmm map[string]*ProjectMetric, cmm map[string]*CompanyMetric) error {
+// updateCompanyProjectDetails sets the project and company names of the company-project metrics - the names are
+// looked up from the project service so they are refreshed by the reconciliation rather than on each change
+func (repo *repo) updateCompanyProjectDetails(in *CompanyProjectMetrics, pmm map[string]*ProjectMetric, cmm map[string]*CompanyMetric) error {
 	t := time.Now()
-	log.Println("saving company_project_metrics")
+	log.Println("updating company_project_metrics details")
 	psc := project_service.GetClient()
 	claGroupMapping, err := repo.getClaGroupProjectsMapping()
 	if err != nil {
@@ -985,17 +825,17 @@ func (repo *repo) saveCompanyProjectMetrics(in *CompanyProjectMetrics, pmm map[s
 	for id, cpm := range in.CompanyProjectMetrics {
 		pm, ok := pmm[cpm.ProjectID]
 		if !ok {
-			log.Warnf("saveCompanyProjectMetrics error = project not found with id. [%s]", cpm.ProjectID)
+			log.Warnf("updateCompanyProjectDetails error = project not found with id. [%s]", cpm.ProjectID)
 			continue
 		}
 		cm, ok := cmm[cpm.CompanyID]
 		if !ok {
-			log.Warnf("saveCompanyProjectMetrics error = company not found with id. [%s]", cpm.CompanyID)
+			log.Warnf("updateCompanyProjectDetails error = company not found with id. [%s]", cpm.CompanyID)
 			continue
 		}
 		claGroupMap, ok := claGroupMapping[cpm.ProjectID]
 		if !ok {
-			log.Warnf("saveCompanyProjectMetrics error = cla group not present in cla-group project mapping. [%s]", cpm.ProjectID)
+			log.Warnf("updateCompanyProjectDetails error = cla group not present in cla-group project mapping. [%s]", cpm.ProjectID)
 			continue
 		}
 		if len(claGroupMap.projectSFIDList) == 1 {
@@ -1005,47 +845,21 @@ func (repo *repo) saveCompanyProjectMetrics(in *CompanyProjectMetrics, pmm map[s
 		}
 		projectDetails, err := psc.GetProject(cpm.ProjectSFID)
 		if err != nil {
-			log.Warnf("saveCompanyProjectMetrics error = unable to get project details from project-service. %s", cpm.ProjectSFID)
+			log.Warnf("updateCompanyProjectDetails error = unable to get project details from project-service. %s", cpm.ProjectSFID)
 			continue
 		}
-		cpm.ProjectName = projectDetails.Name
 
-		cpm.CompanyName = cm.CompanyName
-		cpm.ClaGroupName = pm.ProjectName
-		av, err := dynamodbattribute.MarshalMap(cpm)
-		if err != nil {
-			return err
-		}
-
-		addIDTypeTime(av, id, MetricTypeCompanyProject)
-		_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
-			Item:      av,
-			TableName: aws.String(repo.metricTableName),
+		err = repo.setMetricFields(id, MetricTypeCompanyProject, map[string]string{
+			"project_sfid":   cpm.ProjectSFID,
+			"project_name":   projectDetails.Name,
+			"company_name":   cm.CompanyName,
+			"cla_group_name": pm.ProjectName,
 		})
 		if err != nil {
-			log.Printf("cannot put company_project_metric in dynamodb, metric = %v, error = %s\n", cpm, err.Error())
-
 			return err
 		}
 	}
-	log.Printf("saving company_project_metrics took :%s \n", time.Since(t).String())
-	return nil
-}
-
-func (repo *repo) CalculateAndSaveMetrics() error {
-	timeBeforeStartingMetricsCalculation := time.Now()
-	m, err := repo.calculateMetrics()
-	if err != nil {
-		return err
-	}
-	err = repo.saveMetrics(m)
-	if err != nil {
-		return err
-	}
-	err = repo.clearOldMetrics(timeBeforeStartingMetricsCalculation)
-	if err != nil {
-		return err
-	}
+	log.Printf("updating company_project_metrics details took :%s \n", time.Since(t).String())
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
@@ -36,6 +37,7 @@ type Service interface {
 	GetTopProjects() (*models.TopProjects, error)
 	ListProjectMetrics(paramPageSize *int64, paramNextKey *string) (*models.ListProjectMetric, error)
 	ListCompanyProjectMetrics(companyID string, projectSFID string) (*models.CompanyProjectMetrics, error)
+	GetMetricTrend(scope, scopeID, metric, granularity string, from, to time.Time) (*models.MetricTrend, error)
 }
 
 type service struct {
@@ -0,0 +1,167 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// Trend metric constants
+const (
+	TrendMetricSignatures   = "signatures"
+	TrendMetricIcla         = "icla"
+	TrendMetricCcla         = "ccla"
+	TrendMetricEcla         = "ecla"
+	TrendMetricContributors = "contributors"
+	TrendMetricClaManagers  = "cla-managers"
+	TrendMetricRepositories = "repositories"
+)
+
+// Trend granularity constants
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// DefaultTrendDays is the length of the trend range when no from date is given
+const DefaultTrendDays = 90
+
+// errors
+var (
+	ErrInvalidTrendMetric      = errors.New("invalid trend metric")
+	ErrInvalidTrendGranularity = errors.New("invalid trend granularity")
+	ErrInvalidTrendRange       = errors.New("invalid trend date range - from date is after to date")
+)
+
+// trendPoint is the value of a metric at the end of a period, and its change over the period
+type trendPoint struct {
+	period string
+	value  int64
+	change int64
+}
+
+func (s *service) GetMetricTrend(scope, scopeID, metric, granularity string, from, to time.Time) (*models.MetricTrend, error) {
+	if granularity == "" {
+		granularity = GranularityDay
+	}
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -DefaultTrendDays)
+	}
+	if from.After(to) {
+		return nil, ErrInvalidTrendRange
+	}
+	if _, err := rollupMetricValue(&DailyRollup{}, metric); err != nil {
+		return nil, err
+	}
+	if _, err := trendPeriod(from, granularity); err != nil {
+		return nil, err
+	}
+
+	fromDate, toDate := from.Format(RollupDateFormat), to.Format(RollupDateFormat)
+	rollups, err := s.metricsRepo.GetDailyRollups(scope, scopeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	points, err := bucketRollups(rollups, metric, granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.MetricTrend{
+		Scope:       scope,
+		ScopeID:     scopeID,
+		Metric:      metric,
+		Granularity: granularity,
+		From:        fromDate,
+		To:          toDate,
+		Points:      make([]*models.MetricTrendPoint, 0, len(points)),
+	}
+	for _, p := range points {
+		out.Points = append(out.Points, &models.MetricTrendPoint{
+			Period: p.period,
+			Value:  p.value,
+			Change: p.change,
+		})
+	}
+	return out, nil
+}
+
+// rollupMetricValue returns the value of the trend metric in the rollup
+func rollupMetricValue(r *DailyRollup, metric string) (int64, error) {
+	switch metric {
+	case TrendMetricSignatures:
+		return r.IclaCount + r.CclaCount + r.EclaCount, nil
+	case TrendMetricIcla:
+		return r.IclaCount, nil
+	case TrendMetricCcla:
+		return r.CclaCount, nil
+	case TrendMetricEcla:
+		return r.EclaCount, nil
+	case TrendMetricContributors:
+		return r.ContributorsCount, nil
+	case TrendMetricClaManagers:
+		return r.ClaManagersCount, nil
+	case TrendMetricRepositories:
+		return r.RepositoriesCount, nil
+	default:
+		return 0, ErrInvalidTrendMetric
+	}
+}
+
+// trendPeriod returns the first day of the period of the granularity containing the date - weeks start on Monday
+func trendPeriod(date time.Time, granularity string) (string, error) {
+	switch granularity {
+	case GranularityDay:
+		return date.Format(RollupDateFormat), nil
+	case GranularityWeek:
+		offset := (int(date.Weekday()) + 6) % 7
+		return date.AddDate(0, 0, -offset).Format(RollupDateFormat), nil
+	case GranularityMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC).Format(RollupDateFormat), nil
+	default:
+		return "", ErrInvalidTrendGranularity
+	}
+}
+
+// bucketRollups groups the date ordered rollups by period. The value of a period is the metric at its last rollup and
+// the change is the difference with the previous period - for the first period, with its first rollup - so the changes
+// add up to the growth over the whole range.
+func bucketRollups(rollups []*DailyRollup, metric, granularity string) ([]*trendPoint, error) {
+	points := make([]*trendPoint, 0)
+	var previous int64
+	for i, r := range rollups {
+		date, err := time.Parse(RollupDateFormat, r.Date)
+		if err != nil {
+			return nil, err
+		}
+		period, err := trendPeriod(date, granularity)
+		if err != nil {
+			return nil, err
+		}
+		value, err := rollupMetricValue(r, metric)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			previous = value
+		}
+		if len(points) == 0 || points[len(points)-1].period != period {
+			if len(points) > 0 {
+				previous = points[len(points)-1].value
+			}
+			points = append(points, &trendPoint{period: period})
+		}
+		last := points[len(points)-1]
+		last.value = value
+		last.change = value - previous
+	}
+	return points, nil
+}
@@ -0,0 +1,59 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketRollups(t *testing.T) {
+	rollups := []*DailyRollup{
+		{Date: "2020-06-26", IclaCount: 10, CclaCount: 2}, // Friday
+		{Date: "2020-06-28", IclaCount: 11, CclaCount: 2}, // Sunday
+		{Date: "2020-06-29", IclaCount: 11, CclaCount: 4}, // Monday
+		{Date: "2020-07-03", IclaCount: 15, CclaCount: 5},
+	}
+
+	points, err := bucketRollups(rollups, TrendMetricCcla, GranularityWeek)
+	assert.Nil(t, err)
+	assert.Equal(t, []*trendPoint{
+		{period: "2020-06-22", value: 2, change: 0},
+		{period: "2020-06-29", value: 5, change: 3},
+	}, points)
+
+	points, err = bucketRollups(rollups, TrendMetricSignatures, GranularityMonth)
+	assert.Nil(t, err)
+	assert.Equal(t, []*trendPoint{
+		{period: "2020-06-01", value: 15, change: 3},
+		{period: "2020-07-01", value: 20, change: 5},
+	}, points)
+
+	_, err = bucketRollups(rollups, "commits", GranularityDay)
+	assert.Equal(t, ErrInvalidTrendMetric, err)
+}
+
+func TestProjectRollups(t *testing.T) {
+	claGroupMetrics := []*ProjectMetric{
+		{ID: "cla-group-1", CompaniesCount: 2, RepositoriesCount: 3},
+		{ID: "cla-group-2", CompaniesCount: 5, RepositoriesCount: 1},
+	}
+	pcgs := []*projects_cla_groups.ProjectClaGroup{
+		{ProjectSFID: "project-1", FoundationSFID: "foundation-1", ClaGroupID: "cla-group-1"},
+		{ProjectSFID: "project-2", FoundationSFID: "foundation-1", ClaGroupID: "cla-group-2"},
+		{ProjectSFID: "project-3", FoundationSFID: "foundation-1", ClaGroupID: "cla-group-2"},
+	}
+
+	rollups := make(map[string]*DailyRollup)
+	for _, r := range projectRollups(claGroupMetrics, pcgs, "2020-07-01") {
+		rollups[r.ID] = r
+	}
+	assert.Len(t, rollups, 4)
+	assert.Equal(t, int64(2), rollups["project#project-1"].CclaCount)
+	assert.Equal(t, int64(5), rollups["project#project-3"].CclaCount)
+	assert.Equal(t, int64(7), rollups["project#foundation-1"].CclaCount)
+	assert.Equal(t, int64(4), rollups["project#foundation-1"].RepositoriesCount)
+}
@@ -0,0 +1,191 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/notifications"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Notifications "github.com/communitybridge/easycla/cla-backend-go/notifications"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setup the notification template handlers
+func Configure(api *operations.EasyclaAPI, service v1Notifications.Service, v1ProjectService v1Project.Service) {
+	api.NotificationsGetNotificationTemplatesHandler = notifications.GetNotificationTemplatesHandlerFunc(
+		func(params notifications.GetNotificationTemplatesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "NotificationsGetNotificationTemplatesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return notifications.NewGetNotificationTemplatesNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return notifications.NewGetNotificationTemplatesInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return notifications.NewGetNotificationTemplatesForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to GetNotificationTemplates with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			overrides, err := service.GetTemplateOverrides(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to load notification templates, error: %+v", err)
+				return notifications.NewGetNotificationTemplatesInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result := &models.NotificationTemplateList{Templates: make([]*models.NotificationTemplate, 0, len(overrides))}
+			for _, override := range overrides {
+				result.Templates = append(result.Templates, toNotificationTemplate(override))
+			}
+			return notifications.NewGetNotificationTemplatesOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.NotificationsPutNotificationTemplateHandler = notifications.PutNotificationTemplateHandlerFunc(
+		func(params notifications.PutNotificationTemplateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "NotificationsPutNotificationTemplateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"templateName":   params.TemplateName,
+				"locale":         params.Locale,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return notifications.NewPutNotificationTemplateNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return notifications.NewPutNotificationTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return notifications.NewPutNotificationTemplateForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to PutNotificationTemplate with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			if !v1Notifications.IsBuiltInTemplate(params.TemplateName) {
+				return notifications.NewPutNotificationTemplateNotFound().WithXRequestID(reqID).WithPayload(errorResponse(v1Notifications.ErrTemplateNotFound))
+			}
+
+			override, err := service.PutTemplateOverride(ctx, &v1Notifications.TemplateOverride{
+				ClaGroupID:   params.ClaGroupID,
+				TemplateName: params.TemplateName,
+				Locale:       params.Locale,
+				Subject:      params.Body.Subject,
+				HTMLBody:     params.Body.HTMLBody,
+				TextBody:     params.Body.TextBody,
+			})
+			if err != nil {
+				log.WithFields(f).Warnf("unable to store notification template, error: %+v", err)
+				return notifications.NewPutNotificationTemplateBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return notifications.NewPutNotificationTemplateOK().WithXRequestID(reqID).WithPayload(toNotificationTemplate(override))
+		})
+
+	api.NotificationsDeleteNotificationTemplateHandler = notifications.DeleteNotificationTemplateHandlerFunc(
+		func(params notifications.DeleteNotificationTemplateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "NotificationsDeleteNotificationTemplateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"templateName":   params.TemplateName,
+				"locale":         params.Locale,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return notifications.NewDeleteNotificationTemplateNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return notifications.NewDeleteNotificationTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return notifications.NewDeleteNotificationTemplateForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to DeleteNotificationTemplate with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			err = service.DeleteTemplateOverride(ctx, params.ClaGroupID, params.TemplateName, params.Locale)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to delete notification template, error: %+v", err)
+				return notifications.NewDeleteNotificationTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return notifications.NewDeleteNotificationTemplateNoContent().WithXRequestID(reqID)
+		})
+}
+
+// toNotificationTemplate converts the template override into the response model
+func toNotificationTemplate(override *v1Notifications.TemplateOverride) *models.NotificationTemplate {
+	return &models.NotificationTemplate{
+		ClaGroupID:   override.ClaGroupID,
+		TemplateName: override.TemplateName,
+		Locale:       override.Locale,
+		Subject:      override.Subject,
+		HTMLBody:     override.HTMLBody,
+		TextBody:     override.TextBody,
+		DateCreated:  override.DateCreated,
+		DateModified: override.DateModified,
+	}
+}
+
+type codedResponse interface {
+	Code() string
+}
+
+func errorResponse(err error) *models.ErrorResponse {
+	code := ""
+	if e, ok := err.(codedResponse); ok {
+		code = e.Code()
+	}
+
+	e := models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	return &e
+}
@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/privacy"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setup the data subject export and erasure API handlers
+func Configure(api *operations.EasyclaAPI, service Service, eventsService events.Service) {
+	api.PrivacyExportDataSubjectHandler = privacy.ExportDataSubjectHandlerFunc(
+		func(params privacy.ExportDataSubjectParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return privacy.NewExportDataSubjectForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "Export Data Subject"))
+			}
+
+			subject := &DataSubject{
+				LFUsername:     strings.TrimSpace(utils.StringValue(params.LfUsername)),
+				Email:          strings.TrimSpace(utils.StringValue(params.Email)),
+				GitHubUsername: strings.TrimSpace(utils.StringValue(params.GithubUsername)),
+			}
+			if err := subject.Validate(); err != nil {
+				return privacy.NewExportDataSubjectBadRequest().WithXRequestID(reqID).WithPayload(errorResponse("400", err))
+			}
+
+			result, err := service.ExportDataSubject(ctx, subject)
+			if err != nil {
+				if err == ErrDataSubjectNotFound {
+					return privacy.NewExportDataSubjectNotFound().WithXRequestID(reqID).WithPayload(errorResponse("404", err))
+				}
+				return privacy.NewExportDataSubjectInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.DataSubjectExported,
+				LfUsername: authUser.UserName,
+				EventData: &events.DataSubjectExportedEventData{
+					UserIDs: result.UserIDs,
+					RecordCount: len(result.Users) + len(result.Signatures) + len(result.ApprovalListMemberships) +
+						len(result.ClaManagerRoles) + len(result.CompanyInvites) + len(result.ApprovalRequests) +
+						len(result.ClaManagerRequests) + len(result.Events) + len(result.SubjectEvents) + len(result.ResignSigners) +
+						len(result.PendingSignatures) + len(result.DomainVerifications) + len(result.WebhookDeliveries),
+				},
+			})
+
+			return privacy.NewExportDataSubjectOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.PrivacyEraseDataSubjectHandler = privacy.EraseDataSubjectHandlerFunc(
+		func(params privacy.EraseDataSubjectParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return privacy.NewEraseDataSubjectForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "Erase Data Subject"))
+			}
+
+			subject := &DataSubject{
+				LFUsername:     strings.TrimSpace(params.Body.LfUsername),
+				Email:          strings.TrimSpace(params.Body.Email.String()),
+				GitHubUsername: strings.TrimSpace(params.Body.GithubUsername),
+				Reason:         strings.TrimSpace(params.Body.Reason),
+			}
+			if err := subject.Validate(); err != nil {
+				return privacy.NewEraseDataSubjectBadRequest().WithXRequestID(reqID).WithPayload(errorResponse("400", err))
+			}
+
+			result, err := service.RequestErasure(ctx, subject, authUser.UserName)
+			if err != nil {
+				return privacy.NewEraseDataSubjectInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			return privacy.NewEraseDataSubjectAccepted().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.PrivacyGetDataSubjectErasureHandler = privacy.GetDataSubjectErasureHandlerFunc(
+		func(params privacy.GetDataSubjectErasureParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return privacy.NewGetDataSubjectErasureForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "Get Data Subject Erasure"))
+			}
+
+			result, err := service.GetErasure(ctx, params.ErasureID)
+			if err != nil {
+				if err == ErrErasureNotFound {
+					return privacy.NewGetDataSubjectErasureNotFound().WithXRequestID(reqID).WithPayload(errorResponse("404", err))
+				}
+				return privacy.NewGetDataSubjectErasureInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			return privacy.NewGetDataSubjectErasureOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
+
+func forbidden(authUser *auth.User, operation string) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code: "403",
+		Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to %s - only Admins are allowed to access the personal data of a data subject.",
+			authUser.UserName, operation),
+	}
+}
+
+func errorResponse(code string, err error) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package privacy
+
+import (
+	"errors"
+	"strings"
+)
+
+// errors
+var (
+	ErrDataSubjectInput    = errors.New("at least one of the LF username, email or GitHub username is required")
+	ErrDataSubjectNotFound = errors.New("no records found for the data subject")
+	ErrErasureNotFound     = errors.New("erasure not found")
+)
+
+// erasure status values
+const (
+	ErasureStatusPending   = "pending"
+	ErasureStatusRunning   = "running"
+	ErasureStatusCompleted = "completed"
+	ErasureStatusNotFound  = "not_found"
+	ErasureStatusFailed    = "failed"
+)
+
+// Erasure is a requested erasure of the personal data of a data subject - the erasure scans most of the tables, it is
+// processed by the privacy erasure lambda. The identities of the data subject are cleared once the erasure is done,
+// the result only holds the pseudonymous user IDs.
+type Erasure struct {
+	ErasureID      string `json:"erasure_id"`
+	Status         string `json:"status"`
+	LFUsername     string `json:"lf_username,omitempty"`
+	Email          string `json:"email,omitempty"`
+	GitHubUsername string `json:"github_username,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	RequestedBy    string `json:"requested_by"`
+	Attempts       int64  `json:"attempts"`
+	LastError      string `json:"last_error,omitempty"`
+	// Result is the JSON encoded outcome of the completed erasure
+	Result        string `json:"result,omitempty"`
+	DateCreated   string `json:"date_created"`
+	DateModified  string `json:"date_modified"`
+	DateCompleted string `json:"date_completed,omitempty"`
+}
+
+// subject returns the data subject of the erasure
+func (e *Erasure) subject() *DataSubject {
+	return &DataSubject{
+		LFUsername:     e.LFUsername,
+		Email:          e.Email,
+		GitHubUsername: e.GitHubUsername,
+		Reason:         e.Reason,
+	}
+}
+
+// clearSubject removes the identities of the data subject from the completed erasure
+func (e *Erasure) clearSubject() {
+	e.LFUsername = ""
+	e.Email = ""
+	e.GitHubUsername = ""
+}
+
+// DataSubject identifies the person whose records are exported or erased
+type DataSubject struct {
+	LFUsername     string
+	Email          string
+	GitHubUsername string
+	Reason         string
+}
+
+// Validate checks that at least one identity of the data subject is provided
+func (in *DataSubject) Validate() error {
+	if in == nil || (in.LFUsername == "" && in.Email == "" && in.GitHubUsername == "") {
+		return ErrDataSubjectInput
+	}
+	return nil
+}
+
+// Identity holds all the identities of the data subject - the identities provided by the request, widened with the
+// ones of the user records found
+type Identity struct {
+	UserIDs         []string
+	LFUsernames     []string
+	Emails          []string
+	GitHubUsernames []string
+}
+
+// newIdentity returns the identities provided by the request
+func newIdentity(subject *DataSubject) *Identity {
+	identity := &Identity{}
+	identity.addLFUsername(subject.LFUsername)
+	identity.addEmail(subject.Email)
+	identity.addGitHubUsername(subject.GitHubUsername)
+	return identity
+}
+
+// addUser adds the identities of the user record
+func (identity *Identity) addUser(user Record) {
+	identity.UserIDs = appendUnique(identity.UserIDs, user.String("user_id"))
+	identity.addLFUsername(user.String("lf_username"))
+	identity.addEmail(user.String("lf_email"))
+	for _, email := range user.Strings("user_emails") {
+		identity.addEmail(email)
+	}
+	identity.addGitHubUsername(user.String("user_github_username"))
+}
+
+func (identity *Identity) addLFUsername(lfUsername string) {
+	identity.LFUsernames = appendUnique(identity.LFUsernames, strings.TrimSpace(lfUsername))
+}
+
+// addEmail adds the email as provided and in lower case, the lookups are case sensitive
+func (identity *Identity) addEmail(email string) {
+	email = strings.TrimSpace(email)
+	identity.Emails = appendUnique(identity.Emails, email)
+	identity.Emails = appendUnique(identity.Emails, strings.ToLower(email))
+}
+
+func (identity *Identity) addGitHubUsername(gitHubUsername string) {
+	identity.GitHubUsernames = appendUnique(identity.GitHubUsernames, strings.TrimSpace(gitHubUsername))
+}
+
+// Record is a stored record with its attributes
+type Record map[string]interface{}
+
+// String returns the string attribute, empty if it is missing or not a string
+func (r Record) String(name string) string {
+	value, _ := r[name].(string)
+	return value
+}
+
+// Bool returns the boolean attribute, false if it is missing or not a boolean
+func (r Record) Bool(name string) bool {
+	value, _ := r[name].(bool)
+	return value
+}
+
+// Strings returns the string list or string set attribute
+func (r Record) Strings(name string) []string {
+	switch values := r[name].(type) {
+	case []string:
+		return values
+	case []interface{}:
+		var result []string
+		for _, value := range values {
+			if s, ok := value.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// Maps returns the list of maps attribute
+func (r Record) Maps(name string) []Record {
+	values, _ := r[name].([]interface{})
+	var result []Record
+	for _, value := range values {
+		if m, ok := value.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// appendUnique appends the value when it is not empty and not in the list yet
+func appendUnique(list []string, value string) []string {
+	if value == "" {
+		return list
+	}
+	for _, item := range list {
+		if item == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,442 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package privacy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+)
+
+// tables holding personal data
+const (
+	UsersTable              = "users"
+	SignaturesTable         = "signatures"
+	CompaniesTable          = "companies"
+	CompanyInvitesTable     = "company-invites"
+	ApprovalRequestsTable   = "ccla-whitelist-requests"
+	ClaManagerRequestsTable = "cla-manager-requests"
+	EventsTable             = "events"
+	ResignSignersTable      = "resign-signers"
+	PendingSignaturesTable  = "pending-signatures"
+	WebhookDeliveriesTable  = "webhook-deliveries"
+	ErasuresTable           = "privacy-erasures"
+)
+
+// tableKeys are the primary key attributes of the tables holding personal data
+var tableKeys = map[string][]string{
+	UsersTable:              {"user_id"},
+	SignaturesTable:         {"signature_id"},
+	CompaniesTable:          {"company_id"},
+	CompanyInvitesTable:     {"company_invite_id"},
+	ApprovalRequestsTable:   {"request_id"},
+	ClaManagerRequestsTable: {"request_id"},
+	EventsTable:             {"event_id"},
+	ResignSignersTable:      {"campaign_id", "signature_id"},
+	PendingSignaturesTable:  {"request_id"},
+	WebhookDeliveriesTable:  {"delivery_id"},
+}
+
+// the index of the erasures by status
+const erasureStatusIndex = "status-date-created-index"
+
+// Repository defines the lookups and the redaction of the records holding the personal data of a data subject - the
+// tables have no index for most of the identities, the lookups scan the tables
+type Repository interface {
+	FindUsers(identity *Identity) ([]Record, error)
+	FindSignatures(identity *Identity) ([]Record, error)
+	FindCompanies(identity *Identity) ([]Record, error)
+	FindCompanyInvites(identity *Identity) ([]Record, error)
+	FindApprovalRequests(identity *Identity) ([]Record, error)
+	FindClaManagerRequests(identity *Identity) ([]Record, error)
+	FindEvents(identity *Identity) ([]Record, error)
+	FindResignSigners(identity *Identity) ([]Record, error)
+	FindPendingSignatures(identity *Identity) ([]Record, error)
+	FindDomainVerifications(identity *Identity) ([]Record, error)
+	FindWebhookDeliveries(identity *Identity) ([]Record, error)
+
+	RedactRecord(table string, record Record, set map[string]interface{}, remove []string) error
+	DeleteRecord(table string, record Record) error
+
+	PutErasure(erasure *Erasure) error
+	GetErasure(erasureID string) (*Erasure, error)
+	GetErasuresByStatus(status string) ([]*Erasure, error)
+	ClaimErasure(erasureID, now, staleBefore string) (bool, error)
+}
+
+type repo struct {
+	stage          string
+	dynamoDBClient storage.DynamoDBAPI
+}
+
+// NewRepository creates a new privacy repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repo{
+		stage:          stage,
+		dynamoDBClient: storage.NewClient(awsSession),
+	}
+}
+
+// FindUsers returns the user records matching any of the identities
+func (repo *repo) FindUsers(identity *Identity) ([]Record, error) {
+	return repo.find(UsersTable,
+		equalsAny("user_id", identity.UserIDs),
+		equalsAny("lf_username", identity.LFUsernames),
+		equalsAny("lf_email", identity.Emails),
+		containsAny("user_emails", identity.Emails),
+		equalsAny("user_github_username", identity.GitHubUsernames))
+}
+
+// FindSignatures returns the signatures of the data subject, the CCLA signatures with the data subject in an approval
+// list and the CCLA signatures managed by the data subject
+func (repo *repo) FindSignatures(identity *Identity) ([]Record, error) {
+	return repo.find(SignaturesTable,
+		equalsAny("signature_reference_id", identity.UserIDs),
+		containsAny("email_whitelist", identity.Emails),
+		containsAny("github_whitelist", identity.GitHubUsernames),
+		containsAny("signature_acl", identity.LFUsernames))
+}
+
+// FindCompanies returns the companies managed by the data subject
+func (repo *repo) FindCompanies(identity *Identity) ([]Record, error) {
+	return repo.find(CompaniesTable, containsAny("company_acl", identity.LFUsernames))
+}
+
+// FindCompanyInvites returns the company access requests of the data subject
+func (repo *repo) FindCompanyInvites(identity *Identity) ([]Record, error) {
+	return repo.find(CompanyInvitesTable, equalsAny("user_id", identity.UserIDs))
+}
+
+// FindApprovalRequests returns the requests of the data subject to be added to a CCLA approval list
+func (repo *repo) FindApprovalRequests(identity *Identity) ([]Record, error) {
+	return repo.find(ApprovalRequestsTable,
+		equalsAny("user_id", identity.UserIDs),
+		containsAny("user_emails", identity.Emails),
+		equalsAny("user_github_username", identity.GitHubUsernames))
+}
+
+// FindClaManagerRequests returns the requests of the data subject to become a CLA manager
+func (repo *repo) FindClaManagerRequests(identity *Identity) ([]Record, error) {
+	return repo.find(ClaManagerRequestsTable,
+		equalsAny("user_id", identity.UserIDs),
+		equalsAny("user_email", identity.Emails))
+}
+
+// FindEvents returns the events of the data subject and the events about the data subject - the events of other
+// users whose data holds the email or the user ID of the data subject
+func (repo *repo) FindEvents(identity *Identity) ([]Record, error) {
+	return repo.find(EventsTable,
+		equalsAny("event_user_id", identity.UserIDs),
+		equalsAny("event_lf_username", identity.LFUsernames),
+		containsAny("event_data", identity.Emails),
+		containsAny("event_data", identity.UserIDs),
+		containsAny("event_summary", identity.Emails))
+}
+
+// FindResignSigners returns the re-sign campaign signers of the data subject
+func (repo *repo) FindResignSigners(identity *Identity) ([]Record, error) {
+	return repo.find(ResignSignersTable,
+		equalsAny("reference_id", identity.UserIDs),
+		containsAny("emails", identity.Emails))
+}
+
+// FindPendingSignatures returns the corporate signature requests sent to or requested by the data subject
+func (repo *repo) FindPendingSignatures(identity *Identity) ([]Record, error) {
+	return repo.find(PendingSignaturesTable,
+		equalsAny("signatory_email", identity.Emails),
+		equalsAny("requested_by", identity.LFUsernames))
+}
+
+// FindDomainVerifications returns the companies with a domain verification requested by the data subject - the
+// requests are nested in the company records, the companies with domain verifications are filtered here
+func (repo *repo) FindDomainVerifications(identity *Identity) ([]Record, error) {
+	if len(identity.LFUsernames) == 0 {
+		return nil, nil
+	}
+	companies, err := repo.find(CompaniesTable, []expression.ConditionBuilder{expression.Name("domain_verifications").AttributeExists()})
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	for _, company := range companies {
+		for _, verification := range company.Maps("domain_verifications") {
+			if containsFold(identity.LFUsernames, verification.String("requested_by")) {
+				records = append(records, company)
+				break
+			}
+		}
+	}
+	return records, nil
+}
+
+// FindWebhookDeliveries returns the webhook deliveries with a payload about the data subject
+func (repo *repo) FindWebhookDeliveries(identity *Identity) ([]Record, error) {
+	return repo.find(WebhookDeliveriesTable,
+		containsAny("payload", identity.Emails),
+		containsAny("payload", jsonFields("userID", identity.UserIDs)),
+		containsAny("payload", jsonFields("lfUsername", identity.LFUsernames)))
+}
+
+// RedactRecord replaces the attributes of the record with the values and removes the other attributes, the record is
+// left untouched if it no longer exists
+func (repo *repo) RedactRecord(table string, record Record, set map[string]interface{}, remove []string) error {
+	var update expression.UpdateBuilder
+	for name, value := range set {
+		update = update.Set(expression.Name(name), expression.Value(value))
+	}
+	for _, name := range remove {
+		update = update.Remove(expression.Name(name))
+	}
+	keyNames := tableKeys[table]
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(expression.Name(keyNames[0]).AttributeExists()).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 aws.String(repo.tableName(table)),
+		Key:                       recordKey(table, record),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil
+		}
+		log.Warnf("error redacting the %s record: %s, error: %v", table, recordID(table, record), err)
+		return err
+	}
+	return nil
+}
+
+// DeleteRecord deletes the record
+func (repo *repo) DeleteRecord(table string, record Record) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.tableName(table)),
+		Key:       recordKey(table, record),
+	})
+	if err != nil {
+		log.Warnf("error deleting the %s record: %s, error: %v", table, recordID(table, record), err)
+		return err
+	}
+	return nil
+}
+
+// PutErasure stores the erasure
+func (repo *repo) PutErasure(erasure *Erasure) error {
+	item, err := dynamodbattribute.MarshalMap(erasure)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(repo.tableName(ErasuresTable)),
+		Item:      item,
+	})
+	if err != nil {
+		log.Warnf("error storing the erasure: %s, error: %v", erasure.ErasureID, err)
+		return err
+	}
+	return nil
+}
+
+// GetErasure returns the erasure, ErrErasureNotFound if it does not exist
+func (repo *repo) GetErasure(erasureID string) (*Erasure, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.tableName(ErasuresTable)),
+		Key:       map[string]*dynamodb.AttributeValue{"erasure_id": {S: aws.String(erasureID)}},
+	})
+	if err != nil {
+		log.Warnf("error fetching the erasure: %s, error: %v", erasureID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrErasureNotFound
+	}
+	var erasure Erasure
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &erasure); err != nil {
+		return nil, err
+	}
+	return &erasure, nil
+}
+
+// GetErasuresByStatus returns the erasures with the status, the oldest first
+func (repo *repo) GetErasuresByStatus(status string) ([]*Erasure, error) {
+	keyCondition := expression.Key("status").Equal(expression.Value(status))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, err
+	}
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName(ErasuresTable)),
+		IndexName:                 aws.String(erasureStatusIndex),
+	}
+
+	var erasures []*Erasure
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.Warnf("error querying the %s erasures, error: %v", status, err)
+			return nil, err
+		}
+		var page []*Erasure
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			return nil, err
+		}
+		erasures = append(erasures, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return erasures, nil
+}
+
+// ClaimErasure marks the pending erasure as running, or takes over a running erasure not updated since the stale
+// date - the erasure processed by a lambda which timed out. Returns false if the erasure was claimed by another run.
+func (repo *repo) ClaimErasure(erasureID, now, staleBefore string) (bool, error) {
+	update := expression.Set(expression.Name("status"), expression.Value(ErasureStatusRunning)).
+		Set(expression.Name("date_modified"), expression.Value(now)).
+		Add(expression.Name("attempts"), expression.Value(1))
+	condition := expression.Or(
+		expression.Name("status").Equal(expression.Value(ErasureStatusPending)),
+		expression.And(
+			expression.Name("status").Equal(expression.Value(ErasureStatusRunning)),
+			expression.Name("date_modified").LessThan(expression.Value(staleBefore))))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 aws.String(repo.tableName(ErasuresTable)),
+		Key:                       map[string]*dynamodb.AttributeValue{"erasure_id": {S: aws.String(erasureID)}},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		log.Warnf("error claiming the erasure: %s, error: %v", erasureID, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// recordKey returns the primary key of the record
+func recordKey(table string, record Record) map[string]*dynamodb.AttributeValue {
+	key := make(map[string]*dynamodb.AttributeValue)
+	for _, name := range tableKeys[table] {
+		key[name] = &dynamodb.AttributeValue{S: aws.String(record.String(name))}
+	}
+	return key
+}
+
+// recordID returns the primary key values of the record, used by the messages
+func recordID(table string, record Record) string {
+	var values []string
+	for _, name := range tableKeys[table] {
+		values = append(values, record.String(name))
+	}
+	return strings.Join(values, "/")
+}
+
+func (repo *repo) tableName(table string) string {
+	return fmt.Sprintf("cla-%s-%s", repo.stage, table)
+}
+
+// find scans the table for the records matching any of the conditions, nothing matches without conditions
+func (repo *repo) find(table string, conditions ...[]expression.ConditionBuilder) ([]Record, error) {
+	var all []expression.ConditionBuilder
+	for _, c := range conditions {
+		all = append(all, c...)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	filter := all[0]
+	if len(all) > 1 {
+		filter = expression.Or(all[0], all[1], all[2:]...)
+	}
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.tableName(table)),
+	}
+
+	var records []Record
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.Warnf("error scanning the %s table for the data subject, error: %v", table, err)
+			return nil, err
+		}
+
+		for _, item := range results.Items {
+			var record Record
+			err = dynamodbattribute.UnmarshalMap(item, &record)
+			if err != nil {
+				log.Warnf("error unmarshalling the %s record, error: %v", table, err)
+				return nil, err
+			}
+			records = append(records, record)
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return records, nil
+}
+
+// equalsAny matches the attribute against the values
+func equalsAny(name string, values []string) []expression.ConditionBuilder {
+	var conditions []expression.ConditionBuilder
+	for _, value := range values {
+		conditions = append(conditions, expression.Name(name).Equal(expression.Value(value)))
+	}
+	return conditions
+}
+
+// jsonFields returns the JSON encoded string fields with the values, matched in the JSON payloads
+func jsonFields(name string, values []string) []string {
+	var fields []string
+	for _, value := range values {
+		fields = append(fields, fmt.Sprintf("%q:%q", name, value))
+	}
+	return fields
+}
+
+// containsAny matches the list or set attribute containing any of the values, or the string attribute containing any
+// of the values
+func containsAny(name string, values []string) []expression.ConditionBuilder {
+	var conditions []expression.ConditionBuilder
+	for _, value := range values {
+		conditions = append(conditions, expression.Name(name).Contains(value))
+	}
+	return conditions
+}
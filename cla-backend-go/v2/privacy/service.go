@@ -0,0 +1,789 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package privacy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CLA manager role scopes
+const (
+	RoleScopeSignature = "signature"
+	RoleScopeCompany   = "company"
+)
+
+// constants
+const (
+	// RedactedValue replaces the names and the event data of the erased data subject
+	RedactedValue = "redacted"
+
+	// RetainedRoleReason explains why a CLA manager role is not removed by the erasure
+	RetainedRoleReason = "the data subject is the only CLA manager - assign another CLA manager and erase again"
+
+	// ErasedDeliveryError is the last error of the pending webhook deliveries whose payload was erased
+	ErasedDeliveryError = "the payload was erased with the personal data of a data subject"
+
+	// ErasureLease is how long a running erasure is left to its lambda before another run takes it over - longer than
+	// the timeout of the privacy erasure lambda
+	ErasureLease = 20 * time.Minute
+
+	// MaxErasureAttempts is the number of attempts before an erasure is failed
+	MaxErasureAttempts = 3
+)
+
+// the personal data attributes removed by the erasure - the IDs are kept, they are pseudonymous and link the records
+var (
+	userPIIAttributes      = []string{"lf_email", "lf_username", "user_emails", "user_external_id", "user_github_id", "user_github_username"}
+	signaturePIIAttributes = []string{"signatory_name", "user_email", "user_github_username", "user_lf_username", "user_name"}
+	eventPIIAttributes     = []string{"event_lf_username"}
+)
+
+// the exported attributes of the domain verifications requested by the data subject - the token is a secret
+var domainVerificationAttributes = []string{"domain", "method", "status", "requested_by", "date_created", "date_verified"}
+
+// SignatureRepo contains the signature repo methods
+type SignatureRepo interface {
+	GetSignature(ctx context.Context, signatureID string) (*v1Models.Signature, error)
+	UpdateApprovalList(ctx context.Context, projectID, companyID string, params *v1Models.ApprovalList) (*v1Models.Signature, error)
+	UpdateApprovalListEntries(ctx context.Context, signatureID string, version int64, entries []*v1Models.ApprovalListEntry) error
+	RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*v1Models.Signature, error)
+}
+
+// CompanyRepo contains the company repo methods
+type CompanyRepo interface {
+	UpdateCompanyAccessList(ctx context.Context, companyID string, companyACL []string) error
+}
+
+// Service defines the data subject export and erasure functions
+type Service interface {
+	ExportDataSubject(ctx context.Context, subject *DataSubject) (*models.DataSubjectExport, error)
+	EraseDataSubject(ctx context.Context, subject *DataSubject) (*models.DataSubjectErasure, error)
+	RequestErasure(ctx context.Context, subject *DataSubject, requestedBy string) (*models.DataSubjectErasureJob, error)
+	GetErasure(ctx context.Context, erasureID string) (*models.DataSubjectErasureJob, error)
+	ProcessPendingErasures(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo          Repository
+	signatureRepo SignatureRepo
+	companyRepo   CompanyRepo
+	eventsService events.Service
+}
+
+// NewService creates a new privacy service
+func NewService(repo Repository, signatureRepo SignatureRepo, companyRepo CompanyRepo, eventsService events.Service) Service {
+	return &service{
+		repo:          repo,
+		signatureRepo: signatureRepo,
+		companyRepo:   companyRepo,
+		eventsService: eventsService,
+	}
+}
+
+// collection holds the records of the data subject
+type collection struct {
+	identity *Identity
+
+	users      []Record
+	signatures []Record
+	events     []Record
+	// subjectEvents are the events of other users about the data subject
+	subjectEvents []Record
+
+	// approvalListSignatures are the CCLA signatures with the data subject in an approval list
+	approvalListSignatures []Record
+	// managedSignatures and managedCompanies are the CCLA signatures and the companies managed by the data subject
+	managedSignatures []Record
+	managedCompanies  []Record
+
+	companyInvites     []Record
+	approvalRequests   []Record
+	claManagerRequests []Record
+
+	resignSigners []Record
+	// pendingSignatures are the corporate signature requests sent to the data subject, requestedSignatures the ones
+	// requested by the data subject
+	pendingSignatures   []Record
+	requestedSignatures []Record
+	// verificationCompanies are the companies with a domain verification requested by the data subject
+	verificationCompanies []Record
+	webhookDeliveries     []Record
+}
+
+// recordCount returns the number of records held about the data subject
+func (c *collection) recordCount() int {
+	return len(c.users) + len(c.signatures) + len(c.events) + len(c.subjectEvents) + len(c.approvalListSignatures) +
+		len(c.managedSignatures) + len(c.managedCompanies) + len(c.companyInvites) + len(c.approvalRequests) +
+		len(c.claManagerRequests) + len(c.resignSigners) + len(c.pendingSignatures) + len(c.requestedSignatures) +
+		len(c.verificationCompanies) + len(c.webhookDeliveries)
+}
+
+// ExportDataSubject returns everything held about the data subject
+func (s *service) ExportDataSubject(ctx context.Context, subject *DataSubject) (*models.DataSubjectExport, error) {
+	c, err := s.collect(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	_, now := utils.CurrentTime()
+	export := &models.DataSubjectExport{
+		LfUsername:         subject.LFUsername,
+		Email:              subject.Email,
+		GithubUsername:     subject.GitHubUsername,
+		UserIDs:            c.identity.UserIDs,
+		DateGenerated:      now,
+		Users:              toMaps(c.users),
+		Signatures:         toMaps(c.signatures),
+		CompanyInvites:     toMaps(c.companyInvites),
+		ApprovalRequests:   toMaps(c.approvalRequests),
+		ClaManagerRequests: toMaps(c.claManagerRequests),
+		Events:             toMaps(c.events),
+		SubjectEvents:      toMaps(c.subjectEvents),
+		ResignSigners:      toMaps(c.resignSigners),
+		PendingSignatures:  toMaps(append(append([]Record{}, c.pendingSignatures...), c.requestedSignatures...)),
+		WebhookDeliveries:  toMaps(c.webhookDeliveries),
+	}
+	for _, company := range c.verificationCompanies {
+		export.DomainVerifications = append(export.DomainVerifications, toMaps(domainVerifications(company, c.identity))...)
+	}
+	for _, sig := range c.approvalListSignatures {
+		export.ApprovalListMemberships = append(export.ApprovalListMemberships, approvalListMemberships(sig, c.identity)...)
+	}
+	for _, sig := range c.managedSignatures {
+		managers, _ := partition(sig.Strings("signature_acl"), c.identity.LFUsernames)
+		export.ClaManagerRoles = append(export.ClaManagerRoles, signatureRoles(sig, managers)...)
+	}
+	for _, company := range c.managedCompanies {
+		managers, _ := partition(company.Strings("company_acl"), c.identity.LFUsernames)
+		export.ClaManagerRoles = append(export.ClaManagerRoles, companyRoles(company, managers)...)
+	}
+	return export, nil
+}
+
+// EraseDataSubject redacts the personal data of the data subject - the signatures are legally required and are kept
+// in pseudonymized form. The user records are redacted last, an erasure which fails half way can be retried with the
+// same identities.
+func (s *service) EraseDataSubject(ctx context.Context, subject *DataSubject) (*models.DataSubjectErasure, error) {
+	f := logrus.Fields{
+		"functionName":   "EraseDataSubject",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	c, err := s.collect(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(f).Debugf("erasing %d records of the data subject with the user IDs: %s",
+		c.recordCount(), strings.Join(c.identity.UserIDs, ","))
+
+	_, now := utils.CurrentTime()
+	result := &models.DataSubjectErasure{
+		UserIDs:    c.identity.UserIDs,
+		DateErased: now,
+	}
+
+	if err = s.removeApprovalListMemberships(ctx, c, result); err != nil {
+		return nil, err
+	}
+	if err = s.removeClaManagerRoles(ctx, c, result); err != nil {
+		return nil, err
+	}
+
+	for _, request := range []struct {
+		table   string
+		records []Record
+	}{
+		{CompanyInvitesTable, c.companyInvites},
+		{ApprovalRequestsTable, c.approvalRequests},
+		{ClaManagerRequestsTable, c.claManagerRequests},
+		{PendingSignaturesTable, c.pendingSignatures},
+	} {
+		for _, record := range request.records {
+			if err = s.repo.DeleteRecord(request.table, record); err != nil {
+				return nil, err
+			}
+			result.RequestsDeleted++
+		}
+	}
+	for _, request := range c.requestedSignatures {
+		if err = s.repo.RedactRecord(PendingSignaturesTable, request, map[string]interface{}{"requested_by": RedactedValue}, nil); err != nil {
+			return nil, err
+		}
+		result.PendingSignaturesRedacted++
+	}
+
+	if err = s.redactRelatedRecords(c, result); err != nil {
+		return nil, err
+	}
+
+	for _, event := range c.events {
+		name := pseudonym(event.String("event_user_id"))
+		set := map[string]interface{}{"event_user_name": name, "event_user_name_lower": name}
+		if event.Bool("contains_pii") || mentionsAny(event, c.identity.Emails, "event_data", "event_summary") {
+			set["event_data"] = RedactedValue
+			set["event_summary"] = RedactedValue
+		}
+		if err = s.repo.RedactRecord(EventsTable, event, set, eventPIIAttributes); err != nil {
+			return nil, err
+		}
+		result.EventsRedacted++
+	}
+	for _, event := range c.subjectEvents {
+		set := map[string]interface{}{"event_data": RedactedValue, "event_summary": RedactedValue}
+		if err = s.repo.RedactRecord(EventsTable, event, set, nil); err != nil {
+			return nil, err
+		}
+		result.EventsRedacted++
+	}
+
+	for _, sig := range c.signatures {
+		name := pseudonym(sig.String("signature_reference_id"))
+		set := map[string]interface{}{"signature_reference_name": name, "signature_reference_name_lower": name}
+		if err = s.repo.RedactRecord(SignaturesTable, sig, set, signaturePIIAttributes); err != nil {
+			return nil, err
+		}
+		result.SignaturesPreserved++
+	}
+
+	for _, user := range c.users {
+		userID := user.String("user_id")
+		set := map[string]interface{}{"user_name": pseudonym(userID), "note": fmt.Sprintf("personal data erased on %s", now)}
+		if err = s.repo.RedactRecord(UsersTable, user, set, userPIIAttributes); err != nil {
+			return nil, err
+		}
+		result.UsersRedacted++
+	}
+
+	return result, nil
+}
+
+// redactRelatedRecords redacts the data subject from the re-sign campaign signers, the domain verifications of the
+// companies and the payloads of the webhook deliveries
+func (s *service) redactRelatedRecords(c *collection, result *models.DataSubjectErasure) error {
+	for _, signer := range c.resignSigners {
+		set := map[string]interface{}{}
+		var remove []string
+		if containsFold(c.identity.UserIDs, signer.String("reference_id")) {
+			set["name"] = pseudonym(signer.String("reference_id"))
+			remove = append(remove, "emails")
+		} else if _, others := partition(signer.Strings("emails"), c.identity.Emails); len(others) > 0 {
+			set["emails"] = others
+		} else {
+			remove = append(remove, "emails")
+		}
+		if err := s.repo.RedactRecord(ResignSignersTable, signer, set, remove); err != nil {
+			return err
+		}
+		result.ResignSignersRedacted++
+	}
+
+	for _, company := range c.verificationCompanies {
+		var verifications []interface{}
+		for _, verification := range company.Maps("domain_verifications") {
+			if containsFold(c.identity.LFUsernames, verification.String("requested_by")) {
+				verification["requested_by"] = RedactedValue
+				result.DomainVerificationsRedacted++
+			}
+			verifications = append(verifications, map[string]interface{}(verification))
+		}
+		set := map[string]interface{}{"domain_verifications": verifications}
+		if err := s.repo.RedactRecord(CompaniesTable, company, set, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, delivery := range c.webhookDeliveries {
+		set := map[string]interface{}{"payload": RedactedValue}
+		var remove []string
+		if delivery.String("status") == webhooks.DeliveryStatusPending {
+			// the redacted payload is not delivered, the delivery is dead lettered
+			set["status"] = webhooks.DeliveryStatusDeadLetter
+			set["last_error"] = ErasedDeliveryError
+			remove = append(remove, "next_attempt_at")
+		}
+		if err := s.repo.RedactRecord(WebhookDeliveriesTable, delivery, set, remove); err != nil {
+			return err
+		}
+		result.WebhookDeliveriesRedacted++
+	}
+	return nil
+}
+
+// RequestErasure records the erasure of the personal data of the data subject, processed by the privacy erasure lambda
+func (s *service) RequestErasure(ctx context.Context, subject *DataSubject, requestedBy string) (*models.DataSubjectErasureJob, error) {
+	if err := subject.Validate(); err != nil {
+		return nil, err
+	}
+	erasureID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	_, now := utils.CurrentTime()
+	erasure := &Erasure{
+		ErasureID:      erasureID.String(),
+		Status:         ErasureStatusPending,
+		LFUsername:     subject.LFUsername,
+		Email:          subject.Email,
+		GitHubUsername: subject.GitHubUsername,
+		Reason:         subject.Reason,
+		RequestedBy:    requestedBy,
+		DateCreated:    now,
+		DateModified:   now,
+	}
+	if err = s.repo.PutErasure(erasure); err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"functionName":   "RequestErasure",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"erasureID":      erasure.ErasureID,
+	}).Debug("erasure of the data subject requested")
+	return toErasureJob(erasure), nil
+}
+
+// GetErasure returns the erasure and its outcome once completed
+func (s *service) GetErasure(ctx context.Context, erasureID string) (*models.DataSubjectErasureJob, error) {
+	erasure, err := s.repo.GetErasure(erasureID)
+	if err != nil {
+		return nil, err
+	}
+	return toErasureJob(erasure), nil
+}
+
+// ProcessPendingErasures erases the data subjects of the pending erasures and of the running erasures whose lease
+// expired - the erasures are processed one at a time, they scan most of the tables. Returns the number of processed
+// erasures.
+func (s *service) ProcessPendingErasures(ctx context.Context) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessPendingErasures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	now, nowStr := utils.CurrentTime()
+	staleBefore := utils.TimeToString(now.Add(-ErasureLease))
+
+	erasures, err := s.repo.GetErasuresByStatus(ErasureStatusPending)
+	if err != nil {
+		return 0, err
+	}
+	running, err := s.repo.GetErasuresByStatus(ErasureStatusRunning)
+	if err != nil {
+		return 0, err
+	}
+	for _, erasure := range running {
+		if erasure.DateModified < staleBefore {
+			erasures = append(erasures, erasure)
+		}
+	}
+
+	processed := 0
+	for _, erasure := range erasures {
+		claimed, err := s.repo.ClaimErasure(erasure.ErasureID, nowStr, staleBefore)
+		if err != nil {
+			return processed, err
+		}
+		if !claimed {
+			log.WithFields(f).Debugf("erasure %s was claimed by another run", erasure.ErasureID)
+			continue
+		}
+		erasure.Attempts++
+		if err = s.processErasure(ctx, erasure); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// processErasure erases the data subject of the erasure and records the outcome - a failed erasure is retried by the
+// next run until it runs out of attempts. The identities of the data subject are cleared once the erasure is done.
+func (s *service) processErasure(ctx context.Context, erasure *Erasure) error {
+	f := logrus.Fields{
+		"functionName":   "processErasure",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"erasureID":      erasure.ErasureID,
+		"attempts":       erasure.Attempts,
+	}
+
+	result, err := s.EraseDataSubject(ctx, erasure.subject())
+	_, now := utils.CurrentTime()
+	erasure.DateModified = now
+	switch {
+	case err == ErrDataSubjectNotFound:
+		erasure.Status = ErasureStatusNotFound
+		erasure.DateCompleted = now
+		erasure.clearSubject()
+	case err != nil:
+		log.WithFields(f).Warnf("unable to erase the data subject, error: %+v", err)
+		erasure.LastError = err.Error()
+		erasure.Status = ErasureStatusPending
+		if erasure.Attempts >= MaxErasureAttempts {
+			erasure.Status = ErasureStatusFailed
+		}
+	default:
+		content, jsonErr := json.Marshal(result)
+		if jsonErr != nil {
+			return jsonErr
+		}
+		erasure.Status = ErasureStatusCompleted
+		erasure.Result = string(content)
+		erasure.LastError = ""
+		erasure.DateCompleted = now
+		erasure.clearSubject()
+	}
+	if err = s.repo.PutErasure(erasure); err != nil {
+		return err
+	}
+
+	if erasure.Status == ErasureStatusCompleted {
+		// The event identifies the data subject by the pseudonymous user IDs only
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:  events.DataSubjectErased,
+			LfUsername: erasure.RequestedBy,
+			EventData: &events.DataSubjectErasedEventData{
+				UserIDs: result.UserIDs,
+				Reason:  erasure.Reason,
+				RedactedCount: int(result.UsersRedacted + result.EventsRedacted + result.SignaturesPreserved + result.ResignSignersRedacted +
+					result.PendingSignaturesRedacted + result.DomainVerificationsRedacted + result.WebhookDeliveriesRedacted),
+				RemovedCount:        int(result.ApprovalListEntriesRemoved + result.ClaManagerRolesRemoved),
+				DeletedCount:        int(result.RequestsDeleted),
+				RetainedRoleCount:   len(result.RetainedClaManagerRoles),
+				PreservedSignatures: int(result.SignaturesPreserved),
+			},
+		})
+	}
+	return nil
+}
+
+// removeApprovalListMemberships removes the data subject from the CCLA approval lists and drops the metadata of the
+// removed entries
+func (s *service) removeApprovalListMemberships(ctx context.Context, c *collection, result *models.DataSubjectErasure) error {
+	for _, sig := range c.approvalListSignatures {
+		signatureID := sig.String("signature_id")
+		emails, _ := partition(sig.Strings("email_whitelist"), c.identity.Emails)
+		gitHubUsernames, _ := partition(sig.Strings("github_whitelist"), c.identity.GitHubUsernames)
+		removal := &v1Models.ApprovalList{
+			RemoveEmailApprovalList:          emails,
+			RemoveGithubUsernameApprovalList: gitHubUsernames,
+		}
+		_, err := s.signatureRepo.UpdateApprovalList(ctx, sig.String("signature_project_id"), sig.String("signature_reference_id"), removal)
+		if err != nil {
+			log.Warnf("unable to remove the data subject from the approval list of signature: %s, error: %+v", signatureID, err)
+			return err
+		}
+		result.ApprovalListEntriesRemoved += int64(len(emails) + len(gitHubUsernames))
+
+		sigModel, err := s.signatureRepo.GetSignature(ctx, signatureID)
+		if err != nil {
+			return err
+		}
+		if sigModel == nil || len(sigModel.ApprovalListEntries) == 0 {
+			continue
+		}
+		var remaining []*v1Models.ApprovalListEntry
+		for _, entry := range sigModel.ApprovalListEntries {
+			if (entry.ListType == signatures.ApprovalListRuleEmail && containsFold(emails, entry.Value)) ||
+				(entry.ListType == signatures.ApprovalListRuleGitHubUsername && containsFold(gitHubUsernames, entry.Value)) {
+				continue
+			}
+			remaining = append(remaining, entry)
+		}
+		if len(remaining) != len(sigModel.ApprovalListEntries) {
+			if err = s.signatureRepo.UpdateApprovalListEntries(ctx, signatureID, sigModel.ApprovalListEntriesVersion, remaining); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeClaManagerRoles removes the data subject from the access control lists of the CCLA signatures and the
+// companies, unless no other CLA manager is left
+func (s *service) removeClaManagerRoles(ctx context.Context, c *collection, result *models.DataSubjectErasure) error {
+	for _, sig := range c.managedSignatures {
+		managers, others := partition(sig.Strings("signature_acl"), c.identity.LFUsernames)
+		if len(others) == 0 {
+			result.RetainedClaManagerRoles = append(result.RetainedClaManagerRoles, retained(signatureRoles(sig, managers))...)
+			continue
+		}
+		for _, manager := range managers {
+			if _, err := s.signatureRepo.RemoveCLAManager(ctx, sig.String("signature_id"), manager); err != nil {
+				return err
+			}
+			result.ClaManagerRolesRemoved++
+		}
+	}
+
+	for _, company := range c.managedCompanies {
+		managers, others := partition(company.Strings("company_acl"), c.identity.LFUsernames)
+		if len(others) == 0 {
+			result.RetainedClaManagerRoles = append(result.RetainedClaManagerRoles, retained(companyRoles(company, managers))...)
+			continue
+		}
+		if err := s.companyRepo.UpdateCompanyAccessList(ctx, company.String("company_id"), others); err != nil {
+			return err
+		}
+		result.ClaManagerRolesRemoved += int64(len(managers))
+	}
+	return nil
+}
+
+// collect looks up the records of the data subject - the identities of the user records found widen the lookup of
+// the other records
+func (s *service) collect(ctx context.Context, subject *DataSubject) (*collection, error) {
+	if err := subject.Validate(); err != nil {
+		return nil, err
+	}
+	f := logrus.Fields{
+		"functionName":   "collect",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	c := &collection{identity: newIdentity(subject)}
+	var err error
+	c.users, err = s.repo.FindUsers(c.identity)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range c.users {
+		c.identity.addUser(user)
+	}
+
+	sigs, err := s.repo.FindSignatures(c.identity)
+	if err != nil {
+		return nil, err
+	}
+	for _, sig := range sigs {
+		if sig.String("signature_reference_type") == signatures.ReferenceTypeUser && containsFold(c.identity.UserIDs, sig.String("signature_reference_id")) {
+			c.signatures = append(c.signatures, sig)
+			continue
+		}
+		if len(approvalListMemberships(sig, c.identity)) > 0 {
+			c.approvalListSignatures = append(c.approvalListSignatures, sig)
+		}
+		if managers, _ := partition(sig.Strings("signature_acl"), c.identity.LFUsernames); len(managers) > 0 {
+			c.managedSignatures = append(c.managedSignatures, sig)
+		}
+	}
+
+	if c.managedCompanies, err = s.repo.FindCompanies(c.identity); err != nil {
+		return nil, err
+	}
+	if c.companyInvites, err = s.repo.FindCompanyInvites(c.identity); err != nil {
+		return nil, err
+	}
+	if c.approvalRequests, err = s.repo.FindApprovalRequests(c.identity); err != nil {
+		return nil, err
+	}
+	if c.claManagerRequests, err = s.repo.FindClaManagerRequests(c.identity); err != nil {
+		return nil, err
+	}
+	foundEvents, err := s.repo.FindEvents(c.identity)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range foundEvents {
+		if containsFold(c.identity.UserIDs, event.String("event_user_id")) || containsFold(c.identity.LFUsernames, event.String("event_lf_username")) {
+			c.events = append(c.events, event)
+		} else {
+			c.subjectEvents = append(c.subjectEvents, event)
+		}
+	}
+
+	if c.resignSigners, err = s.repo.FindResignSigners(c.identity); err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.FindPendingSignatures(c.identity)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range pending {
+		if containsFold(c.identity.Emails, request.String("signatory_email")) {
+			c.pendingSignatures = append(c.pendingSignatures, request)
+		} else {
+			c.requestedSignatures = append(c.requestedSignatures, request)
+		}
+	}
+	if c.verificationCompanies, err = s.repo.FindDomainVerifications(c.identity); err != nil {
+		return nil, err
+	}
+	if c.webhookDeliveries, err = s.repo.FindWebhookDeliveries(c.identity); err != nil {
+		return nil, err
+	}
+
+	if c.recordCount() == 0 {
+		log.WithFields(f).Debug("no records found for the data subject")
+		return nil, ErrDataSubjectNotFound
+	}
+	return c, nil
+}
+
+// approvalListMemberships returns the approval list entries of the CCLA signature matching the data subject
+func approvalListMemberships(sig Record, identity *Identity) []*models.DataSubjectApprovalListMembership {
+	var memberships []*models.DataSubjectApprovalListMembership
+	for _, list := range []struct {
+		listType   string
+		attribute  string
+		identities []string
+	}{
+		{signatures.ApprovalListRuleEmail, "email_whitelist", identity.Emails},
+		{signatures.ApprovalListRuleGitHubUsername, "github_whitelist", identity.GitHubUsernames},
+	} {
+		values, _ := partition(sig.Strings(list.attribute), list.identities)
+		for _, value := range values {
+			memberships = append(memberships, &models.DataSubjectApprovalListMembership{
+				SignatureID: sig.String("signature_id"),
+				ClaGroupID:  sig.String("signature_project_id"),
+				CompanyID:   sig.String("signature_reference_id"),
+				ListType:    list.listType,
+				Value:       value,
+			})
+		}
+	}
+	return memberships
+}
+
+// domainVerifications returns the domain verifications of the company requested by the data subject
+func domainVerifications(company Record, identity *Identity) []Record {
+	var records []Record
+	for _, verification := range company.Maps("domain_verifications") {
+		if !containsFold(identity.LFUsernames, verification.String("requested_by")) {
+			continue
+		}
+		record := Record{"company_id": company.String("company_id"), "company_name": company.String("company_name")}
+		for _, name := range domainVerificationAttributes {
+			if value, ok := verification[name]; ok {
+				record[name] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// mentionsAny returns true if any of the string attributes of the record contains any of the values
+func mentionsAny(record Record, values []string, names ...string) bool {
+	for _, name := range names {
+		for _, value := range values {
+			if value != "" && strings.Contains(record.String(name), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toErasureJob returns the erasure model
+func toErasureJob(erasure *Erasure) *models.DataSubjectErasureJob {
+	job := &models.DataSubjectErasureJob{
+		ErasureID:      erasure.ErasureID,
+		Status:         erasure.Status,
+		LfUsername:     erasure.LFUsername,
+		Email:          erasure.Email,
+		GithubUsername: erasure.GitHubUsername,
+		Reason:         erasure.Reason,
+		RequestedBy:    erasure.RequestedBy,
+		Attempts:       erasure.Attempts,
+		LastError:      erasure.LastError,
+		DateCreated:    erasure.DateCreated,
+		DateModified:   erasure.DateModified,
+		DateCompleted:  erasure.DateCompleted,
+	}
+	if erasure.Result != "" {
+		var result models.DataSubjectErasure
+		if err := json.Unmarshal([]byte(erasure.Result), &result); err == nil {
+			job.Result = &result
+		}
+	}
+	return job
+}
+
+// signatureRoles returns the roles of the managers of the CCLA signature
+func signatureRoles(sig Record, managers []string) []*models.DataSubjectClaManagerRole {
+	var roles []*models.DataSubjectClaManagerRole
+	for _, manager := range managers {
+		roles = append(roles, &models.DataSubjectClaManagerRole{
+			Scope:       RoleScopeSignature,
+			SignatureID: sig.String("signature_id"),
+			ClaGroupID:  sig.String("signature_project_id"),
+			CompanyID:   sig.String("signature_reference_id"),
+			LfUsername:  manager,
+		})
+	}
+	return roles
+}
+
+// companyRoles returns the roles of the managers of the company
+func companyRoles(company Record, managers []string) []*models.DataSubjectClaManagerRole {
+	var roles []*models.DataSubjectClaManagerRole
+	for _, manager := range managers {
+		roles = append(roles, &models.DataSubjectClaManagerRole{
+			Scope:       RoleScopeCompany,
+			CompanyID:   company.String("company_id"),
+			CompanyName: company.String("company_name"),
+			LfUsername:  manager,
+		})
+	}
+	return roles
+}
+
+// retained flags the roles as retained by the erasure
+func retained(roles []*models.DataSubjectClaManagerRole) []*models.DataSubjectClaManagerRole {
+	for _, role := range roles {
+		role.Reason = RetainedRoleReason
+	}
+	return roles
+}
+
+// partition splits the values into the ones matching the identities and the others, ignoring the case
+func partition(values, identities []string) ([]string, []string) {
+	var matching, others []string
+	for _, value := range values {
+		if containsFold(identities, value) {
+			matching = append(matching, value)
+		} else {
+			others = append(others, value)
+		}
+	}
+	return matching, others
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// pseudonym returns the stable replacement of the name of the user - the same user ID always gives the same pseudonym
+func pseudonym(userID string) string {
+	if userID == "" {
+		return RedactedValue
+	}
+	sum := sha256.Sum256([]byte(userID))
+	return fmt.Sprintf("%s-%x", RedactedValue, sum[:6])
+}
+
+func toMaps(records []Record) []map[string]interface{} {
+	maps := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		maps = append(maps, record)
+	}
+	return maps
+}
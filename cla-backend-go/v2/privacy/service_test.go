@@ -0,0 +1,325 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package privacy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubRepository returns the same records whatever the identity and records the changes
+type stubRepository struct {
+	users, signatures, companies, invites, events []Record
+	resignSigners, pendingSignatures              []Record
+	verificationCompanies, webhookDeliveries      []Record
+	redacted                                      map[string]map[string]interface{}
+	removed                                       map[string][]string
+	deleted                                       []string
+	erasures                                      map[string]*Erasure
+}
+
+func newStubRepository() *stubRepository {
+	return &stubRepository{
+		redacted: map[string]map[string]interface{}{},
+		removed:  map[string][]string{},
+		erasures: map[string]*Erasure{},
+	}
+}
+
+func (r *stubRepository) FindUsers(identity *Identity) ([]Record, error) {
+	return r.users, nil
+}
+
+func (r *stubRepository) FindSignatures(identity *Identity) ([]Record, error) {
+	return r.signatures, nil
+}
+
+func (r *stubRepository) FindCompanies(identity *Identity) ([]Record, error) {
+	return r.companies, nil
+}
+
+func (r *stubRepository) FindCompanyInvites(identity *Identity) ([]Record, error) {
+	return r.invites, nil
+}
+
+func (r *stubRepository) FindApprovalRequests(identity *Identity) ([]Record, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) FindClaManagerRequests(identity *Identity) ([]Record, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) FindEvents(identity *Identity) ([]Record, error) {
+	return r.events, nil
+}
+
+func (r *stubRepository) FindResignSigners(identity *Identity) ([]Record, error) {
+	return r.resignSigners, nil
+}
+
+func (r *stubRepository) FindPendingSignatures(identity *Identity) ([]Record, error) {
+	return r.pendingSignatures, nil
+}
+
+func (r *stubRepository) FindDomainVerifications(identity *Identity) ([]Record, error) {
+	return r.verificationCompanies, nil
+}
+
+func (r *stubRepository) FindWebhookDeliveries(identity *Identity) ([]Record, error) {
+	return r.webhookDeliveries, nil
+}
+
+func (r *stubRepository) RedactRecord(table string, record Record, set map[string]interface{}, remove []string) error {
+	r.redacted[table+"/"+recordID(table, record)] = set
+	r.removed[table+"/"+recordID(table, record)] = remove
+	return nil
+}
+
+func (r *stubRepository) DeleteRecord(table string, record Record) error {
+	r.deleted = append(r.deleted, table+"/"+recordID(table, record))
+	return nil
+}
+
+func (r *stubRepository) PutErasure(erasure *Erasure) error {
+	stored := *erasure
+	r.erasures[erasure.ErasureID] = &stored
+	return nil
+}
+
+func (r *stubRepository) GetErasure(erasureID string) (*Erasure, error) {
+	erasure, ok := r.erasures[erasureID]
+	if !ok {
+		return nil, ErrErasureNotFound
+	}
+	found := *erasure
+	return &found, nil
+}
+
+func (r *stubRepository) GetErasuresByStatus(status string) ([]*Erasure, error) {
+	var erasures []*Erasure
+	for _, erasure := range r.erasures {
+		if erasure.Status == status {
+			found := *erasure
+			erasures = append(erasures, &found)
+		}
+	}
+	return erasures, nil
+}
+
+func (r *stubRepository) ClaimErasure(erasureID, now, staleBefore string) (bool, error) {
+	erasure, ok := r.erasures[erasureID]
+	if !ok || !(erasure.Status == ErasureStatusPending || (erasure.Status == ErasureStatusRunning && erasure.DateModified < staleBefore)) {
+		return false, nil
+	}
+	erasure.Status = ErasureStatusRunning
+	erasure.DateModified = now
+	erasure.Attempts++
+	return true, nil
+}
+
+type stubSignatureRepo struct {
+	SignatureRepo
+	removals        []*v1Models.ApprovalList
+	removedManagers []string
+}
+
+func (r *stubSignatureRepo) UpdateApprovalList(ctx context.Context, projectID, companyID string, params *v1Models.ApprovalList) (*v1Models.Signature, error) {
+	r.removals = append(r.removals, params)
+	return nil, nil
+}
+
+func (r *stubSignatureRepo) GetSignature(ctx context.Context, signatureID string) (*v1Models.Signature, error) {
+	return nil, nil
+}
+
+func (r *stubSignatureRepo) RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*v1Models.Signature, error) {
+	r.removedManagers = append(r.removedManagers, signatureID+"/"+claManagerID)
+	return nil, nil
+}
+
+type stubCompanyRepo struct{}
+
+func (r stubCompanyRepo) UpdateCompanyAccessList(ctx context.Context, companyID string, companyACL []string) error {
+	return nil
+}
+
+type stubEventsService struct {
+	events.Service
+	logged []*events.LogEventArgs
+}
+
+func (s *stubEventsService) LogEvent(args *events.LogEventArgs) {
+	s.logged = append(s.logged, args)
+}
+
+func TestEraseDataSubject(t *testing.T) {
+	repo := newStubRepository()
+	repo.users = []Record{{"user_id": "u1", "lf_username": "jdoe", "lf_email": "jdoe@acme.org",
+		"user_emails": []interface{}{"Jane@Example.org"}, "user_github_username": "jane-gh"}}
+	repo.signatures = []Record{
+		{"signature_id": "icla", "signature_reference_type": "user", "signature_reference_id": "u1", "signature_reference_name": "Jane Doe"},
+		{"signature_id": "ccla1", "signature_reference_type": "company", "signature_reference_id": "c1", "signature_project_id": "p1",
+			"email_whitelist": []interface{}{"jane@example.org", "bob@example.org"}, "github_whitelist": []interface{}{"JANE-GH"},
+			"signature_acl": []string{"jdoe", "bob"}},
+		{"signature_id": "ccla2", "signature_reference_type": "company", "signature_reference_id": "c2", "signature_project_id": "p1",
+			"signature_acl": []string{"jdoe"}},
+	}
+	repo.invites = []Record{{"company_invite_id": "i1", "user_id": "u1"}}
+	repo.events = []Record{
+		{"event_id": "e1", "event_user_id": "u1", "contains_pii": true},
+		{"event_id": "e2", "event_user_id": "u1", "event_data": "signed the ICLA"},
+		{"event_id": "e3", "event_user_id": "u9", "event_data": "added jane@example.org to the approval list"},
+	}
+	repo.resignSigners = []Record{
+		{"campaign_id": "rc1", "signature_id": "icla", "reference_id": "u1", "name": "Jane Doe", "emails": []interface{}{"jane@example.org"}},
+		{"campaign_id": "rc1", "signature_id": "ccla9", "reference_id": "u7", "emails": []interface{}{"jane@example.org", "ops@acme.org"}},
+	}
+	repo.pendingSignatures = []Record{
+		{"request_id": "ps1", "signatory_email": "jane@example.org", "signatory_name": "Jane Doe"},
+		{"request_id": "ps2", "signatory_email": "bob@example.org", "requested_by": "jdoe"},
+	}
+	repo.verificationCompanies = []Record{{"company_id": "c3", "domain_verifications": []interface{}{
+		map[string]interface{}{"domain": "acme.org", "requested_by": "jdoe"},
+		map[string]interface{}{"domain": "acme.io", "requested_by": "bob"},
+	}}}
+	repo.webhookDeliveries = []Record{
+		{"delivery_id": "d1", "status": webhooks.DeliveryStatusPending, "payload": `{"lfUsername":"jdoe"}`},
+		{"delivery_id": "d2", "status": webhooks.DeliveryStatusSucceeded, "payload": `{"lfUsername":"jdoe"}`},
+	}
+	signatureRepo := &stubSignatureRepo{}
+	s := NewService(repo, signatureRepo, stubCompanyRepo{}, &stubEventsService{})
+
+	_, err := s.EraseDataSubject(context.Background(), &DataSubject{})
+	assert.Equal(t, ErrDataSubjectInput, err)
+
+	export, err := s.ExportDataSubject(context.Background(), &DataSubject{LFUsername: "jdoe"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"u1"}, export.UserIDs)
+		assert.Len(t, export.Signatures, 1)
+		assert.Len(t, export.ApprovalListMemberships, 2)
+		assert.Len(t, export.ClaManagerRoles, 2)
+		assert.Len(t, export.SubjectEvents, 1)
+		assert.Len(t, export.ResignSigners, 2)
+		assert.Len(t, export.PendingSignatures, 2)
+		assert.Len(t, export.DomainVerifications, 1)
+		assert.Len(t, export.WebhookDeliveries, 2)
+	}
+
+	result, err := s.EraseDataSubject(context.Background(), &DataSubject{LFUsername: "jdoe"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(2), result.ApprovalListEntriesRemoved)
+	if assert.Len(t, signatureRepo.removals, 1) {
+		assert.Equal(t, []string{"jane@example.org"}, signatureRepo.removals[0].RemoveEmailApprovalList)
+		assert.Equal(t, []string{"JANE-GH"}, signatureRepo.removals[0].RemoveGithubUsernameApprovalList)
+	}
+	assert.Equal(t, []string{"ccla1/jdoe"}, signatureRepo.removedManagers)
+	if assert.Len(t, result.RetainedClaManagerRoles, 1) {
+		assert.Equal(t, "ccla2", result.RetainedClaManagerRoles[0].SignatureID)
+		assert.Equal(t, RetainedRoleReason, result.RetainedClaManagerRoles[0].Reason)
+	}
+	assert.Equal(t, []string{CompanyInvitesTable + "/i1", PendingSignaturesTable + "/ps1"}, repo.deleted)
+	assert.Equal(t, int64(2), result.RequestsDeleted)
+
+	name := pseudonym("u1")
+	assert.Equal(t, name, pseudonym("u1"))
+	assert.NotEqual(t, name, pseudonym("u2"))
+	assert.Equal(t, name, repo.redacted[SignaturesTable+"/icla"]["signature_reference_name"])
+	assert.Equal(t, name, repo.redacted[UsersTable+"/u1"]["user_name"])
+	assert.Equal(t, RedactedValue, repo.redacted[EventsTable+"/e1"]["event_data"])
+	assert.NotContains(t, repo.redacted[EventsTable+"/e2"], "event_data")
+	assert.Equal(t, int64(1), result.SignaturesPreserved)
+	assert.Equal(t, RedactedValue, repo.redacted[EventsTable+"/e3"]["event_data"])
+	assert.Equal(t, int64(3), result.EventsRedacted)
+
+	// the re-sign signer of the data subject is pseudonymized, the data subject is removed from the other signers
+	assert.Equal(t, name, repo.redacted[ResignSignersTable+"/rc1/icla"]["name"])
+	assert.Equal(t, []string{"emails"}, repo.removed[ResignSignersTable+"/rc1/icla"])
+	assert.Equal(t, []string{"ops@acme.org"}, repo.redacted[ResignSignersTable+"/rc1/ccla9"]["emails"])
+	assert.Equal(t, int64(2), result.ResignSignersRedacted)
+
+	assert.Equal(t, RedactedValue, repo.redacted[PendingSignaturesTable+"/ps2"]["requested_by"])
+	assert.Equal(t, int64(1), result.PendingSignaturesRedacted)
+
+	verifications := repo.redacted[CompaniesTable+"/c3"]["domain_verifications"].([]interface{})
+	assert.Equal(t, RedactedValue, verifications[0].(map[string]interface{})["requested_by"])
+	assert.Equal(t, "bob", verifications[1].(map[string]interface{})["requested_by"])
+	assert.Equal(t, int64(1), result.DomainVerificationsRedacted)
+
+	// the pending delivery is not sent with the redacted payload
+	assert.Equal(t, RedactedValue, repo.redacted[WebhookDeliveriesTable+"/d1"]["payload"])
+	assert.Equal(t, webhooks.DeliveryStatusDeadLetter, repo.redacted[WebhookDeliveriesTable+"/d1"]["status"])
+	assert.Equal(t, []string{"next_attempt_at"}, repo.removed[WebhookDeliveriesTable+"/d1"])
+	assert.NotContains(t, repo.redacted[WebhookDeliveriesTable+"/d2"], "status")
+	assert.Equal(t, int64(2), result.WebhookDeliveriesRedacted)
+}
+
+func TestProcessPendingErasures(t *testing.T) {
+	repo := newStubRepository()
+	repo.users = []Record{{"user_id": "u1", "lf_username": "jdoe"}}
+	eventsService := &stubEventsService{}
+	s := NewService(repo, &stubSignatureRepo{}, stubCompanyRepo{}, eventsService)
+	ctx := context.Background()
+
+	_, err := s.RequestErasure(ctx, &DataSubject{}, "admin")
+	assert.Equal(t, ErrDataSubjectInput, err)
+
+	job, err := s.RequestErasure(ctx, &DataSubject{LFUsername: "jdoe", Reason: "user request"}, "admin")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, ErasureStatusPending, job.Status)
+
+	// a running erasure whose lease expired is taken over, a running erasure within its lease is left alone
+	repo.erasures["stale"] = &Erasure{ErasureID: "stale", Status: ErasureStatusRunning, LFUsername: "jdoe", Attempts: 1,
+		DateModified: utils.TimeToString(time.Now().Add(-2 * ErasureLease))}
+	repo.erasures["running"] = &Erasure{ErasureID: "running", Status: ErasureStatusRunning, LFUsername: "jdoe", Attempts: 1,
+		DateModified: utils.TimeToString(time.Now())}
+
+	processed, err := s.ProcessPendingErasures(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+
+	job, err = s.GetErasure(ctx, job.ErasureID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ErasureStatusCompleted, job.Status)
+		assert.Equal(t, int64(1), job.Attempts)
+		assert.Empty(t, job.LfUsername)
+		assert.Equal(t, "user request", job.Reason)
+		if assert.NotNil(t, job.Result) {
+			assert.Equal(t, []string{"u1"}, job.Result.UserIDs)
+			assert.Equal(t, int64(1), job.Result.UsersRedacted)
+		}
+	}
+	assert.Equal(t, ErasureStatusCompleted, repo.erasures["stale"].Status)
+	assert.Equal(t, int64(2), repo.erasures["stale"].Attempts)
+	assert.Equal(t, ErasureStatusRunning, repo.erasures["running"].Status)
+	if assert.Len(t, eventsService.logged, 2) {
+		assert.Equal(t, events.DataSubjectErased, eventsService.logged[0].EventType)
+		assert.Equal(t, "admin", eventsService.logged[0].LfUsername)
+	}
+
+	// nothing is left to erase
+	repo.users = nil
+	job, err = s.RequestErasure(ctx, &DataSubject{LFUsername: "jdoe"}, "admin")
+	assert.NoError(t, err)
+	processed, err = s.ProcessPendingErasures(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, ErasureStatusNotFound, repo.erasures[job.ErasureID].Status)
+	assert.Empty(t, repo.erasures[job.ErasureID].LFUsername)
+
+	_, err = s.GetErasure(ctx, "unknown")
+	assert.Equal(t, ErrErasureNotFound, err)
+}
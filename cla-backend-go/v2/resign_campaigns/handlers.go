@@ -0,0 +1,405 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaigns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/resign_campaigns"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	v1ResignCampaigns "github.com/communitybridge/easycla/cla-backend-go/resign_campaigns"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setup the re-sign campaign handlers
+func Configure(api *operations.EasyclaAPI, service v1ResignCampaigns.Service, v1ProjectService v1Project.Service, eventsService events.Service) {
+	api.ResignCampaignsListResignCampaignsHandler = resign_campaigns.ListResignCampaignsHandlerFunc(
+		func(params resign_campaigns.ListResignCampaignsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "ResignCampaignsListResignCampaignsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return resign_campaigns.NewListResignCampaignsNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewListResignCampaignsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return resign_campaigns.NewListResignCampaignsForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ListResignCampaigns with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			campaigns, err := service.GetCampaigns(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to load re-sign campaigns, error: %+v", err)
+				return resign_campaigns.NewListResignCampaignsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result := &models.ResignCampaignList{Campaigns: make([]*models.ResignCampaign, 0, len(campaigns))}
+			for _, campaign := range campaigns {
+				result.Campaigns = append(result.Campaigns, toResignCampaign(campaign))
+			}
+			return resign_campaigns.NewListResignCampaignsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.ResignCampaignsCreateResignCampaignHandler = resign_campaigns.CreateResignCampaignHandlerFunc(
+		func(params resign_campaigns.CreateResignCampaignParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "ResignCampaignsCreateResignCampaignHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"claType":        params.Body.ClaType,
+				"majorVersion":   params.Body.MajorVersion,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return resign_campaigns.NewCreateResignCampaignNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewCreateResignCampaignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return resign_campaigns.NewCreateResignCampaignForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to CreateResignCampaign with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			campaign, err := service.CreateCampaign(ctx, &v1ResignCampaigns.Campaign{
+				ClaGroupID:   params.ClaGroupID,
+				ClaType:      params.Body.ClaType,
+				MajorVersion: params.Body.MajorVersion,
+				GraceDate:    params.Body.GraceDate,
+				CreatedBy:    authUser.UserName,
+			})
+			if err != nil {
+				log.WithFields(f).Warnf("unable to create re-sign campaign, error: %+v", err)
+				switch err {
+				case v1ResignCampaigns.ErrInvalidClaType, v1ResignCampaigns.ErrInvalidGraceDate, v1ResignCampaigns.ErrUnknownMajorVersion, v1ResignCampaigns.ErrCampaignExists:
+					return resign_campaigns.NewCreateResignCampaignBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewCreateResignCampaignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.ResignCampaignCreated,
+				ProjectID:  params.ClaGroupID,
+				LfUsername: authUser.UserName,
+				EventData: &events.ResignCampaignCreatedEventData{
+					CampaignID:   campaign.CampaignID,
+					ClaType:      campaign.ClaType,
+					MajorVersion: campaign.MajorVersion,
+					GraceDate:    campaign.GraceDate,
+					SignerCount:  campaign.Progress.Total,
+				},
+			})
+
+			return resign_campaigns.NewCreateResignCampaignOK().WithXRequestID(reqID).WithPayload(toResignCampaign(campaign))
+		})
+
+	api.ResignCampaignsGetResignCampaignHandler = resign_campaigns.GetResignCampaignHandlerFunc(
+		func(params resign_campaigns.GetResignCampaignParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "ResignCampaignsGetResignCampaignHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"campaignID":     params.CampaignID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return resign_campaigns.NewGetResignCampaignNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewGetResignCampaignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return resign_campaigns.NewGetResignCampaignForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to GetResignCampaign with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			campaign, err := getClaGroupCampaign(ctx, service, params.ClaGroupID, params.CampaignID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1ResignCampaigns.ErrCampaignNotFound {
+					return resign_campaigns.NewGetResignCampaignNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewGetResignCampaignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return resign_campaigns.NewGetResignCampaignOK().WithXRequestID(reqID).WithPayload(toResignCampaign(campaign))
+		})
+
+	api.ResignCampaignsCancelResignCampaignHandler = resign_campaigns.CancelResignCampaignHandlerFunc(
+		func(params resign_campaigns.CancelResignCampaignParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "ResignCampaignsCancelResignCampaignHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"campaignID":     params.CampaignID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return resign_campaigns.NewCancelResignCampaignNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewCancelResignCampaignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return resign_campaigns.NewCancelResignCampaignForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to CancelResignCampaign with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			if _, err = getClaGroupCampaign(ctx, service, params.ClaGroupID, params.CampaignID); err != nil {
+				log.WithFields(f).Warn(err)
+				return resign_campaigns.NewCancelResignCampaignNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			campaign, err := service.CancelCampaign(ctx, params.CampaignID)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to cancel re-sign campaign, error: %+v", err)
+				if err == v1ResignCampaigns.ErrCampaignNotActive {
+					return resign_campaigns.NewCancelResignCampaignBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewCancelResignCampaignInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.ResignCampaignCancelled,
+				ProjectID:  params.ClaGroupID,
+				LfUsername: authUser.UserName,
+				EventData: &events.ResignCampaignCancelledEventData{
+					CampaignID:   campaign.CampaignID,
+					MajorVersion: campaign.MajorVersion,
+				},
+			})
+
+			return resign_campaigns.NewCancelResignCampaignOK().WithXRequestID(reqID).WithPayload(toResignCampaign(campaign))
+		})
+
+	api.ResignCampaignsListResignCampaignSignersHandler = resign_campaigns.ListResignCampaignSignersHandlerFunc(
+		func(params resign_campaigns.ListResignCampaignSignersParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "ResignCampaignsListResignCampaignSignersHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"campaignID":     params.CampaignID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return resign_campaigns.NewListResignCampaignSignersNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewListResignCampaignSignersInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return resign_campaigns.NewListResignCampaignSignersForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ListResignCampaignSigners with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			if _, err = getClaGroupCampaign(ctx, service, params.ClaGroupID, params.CampaignID); err != nil {
+				log.WithFields(f).Warn(err)
+				return resign_campaigns.NewListResignCampaignSignersNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			var status string
+			if params.Status != nil {
+				status = *params.Status
+			}
+			signers, err := service.GetSigners(ctx, params.CampaignID, status)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to load re-sign campaign signers, error: %+v", err)
+				return resign_campaigns.NewListResignCampaignSignersInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result := &models.ResignCampaignSignerList{Signers: make([]*models.ResignCampaignSigner, 0, len(signers))}
+			for _, signer := range signers {
+				result.Signers = append(result.Signers, toResignCampaignSigner(signer))
+			}
+			return resign_campaigns.NewListResignCampaignSignersOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.ResignCampaignsSendResignCampaignInvitationsHandler = resign_campaigns.SendResignCampaignInvitationsHandlerFunc(
+		func(params resign_campaigns.SendResignCampaignInvitationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "ResignCampaignsSendResignCampaignInvitationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"claGroupID":     params.ClaGroupID,
+				"campaignID":     params.CampaignID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+			if err != nil {
+				log.WithFields(f).Warn(err)
+				if err == v1Project.ErrProjectDoesNotExist {
+					return resign_campaigns.NewSendResignCampaignInvitationsNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewSendResignCampaignInvitationsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, claGroupModel.FoundationSFID) {
+				return resign_campaigns.NewSendResignCampaignInvitationsForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to SendResignCampaignInvitations with Project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+
+			if _, err = getClaGroupCampaign(ctx, service, params.ClaGroupID, params.CampaignID); err != nil {
+				log.WithFields(f).Warn(err)
+				return resign_campaigns.NewSendResignCampaignInvitationsNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			resend := params.Resend != nil && *params.Resend
+			invited, err := service.SendInvitations(ctx, params.CampaignID, resend)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to send re-sign campaign invitations, error: %+v", err)
+				if err == v1ResignCampaigns.ErrCampaignNotActive {
+					return resign_campaigns.NewSendResignCampaignInvitationsBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return resign_campaigns.NewSendResignCampaignInvitationsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return resign_campaigns.NewSendResignCampaignInvitationsOK().WithXRequestID(reqID).WithPayload(&models.ResignCampaignInvitations{
+				InvitedCount: int64(invited),
+			})
+		})
+}
+
+// getClaGroupCampaign returns the re-sign campaign if it belongs to the CLA Group
+func getClaGroupCampaign(ctx context.Context, service v1ResignCampaigns.Service, claGroupID, campaignID string) (*v1ResignCampaigns.Campaign, error) {
+	campaign, err := service.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.ClaGroupID != claGroupID {
+		return nil, v1ResignCampaigns.ErrCampaignNotFound
+	}
+	return campaign, nil
+}
+
+// toResignCampaign converts the campaign into the response model
+func toResignCampaign(campaign *v1ResignCampaigns.Campaign) *models.ResignCampaign {
+	result := &models.ResignCampaign{
+		CampaignID:    campaign.CampaignID,
+		ClaGroupID:    campaign.ClaGroupID,
+		ClaType:       campaign.ClaType,
+		MajorVersion:  campaign.MajorVersion,
+		GraceDate:     campaign.GraceDate,
+		Status:        campaign.Status,
+		CreatedBy:     campaign.CreatedBy,
+		DateCreated:   campaign.DateCreated,
+		DateModified:  campaign.DateModified,
+		DateCompleted: campaign.DateCompleted,
+	}
+	if campaign.Progress != nil {
+		result.SignerCount = int64(campaign.Progress.Total)
+		result.PendingCount = int64(campaign.Progress.Pending)
+		result.InvitedCount = int64(campaign.Progress.Invited)
+		result.ResignedCount = int64(campaign.Progress.Resigned)
+	}
+	return result
+}
+
+// toResignCampaignSigner converts the campaign signer into the response model - the email addresses are not returned
+func toResignCampaignSigner(signer *v1ResignCampaigns.Signer) *models.ResignCampaignSigner {
+	return &models.ResignCampaignSigner{
+		SignatureID:         signer.SignatureID,
+		ClaType:             signer.ClaType,
+		ReferenceID:         signer.ReferenceID,
+		Name:                signer.Name,
+		SignedMajorVersion:  signer.SignedMajorVersion,
+		Status:              signer.Status,
+		Invitations:         signer.Invitations,
+		DateInvited:         signer.DateInvited,
+		DateResigned:        signer.DateResigned,
+		ResignedSignatureID: signer.ResignedSignatureID,
+	}
+}
+
+type codedResponse interface {
+	Code() string
+}
+
+func errorResponse(err error) *models.ErrorResponse {
+	code := ""
+	if e, ok := err.(codedResponse); ok {
+		code = e.Code()
+	}
+
+	e := models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	return &e
+}
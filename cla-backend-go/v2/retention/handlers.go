@@ -0,0 +1,247 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/retention"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Retention "github.com/communitybridge/easycla/cla-backend-go/retention"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setup the retention policy and legal hold handlers
+func Configure(api *operations.EasyclaAPI, service v1Retention.Service, eventsService events.Service) {
+	api.RetentionGetRetentionPolicyHandler = retention.GetRetentionPolicyHandlerFunc(
+		func(params retention.GetRetentionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return retention.NewGetRetentionPolicyForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "GetRetentionPolicy"))
+			}
+
+			policy, err := service.GetPolicy(ctx, params.FoundationSFID)
+			if err != nil {
+				if err == v1Retention.ErrPolicyNotFound {
+					return retention.NewGetRetentionPolicyNotFound().WithXRequestID(reqID).WithPayload(errorResponse("404", err))
+				}
+				return retention.NewGetRetentionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+			return retention.NewGetRetentionPolicyOK().WithXRequestID(reqID).WithPayload(toRetentionPolicy(policy))
+		})
+
+	api.RetentionUpdateRetentionPolicyHandler = retention.UpdateRetentionPolicyHandlerFunc(
+		func(params retention.UpdateRetentionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "RetentionUpdateRetentionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"foundationSFID": params.FoundationSFID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+			if !utils.IsUserAdmin(authUser) {
+				return retention.NewUpdateRetentionPolicyForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "UpdateRetentionPolicy"))
+			}
+
+			policy, err := service.PutPolicy(ctx, &v1Retention.Policy{
+				FoundationSFID:                     params.FoundationSFID,
+				DeletedClaGroupRetentionYears:      params.Body.DeletedClaGroupRetentionYears,
+				InvalidatedSignatureRetentionYears: params.Body.InvalidatedSignatureRetentionYears,
+				Action:                             params.Body.Action,
+				CreatedBy:                          authUser.UserName,
+			})
+			if err != nil {
+				log.WithFields(f).Warnf("unable to store the retention policy, error: %+v", err)
+				if err == v1Retention.ErrInvalidAction || err == v1Retention.ErrInvalidRetentionYears {
+					return retention.NewUpdateRetentionPolicyBadRequest().WithXRequestID(reqID).WithPayload(errorResponse("400", err))
+				}
+				return retention.NewUpdateRetentionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.RetentionPolicyUpdated,
+				LfUsername: authUser.UserName,
+				EventData: &events.RetentionPolicyUpdatedEventData{
+					FoundationSFID:                     policy.FoundationSFID,
+					DeletedClaGroupRetentionYears:      policy.DeletedClaGroupRetentionYears,
+					InvalidatedSignatureRetentionYears: policy.InvalidatedSignatureRetentionYears,
+					Action:                             policy.Action,
+				},
+			})
+
+			return retention.NewUpdateRetentionPolicyOK().WithXRequestID(reqID).WithPayload(toRetentionPolicy(policy))
+		})
+
+	api.RetentionDeleteRetentionPolicyHandler = retention.DeleteRetentionPolicyHandlerFunc(
+		func(params retention.DeleteRetentionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return retention.NewDeleteRetentionPolicyForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "DeleteRetentionPolicy"))
+			}
+
+			err := service.DeletePolicy(ctx, params.FoundationSFID)
+			if err != nil {
+				if err == v1Retention.ErrPolicyNotFound {
+					return retention.NewDeleteRetentionPolicyNotFound().WithXRequestID(reqID).WithPayload(errorResponse("404", err))
+				}
+				return retention.NewDeleteRetentionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.RetentionPolicyDeleted,
+				LfUsername: authUser.UserName,
+				EventData: &events.RetentionPolicyDeletedEventData{
+					FoundationSFID: params.FoundationSFID,
+				},
+			})
+
+			return retention.NewDeleteRetentionPolicyNoContent().WithXRequestID(reqID)
+		})
+
+	api.RetentionListLegalHoldsHandler = retention.ListLegalHoldsHandlerFunc(
+		func(params retention.ListLegalHoldsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return retention.NewListLegalHoldsForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "ListLegalHolds"))
+			}
+
+			holds, err := service.GetLegalHolds(ctx)
+			if err != nil {
+				return retention.NewListLegalHoldsInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			result := &models.LegalHoldList{LegalHolds: make([]*models.LegalHold, 0, len(holds))}
+			for _, hold := range holds {
+				result.LegalHolds = append(result.LegalHolds, toLegalHold(hold))
+			}
+			return retention.NewListLegalHoldsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.RetentionPlaceLegalHoldHandler = retention.PlaceLegalHoldHandlerFunc(
+		func(params retention.PlaceLegalHoldParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "RetentionPlaceLegalHoldHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"holdType":       params.HoldType,
+				"referenceID":    params.ReferenceID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+			if !utils.IsUserAdmin(authUser) {
+				return retention.NewPlaceLegalHoldForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "PlaceLegalHold"))
+			}
+
+			hold, err := service.PlaceLegalHold(ctx, &v1Retention.LegalHold{
+				ReferenceID:   params.ReferenceID,
+				ReferenceType: params.HoldType,
+				Reason:        strings.TrimSpace(utils.StringValue(params.Body.Reason)),
+				CreatedBy:     authUser.UserName,
+			})
+			if err != nil {
+				log.WithFields(f).Warnf("unable to place the legal hold, error: %+v", err)
+				if err == v1Retention.ErrInvalidHoldType || err == v1Retention.ErrLegalHoldReason {
+					return retention.NewPlaceLegalHoldBadRequest().WithXRequestID(reqID).WithPayload(errorResponse("400", err))
+				}
+				return retention.NewPlaceLegalHoldInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.LegalHoldPlaced,
+				LfUsername: authUser.UserName,
+				EventData: &events.LegalHoldPlacedEventData{
+					HoldType:    hold.ReferenceType,
+					ReferenceID: hold.ReferenceID,
+					Reason:      hold.Reason,
+				},
+			})
+
+			return retention.NewPlaceLegalHoldOK().WithXRequestID(reqID).WithPayload(toLegalHold(hold))
+		})
+
+	api.RetentionReleaseLegalHoldHandler = retention.ReleaseLegalHoldHandlerFunc(
+		func(params retention.ReleaseLegalHoldParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return retention.NewReleaseLegalHoldForbidden().WithXRequestID(reqID).WithPayload(forbidden(authUser, "ReleaseLegalHold"))
+			}
+
+			err := service.ReleaseLegalHold(ctx, params.HoldType, params.ReferenceID)
+			if err != nil {
+				if err == v1Retention.ErrLegalHoldNotFound {
+					return retention.NewReleaseLegalHoldNotFound().WithXRequestID(reqID).WithPayload(errorResponse("404", err))
+				}
+				return retention.NewReleaseLegalHoldInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse("500", err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.LegalHoldReleased,
+				LfUsername: authUser.UserName,
+				EventData: &events.LegalHoldReleasedEventData{
+					HoldType:    params.HoldType,
+					ReferenceID: params.ReferenceID,
+				},
+			})
+
+			return retention.NewReleaseLegalHoldNoContent().WithXRequestID(reqID)
+		})
+}
+
+func toRetentionPolicy(policy *v1Retention.Policy) *models.RetentionPolicy {
+	return &models.RetentionPolicy{
+		FoundationSFID:                     policy.FoundationSFID,
+		DeletedClaGroupRetentionYears:      policy.DeletedClaGroupRetentionYears,
+		InvalidatedSignatureRetentionYears: policy.InvalidatedSignatureRetentionYears,
+		Action:                             policy.Action,
+		CreatedBy:                          policy.CreatedBy,
+		DateCreated:                        policy.DateCreated,
+		DateModified:                       policy.DateModified,
+	}
+}
+
+func toLegalHold(hold *v1Retention.LegalHold) *models.LegalHold {
+	return &models.LegalHold{
+		HoldType:    hold.ReferenceType,
+		ReferenceID: hold.ReferenceID,
+		Reason:      hold.Reason,
+		CreatedBy:   hold.CreatedBy,
+		DateCreated: hold.DateCreated,
+	}
+}
+
+func forbidden(authUser *auth.User, operation string) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code: "403",
+		Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to %s - only Admins are allowed to manage the retention policies and legal holds.",
+			authUser.UserName, operation),
+	}
+}
+
+func errorResponse(code string, err error) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+}
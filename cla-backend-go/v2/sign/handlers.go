@@ -65,6 +65,85 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 			}
 			return sign.NewRequestCorporateSignatureOK().WithPayload(resp)
 		})
+
+	api.SignListPendingCorporateSignaturesHandler = sign.ListPendingCorporateSignaturesHandlerFunc(
+		func(params sign.ListPendingCorporateSignaturesParams, user *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectOrganizationTree(user, params.ProjectSFID, params.CompanySFID) {
+				return sign.NewListPendingCorporateSignaturesForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to List Pending Corporate Signatures with Project|Organization scope of %s | %s",
+						user.UserName, params.ProjectSFID, params.CompanySFID),
+				})
+			}
+
+			result, err := service.ListPendingSignatures(ctx, params.CompanySFID, params.ProjectSFID, utils.StringValue(params.Status))
+			if err != nil {
+				if err == projects_cla_groups.ErrProjectNotAssociatedWithClaGroup {
+					return sign.NewListPendingCorporateSignaturesNotFound().WithPayload(errorResponse(err))
+				}
+				return sign.NewListPendingCorporateSignaturesInternalServerError().WithPayload(errorResponse(err))
+			}
+
+			response := &models.PendingCorporateSignatureList{
+				PendingSignatures: make([]*models.PendingCorporateSignature, 0, len(result)),
+			}
+			for _, pending := range result {
+				response.PendingSignatures = append(response.PendingSignatures, toPendingCorporateSignature(pending))
+			}
+			return sign.NewListPendingCorporateSignaturesOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.SignCancelPendingCorporateSignatureHandler = sign.CancelPendingCorporateSignatureHandlerFunc(
+		func(params sign.CancelPendingCorporateSignatureParams, user *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForProjectOrganizationTree(user, params.ProjectSFID, params.CompanySFID) {
+				return sign.NewCancelPendingCorporateSignatureForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Cancel Pending Corporate Signature with Project|Organization scope of %s | %s",
+						user.UserName, params.ProjectSFID, params.CompanySFID),
+				})
+			}
+
+			pending, err := service.CancelPendingSignature(ctx, params.Authorization, params.CompanySFID, params.ProjectSFID, params.RequestID, user.UserName)
+			if err != nil {
+				if err == ErrPendingSignatureNotFound || err == projects_cla_groups.ErrProjectNotAssociatedWithClaGroup {
+					return sign.NewCancelPendingCorporateSignatureNotFound().WithPayload(errorResponse(err))
+				}
+				if err == ErrPendingSignatureNotPending {
+					return sign.NewCancelPendingCorporateSignatureBadRequest().WithPayload(errorResponse(err))
+				}
+				return sign.NewCancelPendingCorporateSignatureInternalServerError().WithPayload(errorResponse(err))
+			}
+			return sign.NewCancelPendingCorporateSignatureOK().WithXRequestID(reqID).WithPayload(toPendingCorporateSignature(pending))
+		})
+}
+
+func toPendingCorporateSignature(pending *PendingSignature) *models.PendingCorporateSignature {
+	return &models.PendingCorporateSignature{
+		RequestID:        pending.RequestID,
+		CompanySFID:      pending.CompanySFID,
+		CompanyID:        pending.CompanyID,
+		CompanyName:      pending.CompanyName,
+		ProjectSFID:      pending.ProjectSFID,
+		ClaGroupID:       pending.ClaGroupID,
+		ClaGroupName:     pending.ClaGroupName,
+		SignatureID:      pending.SignatureID,
+		SignatoryName:    pending.SignatoryName,
+		SignatoryEmail:   pending.SignatoryEmail,
+		RequestedBy:      pending.RequestedBy,
+		SendAsEmail:      pending.SendAsEmail,
+		Status:           pending.Status,
+		ReminderCount:    int64(pending.ReminderCount),
+		DateLastReminded: pending.DateLastReminded,
+		DateRequested:    pending.DateRequested,
+		DateModified:     pending.DateModified,
+		DateClosed:       pending.DateClosed,
+	}
 }
 
 type codedResponse interface {
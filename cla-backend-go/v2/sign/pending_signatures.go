@@ -0,0 +1,306 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	projectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// PendingSignatureReminderPolicy defines when the signatory of a pending signature request is reminded and when the
+// request is abandoned - both relative to the date of the request
+type PendingSignatureReminderPolicy struct {
+	ReminderIntervals []time.Duration
+	AbandonAfter      time.Duration
+}
+
+// DefaultPendingSignatureReminderPolicy reminds the signatory 3, 7 and 14 days after the request and abandons the
+// request after 30 days
+var DefaultPendingSignatureReminderPolicy = PendingSignatureReminderPolicy{
+	ReminderIntervals: []time.Duration{3 * 24 * time.Hour, 7 * 24 * time.Hour, 14 * 24 * time.Hour},
+	AbandonAfter:      30 * 24 * time.Hour,
+}
+
+// PendingSignatureReport summarizes a run of ProcessPendingSignatures
+type PendingSignatureReport struct {
+	RequestsChecked   int
+	RequestsSigned    int
+	RemindersSent     int
+	RequestsAbandoned int
+}
+
+type pendingSignatureAction int
+
+const (
+	pendingSignatureWait pendingSignatureAction = iota
+	pendingSignatureRemind
+	pendingSignatureAbandon
+)
+
+// nextPendingSignatureAction returns what to do with the pending request at the given time along with the number of
+// reminder intervals which have elapsed - at most one reminder is sent per run, even when several intervals elapsed
+// since the last one
+func nextPendingSignatureAction(pending *PendingSignature, now time.Time, policy PendingSignatureReminderPolicy) (pendingSignatureAction, int) {
+	requested, err := utils.ParseDateTime(pending.DateRequested)
+	if err != nil {
+		log.Warnf("unable to parse the date of pending signature request: %s, date: %s, error: %+v",
+			pending.RequestID, pending.DateRequested, err)
+		return pendingSignatureWait, pending.ReminderCount
+	}
+	age := now.Sub(requested)
+	if policy.AbandonAfter > 0 && age >= policy.AbandonAfter {
+		return pendingSignatureAbandon, pending.ReminderCount
+	}
+
+	elapsed := 0
+	for _, interval := range policy.ReminderIntervals {
+		if age >= interval {
+			elapsed++
+		}
+	}
+	if elapsed > pending.ReminderCount {
+		return pendingSignatureRemind, elapsed
+	}
+	return pendingSignatureWait, pending.ReminderCount
+}
+
+// recordPendingSignature adds the request to the pending signature ledger - older pending requests of the company for
+// the same CLA Group are superseded by the new one and cancelled
+func (s *service) recordPendingSignature(pending *PendingSignature) {
+	f := logrus.Fields{
+		"functionName": "recordPendingSignature",
+		"companySFID":  pending.CompanySFID,
+		"claGroupID":   pending.ClaGroupID,
+		"signatureID":  pending.SignatureID,
+	}
+	requestID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to generate the pending signature request ID, error: %+v", err)
+		return
+	}
+	pending.RequestID = requestID.String()
+	pending.Status = PendingSignatureStatusPending
+
+	existing, err := s.pendingRepo.GetPendingSignaturesByCompany(pending.CompanySFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the pending signature requests of the company, error: %+v", err)
+	}
+	for _, previous := range existing {
+		if previous.ClaGroupID != pending.ClaGroupID || previous.Status != PendingSignatureStatusPending {
+			continue
+		}
+		// the signatory of the new request keeps the cla-signatory role
+		removeRole := !strings.EqualFold(previous.SignatoryEmail, pending.SignatoryEmail)
+		if closeErr := s.closePendingSignature(previous, PendingSignatureStatusCancelled, removeRole); closeErr != nil {
+			log.WithFields(f).Warnf("unable to cancel the superseded pending signature request: %s, error: %+v", previous.RequestID, closeErr)
+		}
+	}
+
+	if err = s.pendingRepo.PutPendingSignature(pending); err != nil {
+		log.WithFields(f).Warnf("unable to record the pending signature request, error: %+v", err)
+	}
+}
+
+// closePendingSignature moves the pending request to the final status, optionally removing the cla-signatory role
+// assigned to the signatory for the request
+func (s *service) closePendingSignature(pending *PendingSignature, status string, removeRole bool) error {
+	if removeRole && pending.SignatoryEmail != "" {
+		if err := removeSignatoryRole(pending.SignatoryEmail, pending.CompanySFID, pending.ProjectSFID); err != nil {
+			// the signatory may not have an LF login, in which case no role was assigned
+			log.Warnf("unable to remove the cla-signatory role of: %s for pending signature request: %s, error: %+v",
+				pending.SignatoryEmail, pending.RequestID, err)
+		}
+	}
+	_, currentTime := utils.CurrentTime()
+	pending.Status = status
+	pending.DateClosed = currentTime
+	return s.pendingRepo.PutPendingSignature(pending)
+}
+
+// refreshPendingSignature marks the pending request as signed once the signatory signed the corporate signature
+func (s *service) refreshPendingSignature(ctx context.Context, pending *PendingSignature) (bool, error) {
+	if pending.Status != PendingSignatureStatusPending || pending.SignatureID == "" {
+		return false, nil
+	}
+	signature, err := s.signatureRepo.GetSignature(ctx, pending.SignatureID)
+	if err != nil {
+		return false, err
+	}
+	if signature == nil || !signature.SignatureSigned {
+		return false, nil
+	}
+	return true, s.closePendingSignature(pending, PendingSignatureStatusSigned, false)
+}
+
+// ListPendingSignatures returns the signature requests of the company for the CLA Group of the project with the
+// status - pending by default
+func (s *service) ListPendingSignatures(ctx context.Context, companySFID, projectSFID, status string) ([]*PendingSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "ListPendingSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"projectSFID":    projectSFID,
+		"status":         status,
+	}
+	if status == "" {
+		status = PendingSignatureStatusPending
+	}
+	claGroupID, err := s.getProjectCLAGroupID(projectSFID)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.pendingRepo.GetPendingSignaturesByCompany(companySFID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PendingSignature, 0)
+	for _, pending := range requests {
+		if pending.ClaGroupID != claGroupID {
+			continue
+		}
+		// the ledger is reconciled daily, catch up on the requests signed since the last run
+		if _, refreshErr := s.refreshPendingSignature(ctx, pending); refreshErr != nil {
+			log.WithFields(f).Warnf("unable to refresh the pending signature request: %s, error: %+v", pending.RequestID, refreshErr)
+		}
+		if pending.Status == status {
+			result = append(result, pending)
+		}
+	}
+	return result, nil
+}
+
+// CancelPendingSignature cancels the pending signature request of the company for the CLA Group of the project - the
+// DocuSign envelope is voided, the signature record of the request is invalidated and the cla-signatory role assigned
+// to the signatory for the request is removed
+func (s *service) CancelPendingSignature(ctx context.Context, authorizationHeader, companySFID, projectSFID, requestID, lfUsername string) (*PendingSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "CancelPendingSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"projectSFID":    projectSFID,
+		"requestID":      requestID,
+		"lfUsername":     lfUsername,
+	}
+	claGroupID, err := s.getProjectCLAGroupID(projectSFID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.pendingRepo.GetPendingSignature(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if pending.CompanySFID != companySFID || pending.ClaGroupID != claGroupID {
+		return nil, ErrPendingSignatureNotFound
+	}
+	signed, err := s.refreshPendingSignature(ctx, pending)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to refresh the pending signature request, error: %+v", err)
+	}
+	if signed || pending.Status != PendingSignatureStatusPending {
+		return nil, ErrPendingSignatureNotPending
+	}
+
+	log.WithFields(f).Debug("cancelling pending signature request")
+	if pending.SignatureID != "" {
+		out, cancelErr := cancelCorporateSignature(authorizationHeader, s.ClaV1ApiURL, pending.SignatureID)
+		if cancelErr != nil {
+			log.WithFields(f).Warnf("unable to void the corporate signature request, error: %+v", cancelErr)
+			return nil, cancelErr
+		}
+		if !out.EnvelopeVoided {
+			log.WithFields(f).Warnf("the DocuSign envelope of signature: %s was not voided, the signature was invalidated", pending.SignatureID)
+		}
+	}
+	if err = s.closePendingSignature(pending, PendingSignatureStatusCancelled, true); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// ProcessPendingSignatures reconciles the pending signature requests with their signatures, reminds the signatories
+// according to the policy and abandons the requests which were not signed in time
+func (s *service) ProcessPendingSignatures(ctx context.Context, now time.Time, policy PendingSignatureReminderPolicy) (*PendingSignatureReport, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessPendingSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	requests, err := s.pendingRepo.GetPendingSignaturesByStatus(PendingSignatureStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PendingSignatureReport{}
+	for _, pending := range requests {
+		report.RequestsChecked++
+		signed, refreshErr := s.refreshPendingSignature(ctx, pending)
+		if refreshErr != nil {
+			log.WithFields(f).Warnf("unable to refresh the pending signature request: %s, error: %+v", pending.RequestID, refreshErr)
+			continue
+		}
+		if signed {
+			report.RequestsSigned++
+			continue
+		}
+
+		action, elapsed := nextPendingSignatureAction(pending, now, policy)
+		switch action {
+		case pendingSignatureAbandon:
+			log.WithFields(f).Debugf("abandoning pending signature request: %s", pending.RequestID)
+			if closeErr := s.closePendingSignature(pending, PendingSignatureStatusAbandoned, true); closeErr != nil {
+				log.WithFields(f).Warnf("unable to abandon the pending signature request: %s, error: %+v", pending.RequestID, closeErr)
+				continue
+			}
+			report.RequestsAbandoned++
+		case pendingSignatureRemind:
+			if sendErr := sendPendingSignatureReminder(ctx, pending); sendErr != nil {
+				log.WithFields(f).Warnf("unable to send the reminder for pending signature request: %s, error: %+v", pending.RequestID, sendErr)
+				continue
+			}
+			pending.ReminderCount = elapsed
+			pending.DateLastReminded = utils.TimeToString(now)
+			if putErr := s.pendingRepo.PutPendingSignature(pending); putErr != nil {
+				log.WithFields(f).Warnf("unable to update the pending signature request: %s, error: %+v", pending.RequestID, putErr)
+			}
+			report.RemindersSent++
+		}
+	}
+	return report, nil
+}
+
+// getProjectCLAGroupID returns the CLA Group of the project
+func (s *service) getProjectCLAGroupID(projectSFID string) (string, error) {
+	project, err := projectService.GetClient().GetProject(projectSFID)
+	if err != nil {
+		return "", err
+	}
+	return s.getCLAGroupID(project.Parent, projectSFID)
+}
+
+// sendPendingSignatureReminder reminds the signatory to sign the corporate CLA
+func sendPendingSignatureReminder(ctx context.Context, pending *PendingSignature) error {
+	recipientName := pending.SignatoryName
+	if recipientName == "" {
+		recipientName = pending.SignatoryEmail
+	}
+	return notifications.Send(ctx, pending.ClaGroupID, notifications.CorporateSignatureReminderTemplate, []string{pending.SignatoryEmail},
+		notifications.CorporateSignatureReminderData{
+			RecipientName:       recipientName,
+			CompanyName:         pending.CompanyName,
+			ProjectName:         pending.ClaGroupName,
+			RequestedBy:         pending.RequestedBy,
+			DateRequested:       pending.DateRequested,
+			SendAsEmail:         pending.SendAsEmail,
+			CorporateConsoleURL: utils.GetCorporateURL(true),
+			V2:                  true,
+		})
+}
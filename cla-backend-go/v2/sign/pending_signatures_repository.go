@@ -0,0 +1,181 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// pending signature indexes
+const (
+	CompanySFIDIndex = "company-sfid-index"
+	StatusIndex      = "status-index"
+)
+
+// pending signature status values
+const (
+	PendingSignatureStatusPending   = "pending"
+	PendingSignatureStatusSigned    = "signed"
+	PendingSignatureStatusCancelled = "cancelled"
+	PendingSignatureStatusAbandoned = "abandoned"
+)
+
+// errors
+var (
+	ErrPendingSignatureNotFound   = errors.New("pending signature request not found")
+	ErrPendingSignatureNotPending = errors.New("signature request is no longer pending")
+)
+
+// PendingSignature is a corporate signature request sent to a CLA signatory - it stays pending until the signatory
+// signs the CCLA, a CLA manager cancels it or it is abandoned after the configured period
+type PendingSignature struct {
+	RequestID        string `json:"request_id"`
+	CompanySFID      string `json:"company_sfid"`
+	CompanyID        string `json:"company_id"`
+	CompanyName      string `json:"company_name"`
+	ProjectSFID      string `json:"project_sfid"`
+	ClaGroupID       string `json:"cla_group_id"`
+	ClaGroupName     string `json:"cla_group_name"`
+	SignatureID      string `json:"signature_id"`
+	SignatoryName    string `json:"signatory_name"`
+	SignatoryEmail   string `json:"signatory_email"`
+	RequestedBy      string `json:"requested_by"`
+	SendAsEmail      bool   `json:"send_as_email"`
+	Status           string `json:"status"`
+	ReminderCount    int    `json:"reminder_count"`
+	DateLastReminded string `json:"date_last_reminded,omitempty"`
+	DateRequested    string `json:"date_requested"`
+	DateModified     string `json:"date_modified"`
+	DateClosed       string `json:"date_closed,omitempty"`
+}
+
+// PendingSignatureRepository defines functions of the pending corporate signature ledger
+type PendingSignatureRepository interface {
+	PutPendingSignature(pending *PendingSignature) error
+	GetPendingSignature(requestID string) (*PendingSignature, error)
+	GetPendingSignaturesByCompany(companySFID string) ([]*PendingSignature, error)
+	GetPendingSignaturesByStatus(status string) ([]*PendingSignature, error)
+}
+
+type pendingSignatureRepo struct {
+	stage          string
+	dynamoDBClient storage.DynamoDBAPI
+	tableName      string
+}
+
+// NewPendingSignatureRepository creates a new pending corporate signature repository
+func NewPendingSignatureRepository(awsSession *session.Session, stage string) PendingSignatureRepository {
+	return &pendingSignatureRepo{
+		stage:          stage,
+		dynamoDBClient: storage.NewClient(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-pending-signatures", stage),
+	}
+}
+
+// PutPendingSignature creates or replaces the pending signature request
+func (repo *pendingSignatureRepo) PutPendingSignature(pending *PendingSignature) error {
+	_, currentTime := utils.CurrentTime()
+	if pending.DateRequested == "" {
+		pending.DateRequested = currentTime
+	}
+	pending.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(pending)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.Warnf("error storing pending signature request for company: %s, CLA Group: %s, error: %v",
+			pending.CompanySFID, pending.ClaGroupID, err)
+		return err
+	}
+	return nil
+}
+
+// GetPendingSignature returns the pending signature request
+func (repo *pendingSignatureRepo) GetPendingSignature(requestID string) (*PendingSignature, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {S: aws.String(requestID)},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching pending signature request: %s, error: %v", requestID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrPendingSignatureNotFound
+	}
+
+	var pending PendingSignature
+	err = dynamodbattribute.UnmarshalMap(result.Item, &pending)
+	if err != nil {
+		log.Warnf("error unmarshalling pending signature request, error: %v", err)
+		return nil, err
+	}
+	return &pending, nil
+}
+
+// GetPendingSignaturesByCompany returns the signature requests of the company, whatever their status
+func (repo *pendingSignatureRepo) GetPendingSignaturesByCompany(companySFID string) ([]*PendingSignature, error) {
+	return repo.query(CompanySFIDIndex, expression.Key("company_sfid").Equal(expression.Value(companySFID)))
+}
+
+// GetPendingSignaturesByStatus returns the signature requests with the status
+func (repo *pendingSignatureRepo) GetPendingSignaturesByStatus(status string) ([]*PendingSignature, error) {
+	return repo.query(StatusIndex, expression.Key("status").Equal(expression.Value(status)))
+}
+
+func (repo *pendingSignatureRepo) query(indexName string, condition expression.KeyConditionBuilder) ([]*PendingSignature, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	pendingSignatures := make([]*PendingSignature, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching pending signature requests using index: %s, error: %v", indexName, errQuery)
+			return nil, errQuery
+		}
+
+		var page []*PendingSignature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling pending signature requests, error: %v", err)
+			return nil, err
+		}
+		pendingSignatures = append(pendingSignatures, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return pendingSignatures, nil
+}
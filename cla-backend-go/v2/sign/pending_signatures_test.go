@@ -0,0 +1,41 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextPendingSignatureAction(t *testing.T) {
+	requested := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	pending := &PendingSignature{
+		RequestID:     "request-1",
+		DateRequested: utils.TimeToString(requested),
+	}
+
+	action, _ := nextPendingSignatureAction(pending, requested.Add(2*day), DefaultPendingSignatureReminderPolicy)
+	assert.Equal(t, pendingSignatureWait, action)
+
+	action, reminders := nextPendingSignatureAction(pending, requested.Add(3*day), DefaultPendingSignatureReminderPolicy)
+	assert.Equal(t, pendingSignatureRemind, action)
+	assert.Equal(t, 1, reminders)
+
+	// the reminder of the first interval was sent
+	pending.ReminderCount = 1
+	action, _ = nextPendingSignatureAction(pending, requested.Add(5*day), DefaultPendingSignatureReminderPolicy)
+	assert.Equal(t, pendingSignatureWait, action)
+
+	// a missed run sends a single reminder covering all the elapsed intervals
+	action, reminders = nextPendingSignatureAction(pending, requested.Add(15*day), DefaultPendingSignatureReminderPolicy)
+	assert.Equal(t, pendingSignatureRemind, action)
+	assert.Equal(t, 3, reminders)
+
+	action, _ = nextPendingSignatureAction(pending, requested.Add(30*day), DefaultPendingSignatureReminderPolicy)
+	assert.Equal(t, pendingSignatureAbandon, action)
+}
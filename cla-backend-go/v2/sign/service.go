@@ -12,6 +12,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
@@ -50,9 +51,18 @@ type ProjectRepo interface {
 	GetCLAGroupByID(projectID string, loadRepoDetails bool) (*v1Models.Project, error)
 }
 
+// SignatureRepo contains the signature repo methods
+type SignatureRepo interface {
+	GetSignature(ctx context.Context, signatureID string) (*v1Models.Signature, error)
+}
+
 // Service interface defines the sign service methods
 type Service interface {
 	RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error)
+
+	ListPendingSignatures(ctx context.Context, companySFID, projectSFID, status string) ([]*PendingSignature, error)
+	CancelPendingSignature(ctx context.Context, authorizationHeader, companySFID, projectSFID, requestID, lfUsername string) (*PendingSignature, error)
+	ProcessPendingSignatures(ctx context.Context, now time.Time, policy PendingSignatureReminderPolicy) (*PendingSignatureReport, error)
 }
 
 // service
@@ -62,16 +72,20 @@ type service struct {
 	projectRepo          ProjectRepo
 	projectClaGroupsRepo projects_cla_groups.Repository
 	companyService       company.IService
+	pendingRepo          PendingSignatureRepository
+	signatureRepo        SignatureRepo
 }
 
 // NewService returns an instance of v2 project service
-func NewService(apiURL string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService) Service {
+func NewService(apiURL string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService, pendingRepo PendingSignatureRepository, signatureRepo SignatureRepo) Service {
 	return &service{
 		ClaV1ApiURL:          apiURL,
 		companyRepo:          compRepo,
 		projectRepo:          projectRepo,
 		projectClaGroupsRepo: pcgRepo,
 		companyService:       compService,
+		pendingRepo:          pendingRepo,
+		signatureRepo:        signatureRepo,
 	}
 }
 
@@ -138,33 +152,9 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 	if err != nil {
 		return nil, err
 	}
-	var claGroupID string
-	if project.Parent == "" || project.Parent == utils.TheLinuxFoundation {
-		// this is root project
-		cgmlist, perr := s.projectClaGroupsRepo.GetProjectsIdsForFoundation(utils.StringValue(input.ProjectSfid))
-		if perr != nil {
-			return nil, perr
-		}
-		if len(cgmlist) == 0 {
-			// no cla group is link with root_project
-			return nil, projects_cla_groups.ErrProjectNotAssociatedWithClaGroup
-		}
-		claGroups := utils.NewStringSet()
-		for _, cg := range cgmlist {
-			claGroups.Add(cg.ClaGroupID)
-		}
-		if claGroups.Length() > 1 {
-			// multiple cla group are linked with root_project
-			// so we can not determine which cla-group to use
-			return nil, errors.New("invalid project_sfid. multiple cla-groups are associated with this project_sfid")
-		}
-		claGroupID = (claGroups.List())[0]
-	} else {
-		cgm, perr := s.projectClaGroupsRepo.GetClaGroupIDForProject(utils.StringValue(input.ProjectSfid))
-		if perr != nil {
-			return nil, perr
-		}
-		claGroupID = cgm.ClaGroupID
+	claGroupID, err := s.getCLAGroupID(project.Parent, utils.StringValue(input.ProjectSfid))
+	if err != nil {
+		return nil, err
 	}
 
 	proj, err := s.projectRepo.GetCLAGroupByID(claGroupID, DontLoadRepoDetails)
@@ -177,6 +167,7 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 	if len(proj.ProjectCorporateDocuments) == 0 {
 		return nil, ErrTemplateNotConfigured
 	}
+	signatoryName, signatoryEmail := input.AuthorityName, input.AuthorityEmail.String()
 	if input.SendAsEmail {
 		// this would be used only in case of cla-signatory
 		err = prepareUserForSigning(input.AuthorityEmail.String(), utils.StringValue(input.CompanySfid), utils.StringValue(input.ProjectSfid))
@@ -194,12 +185,14 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 		}
 
 		if userModel != nil {
+			signatoryName = userModel.Name
 			for _, email := range userModel.Emails {
 				if email != nil && *email.IsPrimary {
 					currentUserEmail = *email.EmailAddress
 				}
 			}
 		}
+		signatoryEmail = currentUserEmail
 
 		err = prepareUserForSigning(currentUserEmail, utils.StringValue(input.CompanySfid), utils.StringValue(input.ProjectSfid))
 		if err != nil {
@@ -234,9 +227,54 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 		log.Warnf("AddCLAManager- Unable to add user to company ACL, companyID: %s, user: %s, error: %+v", *input.CompanySfid, lfUsername, companyACLError)
 	}
 
+	// Track the request until the signatory signs - a failure here must not fail the signing request itself
+	s.recordPendingSignature(&PendingSignature{
+		CompanySFID:    utils.StringValue(input.CompanySfid),
+		CompanyID:      comp.CompanyID,
+		CompanyName:    comp.CompanyName,
+		ProjectSFID:    utils.StringValue(input.ProjectSfid),
+		ClaGroupID:     proj.ProjectID,
+		ClaGroupName:   proj.ProjectName,
+		SignatureID:    out.SignatureID,
+		SignatoryName:  signatoryName,
+		SignatoryEmail: signatoryEmail,
+		RequestedBy:    lfUsername,
+		SendAsEmail:    input.SendAsEmail,
+	})
+
 	return out.toModel(), nil
 }
 
+// getCLAGroupID returns the CLA Group of the project - a root project must be associated with exactly one CLA Group
+func (s *service) getCLAGroupID(parentProjectSFID, projectSFID string) (string, error) {
+	if parentProjectSFID == "" || parentProjectSFID == utils.TheLinuxFoundation {
+		// this is root project
+		cgmlist, perr := s.projectClaGroupsRepo.GetProjectsIdsForFoundation(projectSFID)
+		if perr != nil {
+			return "", perr
+		}
+		if len(cgmlist) == 0 {
+			// no cla group is link with root_project
+			return "", projects_cla_groups.ErrProjectNotAssociatedWithClaGroup
+		}
+		claGroups := utils.NewStringSet()
+		for _, cg := range cgmlist {
+			claGroups.Add(cg.ClaGroupID)
+		}
+		if claGroups.Length() > 1 {
+			// multiple cla group are linked with root_project
+			// so we can not determine which cla-group to use
+			return "", errors.New("invalid project_sfid. multiple cla-groups are associated with this project_sfid")
+		}
+		return (claGroups.List())[0], nil
+	}
+	cgm, perr := s.projectClaGroupsRepo.GetClaGroupIDForProject(projectSFID)
+	if perr != nil {
+		return "", perr
+	}
+	return cgm.ClaGroupID, nil
+}
+
 func requestCorporateSignature(authToken string, apiURL string, input *requestCorporateSignatureInput) (*requestCorporateSignatureOutput, error) {
 	log.Debugf("input.AuthorityName %s", input.AuthorityName)
 	f := logrus.Fields{
@@ -307,6 +345,69 @@ func requestCorporateSignature(authToken string, apiURL string, input *requestCo
 	return &out, nil
 }
 
+type cancelCorporateSignatureInput struct {
+	SignatureID string `json:"signature_id"`
+}
+
+type cancelCorporateSignatureOutput struct {
+	SignatureID    string `json:"signature_id"`
+	EnvelopeVoided bool   `json:"envelope_voided"`
+}
+
+// cancelCorporateSignature asks the v1 API to void the DocuSign envelope of the unsigned corporate signature and to
+// invalidate the signature record
+func cancelCorporateSignature(authToken string, apiURL string, signatureID string) (*cancelCorporateSignatureOutput, error) {
+	f := logrus.Fields{
+		"functionName": "cancelCorporateSignature",
+		"apiURL":       apiURL,
+		"signatureID":  signatureID,
+	}
+	requestBody, err := json.Marshal(&cancelCorporateSignatureInput{SignatureID: signatureID})
+	if err != nil {
+		log.WithFields(f).Warnf("json marshal error: %+v", err)
+		return nil, err
+	}
+	client := http.Client{}
+	req, err := http.NewRequest("POST", apiURL+"/v1/cancel-corporate-signature", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authToken)
+	resp, err := client.Do(req)
+	if err != nil {
+		log.WithFields(f).Warnf("client request error: %+v", err)
+		return nil, err
+	}
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			log.WithFields(f).Warnf("error closing response body: %+v", closeErr)
+		}
+	}()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.WithFields(f).Warnf("error reading response body: %+v", err)
+		return nil, err
+	}
+	log.WithFields(f).Debugf("cancel corporate signature response: %#v\n", string(responseBody))
+
+	var out struct {
+		cancelCorporateSignatureOutput
+		Errors map[string]interface{} `json:"errors"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cancel corporate signature failed with status %d: %s", resp.StatusCode, string(responseBody))
+	}
+	if err = json.Unmarshal(responseBody, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Errors) > 0 {
+		return nil, fmt.Errorf("cancel corporate signature failed: %v", out.Errors)
+	}
+	return &out.cancelCorporateSignatureOutput, nil
+}
+
 func removeSignatoryRole(userEmail string, companySFID string, projectSFID string) error {
 	f := logrus.Fields{"functionName": "removeSignatoryRole", "user_email": userEmail, "company_sfid": companySFID, "project_sfid": projectSFID}
 	log.WithFields(f).Debug("removing role for user")
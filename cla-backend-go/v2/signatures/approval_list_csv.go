@@ -0,0 +1,289 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/jinzhu/copier"
+)
+
+// approval list CSV columns
+const (
+	ApprovalListCSVEmail          = "Email"
+	ApprovalListCSVGitHubUsername = "GitHub Username"
+	ApprovalListCSVDomain         = "Domain"
+	ApprovalListCSVGitHubOrg      = "GitHub Org"
+)
+
+// errors
+var (
+	ErrApprovalListCSVNoColumns = errors.New("the approval list CSV header has none of the Email, GitHub Username, Domain or GitHub Org columns")
+	ErrCclaSignatureNotFound    = errors.New("company CCLA signature not found")
+)
+
+var approvalListCSVColumns = []string{ApprovalListCSVEmail, ApprovalListCSVGitHubUsername, ApprovalListCSVDomain, ApprovalListCSVGitHubOrg}
+
+// approvalListCSVColumn returns the approval list column of the CSV header value - the case, spaces and underscores
+// are ignored so that e.g. "github_username" matches
+func approvalListCSVColumn(header string) string {
+	normalized := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.TrimSpace(header)))
+	switch normalized {
+	case "email", "emails":
+		return ApprovalListCSVEmail
+	case "githubusername", "githubusernames", "githubuser":
+		return ApprovalListCSVGitHubUsername
+	case "domain", "domains":
+		return ApprovalListCSVDomain
+	case "githuborg", "githuborgs", "githuborganization", "githuborganizations":
+		return ApprovalListCSVGitHubOrg
+	}
+	return ""
+}
+
+// validateApprovalListCSVValue validates the value of the approval list column
+func validateApprovalListCSVValue(column, value string) (string, bool) {
+	switch column {
+	case ApprovalListCSVEmail:
+		if !utils.ValidEmail(value) {
+			return "invalid email address", false
+		}
+		return "", true
+	case ApprovalListCSVGitHubUsername:
+		return utils.ValidGitHubUsername(value)
+	case ApprovalListCSVDomain:
+		return utils.ValidDomain(value)
+	case ApprovalListCSVGitHubOrg:
+		return utils.ValidGitHubOrg(value)
+	}
+	return "unknown column", false
+}
+
+// parsedApprovalList holds the columns of the approval list CSV header, the valid values by column and the rejected
+// values
+type parsedApprovalList struct {
+	columns map[string]bool
+	values  map[string][]string
+	rows    int
+	errors  []*models.ApprovalListImportError
+}
+
+// parseApprovalListCSV reads the approval list CSV - the first row is the header, the cells of the other rows are
+// validated by column and the duplicates are dropped
+func parseApprovalListCSV(r io.Reader) (*parsedApprovalList, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrApprovalListCSVNoColumns
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(header))
+	haveColumn := false
+	for i, h := range header {
+		columns[i] = approvalListCSVColumn(h)
+		haveColumn = haveColumn || columns[i] != ""
+	}
+	if !haveColumn {
+		return nil, ErrApprovalListCSVNoColumns
+	}
+
+	parsed := &parsedApprovalList{columns: map[string]bool{}, values: map[string][]string{}}
+	for _, column := range columns {
+		if column != "" {
+			parsed.columns[column] = true
+		}
+	}
+	seen := map[string]bool{}
+	for row := 2; ; row++ {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+		parsed.rows++
+		for i, cell := range record {
+			value := strings.TrimSpace(cell)
+			if value == "" {
+				continue
+			}
+			if i >= len(columns) || columns[i] == "" {
+				parsed.errors = append(parsed.errors, &models.ApprovalListImportError{
+					Row: int64(row), Column: fmt.Sprintf("%d", i+1), Value: value, Message: "value outside of the approval list columns"})
+				continue
+			}
+			if msg, valid := validateApprovalListCSVValue(columns[i], value); !valid {
+				parsed.errors = append(parsed.errors, &models.ApprovalListImportError{
+					Row: int64(row), Column: columns[i], Value: value, Message: msg})
+				continue
+			}
+			key := columns[i] + "#" + strings.ToLower(value)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			parsed.values[columns[i]] = append(parsed.values[columns[i]], value)
+		}
+	}
+
+	return parsed, nil
+}
+
+// diffApprovalList returns the changes which bring the signature approval list in line with the CSV values and the
+// number of CSV values already on the approval list. Values are compared ignoring the case, like the approval list
+// evaluation. Without replace the approval list entries missing from the CSV are kept. With replace only the lists
+// whose column is in the CSV header are replaced, the other lists are kept.
+func diffApprovalList(sig *v1Models.Signature, parsed *parsedApprovalList, replace bool) (*v1Models.ApprovalList, int) {
+	changes := &v1Models.ApprovalList{}
+	unchanged := 0
+	lists := []struct {
+		column  string
+		current []string
+		add     *[]string
+		remove  *[]string
+	}{
+		{ApprovalListCSVEmail, sig.EmailApprovalList, &changes.AddEmailApprovalList, &changes.RemoveEmailApprovalList},
+		{ApprovalListCSVGitHubUsername, sig.GithubUsernameApprovalList, &changes.AddGithubUsernameApprovalList, &changes.RemoveGithubUsernameApprovalList},
+		{ApprovalListCSVDomain, sig.DomainApprovalList, &changes.AddDomainApprovalList, &changes.RemoveDomainApprovalList},
+		{ApprovalListCSVGitHubOrg, sig.GithubOrgApprovalList, &changes.AddGithubOrgApprovalList, &changes.RemoveGithubOrgApprovalList},
+	}
+	for _, list := range lists {
+		current := map[string]bool{}
+		for _, value := range list.current {
+			current[strings.ToLower(strings.TrimSpace(value))] = true
+		}
+		imported := map[string]bool{}
+		for _, value := range parsed.values[list.column] {
+			imported[strings.ToLower(value)] = true
+			if current[strings.ToLower(value)] {
+				unchanged++
+				continue
+			}
+			*list.add = append(*list.add, value)
+		}
+		if !replace || !parsed.columns[list.column] {
+			continue
+		}
+		for _, value := range list.current {
+			if !imported[strings.ToLower(strings.TrimSpace(value))] {
+				*list.remove = append(*list.remove, value)
+			}
+		}
+	}
+	return changes, unchanged
+}
+
+// hasApprovalListChanges returns true if the approval list update adds or removes any entry
+func hasApprovalListChanges(changes *v1Models.ApprovalList) bool {
+	return len(changes.AddEmailApprovalList) > 0 || len(changes.RemoveEmailApprovalList) > 0 ||
+		len(changes.AddDomainApprovalList) > 0 || len(changes.RemoveDomainApprovalList) > 0 ||
+		len(changes.AddGithubUsernameApprovalList) > 0 || len(changes.RemoveGithubUsernameApprovalList) > 0 ||
+		len(changes.AddGithubOrgApprovalList) > 0 || len(changes.RemoveGithubOrgApprovalList) > 0
+}
+
+// approvalListCSV writes the approval list of the signature as a CSV document, one column per list
+func approvalListCSV(sig *v1Models.Signature) ([]byte, error) {
+	lists := [][]string{sig.EmailApprovalList, sig.GithubUsernameApprovalList, sig.DomainApprovalList, sig.GithubOrgApprovalList}
+	rows := 0
+	for _, list := range lists {
+		if len(list) > rows {
+			rows = len(list)
+		}
+	}
+
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+	if err := writer.Write(approvalListCSVColumns); err != nil {
+		return nil, err
+	}
+	for row := 0; row < rows; row++ {
+		record := make([]string, len(lists))
+		for i, list := range lists {
+			if row < len(list) {
+				record[i] = list[row]
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return b.Bytes(), writer.Error()
+}
+
+// getCclaSignature returns the signed and approved CCLA signature of the company for the CLA Group
+func (s service) getCclaSignature(ctx context.Context, companyID, claGroupID string) (*v1Models.Signature, error) {
+	signed, approved := true, true
+	sig, err := s.v1SignatureService.GetProjectCompanySignature(ctx, companyID, claGroupID, &signed, &approved, nil, aws.Int64(HugePageSize))
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, ErrCclaSignatureNotFound
+	}
+	return sig, nil
+}
+
+// GetApprovalListCsv returns the approval list of the company CCLA as a CSV document
+func (s service) GetApprovalListCsv(ctx context.Context, companyID, claGroupID string) ([]byte, error) {
+	sig, err := s.getCclaSignature(ctx, companyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return approvalListCSV(sig)
+}
+
+// ImportApprovalListCsv computes the changes of the CSV document to the approval list of the company CCLA and,
+// unless dryRun is set, applies them. The invalid CSV values are reported and skipped.
+func (s service) ImportApprovalListCsv(ctx context.Context, authUser *auth.User, projectModel *v1Models.Project, companyModel *v1Models.Company, claGroupID string, file io.Reader, dryRun, replace bool) (*models.ApprovalListImport, error) {
+	sig, err := s.getCclaSignature(ctx, companyModel.CompanyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseApprovalListCSV(file)
+	if err != nil {
+		return nil, err
+	}
+	changes, unchanged := diffApprovalList(sig, parsed, replace)
+
+	result := &models.ApprovalListImport{
+		DryRun:         dryRun,
+		Replace:        replace,
+		RowsProcessed:  int64(parsed.rows),
+		UnchangedCount: int64(unchanged),
+		Changes:        &models.ApprovalList{},
+		Errors:         parsed.errors,
+	}
+	if err = copier.Copy(result.Changes, changes); err != nil {
+		return nil, err
+	}
+	if dryRun || !hasApprovalListChanges(changes) {
+		return result, nil
+	}
+
+	_, err = s.v1SignatureService.UpdateApprovalList(ctx, authUser, projectModel, companyModel, claGroupID, changes)
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+	return result, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"strings"
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseApprovalListCSV tests that the valid values are kept by column and the invalid ones are reported
+func TestParseApprovalListCSV(t *testing.T) {
+	doc := "email,github_username,Domain,GitHub Organization\n" +
+		"jane@example.org,janedoe,example.org,example-org\n" +
+		"not-an-email,,example.org,\n" +
+		"JANE@example.org,johndoe,,,extra\n"
+
+	parsed, err := parseApprovalListCSV(strings.NewReader(doc))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, parsed.rows)
+		assert.Equal(t, []string{"jane@example.org"}, parsed.values[ApprovalListCSVEmail])
+		assert.Equal(t, []string{"janedoe", "johndoe"}, parsed.values[ApprovalListCSVGitHubUsername])
+		assert.Equal(t, []string{"example.org"}, parsed.values[ApprovalListCSVDomain])
+		assert.Equal(t, []string{"example-org"}, parsed.values[ApprovalListCSVGitHubOrg])
+		assert.Len(t, parsed.columns, 4)
+		if assert.Len(t, parsed.errors, 2) {
+			assert.Equal(t, int64(3), parsed.errors[0].Row)
+			assert.Equal(t, ApprovalListCSVEmail, parsed.errors[0].Column)
+			assert.Equal(t, "5", parsed.errors[1].Column)
+		}
+	}
+
+	_, err = parseApprovalListCSV(strings.NewReader("name,company\n"))
+	assert.Equal(t, ErrApprovalListCSVNoColumns, err)
+}
+
+// TestDiffApprovalList tests the changes of the imported values against the current approval list
+func TestDiffApprovalList(t *testing.T) {
+	sig := &v1Models.Signature{
+		EmailApprovalList:  []string{"Jane@Example.org", "old@example.org"},
+		DomainApprovalList: []string{"example.org"},
+	}
+	parsed := &parsedApprovalList{
+		columns: map[string]bool{ApprovalListCSVEmail: true, ApprovalListCSVGitHubUsername: true, ApprovalListCSVDomain: true},
+		values: map[string][]string{
+			ApprovalListCSVEmail:          {"jane@example.org", "john@example.org"},
+			ApprovalListCSVGitHubUsername: {"janedoe"},
+		},
+	}
+
+	changes, unchanged := diffApprovalList(sig, parsed, false)
+	assert.Equal(t, 1, unchanged)
+	assert.Equal(t, []string{"john@example.org"}, changes.AddEmailApprovalList)
+	assert.Equal(t, []string{"janedoe"}, changes.AddGithubUsernameApprovalList)
+	assert.Nil(t, changes.RemoveEmailApprovalList)
+	assert.Nil(t, changes.RemoveDomainApprovalList)
+
+	changes, _ = diffApprovalList(sig, parsed, true)
+	assert.Equal(t, []string{"old@example.org"}, changes.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"example.org"}, changes.RemoveDomainApprovalList)
+	assert.True(t, hasApprovalListChanges(changes))
+
+	// the lists without a column in the CSV header are kept
+	parsed.columns = map[string]bool{ApprovalListCSVEmail: true}
+	changes, _ = diffApprovalList(sig, parsed, true)
+	assert.Equal(t, []string{"old@example.org"}, changes.RemoveEmailApprovalList)
+	assert.Nil(t, changes.RemoveDomainApprovalList)
+
+	doc, err := approvalListCSV(sig)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Email,GitHub Username,Domain,GitHub Org\nJane@Example.org,,example.org,\nold@example.org,,,\n", string(doc))
+	}
+}
@@ -32,12 +32,13 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	signatureService "github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/gorilla/sessions"
 	"github.com/jinzhu/copier"
-	"github.com/savaki/dynastore"
+	"github.com/sirupsen/logrus"
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, projectService project.Service, projectRepo project.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2service Service, projectClaGroupsRepo projects_cla_groups.Repository) { //nolint
+func Configure(api *operations.EasyclaAPI, projectService project.Service, projectRepo project.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore sessions.Store, eventsService events.Service, v2service Service, projectClaGroupsRepo projects_cla_groups.Repository) { //nolint
 
 	// Get Signature
 	api.SignaturesGetSignatureHandler = signatures.GetSignatureHandlerFunc(func(params signatures.GetSignatureParams, authUser *auth.User) middleware.Responder {
@@ -130,6 +131,186 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 		return signatures.NewUpdateApprovalListOK().WithXRequestID(reqID).WithPayload(&v2Sig)
 	})
 
+	api.SignaturesEvaluateApprovalListHandler = signatures.EvaluateApprovalListHandlerFunc(func(params signatures.EvaluateApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must be in the Project|Organization Scope or the Project Scope to see this
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) && !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to evaluate Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewEvaluateApprovalListForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		if params.Body == nil || (params.Body.LfUsername == "" && len(params.Body.Emails) == 0 && params.Body.GithubUsername == "" && len(params.Body.GithubOrgs) == 0) {
+			msg := "EasyCLA - 400 Bad Request - at least one of the LF username, emails, GitHub username or GitHub organizations is required"
+			log.Warn(msg)
+			return signatures.NewEvaluateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "400",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewEvaluateApprovalListNotFound().WithXRequestID(reqID).WithPayload(errorResponse(compErr))
+		}
+
+		decision, err := v1SignatureService.EvaluateApprovalList(ctx, params.ClaGroupID, companyModel.CompanyID, &signatureService.ApprovalListCandidate{
+			LFUsername:     params.Body.LfUsername,
+			Emails:         params.Body.Emails,
+			GitHubUsername: params.Body.GithubUsername,
+			GitHubOrgs:     params.Body.GithubOrgs,
+		})
+		if err != nil {
+			log.Warnf("unable to evaluate approval list using CLA Group ID: %s and company ID: %s", params.ClaGroupID, companyModel.CompanyID)
+			return signatures.NewEvaluateApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		return signatures.NewEvaluateApprovalListOK().WithXRequestID(reqID).WithPayload(&models.ApprovalListEvaluation{
+			Approved:     decision.Approved,
+			SignatureID:  decision.SignatureID,
+			RuleType:     decision.RuleType,
+			Rule:         decision.Rule,
+			MatchedValue: decision.MatchedValue,
+			Reason:       decision.Reason,
+		})
+	})
+
+	api.SignaturesRemoveSelfFromApprovalListHandler = signatures.RemoveSelfFromApprovalListHandlerFunc(func(params signatures.RemoveSelfFromApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Any authenticated contributor may remove their own entries - no Project|Organization scope is required
+		if authUser.UserName == "" {
+			msg := "EasyCLA - 403 Forbidden - the user name is required to leave the Project Company Approval List"
+			log.Warn(msg)
+			return signatures.NewRemoveSelfFromApprovalListForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewRemoveSelfFromApprovalListNotFound().WithXRequestID(reqID).WithPayload(errorResponse(compErr))
+		}
+
+		projectModel, projErr := projectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || projectModel == nil {
+			log.Warnf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			return signatures.NewRemoveSelfFromApprovalListNotFound().WithXRequestID(reqID).WithPayload(errorResponse(projErr))
+		}
+
+		removal, err := v1SignatureService.RemoveSelfFromApprovalList(ctx, authUser, projectModel, companyModel, params.ClaGroupID)
+		if err != nil {
+			log.Warnf("unable to remove user %s from the approval list using CLA Group ID: %s", authUser.UserName, params.ClaGroupID)
+			return signatures.NewRemoveSelfFromApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		// Convert the v1 output model to a v2 response model
+		v2ApprovalList := models.ApprovalList{}
+		err = copier.Copy(&v2ApprovalList, removal)
+		if err != nil {
+			return signatures.NewRemoveSelfFromApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		return signatures.NewRemoveSelfFromApprovalListOK().WithXRequestID(reqID).WithPayload(&v2ApprovalList)
+	})
+
+	api.SignaturesDownloadApprovalListAsCSVHandler = signatures.DownloadApprovalListAsCSVHandlerFunc(func(params signatures.DownloadApprovalListAsCSVParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must be in the Project|Organization Scope to see this
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to download the Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewDownloadApprovalListAsCSVForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewDownloadApprovalListAsCSVNotFound().WithXRequestID(reqID).WithPayload(errorResponse(compErr))
+		}
+
+		result, err := v2service.GetApprovalListCsv(ctx, companyModel.CompanyID, params.ClaGroupID)
+		if err != nil {
+			if err == ErrCclaSignatureNotFound {
+				return signatures.NewDownloadApprovalListAsCSVNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return signatures.NewDownloadApprovalListAsCSVInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+			rw.Header().Set("Content-Type", "text/csv")
+			rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"approval-list-%s.csv\"", params.CompanySFID))
+			rw.Header().Set(utils.XREQUESTID, reqID)
+			rw.WriteHeader(http.StatusOK)
+			_, err := rw.Write(result)
+			if err != nil {
+				log.Warnf("Error writing csv file, error: %v", err)
+			}
+		})
+	})
+
+	api.SignaturesImportApprovalListCSVHandler = signatures.ImportApprovalListCSVHandlerFunc(func(params signatures.ImportApprovalListCSVParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		defer params.File.Close() // nolint
+
+		// Must be in the Project|Organization Scope to see this
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to import the Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewImportApprovalListCSVForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewImportApprovalListCSVNotFound().WithXRequestID(reqID).WithPayload(errorResponse(compErr))
+		}
+
+		projectModel, projErr := projectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || projectModel == nil {
+			log.Warnf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			return signatures.NewImportApprovalListCSVNotFound().WithXRequestID(reqID).WithPayload(errorResponse(projErr))
+		}
+
+		dryRun := params.DryRun != nil && *params.DryRun
+		replace := params.Replace != nil && *params.Replace
+		result, err := v2service.ImportApprovalListCsv(ctx, authUser, projectModel, companyModel, params.ClaGroupID, params.File, dryRun, replace)
+		if err != nil {
+			log.Warnf("unable to import the approval list CSV using CLA Group ID: %s, company ID: %s, error: %+v",
+				params.ClaGroupID, companyModel.CompanyID, err)
+			if err == ErrCclaSignatureNotFound {
+				return signatures.NewImportApprovalListCSVNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return signatures.NewImportApprovalListCSVBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		return signatures.NewImportApprovalListCSVOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
 	// Retrieve GitHub Approval Entries
 	api.SignaturesGetGitHubOrgWhitelistHandler = signatures.GetGitHubOrgWhitelistHandlerFunc(func(params signatures.GetGitHubOrgWhitelistParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -610,6 +791,86 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 		return signatures.NewGetSignatureSignedDocumentOK().WithXRequestID(reqID).WithPayload(doc)
 	})
 
+	api.SignaturesGetSignatureCertifiedSignedDocumentHandler = signatures.GetSignatureCertifiedSignedDocumentHandlerFunc(func(params signatures.GetSignatureCertifiedSignedDocumentParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "SignaturesGetSignatureCertifiedSignedDocumentHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"signatureID":    params.SignatureID,
+		}
+
+		signature, err := v1SignatureService.GetSignature(ctx, params.SignatureID)
+		if err != nil {
+			return signatures.NewGetSignatureCertifiedSignedDocumentInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		if signature == nil {
+			return signatures.NewGetSignatureCertifiedSignedDocumentNotFound().WithXRequestID(reqID).WithPayload(errorResponse(ErrSignatureNotFound))
+		}
+		haveAccess, err := isUserHaveAccessOfSignedSignaturePDF(ctx, authUser, signature, companyService, projectClaGroupsRepo)
+		if err != nil {
+			return signatures.NewGetSignatureCertifiedSignedDocumentInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		if !haveAccess {
+			return signatures.NewGetSignatureCertifiedSignedDocumentForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: "EasyCLA - 403 Forbidden : user does not have access of signature",
+			})
+		}
+		pdf, err := v2service.GetCertifiedSignedDocument(ctx, signature.SignatureID.String())
+		if err != nil {
+			if err == ErrNoSignedDocument {
+				return signatures.NewGetSignatureCertifiedSignedDocumentBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			log.WithFields(f).Warnf("unable to build the certified signed document, error: %+v", err)
+			return signatures.NewGetSignatureCertifiedSignedDocumentInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+			rw.Header().Set("Content-Type", "application/pdf")
+			rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-certified.pdf", signature.SignatureID))
+			rw.Header().Set(utils.XREQUESTID, reqID)
+			rw.WriteHeader(http.StatusOK)
+			_, err := rw.Write(pdf)
+			if err != nil {
+				log.WithFields(f).Warnf("Error writing pdf file, error: %v", err)
+			}
+		})
+	})
+
+	api.SignaturesVerifySignatureSignedDocumentHandler = signatures.VerifySignatureSignedDocumentHandlerFunc(func(params signatures.VerifySignatureSignedDocumentParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		signature, err := v1SignatureService.GetSignature(ctx, params.SignatureID)
+		if err != nil {
+			return signatures.NewVerifySignatureSignedDocumentInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		if signature == nil {
+			return signatures.NewVerifySignatureSignedDocumentNotFound().WithXRequestID(reqID).WithPayload(errorResponse(ErrSignatureNotFound))
+		}
+		haveAccess, err := isUserHaveAccessOfSignedSignaturePDF(ctx, authUser, signature, companyService, projectClaGroupsRepo)
+		if err != nil {
+			return signatures.NewVerifySignatureSignedDocumentInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		if !haveAccess {
+			return signatures.NewVerifySignatureSignedDocumentForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: "EasyCLA - 403 Forbidden : user does not have access of signature",
+			})
+		}
+		result, err := v2service.VerifySignedDocument(ctx, signature.SignatureID.String())
+		if err != nil {
+			if err == ErrNoSignedDocument {
+				return signatures.NewVerifySignatureSignedDocumentBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return signatures.NewVerifySignatureSignedDocumentInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		return signatures.NewVerifySignatureSignedDocumentOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
 	api.SignaturesDownloadProjectSignatureICLAsHandler = signatures.DownloadProjectSignatureICLAsHandlerFunc(
 		func(params signatures.DownloadProjectSignatureICLAsParams, authUser *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -8,18 +8,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
-
-	"github.com/aws/aws-sdk-go/aws"
+	"github.com/LF-Engineering/lfx-kit/auth"
 
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/jinzhu/copier"
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
@@ -37,18 +36,26 @@ const (
 	ClaSignatureType  = "cla"
 )
 
+// signed document verification status values
+const (
+	SignedDocumentVerified    = "verified"
+	SignedDocumentMismatch    = "mismatch"
+	SignedDocumentNotRecorded = "not_recorded"
+)
+
 // errors
 var (
-	ErrZipNotPresent = errors.New("zip file not present")
+	ErrZipNotPresent     = errors.New("zip file not present")
+	ErrSignatureNotFound = errors.New("signature not found")
+	ErrNoSignedDocument  = errors.New("bad request. employee signature does not have signed document")
 )
 
 type service struct {
 	v1ProjectService      project.Service
 	v1CompanyService      company.IService
 	v1SignatureService    signatures.SignatureService
+	eventsService         events.Service
 	projectsClaGroupsRepo projects_cla_groups.Repository
-	s3                    *s3.S3
-	signaturesBucket      string
 }
 
 // Service contains method of v2 signature service
@@ -59,22 +66,26 @@ type Service interface {
 	GetClaGroupCorporateContributorsCsv(ctx context.Context, claGroupID string, companySFID string) ([]byte, error)
 	GetClaGroupCorporateContributors(ctx context.Context, claGroupID string, companySFID *string, searchTerm *string) (*models.CorporateContributorList, error)
 	GetSignedDocument(ctx context.Context, signatureID string) (*models.SignedDocument, error)
+	GetCertifiedSignedDocument(ctx context.Context, signatureID string) ([]byte, error)
+	VerifySignedDocument(ctx context.Context, signatureID string) (*models.SignedDocumentVerification, error)
 	GetSignedIclaZipPdf(claGroupID string) (*models.URLObject, error)
 	GetSignedCclaZipPdf(claGroupID string) (*models.URLObject, error)
+	GetApprovalListCsv(ctx context.Context, companyID, claGroupID string) ([]byte, error)
+	ImportApprovalListCsv(ctx context.Context, authUser *auth.User, projectModel *v1Models.Project, companyModel *v1Models.Company, claGroupID string, file io.Reader, dryRun, replace bool) (*models.ApprovalListImport, error)
 }
 
 // NewService creates instance of v2 signature service
-func NewService(awsSession *session.Session, signaturesBucketName string, v1ProjectService project.Service,
+func NewService(v1ProjectService project.Service,
 	v1CompanyService company.IService,
 	v1SignatureService signatures.SignatureService,
+	eventsService events.Service,
 	pcgRepo projects_cla_groups.Repository) *service {
 	return &service{
 		v1ProjectService:      v1ProjectService,
 		v1CompanyService:      v1CompanyService,
 		v1SignatureService:    v1SignatureService,
+		eventsService:         eventsService,
 		projectsClaGroupsRepo: pcgRepo,
-		s3:                    s3.New(awsSession),
-		signaturesBucket:      signaturesBucketName,
 	}
 }
 
@@ -181,8 +192,96 @@ func (s service) GetSignedDocument(ctx context.Context, signatureID string) (*mo
 	if err != nil {
 		return nil, err
 	}
+	url, err := signedDocumentFilename(sig)
+	if err != nil {
+		return nil, err
+	}
+	signedURL, err := utils.GetDownloadLink(url)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SignedDocument{
+		SignatureID:  signatureID,
+		SignedClaURL: signedURL,
+	}, nil
+}
+
+// GetCertifiedSignedDocument returns the signed document with an audit trail page appended - the page lists the
+// signer, the document version, the SHA-256 recorded at signing and the approval history of the signature
+func (s service) GetCertifiedSignedDocument(ctx context.Context, signatureID string) ([]byte, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCertifiedSignedDocument",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+	sig, pdf, err := s.getSignedDocumentContent(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*v1Models.Event
+	eventList, err := s.eventsService.GetClaGroupEvents(sig.ProjectID, nil, nil, true, nil)
+	if err != nil {
+		// the document is still useful without the history, the audit page notes the history is unavailable
+		log.WithFields(f).Warnf("unable to load the signature events, error: %+v", err)
+	} else {
+		history = signatureEvents(sig, eventList.Events)
+	}
+
+	auditTrail := utils.BuildTextPdf("EasyCLA Signature Audit Trail", auditTrailLines(sig, utils.Sha256Hex(pdf), history, err == nil))
+	log.WithFields(f).Debug("appending the audit trail to the signed document")
+	return utils.MergePdfs(pdf, auditTrail)
+}
+
+// VerifySignedDocument compares the SHA-256 of the stored signed document with the hash recorded at signing
+func (s service) VerifySignedDocument(ctx context.Context, signatureID string) (*models.SignedDocumentVerification, error) {
+	sig, pdf, err := s.getSignedDocumentContent(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+
+	computed := utils.Sha256Hex(pdf)
+	status := SignedDocumentVerified
+	switch {
+	case sig.SignatureDocumentSha256 == "":
+		status = SignedDocumentNotRecorded
+	case sig.SignatureDocumentSha256 != computed:
+		status = SignedDocumentMismatch
+	}
+	return &models.SignedDocumentVerification{
+		SignatureID:    signatureID,
+		RecordedSha256: sig.SignatureDocumentSha256,
+		ComputedSha256: computed,
+		HashedOn:       sig.SignatureDocumentHashedOn,
+		Status:         status,
+		Verified:       status == SignedDocumentVerified,
+	}, nil
+}
+
+// getSignedDocumentContent loads the signature and downloads its signed document
+func (s service) getSignedDocumentContent(ctx context.Context, signatureID string) (*v1Models.Signature, []byte, error) {
+	sig, err := s.v1SignatureService.GetSignature(ctx, signatureID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sig == nil {
+		return nil, nil, ErrSignatureNotFound
+	}
+	filename, err := signedDocumentFilename(sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	pdf, err := utils.DownloadFromS3(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, pdf, nil
+}
+
+// signedDocumentFilename returns the s3 filename of the signed ICLA or CCLA document
+func signedDocumentFilename(sig *v1Models.Signature) (string, error) {
 	if sig.SignatureType == ClaSignatureType && sig.CompanyName != "" {
-		return nil, errors.New("bad request. employee signature does not have signed document")
+		return "", ErrNoSignedDocument
 	}
 	var url string
 	switch sig.SignatureType {
@@ -191,14 +290,72 @@ func (s service) GetSignedDocument(ctx context.Context, signatureID string) (*mo
 	case CclaSignatureType:
 		url = utils.SignedCLAFilename(sig.ProjectID, "ccla", sig.SignatureReferenceID.String(), sig.SignatureID.String())
 	}
-	signedURL, err := utils.GetDownloadLink(url)
-	if err != nil {
-		return nil, err
+	return url, nil
+}
+
+// signatureEvents returns the CLA Group events of the signer - the company for a CCLA or the user for an ICLA - in
+// chronological order
+func signatureEvents(sig *v1Models.Signature, claGroupEvents []*v1Models.Event) []*v1Models.Event {
+	referenceID := sig.SignatureReferenceID.String()
+	var history []*v1Models.Event
+	for _, event := range claGroupEvents {
+		if (sig.SignatureType == CclaSignatureType && event.EventCompanyID == referenceID) ||
+			(sig.SignatureType == ClaSignatureType && event.UserID == referenceID) {
+			history = append(history, event)
+		}
 	}
-	return &models.SignedDocument{
-		SignatureID:  signatureID,
-		SignedClaURL: signedURL,
-	}, nil
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].EventTimeEpoch < history[j].EventTimeEpoch
+	})
+	return history
+}
+
+// auditTrailLines returns the text of the audit trail page
+func auditTrailLines(sig *v1Models.Signature, computedSha256 string, history []*v1Models.Event, historyLoaded bool) []string {
+	recorded := sig.SignatureDocumentSha256
+	if recorded == "" {
+		recorded = "not recorded"
+	}
+	lines := []string{
+		fmt.Sprintf("Signature ID: %s", sig.SignatureID),
+		fmt.Sprintf("CLA Group ID: %s", sig.ProjectID),
+		fmt.Sprintf("Signature Type: %s", sig.SignatureType),
+		fmt.Sprintf("Signed By: %s", sig.SignatureReferenceName),
+	}
+	if sig.SignatoryName != "" {
+		lines = append(lines, fmt.Sprintf("Signatory: %s", sig.SignatoryName))
+	}
+	if sig.UserLFID != "" {
+		lines = append(lines, fmt.Sprintf("LF ID: %s", sig.UserLFID))
+	}
+	if sig.UserGHUsername != "" {
+		lines = append(lines, fmt.Sprintf("GitHub Username: %s", sig.UserGHUsername))
+	}
+	if sig.CompanyName != "" {
+		lines = append(lines, fmt.Sprintf("Company: %s", sig.CompanyName))
+	}
+	lines = append(lines,
+		fmt.Sprintf("Signed On: %s", sig.SignedOn),
+		fmt.Sprintf("Document Version: %s.%s", sig.SignatureMajorVersion, sig.SignatureMinorVersion),
+		"",
+		fmt.Sprintf("Signed Document SHA-256 (recorded at signing): %s", recorded),
+		fmt.Sprintf("Signed Document SHA-256 (at export): %s", computedSha256),
+	)
+	if sig.SignatureDocumentHashedOn != "" {
+		lines = append(lines, fmt.Sprintf("Hash Recorded On: %s", sig.SignatureDocumentHashedOn))
+	}
+
+	lines = append(lines, "", "Approval History:")
+	switch {
+	case !historyLoaded:
+		lines = append(lines, "  unavailable")
+	case len(history) == 0:
+		lines = append(lines, "  none")
+	}
+	for _, event := range history {
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s", event.EventTime, event.EventType, event.EventSummary))
+	}
+	return lines
 }
 
 func (s service) GetSignedCclaZipPdf(claGroupID string) (*models.URLObject, error) {
@@ -238,18 +395,7 @@ func (s service) GetSignedIclaZipPdf(claGroupID string) (*models.URLObject, erro
 }
 
 func (s service) IsZipPresentOnS3(zipFilePath string) (bool, error) {
-	_, err := s.s3.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String(s.signaturesBucket),
-		Key:    aws.String(zipFilePath),
-	})
-	if err != nil {
-		aerr, ok := err.(awserr.Error)
-		if ok && aerr.Code() == s3.ErrCodeNoSuchKey {
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
+	return utils.ExistsInS3(zipFilePath)
 }
 
 func (s service) GetClaGroupCorporateContributors(ctx context.Context, claGroupID string, companySFID *string, searchTerm *string) (*models.CorporateContributorList, error) {
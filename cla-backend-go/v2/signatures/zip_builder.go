@@ -16,15 +16,6 @@ import (
 	"github.com/juju/zip"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
-
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
-
-	"github.com/aws/aws-sdk-go/aws/awserr"
-
-	"github.com/aws/aws-sdk-go/aws"
-
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
 )
 
 // constants
@@ -36,8 +27,7 @@ const (
 
 // Zipper implements ZipBuilder interface
 type Zipper struct {
-	s3         *s3.S3
-	bucketName string
+	store utils.BlobStore
 }
 
 // ZipBuilder provides method to build ICLA/CCLA zip
@@ -47,10 +37,9 @@ type ZipBuilder interface {
 }
 
 // NewZipBuilder returns the ZipBuilder
-func NewZipBuilder(awsSession *session.Session, bucketName string) ZipBuilder {
+func NewZipBuilder(store utils.BlobStore) ZipBuilder {
 	return &Zipper{
-		s3:         s3.New(awsSession),
-		bucketName: bucketName,
+		store: store,
 	}
 }
 
@@ -94,7 +83,7 @@ func (z *Zipper) buildZip(claType string, claGroupID string) error {
 		return err
 	}
 	var zipUpdated bool
-	log.WithFields(f).Debug("getting s3 files")
+	log.WithFields(f).Debug("getting signed files")
 	downloaderInputChan := make(chan *DownloadFileInput)
 	downloaderOutputChan := make(chan *FileContent)
 	var wg sync.WaitGroup
@@ -107,29 +96,24 @@ func (z *Zipper) buildZip(claType string, claGroupID string) error {
 		close(downloaderOutputChan)
 	}()
 	go func() {
-		err = z.s3.ListObjectsPages(&s3.ListObjectsInput{
-			Bucket: aws.String(z.bucketName),
-			Prefix: aws.String(s3ZipPrefix(claType, claGroupID)),
-		}, func(output *s3.ListObjectsOutput, b bool) bool {
-			for _, obj := range output.Contents {
-				key := utils.StringValue(obj.Key)
-				tmp := strings.Split(key, "/")
-				if len(tmp) != 5 {
-					continue
-				}
-				filename := tmp[4]
-				if files != nil && files.Include(filename) {
-					// skip files which are already present in zip
-					log.Debugf("file %s already present in zip", filename)
-					continue
-				}
-				downloaderInputChan <- &DownloadFileInput{
-					filename: filename,
-					key:      obj.Key,
-				}
+		var keys []string
+		keys, err = z.store.List(s3ZipPrefix(claType, claGroupID))
+		for _, key := range keys {
+			tmp := strings.Split(key, "/")
+			if len(tmp) != 5 {
+				continue
 			}
-			return true
-		})
+			filename := tmp[4]
+			if files != nil && files.Include(filename) {
+				// skip files which are already present in zip
+				log.Debugf("file %s already present in zip", filename)
+				continue
+			}
+			downloaderInputChan <- &DownloadFileInput{
+				filename: filename,
+				key:      key,
+			}
+		}
 		close(downloaderInputChan)
 	}()
 	zipUpdated = writeFileToZip(writer, downloaderOutputChan)
@@ -150,16 +134,16 @@ func (z *Zipper) buildZip(claType string, claGroupID string) error {
 	return nil
 }
 
-// FileContent contains file content of s3 file
+// FileContent contains file content of the signed file
 type FileContent struct {
-	buff     *aws.WriteAtBuffer
+	buff     []byte
 	filename string
 }
 
 // DownloadFileInput is input to downloader
 type DownloadFileInput struct {
 	filename string
-	key      *string
+	key      string
 }
 
 func writeFileToZip(writer *zip.Writer, filesInput chan *FileContent) bool {
@@ -178,7 +162,7 @@ func writeFileToZip(writer *zip.Writer, filesInput chan *FileContent) bool {
 			log.WithField("file", filename).Error("unable to write file header in zip")
 			continue
 		}
-		_, err = f.Write(buff.Bytes())
+		_, err = f.Write(buff)
 		if err != nil {
 			log.WithField("file", filename).Error("unable to write file data in zip")
 			continue
@@ -192,15 +176,9 @@ func (z *Zipper) downloader(wg *sync.WaitGroup, inputChan chan *DownloadFileInpu
 	defer wg.Done()
 	for in := range inputChan {
 		log.Debugf("Downloading file : %s", in.filename)
-		buff := &aws.WriteAtBuffer{}
-		downloader := s3manager.NewDownloaderWithClient(z.s3)
-		_, err := downloader.Download(buff,
-			&s3.GetObjectInput{
-				Bucket: aws.String(z.bucketName),
-				Key:    in.key,
-			})
+		buff, err := z.store.Get(in.key)
 		if err != nil {
-			log.WithField("key", utils.StringValue(in.key)).Error("unable to download file from s3", err)
+			log.WithField("key", in.key).Error("unable to download file from the blob store", err)
 			continue
 		}
 		outputChan <- &FileContent{
@@ -239,45 +217,22 @@ func getZipWriter(buff *bytes.Buffer) (*zip.Writer, error) {
 }
 
 func (z *Zipper) getZipFileFromS3(claType string, claGroupID string) (*bytes.Buffer, error) {
-	var buff aws.WriteAtBuffer
 	remoteFileKey := s3ZipFilepath(claType, claGroupID)
-	_, err := z.s3.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String(z.bucketName),
-		Key:    aws.String(remoteFileKey),
-	})
-	if err != nil {
-		aerr, ok := err.(awserr.Error)
-		if ok && aerr.Code() == s3.ErrCodeNoSuchKey {
-			log.Debugf("zip file %s does not exist on s3", remoteFileKey)
-			return bytes.NewBuffer(buff.Bytes()), nil
-		}
-		return nil, err
-	}
 	log.Debugf("Downloading zip file %s", remoteFileKey)
-
-	downloader := s3manager.NewDownloaderWithClient(z.s3)
-
-	_, err = downloader.Download(&buff,
-		&s3.GetObjectInput{
-			Bucket: aws.String(z.bucketName),
-			Key:    aws.String(remoteFileKey),
-		})
+	buff, err := z.store.Get(remoteFileKey)
 	if err != nil {
+		if err == utils.ErrBlobNotFound {
+			log.Debugf("zip file %s does not exist in the blob store", remoteFileKey)
+			return &bytes.Buffer{}, nil
+		}
 		return nil, err
 	}
 	log.Debugf("Downloading zip file %s completed", remoteFileKey)
-	return bytes.NewBuffer(buff.Bytes()), nil
+	return bytes.NewBuffer(buff), nil
 }
 
 func (z *Zipper) uploadFile(localFileContent *bytes.Buffer, s3ZipFile string) error {
-	uploader := s3manager.NewUploaderWithClient(z.s3)
-	// Upload the file to S3.
-	_, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(z.bucketName),
-		Key:    aws.String(s3ZipFile),
-		Body:   localFileContent,
-	})
-
+	err := z.store.Put(s3ZipFile, localFileContent.Bytes())
 	//in case it fails to upload
 	if err != nil {
 		log.Warnf("failed to upload file %s. error = %v", s3ZipFile, err)
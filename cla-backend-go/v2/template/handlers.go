@@ -4,6 +4,9 @@
 package template
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
@@ -19,14 +22,29 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/jinzhu/copier"
+	"github.com/sirupsen/logrus"
 )
 
 // Configure API call
 func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsService v1Events.Service) {
 	// Retrieve a list of available templates
 	api.TemplateGetTemplatesHandler = template.GetTemplatesHandlerFunc(func(params template.GetTemplatesParams, user *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+		var foundationSFID string
+		if params.FoundationSFID != nil {
+			foundationSFID = *params.FoundationSFID
+		}
+
+		// The custom templates of a foundation are only returned to the users of the foundation
+		if foundationSFID != "" && !utils.IsUserAuthorizedForProjectTree(user, foundationSFID) {
+			return template.NewGetTemplatesForbidden().WithPayload(&models.ErrorResponse{
+				Code: "403",
+				Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to GetTemplates with Project scope of %s",
+					user.UserName, foundationSFID),
+			})
+		}
 
-		templates, err := service.GetTemplates(params.HTTPRequest.Context())
+		templates, err := service.GetTemplates(params.HTTPRequest.Context(), foundationSFID)
 		if err != nil {
 			return template.NewGetTemplatesBadRequest().WithPayload(errorResponse(err))
 		}
@@ -65,6 +83,61 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 		return template.NewCreateCLAGroupTemplateOK().WithPayload(response)
 	})
 
+	api.TemplateUploadFoundationTemplateHandler = template.UploadFoundationTemplateHandlerFunc(func(params template.UploadFoundationTemplateParams, user *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "TemplateUploadFoundationTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"foundationSFID": params.FoundationSFID,
+			"templateName":   params.Body.Name,
+			"authUsername":   params.XUSERNAME,
+			"authEmail":      params.XEMAIL,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(user, params.FoundationSFID) {
+			return template.NewUploadFoundationTemplateForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code: "403",
+				Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to UploadFoundationTemplate with Project scope of %s",
+					user.UserName, params.FoundationSFID),
+			})
+		}
+
+		input := v1Models.Template{}
+		err := copier.Copy(&input, &params.Body)
+		if err != nil {
+			return template.NewUploadFoundationTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		customTemplate, err := service.CreateCustomTemplate(ctx, params.FoundationSFID, user.UserName, input)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to upload the custom template, error: %+v", err)
+			if errors.Is(err, v1Template.ErrInvalidTemplate) {
+				return template.NewUploadFoundationTemplateBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			return template.NewUploadFoundationTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:         events.CLATemplateUploaded,
+			ExternalProjectID: params.FoundationSFID,
+			LfUsername:        user.UserName,
+			EventData: &events.CLATemplateUploadedEventData{
+				TemplateID:     customTemplate.ID,
+				TemplateName:   customTemplate.Name,
+				FoundationSFID: params.FoundationSFID,
+			},
+		})
+
+		response := &models.Template{}
+		err = copier.Copy(response, &customTemplate)
+		if err != nil {
+			return template.NewUploadFoundationTemplateInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+		return template.NewUploadFoundationTemplateOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
 	api.TemplateTemplatePreviewHandler = template.TemplatePreviewHandlerFunc(func(params template.TemplatePreviewParams, user *auth.User) middleware.Responder {
 		var param v1Models.CreateClaGroupTemplate
 		err := copier.Copy(&param, &params.TemplatePreviewInput)
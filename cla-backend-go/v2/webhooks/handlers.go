@@ -0,0 +1,298 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/webhooks"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v1Webhooks "github.com/communitybridge/easycla/cla-backend-go/webhooks"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setup the webhook subscription handlers
+func Configure(api *operations.EasyclaAPI, service v1Webhooks.Service, projectClaGroupRepo projects_cla_groups.Repository) {
+	api.WebhooksListWebhooksHandler = webhooks.ListWebhooksHandlerFunc(
+		func(params webhooks.ListWebhooksParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "WebhooksListWebhooksHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"foundationSFID": params.FoundationSFID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return webhooks.NewListWebhooksForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ListWebhooks with Project scope of %s",
+						authUser.UserName, params.FoundationSFID),
+				})
+			}
+
+			subscriptions, err := service.GetSubscriptions(ctx, params.FoundationSFID)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to load webhook subscriptions, error: %+v", err)
+				return webhooks.NewListWebhooksInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result := &models.WebhookList{Webhooks: make([]*models.Webhook, 0, len(subscriptions))}
+			for _, subscription := range subscriptions {
+				result.Webhooks = append(result.Webhooks, toWebhook(subscription, false))
+			}
+			return webhooks.NewListWebhooksOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.WebhooksCreateWebhookHandler = webhooks.CreateWebhookHandlerFunc(
+		func(params webhooks.CreateWebhookParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "WebhooksCreateWebhookHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"foundationSFID": params.FoundationSFID,
+				"claGroupID":     params.Body.ClaGroupID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return webhooks.NewCreateWebhookForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to CreateWebhook with Project scope of %s",
+						authUser.UserName, params.FoundationSFID),
+				})
+			}
+
+			if params.Body.ClaGroupID != "" {
+				projectCLAGroups, err := projectClaGroupRepo.GetProjectsIdsForClaGroup(params.Body.ClaGroupID)
+				if err != nil {
+					log.WithFields(f).Warnf("unable to lookup the CLA Group projects, error: %+v", err)
+					return webhooks.NewCreateWebhookInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				if len(projectCLAGroups) == 0 || projectCLAGroups[0].FoundationSFID != params.FoundationSFID {
+					return webhooks.NewCreateWebhookBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code: "400",
+						Message: fmt.Sprintf("EasyCLA - 400 Bad Request - CLA Group %s is not part of the foundation %s",
+							params.Body.ClaGroupID, params.FoundationSFID),
+					})
+				}
+			}
+
+			subscription, err := service.CreateSubscription(ctx, &v1Webhooks.Subscription{
+				FoundationSFID: params.FoundationSFID,
+				ClaGroupID:     params.Body.ClaGroupID,
+				URL:            params.Body.URL,
+				Secret:         params.Body.Secret,
+				EventTypes:     params.Body.EventTypes,
+				CreatedBy:      authUser.UserName,
+			})
+			if err != nil {
+				log.WithFields(f).Warnf("unable to create webhook subscription, error: %+v", err)
+				if err == v1Webhooks.ErrInvalidURL || err == v1Webhooks.ErrDisallowedAddress {
+					return webhooks.NewCreateWebhookBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return webhooks.NewCreateWebhookInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return webhooks.NewCreateWebhookOK().WithXRequestID(reqID).WithPayload(toWebhook(subscription, true))
+		})
+
+	api.WebhooksDeleteWebhookHandler = webhooks.DeleteWebhookHandlerFunc(
+		func(params webhooks.DeleteWebhookParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "WebhooksDeleteWebhookHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"foundationSFID": params.FoundationSFID,
+				"webhookID":      params.WebhookID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return webhooks.NewDeleteWebhookForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to DeleteWebhook with Project scope of %s",
+						authUser.UserName, params.FoundationSFID),
+				})
+			}
+
+			if _, err := getFoundationSubscription(ctx, service, params.FoundationSFID, params.WebhookID); err != nil {
+				log.WithFields(f).Warn(err)
+				return webhooks.NewDeleteWebhookNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			if err := service.DeleteSubscription(ctx, params.WebhookID); err != nil {
+				log.WithFields(f).Warnf("unable to delete webhook subscription, error: %+v", err)
+				return webhooks.NewDeleteWebhookInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return webhooks.NewDeleteWebhookNoContent().WithXRequestID(reqID)
+		})
+
+	api.WebhooksListWebhookDeliveriesHandler = webhooks.ListWebhookDeliveriesHandlerFunc(
+		func(params webhooks.ListWebhookDeliveriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "WebhooksListWebhookDeliveriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"foundationSFID": params.FoundationSFID,
+				"webhookID":      params.WebhookID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return webhooks.NewListWebhookDeliveriesForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ListWebhookDeliveries with Project scope of %s",
+						authUser.UserName, params.FoundationSFID),
+				})
+			}
+
+			if _, err := getFoundationSubscription(ctx, service, params.FoundationSFID, params.WebhookID); err != nil {
+				log.WithFields(f).Warn(err)
+				return webhooks.NewListWebhookDeliveriesNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			var status string
+			if params.Status != nil {
+				status = *params.Status
+			}
+			deliveries, err := service.GetDeliveries(ctx, params.WebhookID, status)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to load webhook deliveries, error: %+v", err)
+				return webhooks.NewListWebhookDeliveriesInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			result := &models.WebhookDeliveryList{Deliveries: make([]*models.WebhookDelivery, 0, len(deliveries))}
+			for _, delivery := range deliveries {
+				result.Deliveries = append(result.Deliveries, toWebhookDelivery(delivery))
+			}
+			return webhooks.NewListWebhookDeliveriesOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.WebhooksRedeliverWebhookDeliveryHandler = webhooks.RedeliverWebhookDeliveryHandlerFunc(
+		func(params webhooks.RedeliverWebhookDeliveryParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "WebhooksRedeliverWebhookDeliveryHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"foundationSFID": params.FoundationSFID,
+				"webhookID":      params.WebhookID,
+				"deliveryID":     params.DeliveryID,
+				"authUsername":   params.XUSERNAME,
+				"authEmail":      params.XEMAIL,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.FoundationSFID) {
+				return webhooks.NewRedeliverWebhookDeliveryForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to RedeliverWebhookDelivery with Project scope of %s",
+						authUser.UserName, params.FoundationSFID),
+				})
+			}
+
+			if _, err := getFoundationSubscription(ctx, service, params.FoundationSFID, params.WebhookID); err != nil {
+				log.WithFields(f).Warn(err)
+				return webhooks.NewRedeliverWebhookDeliveryNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			delivery, err := service.Redeliver(ctx, params.WebhookID, params.DeliveryID)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to redeliver webhook delivery, error: %+v", err)
+				if err == v1Webhooks.ErrDeliveryNotFound {
+					return webhooks.NewRedeliverWebhookDeliveryNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return webhooks.NewRedeliverWebhookDeliveryInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return webhooks.NewRedeliverWebhookDeliveryOK().WithXRequestID(reqID).WithPayload(toWebhookDelivery(delivery))
+		})
+}
+
+// getFoundationSubscription returns the webhook subscription if it belongs to the foundation
+func getFoundationSubscription(ctx context.Context, service v1Webhooks.Service, foundationSFID, webhookID string) (*v1Webhooks.Subscription, error) {
+	subscription, err := service.GetSubscription(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.FoundationSFID != foundationSFID {
+		return nil, v1Webhooks.ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// toWebhook converts the subscription into the response model - the secret is only included when requested
+func toWebhook(subscription *v1Webhooks.Subscription, includeSecret bool) *models.Webhook {
+	webhook := &models.Webhook{
+		WebhookID:      subscription.SubscriptionID,
+		FoundationSFID: subscription.FoundationSFID,
+		ClaGroupID:     subscription.ClaGroupID,
+		URL:            subscription.URL,
+		EventTypes:     subscription.EventTypes,
+		Enabled:        subscription.Enabled,
+		CreatedBy:      subscription.CreatedBy,
+		DateCreated:    subscription.DateCreated,
+		DateModified:   subscription.DateModified,
+	}
+	if includeSecret {
+		webhook.Secret = subscription.Secret
+	}
+	return webhook
+}
+
+// toWebhookDelivery converts the delivery log record into the response model
+func toWebhookDelivery(delivery *v1Webhooks.Delivery) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		DeliveryID:   delivery.DeliveryID,
+		WebhookID:    delivery.SubscriptionID,
+		EventID:      delivery.EventID,
+		EventType:    delivery.EventType,
+		Payload:      delivery.Payload,
+		Status:       delivery.Status,
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		DateCreated:  delivery.DateCreated,
+		DateModified: delivery.DateModified,
+	}
+}
+
+type codedResponse interface {
+	Code() string
+}
+
+func errorResponse(err error) *models.ErrorResponse {
+	code := ""
+	if e, ok := err.(codedResponse); ok {
+		code = e.Code()
+	}
+
+	e := models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	return &e
+}
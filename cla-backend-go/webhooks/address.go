@@ -0,0 +1,106 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// errors
+var (
+	ErrDisallowedAddress = errors.New("webhook url must not resolve to a private, loopback or link-local address")
+)
+
+// blockedNetworks are the address ranges the webhooks must not reach - the private, shared and unique local networks
+// of the lambda VPC. The loopback, link-local, multicast and unspecified addresses are checked with the net.IP methods.
+var blockedNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "198.18.0.0/15", "fc00::/7"} {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// allowedIP returns true if the webhooks may connect to the address
+func allowedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolver looks up the addresses of the webhook hosts
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// validateURL checks the webhook url is an absolute https url whose host only resolves to public addresses
+func validateURL(ctx context.Context, resolver Resolver, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return ErrInvalidURL
+	}
+
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		if !allowedIP(ip) {
+			return ErrDisallowedAddress
+		}
+		return nil
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return ErrInvalidURL
+	}
+	for _, addr := range addrs {
+		if !allowedIP(addr.IP) {
+			return ErrDisallowedAddress
+		}
+	}
+	return nil
+}
+
+// newHTTPClient returns the client of the deliveries - the addresses are checked again when connecting, the host may
+// resolve to another address than on subscription, and the redirects are not followed
+func newHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowedIP(ip) {
+				return ErrDisallowedAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+// delivery status values
+const (
+	DeliveryStatusPending    = "pending"
+	DeliveryStatusSucceeded  = "succeeded"
+	DeliveryStatusDeadLetter = "dead_letter"
+)
+
+// Subscription is a webhook subscription of a foundation, optionally limited to a CLA Group and a set of event types
+type Subscription struct {
+	SubscriptionID string   `json:"subscription_id"`
+	FoundationSFID string   `json:"foundation_sfid"`
+	ClaGroupID     string   `json:"cla_group_id,omitempty"`
+	URL            string   `json:"url"`
+	Secret         string   `json:"secret"`
+	EventTypes     []string `json:"event_types,omitempty"`
+	Enabled        bool     `json:"enabled"`
+	CreatedBy      string   `json:"created_by"`
+	DateCreated    string   `json:"date_created"`
+	DateModified   string   `json:"date_modified"`
+}
+
+// Delivery is the delivery log record of an event to a webhook subscription - a failed pending delivery is retried by
+// the webhook retry lambda once its next attempt time is reached. Deliveries which exhaust the retries are kept with
+// the dead letter status so they can be inspected and redelivered
+type Delivery struct {
+	DeliveryID     string `json:"delivery_id"`
+	SubscriptionID string `json:"subscription_id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Payload        string `json:"payload"`
+	Status         string `json:"status"`
+	Attempts       int64  `json:"attempts"`
+	ResponseCode   int64  `json:"response_code"`
+	LastError      string `json:"last_error,omitempty"`
+	// NextAttemptAt is the epoch second of the next retry, only set on the pending deliveries
+	NextAttemptAt int64  `json:"next_attempt_at,omitempty"`
+	DateCreated   string `json:"date_created"`
+	DateModified  string `json:"date_modified"`
+}
+
+// Event is the JSON payload posted to the webhook subscriptions
+type Event struct {
+	EventID        string `json:"eventID"`
+	EventType      string `json:"eventType"`
+	EventTime      string `json:"eventTime"`
+	FoundationSFID string `json:"foundationSFID"`
+	ProjectSFID    string `json:"projectSFID,omitempty"`
+	ClaGroupID     string `json:"claGroupID,omitempty"`
+	CompanyID      string `json:"companyID,omitempty"`
+	CompanySFID    string `json:"companySFID,omitempty"`
+	UserID         string `json:"userID,omitempty"`
+	LfUsername     string `json:"lfUsername,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	Data           string `json:"data,omitempty"`
+}
+
+// matches returns true if the event is within the scope of the subscription
+func (s *Subscription) matches(event *Event) bool {
+	if !s.Enabled || s.FoundationSFID != event.FoundationSFID {
+		return false
+	}
+	if s.ClaGroupID != "" && s.ClaGroupID != event.ClaGroupID {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range s.EventTypes {
+		if eventType == event.EventType {
+			return true
+		}
+	}
+	return false
+}
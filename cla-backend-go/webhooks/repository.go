@@ -0,0 +1,301 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/storage"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// indexes
+const (
+	FoundationSFIDIndex    = "foundation-sfid-index"
+	SubscriptionIDIndex    = "subscription-id-index"
+	StatusNextAttemptIndex = "status-next-attempt-at-index"
+)
+
+// errors
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
+
+// Repository defines functions of the webhook subscriptions and delivery log
+type Repository interface {
+	CreateSubscription(subscription *Subscription) (*Subscription, error)
+	GetSubscription(subscriptionID string) (*Subscription, error)
+	GetSubscriptionsByFoundation(foundationSFID string) ([]*Subscription, error)
+	DeleteSubscription(subscriptionID string) error
+
+	PutDelivery(delivery *Delivery) error
+	GetDelivery(deliveryID string) (*Delivery, error)
+	GetDeliveriesBySubscription(subscriptionID string, status string) ([]*Delivery, error)
+	GetDueDeliveries(now int64, limit int) ([]*Delivery, error)
+}
+
+type repo struct {
+	stage                  string
+	dynamoDBClient         storage.DynamoDBAPI
+	subscriptionsTableName string
+	deliveriesTableName    string
+}
+
+// NewRepository creates a new webhook repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repo{
+		stage:                  stage,
+		dynamoDBClient:         storage.NewClient(awsSession),
+		subscriptionsTableName: fmt.Sprintf("cla-%s-webhook-subscriptions", stage),
+		deliveriesTableName:    fmt.Sprintf("cla-%s-webhook-deliveries", stage),
+	}
+}
+
+// CreateSubscription stores the webhook subscription
+func (repo *repo) CreateSubscription(subscription *Subscription) (*Subscription, error) {
+	_, currentTime := utils.CurrentTime()
+	subscription.DateCreated = currentTime
+	subscription.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(subscription)
+	if err != nil {
+		return nil, err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.subscriptionsTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing webhook subscription for foundation: %s, error: %v", subscription.FoundationSFID, err)
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscription returns the webhook subscription
+func (repo *repo) GetSubscription(subscriptionID string) (*Subscription, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(subscriptionID)},
+		},
+		TableName: aws.String(repo.subscriptionsTableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching webhook subscription: %s, error: %v", subscriptionID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	var subscription Subscription
+	err = dynamodbattribute.UnmarshalMap(result.Item, &subscription)
+	if err != nil {
+		log.Warnf("error unmarshalling webhook subscription, error: %v", err)
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetSubscriptionsByFoundation returns the webhook subscriptions of the foundation
+func (repo *repo) GetSubscriptionsByFoundation(foundationSFID string) ([]*Subscription, error) {
+	condition := expression.Key("foundation_sfid").Equal(expression.Value(foundationSFID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.subscriptionsTableName),
+		IndexName:                 aws.String(FoundationSFIDIndex),
+	}
+
+	subscriptions := make([]*Subscription, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching webhook subscriptions for foundation: %s, error: %v", foundationSFID, errQuery)
+			return nil, errQuery
+		}
+
+		var page []*Subscription
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling webhook subscriptions, error: %v", err)
+			return nil, err
+		}
+		subscriptions = append(subscriptions, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscription deletes the webhook subscription - the delivery log is kept
+func (repo *repo) DeleteSubscription(subscriptionID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(subscriptionID)},
+		},
+		TableName: aws.String(repo.subscriptionsTableName),
+	})
+	if err != nil {
+		log.Warnf("error deleting webhook subscription: %s, error: %v", subscriptionID, err)
+		return err
+	}
+	return nil
+}
+
+// PutDelivery creates or replaces the delivery log record
+func (repo *repo) PutDelivery(delivery *Delivery) error {
+	_, currentTime := utils.CurrentTime()
+	if delivery.DateCreated == "" {
+		delivery.DateCreated = currentTime
+	}
+	delivery.DateModified = currentTime
+
+	av, err := dynamodbattribute.MarshalMap(delivery)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.deliveriesTableName),
+	})
+	if err != nil {
+		log.Warnf("error storing webhook delivery: %s, error: %v", delivery.DeliveryID, err)
+		return err
+	}
+	return nil
+}
+
+// GetDelivery returns the delivery log record
+func (repo *repo) GetDelivery(deliveryID string) (*Delivery, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"delivery_id": {S: aws.String(deliveryID)},
+		},
+		TableName: aws.String(repo.deliveriesTableName),
+	})
+	if err != nil {
+		log.Warnf("error fetching webhook delivery: %s, error: %v", deliveryID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrDeliveryNotFound
+	}
+
+	var delivery Delivery
+	err = dynamodbattribute.UnmarshalMap(result.Item, &delivery)
+	if err != nil {
+		log.Warnf("error unmarshalling webhook delivery, error: %v", err)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetDeliveriesBySubscription returns the delivery log of the subscription, optionally filtered by status
+func (repo *repo) GetDeliveriesBySubscription(subscriptionID string, status string) ([]*Delivery, error) {
+	condition := expression.Key("subscription_id").Equal(expression.Value(subscriptionID))
+	builder := expression.NewBuilder().WithKeyCondition(condition)
+	if status != "" {
+		builder = builder.WithFilter(expression.Name("status").Equal(expression.Value(status)))
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.deliveriesTableName),
+		IndexName:                 aws.String(SubscriptionIDIndex),
+		ScanIndexForward:          aws.Bool(false),
+	}
+
+	deliveries := make([]*Delivery, 0)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching webhook deliveries for subscription: %s, error: %v", subscriptionID, errQuery)
+			return nil, errQuery
+		}
+
+		var page []*Delivery
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling webhook deliveries, error: %v", err)
+			return nil, err
+		}
+		deliveries = append(deliveries, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return deliveries, nil
+}
+
+// GetDueDeliveries returns up to limit pending deliveries whose next attempt time is reached, the oldest first - the
+// succeeded and dead lettered deliveries have no next attempt time and are not in the index
+func (repo *repo) GetDueDeliveries(now int64, limit int) ([]*Delivery, error) {
+	condition := expression.Key("status").Equal(expression.Value(DeliveryStatusPending)).
+		And(expression.Key("next_attempt_at").LessThanEqual(expression.Value(now)))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.deliveriesTableName),
+		IndexName:                 aws.String(StatusNextAttemptIndex),
+	}
+
+	deliveries := make([]*Delivery, 0)
+	for len(deliveries) < limit {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.Warnf("error fetching the due webhook deliveries, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		var page []*Delivery
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.Warnf("error unmarshalling webhook deliveries, error: %v", err)
+			return nil, err
+		}
+		deliveries = append(deliveries, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,340 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// delivery request headers
+const (
+	EventHeader     = "X-EasyCLA-Event"
+	DeliveryHeader  = "X-EasyCLA-Delivery"
+	SignatureHeader = "X-EasyCLA-Signature-256"
+)
+
+// delivery retry defaults - a delivery is attempted once when the event is dispatched, the failed deliveries are retried
+// by the webhook retry lambda with exponential backoff. With the defaults a delivery is attempted over roughly 15
+// minutes before it is dead lettered.
+const (
+	DefaultMaxAttempts    = 5
+	DefaultInitialBackoff = time.Minute
+	DeliveryTimeout       = 10 * time.Second
+	RetryBatchSize        = 50
+)
+
+// errors
+var (
+	ErrInvalidURL = errors.New("webhook url must be an absolute https url")
+)
+
+// Service contains the webhook subscription and delivery functions
+type Service interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	GetSubscriptions(ctx context.Context, foundationSFID string) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	GetDeliveries(ctx context.Context, subscriptionID string, status string) ([]*Delivery, error)
+	Redeliver(ctx context.Context, subscriptionID, deliveryID string) (*Delivery, error)
+
+	Dispatch(ctx context.Context, event *Event) error
+	RetryDueDeliveries(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo           Repository
+	httpClient     *http.Client
+	resolver       Resolver
+	maxAttempts    int
+	initialBackoff time.Duration
+	now            func() time.Time
+}
+
+// NewService creates a new webhook service
+func NewService(repo Repository) Service {
+	return &service{
+		repo:           repo,
+		httpClient:     newHTTPClient(DeliveryTimeout),
+		resolver:       net.DefaultResolver,
+		maxAttempts:    DefaultMaxAttempts,
+		initialBackoff: DefaultInitialBackoff,
+		now:            time.Now,
+	}
+}
+
+// CreateSubscription validates and stores the webhook subscription - a secret is generated when none is provided
+func (s *service) CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": subscription.FoundationSFID,
+		"claGroupID":     subscription.ClaGroupID,
+		"url":            subscription.URL,
+	}
+
+	err := validateURL(ctx, s.resolver, subscription.URL)
+	if err != nil {
+		log.WithFields(f).Debugf("invalid webhook url, error: %v", err)
+		return nil, err
+	}
+
+	subscriptionID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to generate a UUID for the webhook subscription, error: %v", err)
+		return nil, err
+	}
+	subscription.SubscriptionID = subscriptionID.String()
+
+	if subscription.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err = rand.Read(secret); err != nil {
+			return nil, err
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+	subscription.Enabled = true
+
+	log.WithFields(f).Debug("creating webhook subscription")
+	return s.repo.CreateSubscription(subscription)
+}
+
+// GetSubscription returns the webhook subscription
+func (s *service) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	return s.repo.GetSubscription(subscriptionID)
+}
+
+// GetSubscriptions returns the webhook subscriptions of the foundation
+func (s *service) GetSubscriptions(ctx context.Context, foundationSFID string) ([]*Subscription, error) {
+	return s.repo.GetSubscriptionsByFoundation(foundationSFID)
+}
+
+// DeleteSubscription deletes the webhook subscription
+func (s *service) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	return s.repo.DeleteSubscription(subscriptionID)
+}
+
+// GetDeliveries returns the delivery log of the webhook subscription
+func (s *service) GetDeliveries(ctx context.Context, subscriptionID string, status string) ([]*Delivery, error) {
+	return s.repo.GetDeliveriesBySubscription(subscriptionID, status)
+}
+
+// Redeliver attempts the delivery once again, e.g. after a dead lettered delivery once the receiver is fixed - a failed
+// redelivery is retried by the webhook retry lambda
+func (s *service) Redeliver(ctx context.Context, subscriptionID, deliveryID string) (*Delivery, error) {
+	delivery, err := s.repo.GetDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, ErrDeliveryNotFound
+	}
+	subscription, err := s.repo.GetSubscription(delivery.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Attempts = 0
+	delivery.LastError = ""
+	s.attempt(ctx, subscription, delivery)
+	return delivery, nil
+}
+
+// Dispatch delivers the event to each matching webhook subscription of the foundation - each delivery is attempted
+// once, concurrently, so the dispatch takes at most the delivery timeout. The failed deliveries are left to the webhook
+// retry lambda.
+func (s *service) Dispatch(ctx context.Context, event *Event) error {
+	f := logrus.Fields{
+		"functionName":   "Dispatch",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventID":        event.EventID,
+		"eventType":      event.EventType,
+		"foundationSFID": event.FoundationSFID,
+		"claGroupID":     event.ClaGroupID,
+	}
+
+	if event.FoundationSFID == "" {
+		log.WithFields(f).Debug("event has no foundation - skipping webhook dispatch")
+		return nil
+	}
+
+	subscriptions, err := s.repo.GetSubscriptionsByFoundation(event.FoundationSFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load webhook subscriptions, error: %+v", err)
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, subscription := range subscriptions {
+		if !subscription.matches(event) {
+			continue
+		}
+
+		deliveryID, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		delivery := &Delivery{
+			DeliveryID:     deliveryID.String(),
+			SubscriptionID: subscription.SubscriptionID,
+			EventID:        event.EventID,
+			EventType:      event.EventType,
+			Payload:        string(payload),
+			Status:         DeliveryStatusPending,
+		}
+		// Record the delivery as due before the first attempt so an interrupted delivery is retried
+		delivery.NextAttemptAt = s.now().Unix()
+		if err = s.repo.PutDelivery(delivery); err != nil {
+			log.WithFields(f).Warnf("unable to record webhook delivery for subscription: %s, error: %+v", subscription.SubscriptionID, err)
+			continue
+		}
+		wg.Add(1)
+		go func(subscription *Subscription, delivery *Delivery) {
+			defer wg.Done()
+			s.attempt(ctx, subscription, delivery)
+		}(subscription, delivery)
+	}
+	wg.Wait()
+	return nil
+}
+
+// RetryDueDeliveries attempts a batch of the pending deliveries whose next attempt time is reached and returns the
+// number of attempted deliveries - invoked on a schedule by the webhook retry lambda
+func (s *service) RetryDueDeliveries(ctx context.Context) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "RetryDueDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	deliveries, err := s.repo.GetDueDeliveries(s.now().Unix(), RetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := map[string]*Subscription{}
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.repo.GetSubscription(delivery.SubscriptionID)
+			if err != nil && err != ErrSubscriptionNotFound {
+				log.WithFields(f).Warnf("unable to load webhook subscription: %s, error: %+v", delivery.SubscriptionID, err)
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if subscription == nil || !subscription.Enabled {
+			// the subscription was deleted or disabled since the event - no further attempts
+			delivery.Status = DeliveryStatusDeadLetter
+			delivery.NextAttemptAt = 0
+			delivery.LastError = "webhook subscription deleted or disabled"
+			if err = s.repo.PutDelivery(delivery); err != nil {
+				log.WithFields(f).Warnf("unable to update webhook delivery: %s, error: %+v", delivery.DeliveryID, err)
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(subscription *Subscription, delivery *Delivery) {
+			defer wg.Done()
+			s.attempt(ctx, subscription, delivery)
+		}(subscription, delivery)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// attempt posts the payload to the subscription url once. A failed delivery stays pending with the next attempt time
+// backed off exponentially, and is dead lettered once the attempts are exhausted.
+func (s *service) attempt(ctx context.Context, subscription *Subscription, delivery *Delivery) {
+	f := logrus.Fields{
+		"functionName":   "attempt",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+		"eventType":      delivery.EventType,
+	}
+
+	delivery.Attempts++
+	code, err := s.post(subscription, delivery)
+	delivery.ResponseCode = int64(code)
+	switch {
+	case err == nil:
+		delivery.Status = DeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = 0
+	case delivery.Attempts >= int64(s.maxAttempts):
+		delivery.Status = DeliveryStatusDeadLetter
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = 0
+		log.WithFields(f).Warnf("webhook delivery dead lettered after %d attempts, error: %s", delivery.Attempts, delivery.LastError)
+	default:
+		delivery.Status = DeliveryStatusPending
+		delivery.LastError = err.Error()
+		backoff := s.initialBackoff << uint(delivery.Attempts-1)
+		delivery.NextAttemptAt = s.now().Add(backoff).Unix()
+		log.WithFields(f).Debugf("webhook delivery attempt %d of %d failed, retrying in %s, error: %+v", delivery.Attempts, s.maxAttempts, backoff, err)
+	}
+
+	if err := s.repo.PutDelivery(delivery); err != nil {
+		log.WithFields(f).Warnf("unable to update webhook delivery, error: %+v", err)
+	}
+}
+
+// post sends a single delivery attempt and returns the response status code
+func (s *service) post(subscription *Subscription, delivery *Delivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EasyCLA-Webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.DeliveryID)
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, []byte(delivery.Payload)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close() // nolint
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value of the payload - the hex encoded HMAC SHA-256 of the body keyed by the
+// subscription secret, e.g. sha256=5257a869...
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload) // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
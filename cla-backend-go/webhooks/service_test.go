@@ -0,0 +1,210 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepository struct {
+	Repository
+	mu            sync.Mutex
+	subscriptions []*Subscription
+	deliveries    map[string]Delivery
+}
+
+func (r *fakeRepository) CreateSubscription(subscription *Subscription) (*Subscription, error) {
+	r.subscriptions = append(r.subscriptions, subscription)
+	return subscription, nil
+}
+
+func (r *fakeRepository) GetSubscription(subscriptionID string) (*Subscription, error) {
+	for _, subscription := range r.subscriptions {
+		if subscription.SubscriptionID == subscriptionID {
+			return subscription, nil
+		}
+	}
+	return nil, ErrSubscriptionNotFound
+}
+
+func (r *fakeRepository) GetSubscriptionsByFoundation(foundationSFID string) ([]*Subscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *fakeRepository) PutDelivery(delivery *Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[delivery.DeliveryID] = *delivery
+	return nil
+}
+
+func (r *fakeRepository) GetDueDeliveries(now int64, limit int) ([]*Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*Delivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == DeliveryStatusPending && delivery.NextAttemptAt <= now {
+			d := delivery
+			due = append(due, &d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt < due[j].NextAttemptAt })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// fakeResolver resolves the hosts to the addresses of the map
+type fakeResolver map[string]string
+
+func (r fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addr, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host}
+	}
+	return []net.IPAddr{{IP: net.ParseIP(addr)}}, nil
+}
+
+// newTestService returns a service delivering to the local test servers at a fixed time
+func newTestService(repo Repository) *service {
+	s := NewService(repo).(*service)
+	s.httpClient = &http.Client{Timeout: time.Second}
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestDispatchSignsAndDeliversMatchingSubscriptions(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := &fakeRepository{
+		subscriptions: []*Subscription{
+			{SubscriptionID: "all", FoundationSFID: "foundation-1", URL: server.URL, Secret: "s3cret", Enabled: true},
+			{SubscriptionID: "other-cla-group", FoundationSFID: "foundation-1", ClaGroupID: "cla-group-2", URL: server.URL, Enabled: true},
+			{SubscriptionID: "other-type", FoundationSFID: "foundation-1", EventTypes: []string{"CLAGroupCreated"}, URL: server.URL, Enabled: true},
+			{SubscriptionID: "disabled", FoundationSFID: "foundation-1", URL: server.URL},
+		},
+		deliveries: map[string]Delivery{},
+	}
+
+	err := newTestService(repo).Dispatch(context.Background(), &Event{
+		EventID:        "event-1",
+		EventType:      "ClaManagerCreated",
+		FoundationSFID: "foundation-1",
+		ClaGroupID:     "cla-group-1",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, received, 1)
+	assert.Equal(t, "ClaManagerCreated", received[0].Header.Get(EventHeader))
+	assert.Equal(t, Sign("s3cret", bodies[0]), received[0].Header.Get(SignatureHeader))
+
+	assert.Len(t, repo.deliveries, 1)
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, "all", delivery.SubscriptionID)
+		assert.Equal(t, DeliveryStatusSucceeded, delivery.Status)
+		assert.Equal(t, int64(1), delivery.Attempts)
+		assert.Equal(t, int64(http.StatusNoContent), delivery.ResponseCode)
+	}
+}
+
+func TestDispatchRetriesOnScheduleThenDeadLetters(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	repo := &fakeRepository{
+		subscriptions: []*Subscription{{SubscriptionID: "all", FoundationSFID: "foundation-1", URL: server.URL, Enabled: true}},
+		deliveries:    map[string]Delivery{},
+	}
+	s := newTestService(repo)
+	now := s.now()
+
+	err := s.Dispatch(context.Background(), &Event{EventID: "event-1", EventType: "ClaManagerCreated", FoundationSFID: "foundation-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, attempts)
+
+	var backoffs []time.Duration
+	for i := 0; i < 10; i++ {
+		var delivery Delivery
+		for _, d := range repo.deliveries {
+			delivery = d
+		}
+		if delivery.Status != DeliveryStatusPending {
+			break
+		}
+		// not due yet
+		count, retryErr := s.RetryDueDeliveries(context.Background())
+		assert.Nil(t, retryErr)
+		assert.Equal(t, 0, count)
+
+		next := time.Unix(delivery.NextAttemptAt, 0).UTC()
+		backoffs = append(backoffs, next.Sub(now))
+		now = next
+		s.now = func() time.Time { return now }
+		count, retryErr = s.RetryDueDeliveries(context.Background())
+		assert.Nil(t, retryErr)
+		assert.Equal(t, 1, count)
+	}
+
+	assert.Equal(t, DefaultMaxAttempts, attempts)
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute}, backoffs)
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, DeliveryStatusDeadLetter, delivery.Status)
+		assert.Equal(t, int64(DefaultMaxAttempts), delivery.Attempts)
+		assert.Equal(t, int64(http.StatusBadGateway), delivery.ResponseCode)
+		assert.Equal(t, int64(0), delivery.NextAttemptAt)
+		assert.Equal(t, "webhook receiver responded with status 502", delivery.LastError)
+	}
+}
+
+func TestCreateSubscriptionValidatesURL(t *testing.T) {
+	s := newTestService(&fakeRepository{})
+	s.resolver = fakeResolver{"hooks.example.org": "203.0.113.10", "internal.example.org": "10.0.1.20", "metadata.example.org": "169.254.169.254"}
+
+	for rawURL, expected := range map[string]error{
+		"ftp://example.org":                   ErrInvalidURL,
+		"http://hooks.example.org/easycla":    ErrInvalidURL,
+		"https://unknown.example.org/easycla": ErrInvalidURL,
+		"https://127.0.0.1/easycla":           ErrDisallowedAddress,
+		"https://[::1]/easycla":               ErrDisallowedAddress,
+		"https://internal.example.org/hook":   ErrDisallowedAddress,
+		"https://metadata.example.org/hook":   ErrDisallowedAddress,
+		"https://hooks.example.org/easycla":   nil,
+	} {
+		_, err := s.CreateSubscription(context.Background(), &Subscription{URL: rawURL})
+		assert.Equal(t, expected, err, rawURL)
+	}
+}
+
+func TestDeliveryClientRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := newHTTPClient(time.Second).Get(server.URL)
+	assert.NotNil(t, err)
+}
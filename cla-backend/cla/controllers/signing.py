@@ -62,6 +62,18 @@ def request_corporate_signature(auth_user, project_id, company_id, send_as_email
                                                              return_url_type, return_url)
 
 
+def cancel_corporate_signature(auth_user, signature_id):
+    """
+    Cancels the unsigned corporate signature request - the signing request is voided and the signature invalidated.
+
+    :param auth_user: the authenticated user
+    :type auth_user: an auth user object
+    :param signature_id: The ID of the corporate signature.
+    :type signature_id: string
+    """
+    return get_signing_service().cancel_corporate_signature(auth_user, str(signature_id))
+
+
 def request_employee_signature(project_id, company_id, user_id, return_url_type, return_url=None):
     """
     Creates placeholder signature object that represents a user signing a CCLA as an employee.
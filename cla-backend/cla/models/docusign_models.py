@@ -9,6 +9,7 @@ https://developers.docusign.com/esign-rest-api/guides/post-go-live
 
 """
 
+import hashlib
 import io
 import os
 import urllib.request
@@ -865,6 +866,53 @@ class DocuSign(signing_service_interface.SigningService):
             signatory_name=signatory_name, signatory_email=signatory_email,
             send_as_email=send_as_email, return_url_type=return_url_type, return_url=return_url)
 
+    def cancel_corporate_signature(self, auth_user, signature_id):
+        """
+        Cancels the unsigned corporate signature request - the DocuSign envelope is voided and the signature record
+        is invalidated so that a signature completed on the envelope is never approved.
+        """
+        fn = 'cancel_corporate_signature'
+        if auth_user is None or auth_user.username is None:
+            return {'errors': {'user_error': f'{fn} - auth_user is empty'}}
+
+        signature = Signature()
+        try:
+            signature.load(str(signature_id))
+        except DoesNotExist as err:
+            return {'errors': {'signature_id': str(err)}}
+        if signature.get_signature_type() != 'ccla' or signature.get_signature_reference_type() != 'company':
+            return {'errors': {'signature_id': f'{fn} - signature is not a corporate signature'}}
+
+        company = Company()
+        try:
+            company.load(str(signature.get_signature_reference_id()))
+        except DoesNotExist as err:
+            return {'errors': {'company_id': str(err)}}
+        if auth_user.username not in company.get_company_acl():
+            cla.log.warning(f'{fn} - user: {auth_user.username} is not in the ACL of company: {company}')
+            return {'errors': {'user_error': 'user is not authorized to cancel the corporate signature'}}
+
+        if signature.get_signature_signed():
+            return {'errors': {'signature_id': f'{fn} - signature has already been signed'}}
+
+        envelope_voided = False
+        envelope_id = signature.get_signature_envelope_id()
+        if envelope_id is not None:
+            try:
+                self.client.void_envelope(envelope_id, 'The corporate signature request was cancelled.')
+                envelope_voided = True
+            except Exception as err:
+                # the envelope may already be voided or declined - the signature is invalidated regardless
+                cla.log.warning(f'{fn} - DocuSign error while voiding the envelope: {envelope_id} of signature: '
+                                f'{signature_id}, error: {err}')
+
+        signature.set_signature_approved(False)
+        signature.set_signature_sign_url(None)
+        signature.set_note(f'Corporate signature request cancelled by {auth_user.username}')
+        signature.save()
+        cla.log.info(f'{fn} - cancelled corporate signature: {signature_id}, envelope voided: {envelope_voided}')
+        return {'signature_id': str(signature_id), 'envelope_voided': envelope_voided}
+
     def populate_sign_url(self, signature, callback_url=None,
                           authority_or_signatory_name=None,
                           authority_or_signatory_email=None,
@@ -1521,6 +1569,17 @@ class DocuSign(signing_service_interface.SigningService):
         cla.log.debug(f'send_to_s3 - uploading document with filename: {filename}')
         self.s3storage.store(filename, document_data)
 
+        # Record the hash of the stored document so later copies can be verified as unaltered - the signature is
+        # reloaded as it was saved before the document was fetched from DocuSign
+        signature = Signature()
+        try:
+            signature.load(str(signature_id))
+        except DoesNotExist:
+            cla.log.warning(f'send_to_s3 - unable to load signature: {signature_id} to record the document hash')
+            return
+        signature.set_signature_document_sha256(hashlib.sha256(document_data).hexdigest())
+        signature.save()
+
     def get_document_resource(self, url):  # pylint: disable=no-self-use
         """
         Mockable method to fetch the PDF for signing.
@@ -2110,6 +2110,8 @@ class SignatureModel(BaseModel):  # pylint: disable=too-many-instance-attributes
     email_whitelist = ListAttribute(null=True)
     github_whitelist = ListAttribute(null=True)
     github_org_whitelist = ListAttribute(null=True)
+    # who added the approval list entries and when they expire, maintained by the Go backend
+    approval_list_entries = ListAttribute(null=True)
 
     # Additional attributes for ICLAs
     user_email = UnicodeAttribute(null=True)
@@ -2117,6 +2119,16 @@ class SignatureModel(BaseModel):  # pylint: disable=too-many-instance-attributes
     user_name = UnicodeAttribute(null=True)
     user_lf_username = UnicodeAttribute(null=True)
 
+    # SHA-256 of the signed document, recorded when the document is stored
+    signature_document_sha256 = UnicodeAttribute(null=True)
+
+    # Retention attributes - the invalidation and CLA Group deletion dates start the retention of the signed document,
+    # the archive date is set by the retention sweeper once the document is deleted
+    signature_invalidated_on = UnicodeAttribute(null=True)
+    signature_cla_group_deleted_on = UnicodeAttribute(null=True)
+    signature_foundation_sfid = UnicodeAttribute(null=True)
+    signature_archived_on = UnicodeAttribute(null=True)
+
 
 class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-methods
     """
@@ -2330,6 +2342,24 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def get_signature_envelope_id(self):
         return self.model.signature_envelope_id
 
+    def get_signature_document_sha256(self):
+        return self.model.signature_document_sha256
+
+    def get_signature_invalidated_on(self):
+        return self.model.signature_invalidated_on
+
+    def get_signature_cla_group_deleted_on(self):
+        return self.model.signature_cla_group_deleted_on
+
+    def get_signature_foundation_sfid(self):
+        return self.model.signature_foundation_sfid
+
+    def get_signature_archived_on(self):
+        return self.model.signature_archived_on
+
+    def get_approval_list_entries(self):
+        return self.model.approval_list_entries
+
     def get_domain_whitelist(self):
         return self.model.domain_whitelist
 
@@ -2412,6 +2442,12 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
         self.model.sigtype_signed_approved_id = sigtype_signed_approved_id
 
     def set_signature_approved(self, approved):
+        # the invalidation date starts the retention of the signed document, it is kept until the signature is approved
+        # again
+        if approved:
+            self.model.signature_invalidated_on = None
+        elif self.model.signature_approved:
+            self.model.signature_invalidated_on = datetime.datetime.utcnow().strftime('%Y-%m-%dT%H:%M:%SZ')
         self.model.signature_approved = bool(approved)
 
     def set_signature_sign_url(self, sign_url):
@@ -2445,6 +2481,21 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def set_signature_envelope_id(self, signature_envelope_id):
         self.model.signature_envelope_id = signature_envelope_id
 
+    def set_signature_document_sha256(self, signature_document_sha256):
+        self.model.signature_document_sha256 = signature_document_sha256
+
+    def set_signature_invalidated_on(self, signature_invalidated_on):
+        self.model.signature_invalidated_on = signature_invalidated_on
+
+    def set_signature_cla_group_deleted_on(self, signature_cla_group_deleted_on):
+        self.model.signature_cla_group_deleted_on = signature_cla_group_deleted_on
+
+    def set_signature_foundation_sfid(self, signature_foundation_sfid):
+        self.model.signature_foundation_sfid = signature_foundation_sfid
+
+    def set_signature_archived_on(self, signature_archived_on):
+        self.model.signature_archived_on = signature_archived_on
+
     def set_signature_company_signatory_id(self, signature_company_signatory_id):
         self.model.signature_company_signatory_id = signature_company_signatory_id
 
@@ -2466,6 +2517,9 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def set_signature_company_secondary_manager_list(self, signature_company_secondary_manager_list):
         self.model.signature_company_secondary_manager_list = signature_company_secondary_manager_list
 
+    def set_approval_list_entries(self, approval_list_entries):
+        self.model.approval_list_entries = approval_list_entries
+
     # Remove leading and trailing whitespace for all items before setting whitelist
 
     def set_domain_whitelist(self, domain_whitelist):
@@ -2957,6 +3011,9 @@ class CompanyModel(BaseModel):
     company_name = UnicodeAttribute()
     company_external_id_index = ExternalCompanyIndex()
     company_acl = UnicodeSetAttribute(default=set())
+    # domains the company has proven to own and the domain verification challenges, maintained by the Go backend
+    verified_domains = ListAttribute(null=True)
+    domain_verifications = ListAttribute(null=True)
 
 
 class Company(model_interfaces.Company):  # pylint: disable=too-many-public-methods
@@ -3020,6 +3077,12 @@ class Company(model_interfaces.Company):  # pylint: disable=too-many-public-meth
     def get_company_acl(self):
         return self.model.company_acl
 
+    def get_verified_domains(self):
+        return self.model.verified_domains
+
+    def get_domain_verifications(self):
+        return self.model.domain_verifications
+
     def set_company_id(self, company_id):
         self.model.company_id = company_id
 
@@ -3035,6 +3098,12 @@ class Company(model_interfaces.Company):  # pylint: disable=too-many-public-meth
     def set_company_acl(self, company_acl_username):
         self.model.company_acl = set([company_acl_username])
 
+    def set_verified_domains(self, verified_domains):
+        self.model.verified_domains = verified_domains
+
+    def set_domain_verifications(self, domain_verifications):
+        self.model.domain_verifications = domain_verifications
+
     def set_date_modified(self):
         """
         Updates the company modified date/time to the current time.
@@ -4123,3 +4192,42 @@ class CCLAWhitelistRequest(model_interfaces.CCLAWhitelistRequest):
             ccla_whitelist_request.model = request
             ret.append(ccla_whitelist_request)
         return ret
+
+
+class ResignCampaignClaGroupIndex(GlobalSecondaryIndex):
+    """
+    This class represents a global secondary index for querying the re-sign campaigns by CLA Group.
+    """
+
+    class Meta:
+        """Meta class for the re-sign campaign CLA Group index."""
+
+        index_name = "cla-group-id-index"
+        write_capacity_units = int(cla.conf["DYNAMO_WRITE_UNITS"])
+        read_capacity_units = int(cla.conf["DYNAMO_READ_UNITS"])
+        projection = AllProjection()
+
+    # This attribute is the hash key for the index.
+    cla_group_id = UnicodeAttribute(hash_key=True)
+
+
+class ResignCampaignModel(Model):
+    """
+    Represents a re-sign campaign in the database. The campaigns are managed by the Go backend, only the
+    attributes of the signature version policy are read here.
+    """
+
+    class Meta:
+        """Meta class for re-sign campaigns."""
+
+        table_name = "cla-{}-resign-campaigns".format(stage)
+        if stage == "local":
+            host = "http://localhost:8000"
+
+    campaign_id = UnicodeAttribute(hash_key=True)
+    cla_group_id = UnicodeAttribute(null=True)
+    cla_type = UnicodeAttribute(null=True)
+    major_version = NumberAttribute(null=True)
+    grace_date = UnicodeAttribute(null=True)
+    status = UnicodeAttribute(null=True)
+    cla_group_id_index = ResignCampaignClaGroupIndex()
@@ -351,6 +351,8 @@ class GitHub(repository_service_interface.RepositoryService):
         # Find users who have signed and who have not signed.
         signed = []
         missing = []
+        # the signature version policy is evaluated once for all the commit authors
+        version_policy = cla.utils.SignatureVersionPolicy()
 
         cla.log.debug(f'PR: {pull_request.number}, scanning users - determining who has signed a CLA an who has not.')
         for commit_sha, author_info in commit_authors:
@@ -360,7 +362,7 @@ class GitHub(repository_service_interface.RepositoryService):
             author_email = author_info[2]
             cla.log.debug('PR: {}, processing sha: {} from author id: {}, username: {}, email: {}'.
                           format(pull_request.number, commit_sha, author_id, author_username, author_email))
-            handle_commit_from_user(project, commit_sha, author_info, signed, missing)
+            handle_commit_from_user(project, commit_sha, author_info, signed, missing, version_policy)
 
         cla.log.debug('PR: {}, updating github pull request for repo: {}, '
                       'with signed authors: {} with missing authors: {}'.
@@ -574,7 +576,7 @@ def create_repository(data):
         return None
 
 
-def handle_commit_from_user(project, commit_sha, author_info, signed, missing):  # pylint: disable=too-many-arguments
+def handle_commit_from_user(project, commit_sha, author_info, signed, missing, version_policy):  # pylint: disable=too-many-arguments
     """
     Helper method to triage commits between signed and not-signed user signatures.
 
@@ -590,6 +592,8 @@ def handle_commit_from_user(project, commit_sha, author_info, signed, missing):
     :param missing: Reference to a list of authors who have not signed yet.
         Should be modified in-place to add a missing signer if found.
     :type missing: list of strings
+    :param version_policy: The signature version policy shared by the commit authors of the PR check.
+    :type version_policy: cla.utils.SignatureVersionPolicy
     """
 
     # Extract the author_info tuple details
@@ -622,7 +626,7 @@ def handle_commit_from_user(project, commit_sha, author_info, signed, missing):
                 # For now, accept non-github users as legitimate users.
                 # Does this user have a signed signature for this project? If so, add to the signed list and return,
                 # no reason to continue looking
-                if cla.utils.user_signed_project_signature(user, project):
+                if cla.utils.user_signed_project_signature(user, project, version_policy):
                     signed.append((commit_sha, author_username))
                     return
 
@@ -679,7 +683,7 @@ def handle_commit_from_user(project, commit_sha, author_info, signed, missing):
 
         # Does this user have a signed signature for this project? If so, add to the signed list and return,
         # no reason to continue looking
-        if cla.utils.user_signed_project_signature(user, project):
+        if cla.utils.user_signed_project_signature(user, project, version_policy):
             signed.append((commit_sha, author_username))
             return
 
@@ -51,6 +51,19 @@ class SigningService(object):
         """
         raise NotImplementedError()
 
+    def cancel_corporate_signature(self, auth_user, signature_id):
+        """
+        Method used to cancel an unsigned corporate signature request with the signing service provider.
+
+        :param auth_user: The authenticated user cancelling the request.
+        :type auth_user: cla.auth.AuthUser
+        :param signature_id: The ID of the corporate signature.
+        :type signature_id: string
+        :return: Dict with the signature ID and whether the signing request was voided, or errors.
+        :rtype: dict
+        """
+        raise NotImplementedError()
+
     def populate_sign_url(self, signature, callback_url=None):
         """
         Method used to populate the sign_url field in the signature object provided.
@@ -1191,6 +1191,24 @@ def request_corporate_signature(
     )
 
 
+@hug.post("/cancel-corporate-signature", versions=1)
+def cancel_corporate_signature(auth_user: check_auth, signature_id: hug.types.uuid):
+    """
+    POST: /cancel-corporate-signature
+
+    DATA: {'signature_id': 'some-signature-id'}
+
+    Cancels the unsigned corporate signature request - the DocuSign envelope is voided and the signature record is
+    invalidated. The user must be in the ACL of the company.
+
+    Returns a dict of the format:
+
+        {'signature_id': <signature_id>,
+         'envelope_voided': <boolean>}
+    """
+    return cla.controllers.signing.cancel_corporate_signature(auth_user, signature_id)
+
+
 @hug.post("/request-employee-signature", versions=2)
 def request_employee_signature(
     project_id: hug.types.uuid,
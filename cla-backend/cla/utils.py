@@ -20,9 +20,12 @@ import cla
 from cla.models import DoesNotExist
 from cla.models.dynamo_models import User, Signature, Repository, \
     Company, Project, Document, \
-    GitHubOrg, Gerrit, UserPermissions, Event, CompanyInvite, ProjectCLAGroup, CCLAWhitelistRequest
+    GitHubOrg, Gerrit, UserPermissions, Event, CompanyInvite, ProjectCLAGroup, CCLAWhitelistRequest, \
+    ResignCampaignModel
 from cla.models.event_types import EventType
-from datetime import datetime
+from datetime import datetime, timezone
+
+import dateutil.parser
 
 API_BASE_URL = os.environ.get('CLA_API_BASE', '')
 CLA_LOGO_URL = os.environ.get('CLA_BUCKET_LOGO_URL', '')
@@ -594,7 +597,70 @@ def user_ccla_check(user: User, project: Project, signature: Signature) -> bool:
         return False
 
 
-def user_signed_project_signature(user: User, project: Project):
+def get_required_major_version(cla_group_id: str, cla_type: str, now: datetime) -> int:
+    """
+    Returns the lowest document major version still honored for the CLA type (icla or ccla) of the CLA Group - the
+    highest major version of the re-sign campaigns whose grace date has passed, or 0 when all versions are honored.
+    Cancelled campaigns are ignored.
+    """
+    required = 0
+    for campaign in ResignCampaignModel.cla_group_id_index.query(str(cla_group_id)):
+        if campaign.status == 'cancelled' or not campaign.grace_date or campaign.cla_type not in (cla_type, 'all'):
+            continue
+        try:
+            grace_date = dateutil.parser.parse(campaign.grace_date)
+        except (ValueError, OverflowError):
+            continue
+        if grace_date.tzinfo is None:
+            grace_date = grace_date.replace(tzinfo=timezone.utc)
+        if now < grace_date or campaign.major_version is None:
+            continue
+        required = max(required, int(campaign.major_version))
+    return required
+
+
+class SignatureVersionPolicy:
+    """
+    Decides if the document major version of a signature is still honored. The required major versions are cached by
+    CLA Group and CLA type, a PR check uses one policy for all its commit authors.
+    """
+
+    def __init__(self):
+        self.now = datetime.now(timezone.utc)
+        self.required = {}
+
+    def required_major_version(self, cla_group_id: str, cla_type: str) -> int:
+        key = (cla_group_id, cla_type)
+        if key not in self.required:
+            self.required[key] = get_required_major_version(cla_group_id, cla_type, self.now)
+        return self.required[key]
+
+    def is_honored(self, signature: Signature, cla_type: str) -> bool:
+        """
+        Returns True if the document major version of the signature is still honored. The signature is honored when
+        the policy can not be evaluated so that an outage does not block all contributors.
+        """
+        cla_group_id = signature.get_signature_project_id()
+        try:
+            required = self.required_major_version(cla_group_id, cla_type)
+        except Exception as err:
+            cla.log.warning(f'unable to evaluate the signature version policy for CLA Group: {cla_group_id}, '
+                            f'honoring signature: {signature.get_signature_id()}, error: {err}')
+            return True
+        if required == 0:
+            return True
+        try:
+            major = int(signature.get_signature_document_major_version())
+        except (TypeError, ValueError):
+            major = 0
+        if major >= required:
+            return True
+        cla.log.debug(f'signature: {signature.get_signature_id()} document major version {major} is no longer '
+                      f'honored, version {required} is required')
+        return False
+
+
+def user_signed_project_signature(user: User, project: Project, version_policy: SignatureVersionPolicy = None):
     """
     Helper function to check if a user has signed a project signature tied to a repository.
     Will consider both ICLA and employee signatures.
@@ -603,22 +669,24 @@ def user_signed_project_signature(user: User, project: Project):
     :type user: cla.models.model_interfaces.User
     :param project_id: The project to check for.
     :type project_id: string
-    :param latest_major_version: True means only the latest document major version will be considered.
-    :type latest_major_version: bool
+    :param version_policy: The signature version policy, a new policy is used when not provided.
+    :type version_policy: SignatureVersionPolicy
     :return: Whether or not the user has an signature that's signed and approved
         for this project.
     :rtype: boolean
     """
+    if version_policy is None:
+        version_policy = SignatureVersionPolicy()
 
     # Check if we have an ICLA for this user
     cla.log.debug(f'checking to see if user has signed an ICLA, user: {user}, project: {project}')
 
     signature = user.get_latest_signature(project.get_project_id(), signature_signed=True, signature_approved=True)
     icla_pass = False
-    if signature is not None:
+    if signature is not None and version_policy.is_honored(signature, 'icla'):
         icla_pass = True
     else:
-        cla.log.debug(f'ICLA signature NOT found for User: {user} on project: {project}')
+        cla.log.debug(f'honored ICLA signature NOT found for User: {user} on project: {project}')
 
     # If we passed the ICLA check - good, return true, no need to check CCLA
     if icla_pass:
@@ -644,8 +712,8 @@ def user_signed_project_signature(user: User, project: Project):
             signature = company.get_latest_signature(
                 project.get_project_id(), signature_signed=True, signature_approved=True)
 
-            # Don't check the version for employee signatures.
-            if signature is not None:
+            # Don't check the version for employee signatures, only for the CCLA of the company.
+            if signature is not None and version_policy.is_honored(signature, 'ccla'):
                 # Verify if user has been whitelisted: https://github.com/communitybridge/easycla/issues/332
                 if user.is_whitelisted(signature):
                     ccla_pass = True
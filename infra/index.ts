@@ -63,6 +63,17 @@ const eventsTable = buildEventsTable(importResources);
 const cclaWhitelistRequestsTable = buildCclaWhitelistRequestsTable(importResources);
 const metricsTable = buildMetricsTable(importResources);
 const projectsClaGroupsTable = buildProjectsClaGroupsTable(importResources);
+const notificationTemplatesTable = buildNotificationTemplatesTable(importResources);
+const webhookSubscriptionsTable = buildWebhookSubscriptionsTable(importResources);
+const webhookDeliveriesTable = buildWebhookDeliveriesTable(importResources);
+const metricsHistoryTable = buildMetricsHistoryTable(importResources);
+const resignCampaignsTable = buildResignCampaignsTable(importResources);
+const resignSignersTable = buildResignSignersTable(importResources);
+const claTemplatesTable = buildClaTemplatesTable(importResources);
+const pendingSignaturesTable = buildPendingSignaturesTable(importResources);
+const retentionPoliciesTable = buildRetentionPoliciesTable(importResources);
+const legalHoldsTable = buildLegalHoldsTable(importResources);
+const privacyErasuresTable = buildPrivacyErasuresTable(importResources);
 
 /**
  * Build the Logo S3 Bucket.
@@ -979,6 +990,411 @@ function buildProjectsClaGroupsTable(importResources: boolean): aws.dynamodb.Tab
   );
 }
 
+/**
+ * NotificationTemplates Table - the per CLA Group notification template overrides
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildNotificationTemplatesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-notification-templates',
+    {
+      name: 'cla-' + stage + '-notification-templates',
+      attributes: [
+        { name: 'cla_group_id', type: 'S' },
+        { name: 'template_key', type: 'S' },
+      ],
+      hashKey: 'cla_group_id',
+      rangeKey: 'template_key',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-notification-templates' } : {},
+  );
+}
+
+/**
+ * WebhookSubscriptions Table - the outbound webhook subscriptions of the foundations
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildWebhookSubscriptionsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-webhook-subscriptions',
+    {
+      name: 'cla-' + stage + '-webhook-subscriptions',
+      attributes: [
+        { name: 'subscription_id', type: 'S' },
+        { name: 'foundation_sfid', type: 'S' },
+      ],
+      hashKey: 'subscription_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      globalSecondaryIndexes: [
+        {
+          name: 'foundation-sfid-index',
+          hashKey: 'foundation_sfid',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-webhook-subscriptions' } : {},
+  );
+}
+
+/**
+ * WebhookDeliveries Table - the delivery log of the outbound webhooks
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildWebhookDeliveriesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-webhook-deliveries',
+    {
+      name: 'cla-' + stage + '-webhook-deliveries',
+      attributes: [
+        { name: 'delivery_id', type: 'S' },
+        { name: 'subscription_id', type: 'S' },
+        { name: 'date_created', type: 'S' },
+        { name: 'status', type: 'S' },
+        { name: 'next_attempt_at', type: 'N' },
+      ],
+      hashKey: 'delivery_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      globalSecondaryIndexes: [
+        {
+          name: 'subscription-id-index',
+          hashKey: 'subscription_id',
+          rangeKey: 'date_created',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+        {
+          name: 'status-next-attempt-at-index',
+          hashKey: 'status',
+          rangeKey: 'next_attempt_at',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-webhook-deliveries' } : {},
+  );
+}
+
+/**
+ * MetricsHistory Table - the daily metrics rollups
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildMetricsHistoryTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-metrics-history',
+    {
+      name: 'cla-' + stage + '-metrics-history',
+      attributes: [
+        { name: 'id', type: 'S' },
+        { name: 'date', type: 'S' },
+      ],
+      hashKey: 'id',
+      rangeKey: 'date',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-metrics-history' } : {},
+  );
+}
+
+/**
+ * ResignCampaigns Table - the CLA Group re-sign campaigns
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildResignCampaignsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-resign-campaigns',
+    {
+      name: 'cla-' + stage + '-resign-campaigns',
+      attributes: [
+        { name: 'campaign_id', type: 'S' },
+        { name: 'cla_group_id', type: 'S' },
+      ],
+      hashKey: 'campaign_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      globalSecondaryIndexes: [
+        {
+          name: 'cla-group-id-index',
+          hashKey: 'cla_group_id',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-resign-campaigns' } : {},
+  );
+}
+
+/**
+ * ResignSigners Table - the signers targeted by the re-sign campaigns
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildResignSignersTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-resign-signers',
+    {
+      name: 'cla-' + stage + '-resign-signers',
+      attributes: [
+        { name: 'campaign_id', type: 'S' },
+        { name: 'signature_id', type: 'S' },
+      ],
+      hashKey: 'campaign_id',
+      rangeKey: 'signature_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-resign-signers' } : {},
+  );
+}
+
+/**
+ * ClaTemplates Table - the custom CLA templates uploaded per foundation
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildClaTemplatesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-cla-templates',
+    {
+      name: 'cla-' + stage + '-cla-templates',
+      attributes: [
+        { name: 'template_id', type: 'S' },
+        { name: 'foundation_sfid', type: 'S' },
+      ],
+      hashKey: 'template_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      globalSecondaryIndexes: [
+        {
+          name: 'foundation-sfid-index',
+          hashKey: 'foundation_sfid',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-cla-templates' } : {},
+  );
+}
+
+/**
+ * PendingSignatures Table - the ledger of the pending corporate signature requests
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildPendingSignaturesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-pending-signatures',
+    {
+      name: 'cla-' + stage + '-pending-signatures',
+      attributes: [
+        { name: 'request_id', type: 'S' },
+        { name: 'company_sfid', type: 'S' },
+        { name: 'status', type: 'S' },
+      ],
+      hashKey: 'request_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      globalSecondaryIndexes: [
+        {
+          name: 'company-sfid-index',
+          hashKey: 'company_sfid',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+        {
+          name: 'status-index',
+          hashKey: 'status',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-pending-signatures' } : {},
+  );
+}
+
+/**
+ * RetentionPolicies Table - the signed CLA retention policies of the foundations
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildRetentionPoliciesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-retention-policies',
+    {
+      name: 'cla-' + stage + '-retention-policies',
+      attributes: [
+        { name: 'foundation_sfid', type: 'S' },
+      ],
+      hashKey: 'foundation_sfid',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-retention-policies' } : {},
+  );
+}
+
+/**
+ * LegalHolds Table - the legal holds suspending the retention of the signed CLAs
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildLegalHoldsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-legal-holds',
+    {
+      name: 'cla-' + stage + '-legal-holds',
+      attributes: [
+        { name: 'reference_id', type: 'S' },
+      ],
+      hashKey: 'reference_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      streamEnabled: true,
+      streamViewType: "NEW_AND_OLD_IMAGES",
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-legal-holds' } : {},
+  );
+}
+
+/**
+ * PrivacyErasures Table - the requested erasures of the personal data of the data subjects
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildPrivacyErasuresTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-privacy-erasures',
+    {
+      name: 'cla-' + stage + '-privacy-erasures',
+      attributes: [
+        { name: 'erasure_id', type: 'S' },
+        { name: 'status', type: 'S' },
+        { name: 'date_created', type: 'S' },
+      ],
+      hashKey: 'erasure_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'status-date-created-index',
+          hashKey: 'status',
+          rangeKey: 'date_created',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-privacy-erasures' } : {},
+  );
+}
+
 // DynamoDB trigger events handler functions
 const dynamoDBProjectsEventLambdaName = "cla-backend-" + stage + "-dynamo-projects-lambda";
 const dynamoDBProjectsEventLambdaArn = "arn:aws:lambda:" + aws.getRegion().name + ":" + accountID + ":function:" + dynamoDBProjectsEventLambdaName;
@@ -1010,6 +1426,16 @@ repositoriesTable.onEvent("repositoriesStreamEvents",
   aws.lambda.Function.get(dynamoDBRepositoriesEventLambdaName, dynamoDBRepositoriesEventLambdaArn),
   { startingPosition: "LATEST" });
 
+// The metrics are maintained by a separate consumer of the streams - its failed batches are retried
+const dynamoDBMetricsEventLambdaName = "cla-backend-" + stage + "-dynamo-metrics-events-lambda";
+const dynamoDBMetricsEventLambdaArn = "arn:aws:lambda:" + aws.getRegion().name + ":" + accountID + ":function:" + dynamoDBMetricsEventLambdaName;
+const dynamoDBMetricsEventLambda = aws.lambda.Function.get(dynamoDBMetricsEventLambdaName, dynamoDBMetricsEventLambdaArn);
+signaturesTable.onEvent("signatureMetricsStreamEvents", dynamoDBMetricsEventLambda, { startingPosition: "LATEST" });
+companiesTable.onEvent("companiesMetricsStreamEvents", dynamoDBMetricsEventLambda, { startingPosition: "LATEST" });
+projectsTable.onEvent("projectsMetricsStreamEvents", dynamoDBMetricsEventLambda, { startingPosition: "LATEST" });
+repositoriesTable.onEvent("repositoriesMetricsStreamEvents", dynamoDBMetricsEventLambda, { startingPosition: "LATEST" });
+gerritInstancesTable.onEvent("gerritInstancesMetricsStreamEvents", dynamoDBMetricsEventLambda, { startingPosition: "LATEST" });
+
 // Export the name of the bucket
 export const logoBucketName = logoBucket.bucket;
 export const logoBucketPolicyOutput = logoBucketPolicy.policy;
@@ -1029,4 +1455,15 @@ export const sessionStoreTableName = sessionStoreTable.name;
 export const eventsTableName = eventsTable.name;
 export const cclaWhitelistRequestsTableName = cclaWhitelistRequestsTable.name;
 export const metricsTableName = metricsTable.name;
-export const projectsClaGroupsTableName = projectsClaGroupsTable.name
+export const projectsClaGroupsTableName = projectsClaGroupsTable.name;
+export const notificationTemplatesTableName = notificationTemplatesTable.name;
+export const webhookSubscriptionsTableName = webhookSubscriptionsTable.name;
+export const webhookDeliveriesTableName = webhookDeliveriesTable.name;
+export const metricsHistoryTableName = metricsHistoryTable.name;
+export const resignCampaignsTableName = resignCampaignsTable.name;
+export const resignSignersTableName = resignSignersTable.name;
+export const claTemplatesTableName = claTemplatesTable.name;
+export const pendingSignaturesTableName = pendingSignaturesTable.name;
+export const retentionPoliciesTableName = retentionPoliciesTable.name;
+export const legalHoldsTableName = legalHoldsTable.name;
+export const privacyErasuresTableName = privacyErasuresTable.name;
@@ -58,6 +58,8 @@ CONSUMER_SECRET=''
 # Needed for go backend
 SESSION_STORE_TABLE_NAME=''
 ALLOWED_ORIGINS_COMMA_SEPARATED=''
+GERRIT_HOOK_SECRET=''
+METRICS_BEARER_TOKEN=''
 
 ENV='';
 PROFILE='';
@@ -260,4 +262,14 @@ fi
 if [ -n "$ALLOWED_ORIGINS_COMMA_SEPARATED" ]; then
     echo "updating session store table name: $ALLOWED_ORIGINS_COMMA_SEPARATED"
     aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-allowed-origins-$ENV" --description "Allowed origins for CORS" --value "$ALLOWED_ORIGINS_COMMA_SEPARATED" --type "String" --overwrite
-fi
\ No newline at end of file
+fi
+
+if [ -n "$GERRIT_HOOK_SECRET" ]; then
+    echo "updating gerrit hook secret"
+    aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-gerrit-hook-secret-$ENV" --description "Shared secret of the Gerrit hooks" --value "$GERRIT_HOOK_SECRET" --type "String" --overwrite
+fi
+
+if [ -n "$METRICS_BEARER_TOKEN" ]; then
+    echo "updating metrics bearer token"
+    aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-metrics-bearer-token-$ENV" --description "Bearer token of the metrics endpoint scrapers" --value "$METRICS_BEARER_TOKEN" --type "String" --overwrite
+fi